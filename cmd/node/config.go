@@ -0,0 +1,133 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Every configuration value could be overridden with environment variable,
+// named after the key with this prefix, e.g. GOWAVES_API_ADDRESS or GOWAVES_NETWORK_PRESET.
+const envPrefix = "gowaves"
+
+type Config struct {
+	DataDir string        `mapstructure:"data-dir"`
+	Network NetworkConfig `mapstructure:"network"`
+	Peers   PeersConfig   `mapstructure:"peers"`
+	API     APIConfig     `mapstructure:"api"`
+	Logging LoggingConfig `mapstructure:"logging"`
+	Metrics MetricsConfig `mapstructure:"metrics"`
+	GRPC    GRPCConfig    `mapstructure:"grpc"`
+}
+
+type NetworkConfig struct {
+	// Preset is one of mainnet, testnet or custom.
+	Preset string `mapstructure:"preset"`
+	// AddressScheme and Genesis are used only by custom preset.
+	AddressScheme string `mapstructure:"address-scheme"`
+	Genesis       string `mapstructure:"genesis"`
+}
+
+type PeersConfig struct {
+	DeclaredAddress string   `mapstructure:"declared-address"`
+	NodeName        string   `mapstructure:"node-name"`
+	Addresses       []string `mapstructure:"addresses"`
+	MaxOutgoing     int      `mapstructure:"max-outgoing"`
+	MaxIncoming     int      `mapstructure:"max-incoming"`
+}
+
+type APIConfig struct {
+	Address string `mapstructure:"address"`
+}
+
+// GRPCConfig holds address of gRPC API, the API is disabled if it is empty.
+type GRPCConfig struct {
+	Address string `mapstructure:"address"`
+}
+
+// MetricsConfig holds address of Prometheus metrics endpoint, metrics are not served if it is empty.
+type MetricsConfig struct {
+	Address string `mapstructure:"address"`
+}
+
+type LoggingConfig struct {
+	Level       string `mapstructure:"level"`
+	Development bool   `mapstructure:"development"`
+}
+
+var defaults = map[string]interface{}{
+	"data-dir":               "./",
+	"network.preset":         "mainnet",
+	"network.address-scheme": "",
+	"network.genesis":        "",
+	"peers.declared-address": "",
+	"peers.node-name":        "gowaves",
+	"peers.addresses":        []string{},
+	"peers.max-outgoing":     30,
+	"peers.max-incoming":     30,
+	"api.address":            "",
+	"logging.level":          "info",
+	"logging.development":    false,
+	"metrics.address":        "",
+	"grpc.address":           "",
+}
+
+// LoadConfig reads configuration from file at path, if it is not empty, applying defaults and environment overrides.
+func LoadConfig(path string) (*Config, error) {
+	v := viper.New()
+	for k, d := range defaults {
+		v.SetDefault(k, d)
+	}
+	v.SetEnvPrefix(envPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_"))
+	v.AutomaticEnv()
+	if path != "" {
+		v.SetConfigFile(path)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, errors.Wrapf(err, "failed to read config file '%s'", path)
+		}
+	}
+	cfg := new(Config)
+	if err := v.Unmarshal(cfg); err != nil {
+		return nil, errors.Wrap(err, "failed to parse config")
+	}
+	return cfg, nil
+}
+
+// BlockchainSettings returns settings of the blockchain selected by network preset.
+func (c *Config) BlockchainSettings() (*settings.BlockchainSettings, error) {
+	switch strings.ToLower(c.Network.Preset) {
+	case "mainnet":
+		return settings.MainNetSettings, nil
+	case "testnet":
+		return settings.TestNetSettings, nil
+	case "custom":
+		if len(c.Network.AddressScheme) != 1 {
+			return nil, errors.Errorf("custom network requires single character address scheme, found '%s'", c.Network.AddressScheme)
+		}
+		if c.Network.Genesis == "" {
+			return nil, errors.New("custom network requires path to genesis block")
+		}
+		return settings.CustomSettings(c.Network.AddressScheme[0], c.Network.Genesis), nil
+	default:
+		return nil, errors.Errorf("expected network preset to be mainnet, testnet or custom, found '%s'", c.Network.Preset)
+	}
+}
+
+// Logger builds logger according to logging section of the config.
+func (c *Config) Logger() (*zap.Logger, error) {
+	var level zapcore.Level
+	if err := level.UnmarshalText([]byte(c.Logging.Level)); err != nil {
+		return nil, errors.Wrapf(err, "invalid logging level '%s'", c.Logging.Level)
+	}
+	zc := zap.NewProductionConfig()
+	if c.Logging.Development {
+		zc = zap.NewDevelopmentConfig()
+	}
+	zc.Level = zap.NewAtomicLevelAt(level)
+	return zc.Build()
+}
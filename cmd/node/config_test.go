@@ -0,0 +1,140 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+)
+
+const testConfig = `
+data-dir: "/var/lib/gowaves"
+network:
+  preset: "testnet"
+peers:
+  declared-address: "0.0.0.0:6863"
+  addresses:
+    - "1.2.3.4:6863"
+    - "5.6.7.8:6863"
+  max-outgoing: 10
+api:
+  address: "127.0.0.1:8080"
+logging:
+  level: "debug"
+`
+
+func writeConfig(t *testing.T, content string) (string, func()) {
+	dir, err := ioutil.TempDir("", "node")
+	require.NoError(t, err)
+	name := filepath.Join(dir, "config.yaml")
+	require.NoError(t, ioutil.WriteFile(name, []byte(content), 0644))
+	return name, func() {
+		_ = os.RemoveAll(dir)
+	}
+}
+
+func TestLoadConfigDefaults(t *testing.T) {
+	cfg, err := LoadConfig("")
+	require.NoError(t, err)
+	assert.Equal(t, "./", cfg.DataDir)
+	assert.Equal(t, "mainnet", cfg.Network.Preset)
+	assert.Equal(t, "gowaves", cfg.Peers.NodeName)
+	assert.Empty(t, cfg.Peers.Addresses)
+	assert.Equal(t, 30, cfg.Peers.MaxOutgoing)
+	assert.Equal(t, 30, cfg.Peers.MaxIncoming)
+	assert.Equal(t, "info", cfg.Logging.Level)
+	assert.Empty(t, cfg.API.Address)
+}
+
+func TestLoadConfigExample(t *testing.T) {
+	cfg, err := LoadConfig("config.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "/var/lib/gowaves", cfg.DataDir)
+	s, err := cfg.BlockchainSettings()
+	require.NoError(t, err)
+	assert.Equal(t, settings.MainNetSettings, s)
+}
+
+func TestLoadConfigPrecedence(t *testing.T) {
+	name, remove := writeConfig(t, testConfig)
+	defer remove()
+
+	cfg, err := LoadConfig(name)
+	require.NoError(t, err)
+	assert.Equal(t, "/var/lib/gowaves", cfg.DataDir)
+	assert.Equal(t, "testnet", cfg.Network.Preset)
+	assert.Equal(t, "0.0.0.0:6863", cfg.Peers.DeclaredAddress)
+	assert.Equal(t, []string{"1.2.3.4:6863", "5.6.7.8:6863"}, cfg.Peers.Addresses)
+	assert.Equal(t, 10, cfg.Peers.MaxOutgoing)
+	// Values missing in the file are taken from defaults.
+	assert.Equal(t, 30, cfg.Peers.MaxIncoming)
+	assert.Equal(t, "gowaves", cfg.Peers.NodeName)
+	assert.Equal(t, "127.0.0.1:8080", cfg.API.Address)
+	assert.Equal(t, "debug", cfg.Logging.Level)
+
+	// Environment overrides the file.
+	require.NoError(t, os.Setenv("GOWAVES_API_ADDRESS", "127.0.0.1:9090"))
+	require.NoError(t, os.Setenv("GOWAVES_PEERS_MAX_INCOMING", "5"))
+	defer func() {
+		_ = os.Unsetenv("GOWAVES_API_ADDRESS")
+		_ = os.Unsetenv("GOWAVES_PEERS_MAX_INCOMING")
+	}()
+	cfg, err = LoadConfig(name)
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:9090", cfg.API.Address)
+	assert.Equal(t, 5, cfg.Peers.MaxIncoming)
+	assert.Equal(t, "testnet", cfg.Network.Preset)
+
+	// Flags override both, unset flags keep the values.
+	var cli Cli
+	cli.Run.Network = "mainnet"
+	cli.Run.Addresses = "9.9.9.9:6868"
+	cli.Run.HttpAddr = "0.0.0.0:80"
+	cli.applyFlags(cfg)
+	assert.Equal(t, "mainnet", cfg.Network.Preset)
+	assert.Equal(t, []string{"9.9.9.9:6868"}, cfg.Peers.Addresses)
+	assert.Equal(t, "0.0.0.0:80", cfg.API.Address)
+	assert.Equal(t, "/var/lib/gowaves", cfg.DataDir)
+	assert.Equal(t, "0.0.0.0:6863", cfg.Peers.DeclaredAddress)
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	_, err := LoadConfig(filepath.Join("testdata", "missing.yaml"))
+	assert.Error(t, err)
+
+	name, remove := writeConfig(t, "peers:\n  max-outgoing: many\n")
+	defer remove()
+	_, err = LoadConfig(name)
+	assert.Error(t, err)
+}
+
+func TestConfigBlockchainSettings(t *testing.T) {
+	for _, tc := range []struct {
+		network  NetworkConfig
+		settings *settings.BlockchainSettings
+		err      string
+	}{
+		{NetworkConfig{Preset: "mainnet"}, settings.MainNetSettings, ""},
+		{NetworkConfig{Preset: "testnet"}, settings.TestNetSettings, ""},
+		{NetworkConfig{Preset: "TestNet"}, settings.TestNetSettings, ""},
+		{NetworkConfig{Preset: "custom", AddressScheme: "D", Genesis: "genesis.json"}, settings.CustomSettings('D', "genesis.json"), ""},
+		{NetworkConfig{Preset: "custom", Genesis: "genesis.json"}, nil, "custom network requires single character address scheme, found ''"},
+		{NetworkConfig{Preset: "custom", AddressScheme: "DD", Genesis: "genesis.json"}, nil, "custom network requires single character address scheme, found 'DD'"},
+		{NetworkConfig{Preset: "custom", AddressScheme: "D"}, nil, "custom network requires path to genesis block"},
+		{NetworkConfig{Preset: "stagenet"}, nil, "expected network preset to be mainnet, testnet or custom, found 'stagenet'"},
+	} {
+		cfg := &Config{Network: tc.network}
+		s, err := cfg.BlockchainSettings()
+		if tc.err != "" {
+			assert.EqualError(t, err, tc.err, tc.network.Preset)
+			continue
+		}
+		require.NoError(t, err, tc.network.Preset)
+		assert.Equal(t, tc.settings, s, tc.network.Preset)
+		assert.Equal(t, "waves"+string(s.AddressSchemeCharacter), s.AppName())
+	}
+}
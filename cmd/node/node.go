@@ -4,13 +4,14 @@ import (
 	"context"
 	"github.com/alecthomas/kong"
 	"github.com/wavesplatform/gowaves/pkg/api"
-	"github.com/wavesplatform/gowaves/pkg/settings"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/wavesplatform/gowaves/pkg/grpc/server"
 	"github.com/wavesplatform/gowaves/pkg/libs/bytespool"
+	"github.com/wavesplatform/gowaves/pkg/metrics"
 	"github.com/wavesplatform/gowaves/pkg/node"
 	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
 	"github.com/wavesplatform/gowaves/pkg/proto"
@@ -23,10 +24,12 @@ var version = proto.Version{0, 15, 1}
 
 type Cli struct {
 	Run struct {
-		WavesNetwork string `kong:"wavesnetwork,short='n',help='Waves network.',required"`
-		Addresses    string `kong:"address,short='a',help='Addresses connect to.'"`
-		DeclAddr     string `kong:"decladdr,short='d',help='Address listen on.'"`
-		HttpAddr     string `kong:"httpaddr,short='w',help='Http addr bind on.'"`
+		Config    string `kong:"config,short='c',help='Path to config file.'"`
+		Network   string `kong:"network,short='n',help='Network preset: mainnet, testnet or custom, overrides config.'"`
+		DataDir   string `kong:"datadir,help='State directory, overrides config.'"`
+		Addresses string `kong:"address,short='a',help='Addresses connect to, overrides config.'"`
+		DeclAddr  string `kong:"decladdr,short='d',help='Address listen on, overrides config.'"`
+		HttpAddr  string `kong:"httpaddr,short='w',help='Http addr bind on, overrides config.'"`
 	} `kong:"cmd,help='Run node'"`
 }
 
@@ -35,6 +38,25 @@ func init() {
 	zap.ReplaceGlobals(logger)
 }
 
+// applyFlags overrides config values with explicitly set command line flags.
+func (c *Cli) applyFlags(cfg *Config) {
+	if c.Run.Network != "" {
+		cfg.Network.Preset = c.Run.Network
+	}
+	if c.Run.DataDir != "" {
+		cfg.DataDir = c.Run.DataDir
+	}
+	if c.Run.Addresses != "" {
+		cfg.Peers.Addresses = strings.Split(c.Run.Addresses, ",")
+	}
+	if c.Run.DeclAddr != "" {
+		cfg.Peers.DeclaredAddress = c.Run.DeclAddr
+	}
+	if c.Run.HttpAddr != "" {
+		cfg.API.Address = c.Run.HttpAddr
+	}
+}
+
 func noSkip(_ proto.Header) bool {
 	return false
 }
@@ -45,49 +67,78 @@ func main() {
 	var cli Cli
 	kong.Parse(&cli)
 
-	state, err := state.NewState("./", state.DefaultBlockStorageParams(), settings.MainNetSettings)
+	cfg, err := LoadConfig(cli.Run.Config)
 	if err != nil {
 		zap.S().Error(err)
 		return
 	}
+	cli.applyFlags(cfg)
 
-	switch cli.Run.WavesNetwork {
-	case "wavesW", "wavesD", "wavesT":
-	default:
-		zap.S().Error("expected WavesNetwork to be wavesW, wavesD or wavesT, found %s", cli.Run.WavesNetwork)
+	logger, err := cfg.Logger()
+	if err != nil {
+		zap.S().Error(err)
 		return
 	}
+	zap.ReplaceGlobals(logger)
 
-	declAddr := proto.NewTCPAddrFromString(cli.Run.DeclAddr)
+	blockchainSettings, err := cfg.BlockchainSettings()
+	if err != nil {
+		zap.S().Error(err)
+		return
+	}
+
+	state, err := state.NewState(cfg.DataDir, state.DefaultBlockStorageParams(), blockchainSettings)
+	if err != nil {
+		zap.S().Error(err)
+		return
+	}
+
+	declAddr := proto.NewTCPAddrFromString(cfg.Peers.DeclaredAddress)
 
 	//pool := bytespool.NewBytesPool(64, 2*1024*2014)
 	pool := bytespool.NewNoOpBytesPool(2 * 1024 * 2014)
 
 	parent := peer.NewParent()
 
-	peerSpawnerimpl := node.NewPeerSpawner(pool, noSkip, parent, cli.Run.WavesNetwork, declAddr, "gowaves", 100500, version)
+	peerSpawnerimpl := node.NewPeerSpawner(pool, noSkip, parent, blockchainSettings.AppName(), declAddr, cfg.Peers.NodeName, 100500, version)
 
-	peerManager := node.NewPeerManager(peerSpawnerimpl, state)
+	limits := node.PeerLimits{MaxOutgoing: cfg.Peers.MaxOutgoing, MaxIncoming: cfg.Peers.MaxIncoming}
+	peerManager := node.NewPeerManager(peerSpawnerimpl, state, limits)
 
 	n := node.NewNode(state, peerManager, declAddr)
 
 	go node.RunNode(ctx, n, parent)
 
-	if len(cli.Run.Addresses) > 0 {
-		adrs := strings.Split(cli.Run.Addresses, ",")
-		for _, addr := range adrs {
-			peerManager.AddAddress(ctx, addr)
-		}
+	for _, addr := range cfg.Peers.Addresses {
+		peerManager.AddAddress(ctx, addr)
 	}
 
 	webApi := api.NewNodeApi(state, n, peerManager)
 	go func() {
-		err := api.Run(ctx, cli.Run.HttpAddr, webApi)
+		err := api.Run(ctx, cfg.API.Address, webApi)
 		if err != nil {
 			zap.S().Error("Failed to start API: %v", err)
 		}
 	}()
 
+	if cfg.GRPC.Address != "" {
+		go func() {
+			err := server.Run(ctx, cfg.GRPC.Address, server.NewServer(state))
+			if err != nil {
+				zap.S().Errorf("Failed to start gRPC API: %v", err)
+			}
+		}()
+	}
+
+	if cfg.Metrics.Address != "" {
+		go func() {
+			err := metrics.Run(ctx, cfg.Metrics.Address)
+			if err != nil {
+				zap.S().Errorf("Failed to start metrics server: %v", err)
+			}
+		}()
+	}
+
 	var gracefulStop = make(chan os.Signal)
 	signal.Notify(gracefulStop, syscall.SIGTERM)
 	signal.Notify(gracefulStop, syscall.SIGINT)
@@ -16,6 +16,7 @@ import (
 	"github.com/wavesplatform/gowaves/cmd/retransmitter/retransmit/httpserver"
 	"github.com/wavesplatform/gowaves/cmd/retransmitter/retransmit/utils"
 	"github.com/wavesplatform/gowaves/pkg/libs/bytespool"
+	"github.com/wavesplatform/gowaves/pkg/metrics"
 	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"go.uber.org/zap"
@@ -70,12 +71,14 @@ func main() {
 	var wavesNetwork string
 	var cpuprofile string
 	var memprofile string
+	var metricsAddr string
 	flag.StringVarP(&bind, "bind", "b", "", "Local address listen on")
 	flag.StringVarP(&decl, "decl", "d", "", "Declared Address")
 	flag.StringVarP(&addresses, "addresses", "a", "", "Addresses connect to")
 	flag.StringVarP(&wavesNetwork, "wavesnetwork", "n", "", "Required, waves network, should be wavesW or wavesT or wavesD")
 	flag.StringVarP(&cpuprofile, "cpuprofile", "", "", "write cpu profile to file")
 	flag.StringVarP(&memprofile, "memprofile", "", "", "write memory profile to this file")
+	flag.StringVarP(&metricsAddr, "metrics", "", "", "Address of Prometheus metrics endpoint, disabled if empty")
 	flag.Parse()
 
 	if cpuprofile != "" {
@@ -118,7 +121,7 @@ func main() {
 
 	spawner := retransmit.NewPeerSpawner(pool, skipUselessMessages, parent, wavesNetwork, declAddr)
 
-	behaviour := retransmit.NewBehaviour(knownPeers, spawner)
+	behaviour := retransmit.NewBehaviour(knownPeers, spawner, wavesNetwork[len(wavesNetwork)-1])
 
 	r := retransmit.NewRetransmitter(behaviour, parent)
 
@@ -149,6 +152,15 @@ func main() {
 		}
 	}()
 
+	if metricsAddr != "" {
+		go func() {
+			err := metrics.Run(ctx, metricsAddr)
+			if err != nil {
+				zap.S().Error(err)
+			}
+		}()
+	}
+
 	go func() {
 		for {
 			select {
@@ -5,7 +5,10 @@ import (
 	"net"
 	"sync"
 
+	"github.com/pkg/errors"
 	"github.com/wavesplatform/gowaves/cmd/retransmitter/retransmit/utils"
+	"github.com/wavesplatform/gowaves/pkg/fees"
+	"github.com/wavesplatform/gowaves/pkg/metrics"
 	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
 	. "github.com/wavesplatform/gowaves/pkg/p2p/peer"
 	"github.com/wavesplatform/gowaves/pkg/proto"
@@ -20,9 +23,11 @@ type BehaviourImpl struct {
 	activeConnections *utils.Addr2Peers
 	spawnedPeers      *utils.SpawnedPeers
 	peerSpawner       PeerSpawner
+	fees              *fees.Calculator
 }
 
-func NewBehaviour(knownPeers *utils.KnownPeers, peerSpawner PeerSpawner) *BehaviourImpl {
+// NewBehaviour creates the behaviour of the retransmitter in the network with the scheme.
+func NewBehaviour(knownPeers *utils.KnownPeers, peerSpawner PeerSpawner, scheme byte) *BehaviourImpl {
 	return &BehaviourImpl{
 		tl:                NewTransactionList(6000),
 		knownPeers:        knownPeers,
@@ -30,6 +35,7 @@ func NewBehaviour(knownPeers *utils.KnownPeers, peerSpawner PeerSpawner) *Behavi
 		activeConnections: utils.NewAddr2Peers(),
 		spawnedPeers:      utils.NewSpawnedPeers(),
 		peerSpawner:       peerSpawner,
+		fees:              fees.NewCalculator(scheme, nil),
 	}
 }
 
@@ -41,9 +47,15 @@ func (a *BehaviourImpl) ProtoMessage(incomeMessage peer.ProtoMessage) {
 			zap.S().Error(err, incomeMessage.ID, t)
 			return
 		}
+		// without state the fee is checked against the lower bound, other fee errors are left to nodes
+		if err := a.fees.Check(transaction); errors.Cause(err) == fees.ErrInsufficientFee {
+			zap.S().Debugf("transaction from %s is rejected: %s", incomeMessage.ID, err)
+			return
+		}
 
 		if !a.tl.Exists(transaction) {
 			a.tl.Add(transaction)
+			metrics.UtxSize(a.tl.Len())
 			a.counter.IncUniqueTransaction()
 			a.activeConnections.Each(func(id string, c Peer) {
 				if id != incomeMessage.ID {
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/wavesplatform/gowaves/cmd/retransmitter/retransmit"
 	"github.com/wavesplatform/gowaves/cmd/retransmitter/retransmit/utils"
 	"github.com/wavesplatform/gowaves/pkg/crypto"
@@ -28,7 +29,7 @@ func createTransaction() *proto.TransferV2 {
 		proto.OptionalAsset{},
 		1544715621,
 		10000,
-		10000,
+		100000,
 		proto.NewRecipientFromAddress(addr),
 		"",
 	)
@@ -45,7 +46,7 @@ func createTransaction() *proto.TransferV2 {
 func TestClientRecvTransaction(t *testing.T) {
 	knownPeers, _ := utils.NewKnownPeers(utils.NoOnStorage{})
 
-	behaviour := retransmit.NewBehaviour(knownPeers, nil)
+	behaviour := retransmit.NewBehaviour(knownPeers, nil, proto.MainNetScheme)
 
 	peer1 := &mock.Peer{
 		Addr: "peer1",
@@ -73,10 +74,12 @@ func TestClientRecvTransaction(t *testing.T) {
 
 	assert.Len(t, behaviour.ActiveConnections().Addresses(), 2)
 
+	tx, err := createTransaction().MarshalBinary()
+	require.NoError(t, err)
 	protomess := peer.ProtoMessage{
 		ID: peer1.Addr,
 		Message: &proto.TransactionMessage{
-			Transaction: byte_helpers.TransferV1.TransactionBytes,
+			Transaction: tx,
 		},
 	}
 
@@ -86,5 +89,30 @@ func TestClientRecvTransaction(t *testing.T) {
 	// sending again, and no message should arrive
 	behaviour.ProtoMessage(protomess)
 	assert.Len(t, peer2.SendMessageCalledWith, 1)
+}
+
+// transaction with the fee less than minimal is not retransmitted
+func TestClientRecvTransactionWithInsufficientFee(t *testing.T) {
+	knownPeers, _ := utils.NewKnownPeers(utils.NoOnStorage{})
 
+	behaviour := retransmit.NewBehaviour(knownPeers, nil, proto.MainNetScheme)
+
+	peer2 := &mock.Peer{
+		Addr: "peer2",
+	}
+	behaviour.InfoMessage(peer.InfoMessage{
+		ID: peer2.Addr,
+		Value: &peer.Connected{
+			Peer: peer2,
+		},
+	})
+
+	// the fee of the transaction is 0.0001 WAVES
+	behaviour.ProtoMessage(peer.ProtoMessage{
+		ID: "peer1",
+		Message: &proto.TransactionMessage{
+			Transaction: byte_helpers.TransferV1.TransactionBytes,
+		},
+	})
+	assert.Len(t, peer2.SendMessageCalledWith, 0)
 }
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/wavesplatform/gowaves/pkg/ride/compiler"
+	"github.com/wavesplatform/gowaves/pkg/ride/decompiler"
+)
+
+var (
+	output    = flag.String("output", "", "Path to file to write compiled script to. By default the script is printed to stdout.")
+	binary    = flag.Bool("binary", false, "Write the script as raw bytes instead of base64 string.")
+	decompile = flag.Bool("decompile", false, "Decompile the script given as base64 string back into the source code.")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s [options] [file]\n", os.Args[0])
+	fmt.Fprintln(os.Stderr, "Compiles RIDE expression script from file or stdin if file is not given.")
+	fmt.Fprintln(os.Stderr, "With -decompile option restores the source code of compiled script.")
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() > 1 {
+		usage()
+		os.Exit(2)
+	}
+	name := "<stdin>"
+	var src []byte
+	var err error
+	if flag.NArg() == 1 {
+		name = flag.Arg(0)
+		src, err = ioutil.ReadFile(name)
+	} else {
+		src, err = ioutil.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to read script: %v\n", err)
+		os.Exit(1)
+	}
+	if *decompile {
+		code, err := decompiler.DecompileBase64(string(src))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+			os.Exit(1)
+		}
+		write([]byte(code))
+		return
+	}
+	script, err := compiler.Compile(string(src))
+	if err != nil {
+		if _, ok := err.(*compiler.Error); ok {
+			// Position of the error is printed right after the file name
+			fmt.Fprintf(os.Stderr, "%s:%v\n", name, err)
+		} else {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+		}
+		os.Exit(1)
+	}
+	out := script.Bytes
+	if !*binary {
+		// The same representation as returned by node's /utils/script/compile
+		out = []byte("base64:" + base64.StdEncoding.EncodeToString(script.Bytes) + "\n")
+	}
+	write(out)
+}
+
+func write(out []byte) {
+	var err error
+	if *output == "" {
+		_, err = os.Stdout.Write(out)
+	} else {
+		err = ioutil.WriteFile(*output, out, 0644)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write script: %v\n", err)
+		os.Exit(1)
+	}
+}
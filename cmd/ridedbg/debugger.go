@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/ast"
+)
+
+type mode byte
+
+const (
+	modeStep mode = iota
+	modeNext
+	modeOut
+	modeContinue
+)
+
+const help = `Commands:
+  s, step      evaluate the next let, function call or branch, stepping into it
+  n, next      step over the current node
+  o, out       continue until the current node is evaluated
+  c, continue  run to the end
+  t, trace     print the trace recorded so far
+  q, quit      stop debugging
+`
+
+// debugger stops on every event of evaluation and asks the user what to do next.
+type debugger struct {
+	trace *ast.Trace
+	in    *bufio.Reader
+	out   io.Writer
+	mode  mode
+	// depth of the node the debugger should stop after or on exit of
+	depth int
+}
+
+func newDebugger(in *bufio.Reader, out io.Writer) *debugger {
+	fmt.Fprint(out, help)
+	return &debugger{trace: ast.NewTrace(), in: in, out: out}
+}
+
+func (d *debugger) stops(depth int) bool {
+	switch d.mode {
+	case modeStep:
+		return true
+	case modeNext:
+		return depth <= d.depth
+	case modeOut:
+		return depth == d.depth
+	default:
+		return false
+	}
+}
+
+func (d *debugger) Enter(node *ast.TraceNode) {
+	depth := d.trace.Depth()
+	d.trace.Enter(node)
+	if d.stops(depth) {
+		fmt.Fprintf(d.out, "%s-> %s\n", strings.Repeat("  ", depth), node.Describe())
+		d.prompt(depth, depth)
+	}
+}
+
+func (d *debugger) Exit(node *ast.TraceNode) {
+	d.trace.Exit(node)
+	depth := d.trace.Depth()
+	if d.stops(depth) {
+		fmt.Fprintf(d.out, "%s<- %s\n", strings.Repeat("  ", depth), node.Describe())
+		// The node is evaluated, its parent is the current one.
+		d.prompt(depth, depth-1)
+	}
+}
+
+// prompt reads commands at the node of given depth, current is the depth of the node being evaluated.
+func (d *debugger) prompt(depth, current int) {
+	for {
+		fmt.Fprint(d.out, "(ridedbg) ")
+		line, err := d.in.ReadString('\n')
+		if err != nil {
+			// No more input, run to the end
+			d.mode = modeContinue
+			return
+		}
+		switch strings.TrimSpace(line) {
+		case "", "s", "step":
+			d.mode = modeStep
+			return
+		case "n", "next":
+			d.mode, d.depth = modeNext, depth
+			return
+		case "o", "out":
+			d.mode, d.depth = modeOut, current
+			return
+		case "c", "continue":
+			d.mode = modeContinue
+			return
+		case "t", "trace":
+			d.trace.Write(d.out)
+		case "q", "quit":
+			os.Exit(0)
+		default:
+			fmt.Fprint(d.out, help)
+		}
+	}
+}
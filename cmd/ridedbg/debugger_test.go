@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/ast"
+)
+
+// stops runs the debugger with given commands over the evaluation of let a = { let b = { let c }; let d } and
+// returns the events it stopped on.
+func stops(commands ...string) []string {
+	in := bufio.NewReader(strings.NewReader(strings.Join(commands, "\n") + "\n"))
+	out := new(bytes.Buffer)
+	d := newDebugger(in, out)
+	a := &ast.TraceNode{Kind: ast.TraceLet, Name: "a"}
+	b := &ast.TraceNode{Kind: ast.TraceLet, Name: "b"}
+	c := &ast.TraceNode{Kind: ast.TraceLet, Name: "c"}
+	dd := &ast.TraceNode{Kind: ast.TraceLet, Name: "d"}
+	d.Enter(a)
+	d.Enter(b)
+	d.Enter(c)
+	d.Exit(c)
+	d.Exit(b)
+	d.Enter(dd)
+	d.Exit(dd)
+	d.Exit(a)
+	var events []string
+	for _, line := range strings.Split(out.String(), "\n") {
+		line = strings.TrimPrefix(line, "(ridedbg) ")
+		if e := strings.TrimSpace(line); strings.HasPrefix(e, "->") || strings.HasPrefix(e, "<-") {
+			events = append(events, line)
+		}
+	}
+	return events
+}
+
+func TestDebuggerStepping(t *testing.T) {
+	for _, tc := range []struct {
+		commands []string
+		events   []string
+	}{
+		{[]string{"c"}, []string{"-> let a"}},
+		{[]string{"s", "s", "c"}, []string{"-> let a", "  -> let b", "    -> let c"}},
+		{[]string{"s", "n", "c"}, []string{"-> let a", "  -> let b", "  <- let b"}},
+		// Out of the entered node stops on its exit.
+		{[]string{"s", "o", "c"}, []string{"-> let a", "  -> let b", "  <- let b"}},
+		{[]string{"s", "s", "o", "c"}, []string{"-> let a", "  -> let b", "    -> let c", "    <- let c"}},
+		// Out after the exit of the node stops on the exit of its parent.
+		{[]string{"s", "s", "o", "o", "c"}, []string{"-> let a", "  -> let b", "    -> let c", "    <- let c", "  <- let b"}},
+		{[]string{"s", "o", "o", "c"}, []string{"-> let a", "  -> let b", "  <- let b", "<- let a"}},
+		{[]string{"o"}, []string{"-> let a", "<- let a"}},
+	} {
+		assert.Equal(t, tc.events, stops(tc.commands...), strings.Join(tc.commands, ","))
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride/compiler"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/ast"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/evaluate"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/parser"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/reader"
+	"github.com/wavesplatform/gowaves/pkg/ride/mockstate"
+)
+
+var (
+	scriptPath = flag.String("script", "", "Path to the script, either source code or compiled script as base64 string.")
+	txPath     = flag.String("tx", "", "Path to JSON of the transaction to verify.")
+	scheme     = flag.String("scheme", "W", "Address scheme of the blockchain.")
+	height     = flag.Uint64("height", 1, "Height of the blockchain.")
+	this       = flag.String("this", "", "Address of the account the script is attached to.")
+	trace      = flag.Bool("trace", false, "Print the trace of evaluation instead of interactive debugging.")
+)
+
+func main() {
+	flag.Parse()
+	if *scriptPath == "" || *txPath == "" || len(*scheme) != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	script, err := loadScript(*scriptPath)
+	if err != nil {
+		return err
+	}
+	tx, err := loadTransaction(*txPath)
+	if err != nil {
+		return err
+	}
+	var address proto.Address
+	if *this != "" {
+		address, err = proto.NewAddressFromString(*this)
+		if err != nil {
+			return errors.Wrap(err, "invalid address of the account")
+		}
+	}
+	state := mockstate.MockStateImpl{
+		CurrentHeight: *height,
+		BlockHeaders:  map[uint64]*proto.BlockHeader{*height: {}},
+	}
+	s, err := ast.NewScopeByVersion(script.Version, (*scheme)[0], state, address, tx)
+	if err != nil {
+		return err
+	}
+	var rs bool
+	if *trace {
+		var t *ast.Trace
+		rs, t, err = evaluate.EvalWithTrace(script.Verifier, s)
+		t.Write(os.Stdout)
+	} else {
+		rs, err = evaluate.EvalWithTracer(script.Verifier, s, newDebugger(bufio.NewReader(os.Stdin), os.Stdout))
+	}
+	if err != nil {
+		return errors.Wrap(err, "evaluation failed")
+	}
+	fmt.Printf("Result: %t\n", rs)
+	return nil
+}
+
+// loadScript reads compiled script or compiles the source code.
+func loadScript(path string) (*ast.Script, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	text := strings.TrimPrefix(strings.TrimSpace(string(b)), "base64:")
+	code, err := base64.StdEncoding.DecodeString(text)
+	if err != nil {
+		script, err := compiler.Compile(string(b))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compile script %s", path)
+		}
+		code = script.Bytes
+	}
+	r, err := reader.NewReaderFromScript(code)
+	if err != nil {
+		return nil, err
+	}
+	return parser.BuildScript(r)
+}
+
+func loadTransaction(path string) (proto.Transaction, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	tt := proto.TransactionTypeVersion{}
+	if err := json.Unmarshal(b, &tt); err != nil {
+		return nil, errors.Wrap(err, "failed to read transaction type")
+	}
+	tx, err := proto.GuessTransactionType(&tt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, tx); err != nil {
+		return nil, errors.Wrap(err, "failed to read transaction")
+	}
+	return tx, nil
+}
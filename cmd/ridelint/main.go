@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/ride/compiler"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/ast"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/parser"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/reader"
+)
+
+var scriptType = flag.String("type", "", "Type of the script, ACCOUNT or ASSET. By default it is taken from the directives of the source code, compiled scripts are considered account scripts.")
+
+// report is the result of analysis of one file.
+type report struct {
+	File       string             `json:"file"`
+	Version    int                `json:"version"`
+	ScriptType string             `json:"script_type"`
+	Findings   []compiler.Finding `json:"findings"`
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-type ACCOUNT|ASSET] FILE...\n", os.Args[0])
+		fmt.Fprintln(flag.CommandLine.Output(), "Analyzes RIDE scripts given as source code or compiled base64 strings and prints findings as JSON.")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 || (*scriptType != "" && *scriptType != "ACCOUNT" && *scriptType != "ASSET") {
+		flag.Usage()
+		os.Exit(2)
+	}
+	reports := make([]report, 0, flag.NArg())
+	failed := false
+	for _, path := range flag.Args() {
+		script, st, err := loadScript(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			os.Exit(2)
+		}
+		if *scriptType != "" {
+			st = *scriptType
+		}
+		findings := compiler.Analyze(script, st)
+		if findings == nil {
+			findings = []compiler.Finding{}
+		}
+		for _, f := range findings {
+			if f.Severity == compiler.SeverityError {
+				failed = true
+			}
+		}
+		reports = append(reports, report{File: path, Version: script.Version, ScriptType: st, Findings: findings})
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(reports); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// loadScript reads compiled script, optionally prefixed with "base64:", or compiles the source code.
+func loadScript(path string) (*ast.Script, string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+	text := strings.TrimSpace(string(b))
+	if strings.HasPrefix(text, "base64:") {
+		r, err := reader.NewReaderFromBase64(strings.TrimPrefix(text, "base64:"))
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed to decode script")
+		}
+		script, err := parser.BuildScript(r)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed to parse script")
+		}
+		return script, "ACCOUNT", nil
+	}
+	compiled, err := compiler.Compile(string(b))
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to compile script")
+	}
+	return &ast.Script{Version: compiled.Directives.StdLibVersion, Verifier: compiled.Expr}, compiled.Directives.ScriptType, nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wavesplatform/gowaves/pkg/ride/scripttest"
+)
+
+var verbose = flag.Bool("v", false, "Print traces of evaluation of all tests, not only failed ones.")
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-v] FIXTURE_OR_DIRECTORY...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	paths, err := fixtures(flag.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	var passed, failed, broken int
+	for _, path := range paths {
+		f, err := scripttest.Load(path)
+		if err != nil {
+			fmt.Printf("ERROR %s: %v\n", path, err)
+			broken++
+			continue
+		}
+		results, err := scripttest.Run(f)
+		if err != nil {
+			fmt.Printf("ERROR %s: %v\n", path, err)
+			broken++
+			continue
+		}
+		p, fl := scripttest.Report(os.Stdout, f.Name, results, *verbose)
+		passed += p
+		failed += fl
+	}
+	fmt.Printf("%d passed, %d failed, %d fixtures with errors\n", passed, failed, broken)
+	if failed > 0 || broken > 0 {
+		os.Exit(1)
+	}
+}
+
+// fixtures expands directories into the list of YAML and JSON files in them.
+func fixtures(args []string) ([]string, error) {
+	var paths []string
+	for _, arg := range args {
+		fi, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !fi.IsDir() {
+			paths = append(paths, arg)
+			continue
+		}
+		files, err := ioutil.ReadDir(arg)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			switch strings.ToLower(filepath.Ext(file.Name())) {
+			case ".yaml", ".yml", ".json":
+				if !file.IsDir() {
+					paths = append(paths, filepath.Join(arg, file.Name()))
+				}
+			}
+		}
+	}
+	return paths, nil
+}
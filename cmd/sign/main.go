@@ -1,127 +1,284 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"os/user"
 	"path"
+	"sort"
 	"time"
 
 	"github.com/howeyc/gopass"
+	"github.com/mr-tron/base58/base58"
 	"github.com/pkg/errors"
 	flag "github.com/spf13/pflag"
 	"github.com/wavesplatform/gowaves/pkg/client"
 	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/fees"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/wallet"
 )
 
-func main() {
-	if len(os.Args) < 2 {
-		showUsageAndExit()
-	}
+// signable is a transaction that could be signed by the sender.
+type signable interface {
+	proto.Transaction
+	Sign(secretKey crypto.SecretKey) error
+}
 
-	switch os.Args[1] {
-	case "transfer":
-		transferTransaction()
-	default:
-		showUsageAndExit()
-	}
+// builder creates the unsigned transaction of the sender.
+type builder func(sender crypto.PublicKey, c *common) (signable, error)
+
+type command struct {
+	description string
+	// flags registers flags of the command and returns the builder of the transaction
+	flags func(f *flag.FlagSet) builder
 }
 
-func transferTransaction() {
-	type Opts struct {
-		PathToWallet string
-		Recipient    string
-		Amount       uint64
-		Fee          uint64
-		CustomSecret string
-	}
+var commands = map[string]command{
+	"transfer":         {"Transfer WAVES or asset", transferFlags},
+	"issue":            {"Issue new asset", issueFlags},
+	"reissue":          {"Reissue asset", reissueFlags},
+	"burn":             {"Burn asset", burnFlags},
+	"lease":            {"Lease WAVES", leaseFlags},
+	"lease-cancel":     {"Cancel lease", leaseCancelFlags},
+	"alias":            {"Create alias", aliasFlags},
+	"mass-transfer":    {"Transfer WAVES or asset to recipients from CSV file", massTransferFlags},
+	"data":             {"Put data entries from JSON file to account storage", dataFlags},
+	"set-script":       {"Set or remove script of account", setScriptFlags},
+	"sponsorship":      {"Set or cancel sponsorship of asset", sponsorshipFlags},
+	"set-asset-script": {"Set script of asset", setAssetScriptFlags},
+	"invoke-script":    {"Invoke function of dApp", invokeScriptFlags},
+}
 
-	opts := Opts{}
+// keyOpts are the flags to select the key of the signer.
+type keyOpts struct {
+	PathToWallet string
+	Account      string
+	CustomSecret string
+}
 
-	f := flag.NewFlagSet("transfer", flag.ExitOnError)
-	f.StringVarP(&opts.PathToWallet, "wallet", "w", "", "Path to wallet")
-	f.Uint64VarP(&opts.Amount, "amount", "a", 0, "Amount of waves to send")
-	f.Uint64Var(&opts.Fee, "fee", 100000, "Fee, optional")
-	f.StringVarP(&opts.Recipient, "recipient", "r", "", "Address of recipient")
-	f.StringVarP(&opts.CustomSecret, "secret", "s", "", "Use this secret key instead of wallet, optional")
+func keyFlags(f *flag.FlagSet, k *keyOpts) {
+	f.StringVarP(&k.PathToWallet, "wallet", "w", "", "Path to wallet")
+	f.StringVar(&k.Account, "account", "", "Label of the account of wallet, the default account by default")
+	f.StringVarP(&k.CustomSecret, "secret", "s", "", "Use this secret key instead of wallet, optional")
+}
 
-	f.Parse(os.Args[1:])
+// common holds the flags shared by all commands.
+type common struct {
+	keyOpts
+	Sender    string
+	Unsigned  bool
+	Scheme    string
+	Fee       uint64
+	Timestamp uint64
+	Format    string
+	Out       string
+	Broadcast bool
+	Node      string
+}
 
-	pathToWallet := getWalletPath(opts.PathToWallet)
-	if !exists(pathToWallet) {
-		f.PrintDefaults()
-		return
+func (c *common) scheme() byte {
+	return c.Scheme[0]
+}
+
+// defaultNodes are the nodes transactions are broadcasted to by default.
+var defaultNodes = map[byte]string{
+	proto.MainNetScheme: "https://nodes.wavesnodes.com",
+	proto.TestNetScheme: "https://nodes-testnet.wavesnodes.com",
+	'S':                 "https://nodes-stagenet.wavesnodes.com", // StageNet
+}
+
+// nodeURL returns the URL of the node given by the flag or the default node of the network.
+func nodeURL(node string, scheme byte) (string, error) {
+	if node != "" {
+		return node, nil
+	}
+	if u, ok := defaultNodes[scheme]; ok {
+		return u, nil
 	}
+	return "", errors.Errorf("no default node of network '%c', provide it with --node", scheme)
+}
+
+func commonFlags(f *flag.FlagSet) *common {
+	c := &common{}
+	keyFlags(f, &c.keyOpts)
+	f.BoolVar(&c.Unsigned, "unsigned", false, "Export the transaction without proofs to be signed by several parties, requires --sender")
+	f.StringVar(&c.Sender, "sender", "", "Public key of the sender of the unsigned transaction")
+	f.StringVar(&c.Scheme, "scheme", "W", "Scheme of the network, W for MainNet, T for TestNet")
+	f.Uint64Var(&c.Fee, "fee", 0, "Fee, minimal fee of the transaction by default")
+	f.Uint64Var(&c.Timestamp, "timestamp", 0, "Timestamp in milliseconds, current time by default")
+	f.StringVar(&c.Format, "format", "json", "Format of the signed transaction, json or binary")
+	f.StringVarP(&c.Out, "out", "o", "", "Write the signed transaction to file instead of stdout, binary format is written as raw bytes")
+	f.BoolVar(&c.Broadcast, "broadcast", false, "Broadcast the signed transaction to the node")
+	f.StringVar(&c.Node, "node", "", "URL of the node API to broadcast the transaction, the default node of the network by default")
+	return c
+}
 
-	if opts.Recipient == "" {
-		fmt.Println("Err: no recipient provided")
-		f.PrintDefaults()
+func main() {
+	if len(os.Args) < 2 {
+		showUsageAndExit()
+	}
+	if t, ok := tools[os.Args[1]]; ok {
+		f := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+		runTool := t.flags(f)
+		f.Parse(os.Args[2:])
+		if err := runTool(f.Args()); err != nil {
+			fmt.Printf("Err: %s\n", err)
+			os.Exit(2)
+		}
 		return
 	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		showUsageAndExit()
+	}
 
-	if opts.Amount == 0 {
-		fmt.Println("Err: amount should be positive")
-		f.PrintDefaults()
-		return
+	f := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	c := commonFlags(f)
+	build := cmd.flags(f)
+	f.Parse(os.Args[2:])
+
+	if err := run(c, build); err != nil {
+		fmt.Printf("Err: %s\n", err)
+		os.Exit(2)
 	}
+}
 
-	address, err := proto.NewAddressFromString(opts.Recipient)
-	if err != nil {
-		fmt.Printf("Err: %q", err)
-		return
+func run(c *common, build builder) error {
+	if len(c.Scheme) != 1 {
+		return errors.Errorf("invalid scheme '%s'", c.Scheme)
+	}
+	if c.Format != "json" && c.Format != "binary" {
+		return errors.Errorf("unknown format '%s', expected json or binary", c.Format)
+	}
+	if c.Timestamp == 0 {
+		c.Timestamp = client.NewTimestampFromTime(time.Now())
+	}
+	if c.Broadcast {
+		node, err := nodeURL(c.Node, c.scheme())
+		if err != nil {
+			return err
+		}
+		c.Node = node
 	}
 
-	secretKey, err := getSecretKey(opts.CustomSecret, pathToWallet)
+	if c.Unsigned {
+		return runUnsigned(c, build)
+	}
+	secretKey, err := getSecretKey(&c.keyOpts)
 	if err != nil {
-		fmt.Printf("Err: %q", err)
-		return
+		return err
 	}
+	tx, err := buildWithFee(crypto.GeneratePublicKey(secretKey), false, c, build)
+	if err != nil {
+		return err
+	}
+	if err := tx.Sign(secretKey); err != nil {
+		return err
+	}
+	if _, err := tx.Valid(); err != nil {
+		return errors.Wrap(err, "invalid transaction")
+	}
+	if err := output(c.Format, c.Out, tx); err != nil {
+		return err
+	}
+	if c.Broadcast {
+		return broadcast(c.Node, tx)
+	}
+	return nil
+}
 
-	publicKey := crypto.GeneratePublicKey(secretKey)
-
-	timestamp := client.NewTimestampFromTime(time.Now())
-	transfer := proto.NewUnsignedTransferV1(
-		publicKey,
-		proto.OptionalAsset{},
-		proto.OptionalAsset{},
-		timestamp,
-		opts.Amount,
-		opts.Fee,
-		proto.NewRecipientFromAddress(address),
-		"",
-	)
-
-	err = transfer.Sign(secretKey)
+// buildWithFee builds the transaction, if no fee is given the transaction is built again with the minimal fee. The
+// minimal fee is looked up in the node if the transaction is broadcasted, otherwise it's calculated for assets
+// without scripts and the account with script if the sender is smart.
+func buildWithFee(sender crypto.PublicKey, smart bool, c *common, build builder) (signable, error) {
+	tx, err := build(sender, c)
+	if err != nil || c.Fee != 0 {
+		return tx, err
+	}
+	var state fees.State
+	if smart {
+		addr, err := proto.NewAddressFromPublicKey(c.scheme(), sender)
+		if err != nil {
+			return nil, err
+		}
+		state = fees.StaticState{SmartAccounts: map[proto.Address]bool{addr: true}}
+	}
+	if c.Broadcast {
+		cl, err := newClient(c.Node)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		state = fees.NewClientState(ctx, cl)
+	}
+	fee, err := fees.NewCalculator(c.scheme(), state).MinimalFeeInAsset(tx)
 	if err != nil {
-		fmt.Printf("Err: %q", err)
-		os.Exit(2)
+		return nil, errors.Wrap(err, "failed to calculate minimal fee, provide it with --fee")
 	}
+	c.Fee = fee
+	return build(sender, c)
+}
 
-	jsoned, err := json.Marshal(transfer)
+func output(format, out string, tx proto.Transaction) error {
+	var bts []byte
+	var err error
+	if format == "binary" {
+		bts, err = tx.MarshalBinary()
+		if err == nil && out == "" {
+			bts = []byte(base58.Encode(bts))
+		}
+	} else {
+		bts, err = json.Marshal(tx)
+	}
 	if err != nil {
-		fmt.Printf("Err: %q", err)
-		os.Exit(2)
+		return err
 	}
+	if out != "" {
+		return ioutil.WriteFile(out, bts, 0644)
+	}
+	fmt.Printf("%s\n", bts)
+	return nil
+}
 
-	fmt.Printf("\n%s\n\n", string(jsoned))
+func newClient(node string) (*client.Client, error) {
+	return client.NewClient(client.Options{BaseUrl: node, Client: &http.Client{Timeout: 30 * time.Second}})
+}
+
+func broadcast(node string, tx proto.Transaction) error {
+	cl, err := newClient(node)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if _, err := cl.Transactions.Broadcast(ctx, tx); err != nil {
+		return errors.Wrap(err, "failed to broadcast transaction")
+	}
+	fmt.Fprintln(os.Stderr, "Broadcasted!")
+	return nil
 }
 
-func getSecretKey(s string, pathToWallet string) (crypto.SecretKey, error) {
-	if s != "" {
-		return crypto.NewSecretKeyFromBase58(s)
+func getSecretKey(c *keyOpts) (crypto.SecretKey, error) {
+	if c.CustomSecret != "" {
+		return crypto.NewSecretKeyFromBase58(c.CustomSecret)
 	}
 
+	pathToWallet := getWalletPath(c.PathToWallet)
+	if !exists(pathToWallet) {
+		return crypto.SecretKey{}, errors.New("wallet not found")
+	}
 	body, err := ioutil.ReadFile(pathToWallet)
 	if err != nil {
-		fmt.Printf("Err: %s\n", err)
 		return crypto.SecretKey{}, err
 	}
 
-	fmt.Print("Enter password: ")
+	fmt.Fprint(os.Stderr, "Enter password: ")
 	pass, err := gopass.GetPasswd()
 	if err != nil {
 		return crypto.SecretKey{}, errors.New("Interrupt")
@@ -131,16 +288,40 @@ func getSecretKey(s string, pathToWallet string) (crypto.SecretKey, error) {
 		return crypto.SecretKey{}, err
 	}
 
-	secretKey, _, err := wlt.GenPair()
+	if c.Account == "" {
+		secretKey, _, err := wlt.GenPair()
+		return secretKey, err
+	}
+	acc, err := wlt.Account(c.Account)
 	if err != nil {
 		return crypto.SecretKey{}, err
 	}
-
-	return secretKey, nil
+	secretKey, _, err := wlt.KeyPair(acc)
+	return secretKey, err
 }
 
 func showUsageAndExit() {
-	fmt.Println("usage: sign transfer [<args>]")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Println("usage: sign <command> [<args>]")
+	fmt.Println()
+	fmt.Println("Available commands:")
+	for _, name := range names {
+		fmt.Printf("  %-18s %s\n", name, commands[name].description)
+	}
+	names = names[:0]
+	for name := range tools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Println()
+	fmt.Println("Multisig commands:")
+	for _, name := range names {
+		fmt.Printf("  %-18s %s\n", name, tools[name].description)
+	}
 	os.Exit(0)
 }
 
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	flag "github.com/spf13/pflag"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/multisig"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/ast"
+	astparser "github.com/wavesplatform/gowaves/pkg/ride/evaluator/parser"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/reader"
+	"github.com/wavesplatform/gowaves/pkg/ride/mockstate"
+)
+
+type tool struct {
+	description string
+	// flags registers flags of the command and returns the function to run with positional arguments
+	flags func(f *flag.FlagSet) func(args []string) error
+}
+
+var tools = map[string]tool{
+	"proof":     {"Add proof at the position to the unsigned transaction from file", proofFlags},
+	"merge":     {"Merge proofs of the copies of the transaction from files", mergeFlags},
+	"verify":    {"Verify proofs of the transaction from file against multisig script", verifyFlags},
+	"broadcast": {"Broadcast the transaction from file", broadcastFlags},
+}
+
+// runUnsigned exports the transaction of the given sender without proofs.
+func runUnsigned(c *common, build builder) error {
+	if c.Broadcast {
+		return errors.New("unsigned transaction can't be broadcasted")
+	}
+	if c.Format != "json" {
+		return errors.New("unsigned transaction is exported as JSON only")
+	}
+	if c.Sender == "" {
+		return errors.New("no sender public key provided")
+	}
+	sender, err := crypto.NewPublicKeyFromBase58(c.Sender)
+	if err != nil {
+		return errors.Wrap(err, "invalid sender public key")
+	}
+	// the unsigned transaction is meant for the account with script that checks proofs of several parties
+	tx, err := buildWithFee(sender, true, c, build)
+	if err != nil {
+		return err
+	}
+	if err := multisig.Prepare(tx); err != nil {
+		return err
+	}
+	if _, err := tx.Valid(); err != nil {
+		return errors.Wrap(err, "invalid transaction")
+	}
+	return output(c.Format, c.Out, tx)
+}
+
+func schemeFlag(f *flag.FlagSet) *string {
+	return f.String("scheme", "W", "Scheme of the network, W for MainNet, T for TestNet")
+}
+
+func readTransaction(name, scheme string) (proto.Transaction, error) {
+	if name == "" {
+		return nil, errors.New("no transaction file provided")
+	}
+	if len(scheme) != 1 {
+		return nil, errors.Errorf("invalid scheme '%s'", scheme)
+	}
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := multisig.ReadTransaction(b, scheme[0])
+	if err != nil {
+		return nil, errors.Wrapf(err, "file '%s'", name)
+	}
+	return tx, nil
+}
+
+func proofFlags(f *flag.FlagSet) func(args []string) error {
+	k := &keyOpts{}
+	keyFlags(f, k)
+	scheme := schemeFlag(f)
+	txFile := f.String("tx", "", "File of the transaction in JSON")
+	position := f.Int("position", 0, "Position of the proof")
+	out := f.StringP("out", "o", "", "Write the transaction with proof to file instead of stdout")
+	return func(args []string) error {
+		tx, err := readTransaction(*txFile, *scheme)
+		if err != nil {
+			return err
+		}
+		secretKey, err := getSecretKey(k)
+		if err != nil {
+			return err
+		}
+		if err := multisig.Sign(tx, *position, secretKey); err != nil {
+			return err
+		}
+		return output("json", *out, tx)
+	}
+}
+
+func mergeFlags(f *flag.FlagSet) func(args []string) error {
+	scheme := schemeFlag(f)
+	out := f.StringP("out", "o", "", "Write the merged transaction to file instead of stdout")
+	return func(args []string) error {
+		if len(args) == 0 {
+			return errors.New("no transaction files provided")
+		}
+		txs := make([]proto.Transaction, len(args))
+		for i, name := range args {
+			tx, err := readTransaction(name, *scheme)
+			if err != nil {
+				return err
+			}
+			txs[i] = tx
+		}
+		if err := multisig.Merge(txs[0], txs[1:]...); err != nil {
+			return err
+		}
+		return output("json", *out, txs[0])
+	}
+}
+
+// readAST reads the script from file of the source code or the compiled script and parses it.
+func readAST(name string) (*ast.Script, error) {
+	if name == "" {
+		return nil, errors.New("no script provided")
+	}
+	b, err := readScript(name)
+	if err != nil {
+		return nil, err
+	}
+	r, err := reader.NewReaderFromScript(b)
+	if err != nil {
+		return nil, err
+	}
+	return astparser.BuildScript(r)
+}
+
+func verifyFlags(f *flag.FlagSet) func(args []string) error {
+	scheme := schemeFlag(f)
+	txFile := f.String("tx", "", "File of the transaction in JSON")
+	scriptFile := f.String("script", "", "File of the script of the sender account, compiled or source code")
+	keys := f.String("keys", "", "Comma separated public keys of parties in the order of positions of their proofs, optional")
+	height := f.Uint64("height", 1, "Height of the blockchain the script is evaluated at")
+	return func(args []string) error {
+		tx, err := readTransaction(*txFile, *scheme)
+		if err != nil {
+			return err
+		}
+		if *keys != "" {
+			var pks []crypto.PublicKey
+			for _, s := range strings.Split(*keys, ",") {
+				pk, err := crypto.NewPublicKeyFromBase58(strings.TrimSpace(s))
+				if err != nil {
+					return errors.Wrapf(err, "invalid public key '%s'", s)
+				}
+				pks = append(pks, pk)
+			}
+			valid, err := multisig.VerifyProofs(tx, pks)
+			if err != nil {
+				return err
+			}
+			for i, ok := range valid {
+				fmt.Printf("proof %d of %s: %t\n", i, pks[i].String(), ok)
+			}
+		}
+		script, err := readAST(*scriptFile)
+		if err != nil {
+			return err
+		}
+		state := mockstate.MockStateImpl{
+			CurrentHeight: *height,
+			BlockHeaders:  map[uint64]*proto.BlockHeader{*height: {}},
+		}
+		ok, err := multisig.VerifyScript(tx, script, (*scheme)[0], state)
+		if err != nil {
+			return errors.Wrap(err, "failed to evaluate script")
+		}
+		fmt.Printf("script: %t\n", ok)
+		if !ok {
+			os.Exit(1)
+		}
+		return nil
+	}
+}
+
+func broadcastFlags(f *flag.FlagSet) func(args []string) error {
+	scheme := schemeFlag(f)
+	txFile := f.String("tx", "", "File of the transaction in JSON")
+	node := f.String("node", "", "URL of the node API to broadcast the transaction, the default node of the network by default")
+	return func(args []string) error {
+		tx, err := readTransaction(*txFile, *scheme)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Valid(); err != nil {
+			return errors.Wrap(err, "invalid transaction")
+		}
+		u, err := nodeURL(*node, (*scheme)[0])
+		if err != nil {
+			return err
+		}
+		return broadcast(u, tx)
+	}
+}
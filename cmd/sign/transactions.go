@@ -0,0 +1,447 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	flag "github.com/spf13/pflag"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride/compiler"
+)
+
+func versionFlag(f *flag.FlagSet) *int {
+	return f.Int("version", 2, "Version of the transaction, 1 or 2")
+}
+
+func checkVersion(v int) error {
+	if v != 1 && v != 2 {
+		return errors.Errorf("unsupported version %d, expected 1 or 2", v)
+	}
+	return nil
+}
+
+// recipient parses the address or the alias in form "alias:<scheme>:<alias>" and checks that it belongs to the network.
+func recipient(s string, scheme byte) (proto.Recipient, error) {
+	if s == "" {
+		return proto.Recipient{}, errors.New("no recipient provided")
+	}
+	if strings.HasPrefix(s, "alias:") {
+		a, err := proto.NewAliasFromString(s)
+		if err != nil {
+			return proto.Recipient{}, err
+		}
+		if ok, err := a.Valid(); !ok {
+			return proto.Recipient{}, errors.Wrapf(err, "invalid alias '%s'", s)
+		}
+		if a.Scheme != scheme {
+			return proto.Recipient{}, errors.Errorf("alias '%s' belongs to other network", s)
+		}
+		return proto.NewRecipientFromAlias(*a), nil
+	}
+	a, err := address(s, scheme)
+	if err != nil {
+		return proto.Recipient{}, err
+	}
+	return proto.NewRecipientFromAddress(a), nil
+}
+
+func address(s string, scheme byte) (proto.Address, error) {
+	a, err := proto.NewAddressFromString(s)
+	if err != nil {
+		return proto.Address{}, err
+	}
+	if ok, err := a.Valid(); !ok {
+		return proto.Address{}, errors.Wrapf(err, "invalid address '%s'", s)
+	}
+	if a[1] != scheme {
+		return proto.Address{}, errors.Errorf("address '%s' belongs to other network", s)
+	}
+	return a, nil
+}
+
+func optionalAsset(s string) (proto.OptionalAsset, error) {
+	a, err := proto.NewOptionalAssetFromString(s)
+	if err != nil {
+		return proto.OptionalAsset{}, err
+	}
+	return *a, nil
+}
+
+func digest(name, s string) (crypto.Digest, error) {
+	if s == "" {
+		return crypto.Digest{}, errors.Errorf("no %s provided", name)
+	}
+	d, err := crypto.NewDigestFromBase58(s)
+	if err != nil {
+		return crypto.Digest{}, errors.Wrapf(err, "invalid %s", name)
+	}
+	return d, nil
+}
+
+// readScript reads the script from the file, which holds either the compiled script as base64 string with "base64:"
+// prefix or the source code of the script. Empty name gives no script.
+func readScript(name string) ([]byte, error) {
+	if name == "" {
+		return nil, nil
+	}
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	s := strings.TrimSpace(string(b))
+	if strings.HasPrefix(s, "base64:") {
+		script, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(s, "base64:"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid compiled script")
+		}
+		return script, nil
+	}
+	script, err := compiler.Compile(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compile script")
+	}
+	return script.Bytes, nil
+}
+
+func transferFlags(f *flag.FlagSet) builder {
+	version := versionFlag(f)
+	amount := f.Uint64P("amount", "a", 0, "Amount to send")
+	rcp := f.StringP("recipient", "r", "", "Address or alias of recipient")
+	asset := f.String("asset", "WAVES", "Asset to send")
+	feeAsset := f.String("fee-asset", "WAVES", "Sponsored asset to pay the fee")
+	attachment := f.String("attachment", "", "Attachment, optional")
+	return func(sender crypto.PublicKey, c *common) (signable, error) {
+		if err := checkVersion(*version); err != nil {
+			return nil, err
+		}
+		if *amount == 0 {
+			return nil, errors.New("amount should be positive")
+		}
+		r, err := recipient(*rcp, c.scheme())
+		if err != nil {
+			return nil, err
+		}
+		a, err := optionalAsset(*asset)
+		if err != nil {
+			return nil, err
+		}
+		fa, err := optionalAsset(*feeAsset)
+		if err != nil {
+			return nil, err
+		}
+		if *version == 1 {
+			return proto.NewUnsignedTransferV1(sender, a, fa, c.Timestamp, *amount, c.Fee, r, *attachment), nil
+		}
+		return proto.NewUnsignedTransferV2(sender, a, fa, c.Timestamp, *amount, c.Fee, r, *attachment), nil
+	}
+}
+
+func issueFlags(f *flag.FlagSet) builder {
+	version := versionFlag(f)
+	name := f.String("name", "", "Name of the asset")
+	description := f.String("description", "", "Description of the asset")
+	quantity := f.Uint64("quantity", 0, "Quantity of the asset in minimal units")
+	decimals := f.Uint8("decimals", 8, "Number of decimals of the asset")
+	reissuable := f.Bool("reissuable", false, "Whether the asset could be reissued")
+	scriptFile := f.String("script", "", "File of the asset script, compiled or source code, version 2 only")
+	return func(sender crypto.PublicKey, c *common) (signable, error) {
+		if err := checkVersion(*version); err != nil {
+			return nil, err
+		}
+		script, err := readScript(*scriptFile)
+		if err != nil {
+			return nil, err
+		}
+		if *version == 1 {
+			if script != nil {
+				return nil, errors.New("script is not supported by version 1")
+			}
+			return proto.NewUnsignedIssueV1(sender, *name, *description, *quantity, *decimals, *reissuable, c.Timestamp, c.Fee), nil
+		}
+		return proto.NewUnsignedIssueV2(c.scheme(), sender, *name, *description, *quantity, *decimals, *reissuable, script, c.Timestamp, c.Fee), nil
+	}
+}
+
+func reissueFlags(f *flag.FlagSet) builder {
+	version := versionFlag(f)
+	asset := f.String("asset", "", "ID of the asset")
+	quantity := f.Uint64("quantity", 0, "Quantity to reissue in minimal units")
+	reissuable := f.Bool("reissuable", true, "Whether the asset could be reissued later")
+	return func(sender crypto.PublicKey, c *common) (signable, error) {
+		if err := checkVersion(*version); err != nil {
+			return nil, err
+		}
+		id, err := digest("asset", *asset)
+		if err != nil {
+			return nil, err
+		}
+		if *version == 1 {
+			return proto.NewUnsignedReissueV1(sender, id, *quantity, *reissuable, c.Timestamp, c.Fee), nil
+		}
+		return proto.NewUnsignedReissueV2(c.scheme(), sender, id, *quantity, *reissuable, c.Timestamp, c.Fee), nil
+	}
+}
+
+func burnFlags(f *flag.FlagSet) builder {
+	version := versionFlag(f)
+	asset := f.String("asset", "", "ID of the asset")
+	amount := f.Uint64P("amount", "a", 0, "Amount to burn in minimal units")
+	return func(sender crypto.PublicKey, c *common) (signable, error) {
+		if err := checkVersion(*version); err != nil {
+			return nil, err
+		}
+		id, err := digest("asset", *asset)
+		if err != nil {
+			return nil, err
+		}
+		if *version == 1 {
+			return proto.NewUnsignedBurnV1(sender, id, *amount, c.Timestamp, c.Fee), nil
+		}
+		return proto.NewUnsignedBurnV2(c.scheme(), sender, id, *amount, c.Timestamp, c.Fee), nil
+	}
+}
+
+func leaseFlags(f *flag.FlagSet) builder {
+	version := versionFlag(f)
+	amount := f.Uint64P("amount", "a", 0, "Amount of WAVES to lease")
+	rcp := f.StringP("recipient", "r", "", "Address or alias of recipient")
+	return func(sender crypto.PublicKey, c *common) (signable, error) {
+		if err := checkVersion(*version); err != nil {
+			return nil, err
+		}
+		r, err := recipient(*rcp, c.scheme())
+		if err != nil {
+			return nil, err
+		}
+		if *version == 1 {
+			return proto.NewUnsignedLeaseV1(sender, r, *amount, c.Fee, c.Timestamp), nil
+		}
+		return proto.NewUnsignedLeaseV2(sender, r, *amount, c.Fee, c.Timestamp), nil
+	}
+}
+
+func leaseCancelFlags(f *flag.FlagSet) builder {
+	version := versionFlag(f)
+	lease := f.String("lease", "", "ID of the lease transaction")
+	return func(sender crypto.PublicKey, c *common) (signable, error) {
+		if err := checkVersion(*version); err != nil {
+			return nil, err
+		}
+		id, err := digest("lease", *lease)
+		if err != nil {
+			return nil, err
+		}
+		if *version == 1 {
+			return proto.NewUnsignedLeaseCancelV1(sender, id, c.Fee, c.Timestamp), nil
+		}
+		return proto.NewUnsignedLeaseCancelV2(c.scheme(), sender, id, c.Fee, c.Timestamp), nil
+	}
+}
+
+func aliasFlags(f *flag.FlagSet) builder {
+	version := versionFlag(f)
+	name := f.String("alias", "", "Alias to create, without prefix and scheme")
+	return func(sender crypto.PublicKey, c *common) (signable, error) {
+		if err := checkVersion(*version); err != nil {
+			return nil, err
+		}
+		a := proto.NewAlias(c.scheme(), *name)
+		if ok, err := a.Valid(); !ok {
+			return nil, err
+		}
+		if *version == 1 {
+			return proto.NewUnsignedCreateAliasV1(sender, *a, c.Fee, c.Timestamp), nil
+		}
+		return proto.NewUnsignedCreateAliasV2(sender, *a, c.Fee, c.Timestamp), nil
+	}
+}
+
+// readTransfers reads lines "recipient,amount" of CSV file.
+func readTransfers(name string, scheme byte) ([]proto.MassTransferEntry, error) {
+	if name == "" {
+		return nil, errors.New("no CSV file of transfers provided")
+	}
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	r := csv.NewReader(file)
+	r.FieldsPerRecord = 2
+	r.TrimLeadingSpace = true
+	var transfers []proto.MassTransferEntry
+	for line := 1; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		rcp, err := recipient(record[0], scheme)
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", line)
+		}
+		amount, err := strconv.ParseUint(record[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", line)
+		}
+		transfers = append(transfers, proto.MassTransferEntry{Recipient: rcp, Amount: amount})
+	}
+	if len(transfers) == 0 {
+		return nil, errors.New("no transfers")
+	}
+	return transfers, nil
+}
+
+func massTransferFlags(f *flag.FlagSet) builder {
+	csvFile := f.String("csv", "", "CSV file of transfers, each line is recipient and amount")
+	asset := f.String("asset", "WAVES", "Asset to send")
+	attachment := f.String("attachment", "", "Attachment, optional")
+	return func(sender crypto.PublicKey, c *common) (signable, error) {
+		transfers, err := readTransfers(*csvFile, c.scheme())
+		if err != nil {
+			return nil, err
+		}
+		a, err := optionalAsset(*asset)
+		if err != nil {
+			return nil, err
+		}
+		return proto.NewUnsignedMassTransferV1(sender, a, transfers, c.Fee, c.Timestamp, *attachment), nil
+	}
+}
+
+func dataFlags(f *flag.FlagSet) builder {
+	jsonFile := f.String("json", "", "JSON file with the array of data entries in the format of the node API")
+	return func(sender crypto.PublicKey, c *common) (signable, error) {
+		if *jsonFile == "" {
+			return nil, errors.New("no JSON file of data entries provided")
+		}
+		b, err := ioutil.ReadFile(*jsonFile)
+		if err != nil {
+			return nil, err
+		}
+		var entries proto.DataEntries
+		if err := json.Unmarshal(b, &entries); err != nil {
+			return nil, err
+		}
+		tx := proto.NewUnsignedData(sender, c.Fee, c.Timestamp)
+		for _, e := range entries {
+			if err := tx.AppendEntry(e); err != nil {
+				return nil, err
+			}
+		}
+		return tx, nil
+	}
+}
+
+func setScriptFlags(f *flag.FlagSet) builder {
+	scriptFile := f.String("script", "", "File of the account script, compiled or source code, the script is removed if omitted")
+	return func(sender crypto.PublicKey, c *common) (signable, error) {
+		script, err := readScript(*scriptFile)
+		if err != nil {
+			return nil, err
+		}
+		return proto.NewUnsignedSetScriptV1(c.scheme(), sender, script, c.Fee, c.Timestamp), nil
+	}
+}
+
+func sponsorshipFlags(f *flag.FlagSet) builder {
+	asset := f.String("asset", "", "ID of the asset")
+	minFee := f.Uint64("min-fee", 0, "Minimal fee in the asset, 0 cancels the sponsorship")
+	return func(sender crypto.PublicKey, c *common) (signable, error) {
+		id, err := digest("asset", *asset)
+		if err != nil {
+			return nil, err
+		}
+		return proto.NewUnsignedSponsorshipV1(sender, id, *minFee, c.Fee, c.Timestamp), nil
+	}
+}
+
+func setAssetScriptFlags(f *flag.FlagSet) builder {
+	asset := f.String("asset", "", "ID of the asset")
+	scriptFile := f.String("script", "", "File of the asset script, compiled or source code")
+	return func(sender crypto.PublicKey, c *common) (signable, error) {
+		id, err := digest("asset", *asset)
+		if err != nil {
+			return nil, err
+		}
+		if *scriptFile == "" {
+			return nil, errors.New("no script provided")
+		}
+		script, err := readScript(*scriptFile)
+		if err != nil {
+			return nil, err
+		}
+		return proto.NewUnsignedSetAssetScriptV1(c.scheme(), sender, id, script, c.Fee, c.Timestamp), nil
+	}
+}
+
+// arguments parses the JSON array of arguments of the function call.
+func arguments(s string) (proto.Arguments, error) {
+	var out proto.Arguments
+	if err := json.Unmarshal([]byte(s), &out); err != nil {
+		return nil, errors.Wrap(err, "invalid arguments")
+	}
+	return out, nil
+}
+
+// payments parses payments in form "amount" for WAVES or "amount:asset".
+func payments(ss []string) (proto.ScriptPayments, error) {
+	var out proto.ScriptPayments
+	for _, s := range ss {
+		ps := strings.SplitN(s, ":", 2)
+		amount, err := strconv.ParseUint(ps[0], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid payment '%s'", s)
+		}
+		var a proto.OptionalAsset
+		if len(ps) == 2 {
+			a, err = optionalAsset(ps[1])
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid payment '%s'", s)
+			}
+		}
+		out.Append(proto.ScriptPayment{Amount: amount, Asset: a})
+	}
+	return out, nil
+}
+
+func invokeScriptFlags(f *flag.FlagSet) builder {
+	dApp := f.String("dapp", "", "Address of the dApp")
+	function := f.String("function", "", "Name of the function")
+	args := f.String("args", "[]", "JSON array of arguments in the format of the node API")
+	pmts := f.StringArray("payment", nil, "Payment in form AMOUNT for WAVES or AMOUNT:ASSET, could be repeated")
+	feeAsset := f.String("fee-asset", "WAVES", "Sponsored asset to pay the fee")
+	return func(sender crypto.PublicKey, c *common) (signable, error) {
+		a, err := address(*dApp, c.scheme())
+		if err != nil {
+			return nil, err
+		}
+		if *function == "" {
+			return nil, errors.New("no function provided")
+		}
+		callArgs, err := arguments(*args)
+		if err != nil {
+			return nil, err
+		}
+		ps, err := payments(*pmts)
+		if err != nil {
+			return nil, err
+		}
+		fa, err := optionalAsset(*feeAsset)
+		if err != nil {
+			return nil, err
+		}
+		call := proto.FunctionCall{Name: *function, Arguments: callArgs}
+		return proto.NewUnsignedInvokeScriptV1(c.scheme(), sender, a, call, ps, fa, c.Fee, c.Timestamp), nil
+	}
+}
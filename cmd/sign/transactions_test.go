@@ -0,0 +1,127 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+const (
+	testNetAddress = "3MqBoAUmn1XKCebApkszLSUqFpGf5yQZqeL"
+	mainNetAddress = "3P2HNUd5VUPLMQkJmctTPEeeHumiPN2GkTb"
+	testAsset      = "AxAmJaro7BJ4KasYiZhw7HkjwgYtt2nekPuF2CN9LMym"
+)
+
+func TestReadTransfers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sign")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	addr, err := proto.NewAddressFromString(testNetAddress)
+	require.NoError(t, err)
+	alias := proto.NewAlias(proto.TestNetScheme, "merry")
+
+	for i, tc := range []struct {
+		csv       string
+		transfers []proto.MassTransferEntry
+		err       string
+	}{
+		{testNetAddress + ",1000\nalias:T:merry, 2000\n", []proto.MassTransferEntry{
+			{Recipient: proto.NewRecipientFromAddress(addr), Amount: 1000},
+			{Recipient: proto.NewRecipientFromAlias(*alias), Amount: 2000},
+		}, ""},
+		{"", nil, "no transfers"},
+		{testNetAddress + ",1000\n" + testNetAddress + ",-1\n", nil, "line 2: strconv.ParseUint: parsing \"-1\": invalid syntax"},
+		{testNetAddress + ",amount\n", nil, "line 1: strconv.ParseUint: parsing \"amount\": invalid syntax"},
+		{testNetAddress + "\n", nil, "record on line 1: wrong number of fields"},
+		{testNetAddress + ",1,2\n", nil, "record on line 1: wrong number of fields"},
+		{mainNetAddress + ",1000\n", nil, "line 1: address '" + mainNetAddress + "' belongs to other network"},
+		{"3MqBoAUmn1XKCebApkszLSUqFpGf5yQZqeM,1000\n", nil, "line 1: failed to create an Address from Base58 string: invalid address: invalid Address checksum"},
+		{"alias:W:merry,1000\n", nil, "line 1: alias 'alias:W:merry' belongs to other network"},
+		{"alias:T:me,1000\n", nil, "line 1: invalid alias 'alias:T:me': alias length should be between 4 and 30"},
+		{",1000\n", nil, "line 1: no recipient provided"},
+	} {
+		name := filepath.Join(dir, "transfers.csv")
+		require.NoError(t, ioutil.WriteFile(name, []byte(tc.csv), 0644))
+		transfers, err := readTransfers(name, proto.TestNetScheme)
+		if tc.err != "" {
+			assert.EqualError(t, err, tc.err, i)
+			continue
+		}
+		require.NoError(t, err, i)
+		assert.Equal(t, tc.transfers, transfers, i)
+	}
+	_, err = readTransfers("", proto.TestNetScheme)
+	assert.EqualError(t, err, "no CSV file of transfers provided")
+}
+
+func TestPayments(t *testing.T) {
+	asset, err := crypto.NewDigestFromBase58(testAsset)
+	require.NoError(t, err)
+
+	for i, tc := range []struct {
+		args     []string
+		payments proto.ScriptPayments
+		err      string
+	}{
+		{nil, nil, ""},
+		{[]string{"100"}, proto.ScriptPayments{{Amount: 100}}, ""},
+		{[]string{"100:WAVES", "5:" + testAsset}, proto.ScriptPayments{{Amount: 100}, {Amount: 5, Asset: proto.OptionalAsset{Present: true, ID: asset}}}, ""},
+		{[]string{"-1"}, nil, "invalid payment '-1': strconv.ParseUint: parsing \"-1\": invalid syntax"},
+		{[]string{":" + testAsset}, nil, "invalid payment ':" + testAsset + "': strconv.ParseUint: parsing \"\": invalid syntax"},
+		{[]string{"5:asset"}, nil, "invalid payment '5:asset'"},
+	} {
+		payments, err := payments(tc.args)
+		if tc.err != "" {
+			require.Error(t, err, i)
+			assert.Contains(t, err.Error(), tc.err, i)
+			continue
+		}
+		require.NoError(t, err, i)
+		assert.Equal(t, tc.payments, payments, i)
+	}
+}
+
+func TestArguments(t *testing.T) {
+	for i, tc := range []struct {
+		json string
+		args proto.Arguments
+		err  bool
+	}{
+		{"[]", proto.Arguments{}, false},
+		{`[{"type": "integer", "value": 1}, {"type": "string", "value": "a"}, {"type": "boolean", "value": true}]`,
+			proto.Arguments{&proto.IntegerArgument{Value: 1}, &proto.StringArgument{Value: "a"}, &proto.BooleanArgument{Value: true}}, false},
+		{`[{"type": "binary", "value": "base64:AQI="}]`, proto.Arguments{&proto.BinaryArgument{Value: []byte{1, 2}}}, false},
+		{"", nil, true},
+		{"{}", nil, true},
+		{`[{"type": "list", "value": []}]`, nil, true},
+		{`[{"type": "integer", "value": "1"}]`, nil, true},
+	} {
+		args, err := arguments(tc.json)
+		if tc.err {
+			assert.Error(t, err, i)
+			continue
+		}
+		require.NoError(t, err, i)
+		assert.Equal(t, tc.args, args, i)
+	}
+}
+
+func TestNodeURL(t *testing.T) {
+	u, err := nodeURL("", proto.MainNetScheme)
+	require.NoError(t, err)
+	assert.Equal(t, "https://nodes.wavesnodes.com", u)
+	u, err = nodeURL("", proto.TestNetScheme)
+	require.NoError(t, err)
+	assert.Equal(t, "https://nodes-testnet.wavesnodes.com", u)
+	u, err = nodeURL("http://127.0.0.1:6869", proto.TestNetScheme)
+	require.NoError(t, err)
+	assert.Equal(t, "http://127.0.0.1:6869", u)
+	_, err = nodeURL("", 'X')
+	assert.EqualError(t, err, "no default node of network 'X', provide it with --node")
+}
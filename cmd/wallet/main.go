@@ -6,10 +6,12 @@ import (
 	"os"
 	"os/user"
 	"path"
+	"path/filepath"
+	"strconv"
 
 	"github.com/howeyc/gopass"
+	"github.com/pkg/errors"
 	flag "github.com/spf13/pflag"
-	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/wallet"
 )
 
@@ -19,82 +21,268 @@ Usage:
   wallet command [flags]
 
 Available Commands:
-  create       Create wallet
-  show         Print wallet data
+  create                     Create wallet from the new or the imported seed phrase
+  show                       Print keys and address of the account
+  list                       Print accounts of the wallet with their addresses
+  add LABEL [INDEX]          Add the next derived account or restore the account with the index
+  remove LABEL               Remove the account
+  rename LABEL NEW_LABEL     Change the label of the account
+  export public|private|seed Print public or private key of the account, or seed of the wallet
+  reencrypt                  Encrypt the wallet anew with the new salt, optionally changing the password
 
 `
 
 type Opts struct {
 	Force        bool
+	RawSeed      bool
 	PathToWallet string
+	Account      string
+	Scheme       string
 }
 
 func main() {
 	opts := Opts{}
 
 	flag.BoolVarP(&opts.Force, "force", "f", false, "Overwrite existing wallet")
+	flag.BoolVar(&opts.RawSeed, "raw-seed", false, "Accept any seed, not only the phrase of the Waves dictionary")
 	flag.StringVarP(&opts.PathToWallet, "wallet", "w", "", "Path to wallet")
+	flag.StringVarP(&opts.Account, "account", "a", "", "Label of the account, the default account by default")
+	flag.StringVarP(&opts.Scheme, "scheme", "s", "W", "Scheme of the network of addresses, W for MainNet, T for TestNet")
 
 	flag.Parse()
 
-	command := flag.Arg(0)
+	if len(opts.Scheme) != 1 {
+		showUsageAndExit()
+	}
 
-	switch command {
-	case "create":
-		createWallet(opts)
-	case "show":
-		show(opts)
+	var err error
+	switch command, args := flag.Arg(0), flag.Args(); {
+	case command == "create":
+		err = createWallet(opts)
+	case command == "show":
+		err = show(opts)
+	case command == "list":
+		err = list(opts)
+	case command == "add" && len(args) == 2:
+		err = modify(opts, func(w *wallet.Wallet) error {
+			_, err := w.AddAccount(args[1])
+			return err
+		})
+	case command == "add" && len(args) == 3:
+		index, perr := strconv.ParseUint(args[2], 10, 32)
+		if perr != nil {
+			err = errors.Wrap(perr, "invalid account index")
+			break
+		}
+		err = modify(opts, func(w *wallet.Wallet) error {
+			_, err := w.AddAccountWithIndex(args[1], uint32(index))
+			return err
+		})
+	case command == "remove" && len(args) == 2:
+		err = modify(opts, func(w *wallet.Wallet) error { return w.RemoveAccount(args[1]) })
+	case command == "rename" && len(args) == 3:
+		err = modify(opts, func(w *wallet.Wallet) error { return w.RenameAccount(args[1], args[2]) })
+	case command == "export" && len(args) == 2:
+		err = export(opts, args[1])
+	case command == "reencrypt":
+		err = reencrypt(opts)
 	default:
 		showUsageAndExit()
 	}
-}
-
-func show(opts Opts) {
-	walletPath := getWalletPath(opts.PathToWallet)
-	if !exists(walletPath) {
-		fmt.Println("Err: wallet not found")
-		return
+	if err != nil {
+		fmt.Printf("Err: %s\n", err.Error())
+		os.Exit(1)
 	}
+}
 
-	fmt.Print("Enter password: ")
+func readPassword(prompt string) ([]byte, error) {
+	fmt.Print(prompt)
 	pass, err := gopass.GetPasswd()
 	if err != nil {
-		fmt.Println("Interrupt")
-		return
+		return nil, errors.New("interrupt")
 	}
-
 	if len(pass) == 0 {
-		fmt.Println("Err: password required")
-		return
+		return nil, errors.New("password required")
 	}
+	return pass, nil
+}
 
+// openWallet reads the wallet and returns it with the password it is encrypted with.
+func openWallet(opts Opts) (*wallet.Wallet, []byte, error) {
+	walletPath := getWalletPath(opts.PathToWallet)
+	if !exists(walletPath) {
+		return nil, nil, errors.New("wallet not found")
+	}
+	pass, err := readPassword("Enter password: ")
+	if err != nil {
+		return nil, nil, err
+	}
 	b, err := ioutil.ReadFile(walletPath)
 	if err != nil {
-		fmt.Printf("Err: %s\n", err.Error())
-		return
+		return nil, nil, err
 	}
-
 	wlt, err := wallet.Decode(b, pass)
 	if err != nil {
-		fmt.Printf("Err: %s\n", err.Error())
-		return
+		return nil, nil, err
 	}
+	return wlt, pass, nil
+}
 
-	priv, pub, err := wlt.GenPair()
+func saveWallet(opts Opts, wlt *wallet.Wallet, pass []byte) error {
+	bts, err := wlt.Encode(pass)
 	if err != nil {
-		fmt.Printf("Err: %s\n", err.Error())
-		return
+		return err
 	}
+	return writeFileAtomic(getWalletPath(opts.PathToWallet), bts, 0600)
+}
 
-	addr, err := proto.NewAddressFromPublicKey(proto.MainNetScheme, pub)
+// writeFileAtomic writes the data to the temporary file in the same directory and renames it to the name, so the
+// interrupted write never leaves the file truncated.
+func writeFileAtomic(name string, data []byte, perm os.FileMode) error {
+	f, err := ioutil.TempFile(filepath.Dir(name), filepath.Base(name)+".tmp")
 	if err != nil {
-		fmt.Printf("Err: %s\n", err.Error())
-		return
+		return err
+	}
+	tmp := f.Name()
+	defer os.Remove(tmp)
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Chmod(perm); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, name)
+}
+
+func selectAccount(opts Opts, wlt *wallet.Wallet) (wallet.Account, error) {
+	if opts.Account != "" {
+		return wlt.Account(opts.Account)
+	}
+	acc, err := wlt.Account(wallet.DefaultLabel)
+	if err != nil {
+		return wallet.Account{}, errors.Wrap(err, "select the account with --account")
 	}
+	return acc, nil
+}
 
+func show(opts Opts) error {
+	wlt, _, err := openWallet(opts)
+	if err != nil {
+		return err
+	}
+	acc, err := selectAccount(opts, wlt)
+	if err != nil {
+		return err
+	}
+	priv, pub, err := wlt.KeyPair(acc)
+	if err != nil {
+		return err
+	}
+	addr, err := wlt.Address(acc, opts.Scheme[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("account: %s\n", acc.Label)
 	fmt.Printf("private: %s\n", priv.String())
 	fmt.Printf("public: %s\n", pub.String())
 	fmt.Printf("addr: %s\n", addr.String())
+	return nil
+}
+
+func list(opts Opts) error {
+	wlt, _, err := openWallet(opts)
+	if err != nil {
+		return err
+	}
+	for _, acc := range wlt.Accounts() {
+		addr, err := wlt.Address(acc, opts.Scheme[0])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%-20s %5d %s\n", acc.Label, acc.Index, addr.String())
+	}
+	return nil
+}
+
+func modify(opts Opts, f func(w *wallet.Wallet) error) error {
+	wlt, pass, err := openWallet(opts)
+	if err != nil {
+		return err
+	}
+	if err := f(wlt); err != nil {
+		return err
+	}
+	if err := saveWallet(opts, wlt, pass); err != nil {
+		return err
+	}
+	fmt.Println("Saved!")
+	return nil
+}
+
+func export(opts Opts, what string) error {
+	if what != "public" && what != "private" && what != "seed" {
+		return errors.Errorf("unknown export '%s', expected public, private or seed", what)
+	}
+	wlt, _, err := openWallet(opts)
+	if err != nil {
+		return err
+	}
+	if what == "seed" {
+		fmt.Println(string(wlt.Seed()))
+		return nil
+	}
+	acc, err := selectAccount(opts, wlt)
+	if err != nil {
+		return err
+	}
+	priv, pub, err := wlt.KeyPair(acc)
+	if err != nil {
+		return err
+	}
+	if what == "public" {
+		fmt.Println(pub.String())
+	} else {
+		fmt.Println(priv.String())
+	}
+	return nil
+}
+
+// reencrypt writes the wallet in the current authenticated format, wallets of the legacy format are migrated.
+func reencrypt(opts Opts) error {
+	wlt, pass, err := openWallet(opts)
+	if err != nil {
+		return err
+	}
+	fmt.Print("Enter new password (empty to keep the current one): ")
+	newPass, err := gopass.GetPasswd()
+	if err != nil {
+		return errors.New("interrupt")
+	}
+	if len(newPass) != 0 {
+		confirm, err := readPassword("Repeat new password: ")
+		if err != nil {
+			return err
+		}
+		if string(confirm) != string(newPass) {
+			return errors.New("passwords don't match")
+		}
+		pass = newPass
+	}
+	if err := saveWallet(opts, wlt, pass); err != nil {
+		return err
+	}
+	fmt.Println("Saved!")
+	return nil
 }
 
 func showUsageAndExit() {
@@ -103,52 +291,49 @@ func showUsageAndExit() {
 	os.Exit(0)
 }
 
-func createWallet(opts Opts) {
+func createWallet(opts Opts) error {
 	walletPath := getWalletPath(opts.PathToWallet)
-	if exists(walletPath) {
-		if !opts.Force {
-			fmt.Println("Err: Wallet exists, use --force to overwrite")
-			return
-		}
+	if exists(walletPath) && !opts.Force {
+		return errors.New("wallet exists, use --force to overwrite")
 	}
 
-	fmt.Print("Enter password: ")
-	pass, err := gopass.GetPasswd()
+	pass, err := readPassword("Enter password: ")
 	if err != nil {
-		fmt.Println("Interrupt")
-		return
-	}
-
-	if len(pass) == 0 {
-		fmt.Println("Err: Password required")
-		return
+		return err
 	}
 
-	fmt.Print("Enter seed: ")
+	fmt.Print("Enter seed phrase (empty to generate the new one): ")
 	seed, err := gopass.GetPasswd()
 	if err != nil {
-		fmt.Println("Interrupt")
-		return
+		return errors.New("interrupt")
 	}
 
-	wlt, err := wallet.NewWalletFromSeed(seed)
-	if err != nil {
-		fmt.Printf("Err: %s\n", err.Error())
-		return
+	var wlt *wallet.Wallet
+	switch {
+	case len(seed) == 0:
+		phrase, err := wallet.GenerateSeedPhrase()
+		if err != nil {
+			return err
+		}
+		fmt.Println("Write down the seed phrase and keep it in a safe place, it's the only way to restore the wallet:")
+		fmt.Println(phrase)
+		wlt, err = wallet.NewWalletFromSeedPhrase(phrase)
+	case opts.RawSeed:
+		wlt, err = wallet.NewWalletFromSeed(seed)
+	default:
+		wlt, err = wallet.NewWalletFromSeedPhrase(string(seed))
+		if err != nil {
+			return errors.Errorf("%s, use --raw-seed to import the seed anyway", err)
+		}
 	}
-
-	bts, err := wlt.Encode(pass)
 	if err != nil {
-		fmt.Printf("Err: %s\n", err.Error())
-		return
+		return err
 	}
-
-	err = ioutil.WriteFile(walletPath, bts, 0600)
-	if err != nil {
-		fmt.Printf("Err: %s\n", err.Error())
-		return
+	if err := saveWallet(opts, wlt, pass); err != nil {
+		return err
 	}
 	fmt.Println("Created!")
+	return nil
 }
 
 func userHomeDir() (string, error) {
@@ -8,6 +8,7 @@ import (
 	"github.com/wavesplatform/gowaves/cmd/wmd/internal/state"
 	"github.com/wavesplatform/gowaves/pkg/client"
 	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/metrics"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"go.uber.org/zap"
 	"net/http"
@@ -29,7 +30,7 @@ type Synchronizer struct {
 }
 
 func NewSynchronizer(interrupt <-chan struct{}, log *zap.SugaredLogger, storage *state.Storage, scheme byte, matcher crypto.PublicKey, node url.URL, interval int, lag int) (*Synchronizer, error) {
-	c, err := client.NewClient(client.Options{BaseUrl: node.String(), Client: &http.Client{}})
+	c, err := client.NewClient(client.Options{BaseUrl: node.String(), Client: &http.Client{Timeout: time.Minute}})
 	if err != nil {
 		return nil, errors.Wrapf(err, "Failed to create new synchronizer")
 	}
@@ -84,6 +85,12 @@ func (s *Synchronizer) synchronize() {
 	if s.interrupted() {
 		return
 	}
+	lag := 0
+	if rh > lh {
+		lag = rh - lh
+	}
+	metrics.Height(uint64(lh))
+	metrics.SyncHeightLag(lag)
 	if rh > lh {
 		s.log.Infof("Local height %d, node height %d", lh, rh)
 		ch, err := s.findLastCommonHeight(1, lh)
@@ -115,18 +122,37 @@ func (s *Synchronizer) synchronize() {
 
 func (s *Synchronizer) applyBlocks(start, end int) error {
 	s.log.Infof("Synchronizing %d blocks starting from height %d", end-start+1, start)
-	for h := start; h <= end; h++ {
-		if s.interrupted() {
-			return errors.New("synchronization was interrupted")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.interrupt:
+			cancel()
+		case <-ctx.Done():
 		}
-		b, err := s.nodeBlock(h)
-		if err != nil {
-			return err
+	}()
+	it := s.client.Blocks.SeqIterator(ctx, uint64(start), uint64(end), client.IteratorOptions{Prefetch: 2})
+	defer it.Release()
+	h := start
+	for it.Next() {
+		b := it.Block()
+		if int(b.Height) != h {
+			return errors.Errorf("Unexpected block at height %d, expected block at height %d", b.Height, h)
 		}
-		err = s.applyBlock(h, b.Signature, b.Transactions, int(b.TransactionCount), b.Generator)
+		err := s.applyBlock(h, b.Signature, b.Transactions, int(b.TransactionCount), b.Generator)
 		if err != nil {
 			return err
 		}
+		h++
+	}
+	if s.interrupted() {
+		return errors.New("synchronization was interrupted")
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	if h != end+1 {
+		return errors.Errorf("Node returned blocks up to height %d, expected blocks up to height %d", h-1, end)
 	}
 	return nil
 }
@@ -173,16 +199,6 @@ func (s *Synchronizer) nodeBlockSignature(height int) (crypto.Signature, error)
 	return header.Signature, nil
 }
 
-func (s *Synchronizer) nodeBlock(height int) (*client.Block, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	block, _, err := s.client.Blocks.At(ctx, uint64(height))
-	if err != nil {
-		return nil, err
-	}
-	return block, nil
-}
-
 func (s *Synchronizer) findLastCommonHeight(start, stop int) (int, error) {
 	var r int
 	for start <= stop {
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"github.com/pkg/errors"
@@ -8,6 +9,7 @@ import (
 	"github.com/wavesplatform/gowaves/cmd/wmd/internal/data"
 	"github.com/wavesplatform/gowaves/cmd/wmd/internal/state"
 	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/metrics"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"net/http"
@@ -42,6 +44,7 @@ func run() error {
 		rollback       = flag.Int("rollback", 0, "The height to rollback to before importing a blockchain file or staring the synchronization. Default value is 0 (no rollback).")
 		profilerPort   = flag.Int("profiler-port", 0, "Start HTTP profiler on given port (port must be between 1024 and 65535)")
 		cpuProfileFile = flag.String("cpu-profile", "", "Write CPU profile to the specified file")
+		metricsAddress = flag.String("metrics-address", "", "Local network address to serve Prometheus metrics on. No default value, metrics are disabled.")
 	)
 	flag.Parse()
 
@@ -71,6 +74,18 @@ func run() error {
 		}()
 	}
 
+	// Enable metrics server if requested
+	if *metricsAddress != "" {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			log.Infof("Metrics server listening on %s", *metricsAddress)
+			if err := metrics.Run(ctx, *metricsAddress); err != nil {
+				log.Errorf("Failed to start metrics server: %v", err)
+			}
+		}()
+	}
+
 	// Write cpu profile if requested
 	if *cpuProfileFile != "" {
 		f, err := os.Create(*cpuProfileFile)
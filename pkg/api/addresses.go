@@ -0,0 +1,161 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// Depth of generating balance calculation, same as in Scala node.
+const generatingBalanceDepth = 1000
+
+type AddressBalance struct {
+	Address       proto.Address `json:"address"`
+	Confirmations uint64        `json:"confirmations"`
+	Balance       uint64        `json:"balance"`
+}
+
+// AddressBalanceDetails has the available balance equal to the regular one, since leases are not tracked by the state.
+type AddressBalanceDetails struct {
+	Address    proto.Address `json:"address"`
+	Regular    uint64        `json:"regular"`
+	Generating uint64        `json:"generating"`
+	Available  uint64        `json:"available"`
+	Effective  uint64        `json:"effective"`
+}
+
+func addressParam(r *http.Request) (proto.Address, error) {
+	return proto.NewAddressFromString(chi.URLParam(r, "address"))
+}
+
+// balanceWithConfirmations returns minimal Waves balance of address during the last confirmations blocks.
+func (a *NodeApi) balanceWithConfirmations(addr proto.Address, confirmations uint64) (uint64, error) {
+	balance, err := a.state.AccountBalance(addr, nil)
+	if err != nil {
+		return 0, err
+	}
+	if confirmations == 0 || balance == 0 {
+		return balance, nil
+	}
+	return a.effectiveBalance(addr, confirmations)
+}
+
+// effectiveBalance returns minimal effective balance of address during the last confirmations blocks.
+func (a *NodeApi) effectiveBalance(addr proto.Address, confirmations uint64) (uint64, error) {
+	height, err := a.state.Height()
+	if err != nil {
+		return 0, err
+	}
+	start := uint64(1)
+	if height > confirmations {
+		start = height - confirmations
+	}
+	return a.state.EffectiveBalance(addr, start, height)
+}
+
+func (a *NodeApi) AddressesBalance(w http.ResponseWriter, r *http.Request) {
+	addr, err := addressParam(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var confirmations uint64
+	if chi.URLParam(r, "confirmations") != "" {
+		confirmations, err = uintParam(r, "confirmations")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	balance, err := a.balanceWithConfirmations(addr, confirmations)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	sendJson(w, AddressBalance{Address: addr, Confirmations: confirmations, Balance: balance})
+}
+
+func (a *NodeApi) AddressesEffectiveBalance(w http.ResponseWriter, r *http.Request) {
+	addr, err := addressParam(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var confirmations uint64
+	if chi.URLParam(r, "confirmations") != "" {
+		confirmations, err = uintParam(r, "confirmations")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	balance, err := a.effectiveBalance(addr, confirmations)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	sendJson(w, AddressBalance{Address: addr, Confirmations: confirmations, Balance: balance})
+}
+
+func (a *NodeApi) AddressesBalanceDetails(w http.ResponseWriter, r *http.Request) {
+	addr, err := addressParam(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	regular, err := a.state.AccountBalance(addr, nil)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	generating, err := a.balanceWithConfirmations(addr, generatingBalanceDepth)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	effective, err := a.effectiveBalance(addr, 0)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	sendJson(w, AddressBalanceDetails{
+		Address:    addr,
+		Regular:    regular,
+		Generating: generating,
+		Available:  regular,
+		Effective:  effective,
+	})
+}
+
+func (a *NodeApi) AddressesValidate(w http.ResponseWriter, r *http.Request) {
+	s := chi.URLParam(r, "address")
+	_, err := proto.NewAddressFromString(s)
+	sendJson(w, struct {
+		Address string `json:"address"`
+		Valid   bool   `json:"valid"`
+	}{Address: s, Valid: err == nil})
+}
+
+func (a *NodeApi) AddressesPublicKey(w http.ResponseWriter, r *http.Request) {
+	pk, err := crypto.NewPublicKeyFromBase58(chi.URLParam(r, "publicKey"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	settings, err := a.state.BlockchainSettings()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	addr, err := proto.NewAddressFromPublicKey(settings.AddressSchemeCharacter, pk)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	sendJson(w, struct {
+		Address proto.Address `json:"address"`
+	}{Address: addr})
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+func (a *NodeApi) AliasByAlias(w http.ResponseWriter, r *http.Request) {
+	settings, err := a.state.BlockchainSettings()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	alias := proto.NewAlias(settings.AddressSchemeCharacter, chi.URLParam(r, "alias"))
+	if ok, err := alias.Valid(); !ok {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	addr, err := a.state.AddrByAlias(*alias)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if state.ErrorType(err) == state.NotFoundError {
+			code = http.StatusNotFound
+		}
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), code)
+		return
+	}
+	sendJson(w, struct {
+		Address proto.Address `json:"address"`
+	}{Address: addr})
+}
+
+func (a *NodeApi) AliasByAddress(w http.ResponseWriter, r *http.Request) {
+	addr, err := addressParam(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	aliases, err := a.state.AliasesByAddr(addr)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	if aliases == nil {
+		aliases = []proto.Alias{}
+	}
+	sendJson(w, aliases)
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+type AssetBalance struct {
+	Address proto.Address `json:"address"`
+	AssetId crypto.Digest `json:"assetId"`
+	Balance uint64        `json:"balance"`
+}
+
+type AssetDetails struct {
+	AssetId        crypto.Digest `json:"assetId"`
+	IssueTimestamp uint64        `json:"issueTimestamp"`
+	Issuer         proto.Address `json:"issuer"`
+	Name           string        `json:"name"`
+	Description    string        `json:"description"`
+	Decimals       int8          `json:"decimals"`
+	Reissuable     bool          `json:"reissuable"`
+	Quantity       uint64        `json:"quantity"`
+}
+
+func assetIDParam(r *http.Request) (crypto.Digest, error) {
+	return crypto.NewDigestFromBase58(chi.URLParam(r, "assetId"))
+}
+
+func (a *NodeApi) AssetsBalance(w http.ResponseWriter, r *http.Request) {
+	addr, err := addressParam(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	assetID, err := assetIDParam(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	balance, err := a.state.AccountBalance(addr, assetID[:])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	sendJson(w, AssetBalance{Address: addr, AssetId: assetID, Balance: balance})
+}
+
+func (a *NodeApi) AssetsDetails(w http.ResponseWriter, r *http.Request) {
+	assetID, err := assetIDParam(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	info, err := a.state.AssetInfo(assetID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusNotFound)
+		return
+	}
+	out := AssetDetails{
+		AssetId:     assetID,
+		Name:        info.Name,
+		Description: info.Description,
+		Decimals:    info.Decimals,
+		Reissuable:  info.Reissuable,
+		Quantity:    info.Quantity,
+	}
+	// Asset ID is the ID of its issue transaction.
+	tx, err := a.state.TransactionByID(assetID[:])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	var issue *proto.Issue
+	switch t := tx.(type) {
+	case *proto.IssueV1:
+		issue = &t.Issue
+	case *proto.IssueV2:
+		issue = &t.Issue
+	default:
+		http.Error(w, fmt.Sprintf("Failed to complete request: unexpected issue transaction type %T", tx), http.StatusInternalServerError)
+		return
+	}
+	settings, err := a.state.BlockchainSettings()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	out.Issuer, err = proto.NewAddressFromPublicKey(settings.AddressSchemeCharacter, issue.SenderPK)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	out.IssueTimestamp = issue.Timestamp
+	sendJson(w, out)
+}
@@ -0,0 +1,350 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/fees"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// Max number of blocks which could be requested in one sequence, same as in Scala node.
+const maxBlocksSeqLength = 100
+
+// BlockHeader is a block header in format of Scala node REST API.
+type BlockHeader struct {
+	Version          proto.BlockVersion `json:"version"`
+	Timestamp        uint64             `json:"timestamp"`
+	Reference        crypto.Signature   `json:"reference"`
+	NxtConsensus     proto.NxtConsensus `json:"nxt-consensus"`
+	Features         []int16            `json:"features,omitempty"`
+	Generator        proto.Address      `json:"generator"`
+	Signature        crypto.Signature   `json:"signature"`
+	BlockSize        int                `json:"blocksize"`
+	TransactionCount int                `json:"transactionCount"`
+	Height           uint64             `json:"height"`
+}
+
+// Block is a block with transactions in format of Scala node REST API.
+type Block struct {
+	BlockHeader
+	// Fee is the sum of fees of transactions paid in WAVES.
+	Fee          uint64                  `json:"fee"`
+	Transactions proto.TransactionsField `json:"transactions"`
+}
+
+func (a *NodeApi) blockHeader(block *proto.Block, height uint64) (*BlockHeader, error) {
+	settings, err := a.state.BlockchainSettings()
+	if err != nil {
+		return nil, err
+	}
+	generator, err := proto.NewAddressFromPublicKey(settings.AddressSchemeCharacter, block.GenPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	bts, err := block.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &BlockHeader{
+		Version:          block.Version,
+		Timestamp:        block.Timestamp,
+		Reference:        block.Parent,
+		NxtConsensus:     block.NxtConsensus,
+		Features:         block.Features,
+		Generator:        generator,
+		Signature:        block.BlockSignature,
+		BlockSize:        len(bts),
+		TransactionCount: block.TransactionCount,
+		Height:           height,
+	}, nil
+}
+
+func (a *NodeApi) blockAt(height uint64) (*Block, error) {
+	block, err := a.state.BlockByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	header, err := a.blockHeader(block, height)
+	if err != nil {
+		return nil, err
+	}
+	fee, err := blockFee(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Block{BlockHeader: *header, Fee: fee, Transactions: block.Transactions}, nil
+}
+
+func blockFee(block *proto.Block) (uint64, error) {
+	transactions, err := block.Transactions.Transactions()
+	if err != nil {
+		return 0, err
+	}
+	var sum uint64
+	for _, tx := range transactions {
+		fee, asset, err := fees.Fee(tx)
+		if err != nil {
+			return 0, err
+		}
+		if !asset.Present {
+			sum += fee
+		}
+	}
+	return sum, nil
+}
+
+func (a *NodeApi) blockBySignature(sig crypto.Signature) (*Block, error) {
+	height, err := a.state.BlockIDToHeight(sig)
+	if err != nil {
+		return nil, err
+	}
+	return a.blockAt(height)
+}
+
+func (a *NodeApi) headerAt(height uint64) (*BlockHeader, error) {
+	block, err := a.state.BlockByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	return a.blockHeader(block, height)
+}
+
+func uintParam(r *http.Request, name string) (uint64, error) {
+	return strconv.ParseUint(chi.URLParam(r, name), 10, 64)
+}
+
+func signatureParam(r *http.Request, name string) (crypto.Signature, error) {
+	return crypto.NewSignatureFromBase58(chi.URLParam(r, name))
+}
+
+func seqParams(r *http.Request) (uint64, uint64, error) {
+	from, err := uintParam(r, "from")
+	if err != nil {
+		return 0, 0, err
+	}
+	to, err := uintParam(r, "to")
+	if err != nil {
+		return 0, 0, err
+	}
+	if from == 0 || from > to {
+		return 0, 0, errors.New("invalid sequence bounds")
+	}
+	if to-from >= maxBlocksSeqLength {
+		return 0, 0, errors.Errorf("too big sequence requested, max %d blocks allowed", maxBlocksSeqLength)
+	}
+	return from, to, nil
+}
+
+// limitedTop returns the lowest of the current height and the given height.
+func (a *NodeApi) limitedTop(to uint64) (uint64, error) {
+	height, err := a.state.Height()
+	if err != nil {
+		return 0, err
+	}
+	if to > height {
+		return height, nil
+	}
+	return to, nil
+}
+
+func (a *NodeApi) BlocksHeight(w http.ResponseWriter, r *http.Request) {
+	height, err := a.state.Height()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	sendJson(w, map[string]uint64{"height": height})
+}
+
+func (a *NodeApi) BlocksHeightBySignature(w http.ResponseWriter, r *http.Request) {
+	sig, err := signatureParam(r, "signature")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	height, err := a.state.BlockIDToHeight(sig)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusNotFound)
+		return
+	}
+	sendJson(w, map[string]uint64{"height": height})
+}
+
+func (a *NodeApi) BlocksLast(w http.ResponseWriter, r *http.Request) {
+	height, err := a.state.Height()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	block, err := a.blockAt(height)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	sendJson(w, block)
+}
+
+func (a *NodeApi) BlocksFirst(w http.ResponseWriter, r *http.Request) {
+	block, err := a.blockAt(1)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	sendJson(w, block)
+}
+
+func (a *NodeApi) BlockAt(w http.ResponseWriter, r *http.Request) {
+	height, err := uintParam(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	block, err := a.blockAt(height)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusNotFound)
+		return
+	}
+	sendJson(w, block)
+}
+
+func (a *NodeApi) BlocksSeq(w http.ResponseWriter, r *http.Request) {
+	from, to, err := seqParams(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	to, err = a.limitedTop(to)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	out := make([]*Block, 0, maxBlocksSeqLength)
+	for height := from; height <= to; height++ {
+		block, err := a.blockAt(height)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
+			return
+		}
+		out = append(out, block)
+	}
+	sendJson(w, out)
+}
+
+func (a *NodeApi) BlocksBySignature(w http.ResponseWriter, r *http.Request) {
+	sig, err := signatureParam(r, "signature")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	block, err := a.blockBySignature(sig)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusNotFound)
+		return
+	}
+	sendJson(w, block)
+}
+
+func (a *NodeApi) BlocksChild(w http.ResponseWriter, r *http.Request) {
+	sig, err := signatureParam(r, "signature")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	height, err := a.state.BlockIDToHeight(sig)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusNotFound)
+		return
+	}
+	block, err := a.blockAt(height + 1)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusNotFound)
+		return
+	}
+	sendJson(w, block)
+}
+
+func (a *NodeApi) BlocksAddress(w http.ResponseWriter, r *http.Request) {
+	addr, err := proto.NewAddressFromString(chi.URLParam(r, "address"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	from, to, err := seqParams(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	to, err = a.limitedTop(to)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	out := make([]*Block, 0)
+	for height := from; height <= to; height++ {
+		block, err := a.blockAt(height)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
+			return
+		}
+		if block.Generator == addr {
+			out = append(out, block)
+		}
+	}
+	sendJson(w, out)
+}
+
+func (a *NodeApi) BlocksHeadersLast(w http.ResponseWriter, r *http.Request) {
+	height, err := a.state.Height()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	header, err := a.headerAt(height)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	sendJson(w, header)
+}
+
+func (a *NodeApi) BlocksHeadersAt(w http.ResponseWriter, r *http.Request) {
+	height, err := uintParam(r, "id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	header, err := a.headerAt(height)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusNotFound)
+		return
+	}
+	sendJson(w, header)
+}
+
+func (a *NodeApi) BlocksHeadersSeq(w http.ResponseWriter, r *http.Request) {
+	from, to, err := seqParams(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	to, err = a.limitedTop(to)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	out := make([]*BlockHeader, 0, maxBlocksSeqLength)
+	for height := from; height <= to; height++ {
+		header, err := a.headerAt(height)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
+			return
+		}
+		out = append(out, header)
+	}
+	sendJson(w, out)
+}
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+)
+
+type DebugInfo struct {
+	StateHeight        uint64             `json:"stateHeight"`
+	ScoreObserverStats ScoreObserverStats `json:"scoreObserverStats"`
+}
+
+type ScoreObserverStats struct {
+	LocalScore         *big.Int `json:"localScore"`
+	CurrentBestChannel string   `json:"currentBestChannel"`
+}
+
+func (a *NodeApi) DebugInfo(w http.ResponseWriter, r *http.Request) {
+	height, err := a.state.Height()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	score, err := a.state.CurrentScore()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	out := DebugInfo{StateHeight: height, ScoreObserverStats: ScoreObserverStats{LocalScore: score}}
+	if p, _, ok := a.peers.PeerWithHighestScore(); ok {
+		out.ScoreObserverStats.CurrentBestChannel = p.RemoteAddr().String()
+	}
+	sendJson(w, out)
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+// Number of events buffered for each client of events stream.
+const eventsBufferSize = 1000
+
+type BlockEvent struct {
+	Height  uint64           `json:"height"`
+	BlockID crypto.Signature `json:"id"`
+}
+
+// Events streams state events to the client as Server-Sent Events.
+// Every event has name `blockApplied` or `blockRolledBack` and JSON data with height and ID of the block.
+// The stream ends if the client does not keep up with events, the client has to resync and connect again.
+func (a *NodeApi) Events(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	events, unsubscribe := a.state.SubscribeEvents(eventsBufferSize)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := writeEvent(w, e); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, e state.Event) error {
+	data, err := json.Marshal(BlockEvent{Height: e.Height, BlockID: e.BlockID})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data)
+	return err
+}
@@ -8,12 +8,10 @@ import (
 	"github.com/go-chi/chi/middleware"
 	"github.com/wavesplatform/gowaves/pkg/node"
 	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
-	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/state"
 	"go.uber.org/zap"
 	"math/big"
 	"net/http"
-	"strconv"
 	"time"
 )
 
@@ -58,71 +56,56 @@ func NewNodeApi(state state.State, node *node.Node, peers node.PeerManager) *Nod
 
 func (a *NodeApi) routes() chi.Router {
 	r := chi.NewRouter()
+	// blocks
+	r.Get("/blocks/height", a.BlocksHeight)
+	r.Get("/blocks/height/{signature}", a.BlocksHeightBySignature)
 	r.Get("/blocks/last", a.BlocksLast)
 	r.Get("/blocks/first", a.BlocksFirst)
 	r.Get("/blocks/at/{id:\\d+}", a.BlockAt)
+	r.Get("/blocks/seq/{from:\\d+}/{to:\\d+}", a.BlocksSeq)
+	r.Get("/blocks/signature/{signature}", a.BlocksBySignature)
+	r.Get("/blocks/child/{signature}", a.BlocksChild)
+	r.Get("/blocks/address/{address}/{from:\\d+}/{to:\\d+}", a.BlocksAddress)
+	r.Get("/blocks/headers/last", a.BlocksHeadersLast)
+	r.Get("/blocks/headers/at/{id:\\d+}", a.BlocksHeadersAt)
+	r.Get("/blocks/headers/seq/{from:\\d+}/{to:\\d+}", a.BlocksHeadersSeq)
+
+	// addresses
+	r.Get("/addresses/balance/{address}", a.AddressesBalance)
+	r.Get("/addresses/balance/{address}/{confirmations:\\d+}", a.AddressesBalance)
+	r.Get("/addresses/balance/details/{address}", a.AddressesBalanceDetails)
+	r.Get("/addresses/effectiveBalance/{address}", a.AddressesEffectiveBalance)
+	r.Get("/addresses/effectiveBalance/{address}/{confirmations:\\d+}", a.AddressesEffectiveBalance)
+	r.Get("/addresses/validate/{address}", a.AddressesValidate)
+	r.Get("/addresses/publicKey/{publicKey}", a.AddressesPublicKey)
+
+	// assets
+	r.Get("/assets/balance/{address}/{assetId}", a.AssetsBalance)
+	r.Get("/assets/details/{assetId}", a.AssetsDetails)
+
+	// transactions
+	r.Get("/transactions/info/{id}", a.TransactionInfo)
+	r.Post("/transactions/broadcast", a.TransactionsBroadcast)
+
+	// alias
+	r.Get("/alias/by-alias/{alias}", a.AliasByAlias)
+	r.Get("/alias/by-address/{address}", a.AliasByAddress)
+
+	// debug
+	r.Get("/debug/info", a.DebugInfo)
+
+	// events
+	r.Get("/events", a.Events)
 
 	// peers
 	r.Get("/peers/all", a.PeersAll)
+	r.Get("/peers/connected", a.PeersConnected)
 	return r
 }
 
-func (a *NodeApi) BlocksLast(w http.ResponseWriter, r *http.Request) {
-	h, err := a.state.Height()
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
-		return
-	}
-
-	block, err := a.state.BlockByHeight(h)
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
-		return
-	}
-
-	height, err := a.state.BlockIDToHeight(block.BlockSignature)
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
-		return
-	}
-	block.Height = height
-	err = json.NewEncoder(w).Encode(block)
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to marshal status to JSON: %s", err.Error()), http.StatusInternalServerError)
-		return
-	}
-}
-
-func (a *NodeApi) BlocksFirst(w http.ResponseWriter, r *http.Request) {
-	block, err := a.state.BlockByHeight(1)
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
-		return
-	}
-	block.Height = 1
-	err = json.NewEncoder(w).Encode(block)
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to marshal status to JSON: %s", err.Error()), http.StatusInternalServerError)
-		return
-	}
-}
-
-func (a *NodeApi) BlockAt(w http.ResponseWriter, r *http.Request) {
-
-	s := chi.URLParam(r, "id")
-	id, err := strconv.ParseUint(s, 10, 64)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-
-	block, err := a.state.BlockByHeight(id)
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
-		return
-	}
-	block.Height = id
-	err = json.NewEncoder(w).Encode(block)
+func sendJson(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(v)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Failed to marshal status to JSON: %s", err.Error()), http.StatusInternalServerError)
 		return
@@ -170,11 +153,7 @@ func (a *NodeApi) PeersAll(w http.ResponseWriter, r *http.Request) {
 		out = append(out, Peer{Address: row.String()})
 	}
 
-	err = json.NewEncoder(w).Encode(PeersAll{Peers: out})
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to marshal status to JSON: %s", err.Error()), http.StatusInternalServerError)
-		return
-	}
+	sendJson(w, PeersAll{Peers: out})
 }
 
 type PeersConnected struct {
@@ -182,34 +161,27 @@ type PeersConnected struct {
 }
 
 type PeersConnectedRow struct {
-	Address            string
-	DeclaredAddress    string
-	peerName           string
-	peerNonce          uint64
-	applicationName    string
-	applicationVersion proto.Version
+	Address            string `json:"address"`
+	DeclaredAddress    string `json:"declaredAddress"`
+	PeerName           string `json:"peerName"`
+	PeerNonce          uint64 `json:"peerNonce"`
+	ApplicationName    string `json:"applicationName"`
+	ApplicationVersion string `json:"applicationVersion"`
 }
 
 func (a *NodeApi) PeersConnected(w http.ResponseWriter, r *http.Request) {
-	var out []PeersConnectedRow
+	out := make([]PeersConnectedRow, 0)
 	a.peers.EachConnected(func(peer peer.Peer, i *big.Int) {
-
+		h := peer.Handshake()
 		v := PeersConnectedRow{
 			Address:            peer.RemoteAddr().String(),
-			DeclaredAddress:    peer.Handshake().DeclaredAddr.String(),
-			peerName:           peer.Handshake().NodeName,
-			peerNonce:          peer.Handshake().NodeNonce,
-			applicationName:    peer.Handshake().AppName,
-			applicationVersion: peer.Handshake().Version,
+			DeclaredAddress:    h.DeclaredAddr.String(),
+			PeerName:           h.NodeName,
+			PeerNonce:          h.NodeNonce,
+			ApplicationName:    h.AppName,
+			ApplicationVersion: fmt.Sprintf("%d.%d.%d", h.Version.Major, h.Version.Minor, h.Version.Patch),
 		}
-
 		out = append(out, v)
-
 	})
-
-	err := json.NewEncoder(w).Encode(PeersConnected{Peers: out})
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to marshal status to JSON: %s", err.Error()), http.StatusInternalServerError)
-		return
-	}
+	sendJson(w, PeersConnected{Peers: out})
 }
@@ -0,0 +1,282 @@
+package api
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/node"
+	"github.com/wavesplatform/gowaves/pkg/p2p/mock"
+	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+// apiTestState implements methods of the state used by the API, other methods panic.
+type apiTestState struct {
+	state.State
+	blocks   []*proto.Block
+	balances map[proto.Address]uint64
+	aliases  map[string]proto.Address
+}
+
+func (s *apiTestState) Height() (uint64, error) {
+	return uint64(len(s.blocks)), nil
+}
+
+func (s *apiTestState) BlockByHeight(height uint64) (*proto.Block, error) {
+	if height == 0 || height > uint64(len(s.blocks)) {
+		return nil, errors.New("not found")
+	}
+	return s.blocks[height-1], nil
+}
+
+func (s *apiTestState) BlockIDToHeight(id crypto.Signature) (uint64, error) {
+	for i, b := range s.blocks {
+		if b.BlockSignature == id {
+			return uint64(i + 1), nil
+		}
+	}
+	return 0, errors.New("not found")
+}
+
+func (s *apiTestState) AccountBalance(addr proto.Address, asset []byte) (uint64, error) {
+	return s.balances[addr], nil
+}
+
+func (s *apiTestState) EffectiveBalance(addr proto.Address, startHeight, endHeight uint64) (uint64, error) {
+	return s.balances[addr] / 2, nil
+}
+
+func (s *apiTestState) AddrByAlias(alias proto.Alias) (proto.Address, error) {
+	if alias.Alias == "broken" {
+		return proto.Address{}, errors.New("storage failure")
+	}
+	addr, ok := s.aliases[alias.Alias]
+	if !ok {
+		return proto.Address{}, state.NewStateError(state.NotFoundError, errors.New("alias not found"))
+	}
+	return addr, nil
+}
+
+func (s *apiTestState) BlockchainSettings() (*settings.BlockchainSettings, error) {
+	return settings.MainNetSettings, nil
+}
+
+// apiTestPeers has the single connected peer.
+type apiTestPeers struct {
+	node.PeerManager
+	peer *mock.Peer
+}
+
+func (m *apiTestPeers) EachConnected(f func(peer.Peer, *big.Int)) {
+	f(m.peer, big.NewInt(0))
+}
+
+type apiTestEnv struct {
+	server *httptest.Server
+	state  *apiTestState
+	peer   *mock.Peer
+	sk     crypto.SecretKey
+	pk     crypto.PublicKey
+	addr   proto.Address
+}
+
+func newApiTestEnv(t *testing.T) *apiTestEnv {
+	sk, pk := crypto.GenerateKeyPair([]byte("api"))
+	addr, err := proto.NewAddressFromPublicKey(proto.MainNetScheme, pk)
+	require.NoError(t, err)
+	blocks := make([]*proto.Block, 3)
+	for i := range blocks {
+		blocks[i] = &proto.Block{BlockHeader: proto.BlockHeader{
+			Version:        proto.NgBlockVersion,
+			Timestamp:      uint64(1561000000000 + i),
+			GenPublicKey:   pk,
+			BlockSignature: crypto.Signature{byte(i + 1)},
+		}}
+	}
+	rcp := proto.NewRecipientFromAddress(addr)
+	asset, err := proto.NewOptionalAssetFromString("AxAmJaro7BJ4KasYiZhw7HkjwgYtt2nekPuF2CN9LMym")
+	require.NoError(t, err)
+	for _, tx := range []*proto.TransferV2{
+		proto.NewUnsignedTransferV2(pk, proto.OptionalAsset{}, proto.OptionalAsset{}, 1561000000000, 1, 100000, rcp, ""),
+		proto.NewUnsignedTransferV2(pk, proto.OptionalAsset{}, *asset, 1561000000000, 1, 10, rcp, ""),
+	} {
+		require.NoError(t, tx.Sign(sk))
+		b, err := tx.MarshalBinary()
+		require.NoError(t, err)
+		size := make([]byte, 4)
+		binary.BigEndian.PutUint32(size, uint32(len(b)))
+		blocks[1].Transactions = append(append(blocks[1].Transactions, size...), b...)
+		blocks[1].TransactionCount++
+	}
+	env := &apiTestEnv{
+		state: &apiTestState{
+			blocks:   blocks,
+			balances: map[proto.Address]uint64{addr: 1000},
+			aliases:  map[string]proto.Address{"alias": addr},
+		},
+		peer: mock.NewPeer(),
+		sk:   sk,
+		pk:   pk,
+		addr: addr,
+	}
+	a := NewNodeApi(env.state, nil, &apiTestPeers{peer: env.peer})
+	env.server = httptest.NewServer(a.routes())
+	return env
+}
+
+func (env *apiTestEnv) get(t *testing.T, path string, v interface{}) int {
+	resp, err := http.Get(env.server.URL + path)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK && v != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+	}
+	return resp.StatusCode
+}
+
+func (env *apiTestEnv) post(t *testing.T, path string, body string) (int, string) {
+	resp, err := http.Post(env.server.URL+path, "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(b)
+}
+
+func TestNodeApi_Blocks(t *testing.T) {
+	env := newApiTestEnv(t)
+	defer env.server.Close()
+
+	var height struct {
+		Height uint64 `json:"height"`
+	}
+	assert.Equal(t, http.StatusOK, env.get(t, "/blocks/height", &height))
+	assert.EqualValues(t, 3, height.Height)
+
+	var block Block
+	assert.Equal(t, http.StatusOK, env.get(t, "/blocks/at/2", &block))
+	assert.EqualValues(t, 2, block.Height)
+	assert.Equal(t, env.addr, block.Generator)
+	// Fees in assets are not summed up.
+	assert.EqualValues(t, 100000, block.Fee)
+	assert.Equal(t, 2, block.TransactionCount)
+	assert.Equal(t, http.StatusNotFound, env.get(t, "/blocks/at/4", nil))
+
+	assert.Equal(t, http.StatusOK, env.get(t, "/blocks/child/"+crypto.Signature{1}.String(), &block))
+	assert.EqualValues(t, 2, block.Height)
+
+	var seq []Block
+	assert.Equal(t, http.StatusOK, env.get(t, "/blocks/seq/2/10", &seq))
+	require.Len(t, seq, 2)
+	assert.EqualValues(t, 3, seq[1].Height)
+	assert.Equal(t, http.StatusBadRequest, env.get(t, "/blocks/seq/1/101", nil))
+	assert.Equal(t, http.StatusBadRequest, env.get(t, "/blocks/seq/3/2", nil))
+
+	var headers []BlockHeader
+	assert.Equal(t, http.StatusOK, env.get(t, "/blocks/headers/seq/1/3", &headers))
+	assert.Len(t, headers, 3)
+}
+
+func TestNodeApi_Addresses(t *testing.T) {
+	env := newApiTestEnv(t)
+	defer env.server.Close()
+
+	var balance AddressBalance
+	assert.Equal(t, http.StatusOK, env.get(t, "/addresses/balance/"+env.addr.String(), &balance))
+	assert.EqualValues(t, 1000, balance.Balance)
+	assert.Equal(t, http.StatusOK, env.get(t, "/addresses/balance/"+env.addr.String()+"/10", &balance))
+	assert.EqualValues(t, 500, balance.Balance)
+	assert.EqualValues(t, 10, balance.Confirmations)
+	assert.Equal(t, http.StatusBadRequest, env.get(t, "/addresses/balance/invalid", nil))
+
+	var details AddressBalanceDetails
+	assert.Equal(t, http.StatusOK, env.get(t, "/addresses/balance/details/"+env.addr.String(), &details))
+	assert.Equal(t, AddressBalanceDetails{Address: env.addr, Regular: 1000, Generating: 500, Available: 1000, Effective: 500}, details)
+
+	assert.Equal(t, http.StatusOK, env.get(t, "/addresses/effectiveBalance/"+env.addr.String(), &balance))
+	assert.EqualValues(t, 500, balance.Balance)
+	assert.EqualValues(t, 0, balance.Confirmations)
+	assert.Equal(t, http.StatusOK, env.get(t, "/addresses/effectiveBalance/"+env.addr.String()+"/5", &balance))
+	assert.EqualValues(t, 500, balance.Balance)
+	assert.EqualValues(t, 5, balance.Confirmations)
+	assert.Equal(t, http.StatusBadRequest, env.get(t, "/addresses/effectiveBalance/invalid", nil))
+
+	var address struct {
+		Address proto.Address `json:"address"`
+	}
+	assert.Equal(t, http.StatusOK, env.get(t, "/addresses/publicKey/"+env.pk.String(), &address))
+	assert.Equal(t, env.addr, address.Address)
+	assert.Equal(t, http.StatusOK, env.get(t, "/alias/by-alias/alias", &address))
+	assert.Equal(t, env.addr, address.Address)
+	assert.Equal(t, http.StatusNotFound, env.get(t, "/alias/by-alias/unknown", nil))
+	assert.Equal(t, http.StatusInternalServerError, env.get(t, "/alias/by-alias/broken", nil))
+}
+
+func TestNodeApi_TransactionsBroadcast(t *testing.T) {
+	env := newApiTestEnv(t)
+	defer env.server.Close()
+	rcp := proto.NewRecipientFromAddress(env.addr)
+	waves := proto.OptionalAsset{}
+
+	tx := proto.NewUnsignedTransferV2(env.pk, waves, waves, 1561000000000, 1, 100000, rcp, "")
+	require.NoError(t, tx.Sign(env.sk))
+	b, err := json.Marshal(tx)
+	require.NoError(t, err)
+	code, body := env.post(t, "/transactions/broadcast", string(b))
+	require.Equal(t, http.StatusOK, code, body)
+	require.Len(t, env.peer.SendMessageCalledWith, 1)
+	bts, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, &proto.TransactionMessage{Transaction: bts}, env.peer.SendMessageCalledWith[0])
+
+	otherSK, _ := crypto.GenerateKeyPair([]byte("other"))
+	forged := proto.NewUnsignedTransferV2(env.pk, waves, waves, 1561000000000, 2, 100000, rcp, "")
+	require.NoError(t, forged.Sign(otherSK))
+	b, err = json.Marshal(forged)
+	require.NoError(t, err)
+	code, body = env.post(t, "/transactions/broadcast", string(b))
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, body, "invalid signature")
+
+	unsigned := proto.NewUnsignedTransferV2(env.pk, waves, waves, 1561000000000, 3, 100000, rcp, "")
+	b, err = json.Marshal(unsigned)
+	require.NoError(t, err)
+	code, body = env.post(t, "/transactions/broadcast", string(b))
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, body, "no proofs")
+
+	cheap := proto.NewUnsignedTransferV2(env.pk, waves, waves, 1561000000000, 4, 99999, rcp, "")
+	require.NoError(t, cheap.Sign(env.sk))
+	b, err = json.Marshal(cheap)
+	require.NoError(t, err)
+	code, body = env.post(t, "/transactions/broadcast", string(b))
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, body, "fee 99999 is less than minimal fee 100000")
+
+	// Sponsorship is not tracked by the state, the fee in asset is left to nodes.
+	asset, err := proto.NewOptionalAssetFromString("AxAmJaro7BJ4KasYiZhw7HkjwgYtt2nekPuF2CN9LMym")
+	require.NoError(t, err)
+	sponsored := proto.NewUnsignedTransferV2(env.pk, waves, *asset, 1561000000000, 5, 10, rcp, "")
+	require.NoError(t, sponsored.Sign(env.sk))
+	b, err = json.Marshal(sponsored)
+	require.NoError(t, err)
+	code, body = env.post(t, "/transactions/broadcast", string(b))
+	require.Equal(t, http.StatusOK, code, body)
+	require.Len(t, env.peer.SendMessageCalledWith, 2)
+
+	code, _ = env.post(t, "/transactions/broadcast", "{")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Len(t, env.peer.SendMessageCalledWith, 2)
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/fees"
+	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+func (a *NodeApi) TransactionInfo(w http.ResponseWriter, r *http.Request) {
+	id, err := crypto.NewDigestFromBase58(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	tx, err := a.state.TransactionByID(id[:])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusNotFound)
+		return
+	}
+	sendJson(w, tx)
+}
+
+func transactionFromJson(data []byte) (proto.Transaction, error) {
+	tt := new(proto.TransactionTypeVersion)
+	if err := json.Unmarshal(data, tt); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal transaction type")
+	}
+	tx, err := proto.GuessTransactionType(tt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, tx); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal transaction")
+	}
+	return tx, nil
+}
+
+type signedTransaction interface {
+	GetSenderPK() crypto.PublicKey
+	Verify(publicKey crypto.PublicKey) (bool, error)
+}
+
+// verifySignature checks that the transaction is signed by its sender.
+func verifySignature(tx proto.Transaction) error {
+	s, ok := tx.(signedTransaction)
+	if !ok {
+		return errors.Errorf("transaction of type %T could not be broadcasted", tx)
+	}
+	if p, ok := tx.(proto.ProvenTransaction); ok && p.GetProofs() == nil {
+		return errors.New("no proofs")
+	}
+	ok, err := s.Verify(s.GetSenderPK())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+// checkFee checks that the fee of the transaction is not less than the minimal one. Scripts and sponsorship are not
+// tracked by the state, so the fee is checked against the lower bound of the account without script and other fee
+// errors, like the fee in sponsored asset, are left to nodes.
+func (a *NodeApi) checkFee(tx proto.Transaction) error {
+	s, err := a.state.BlockchainSettings()
+	if err != nil {
+		return err
+	}
+	if err := fees.NewCalculator(s.AddressSchemeCharacter, nil).Check(tx); errors.Cause(err) == fees.ErrInsufficientFee {
+		return err
+	}
+	return nil
+}
+
+// TransactionsBroadcast validates signed transaction and sends it to all connected peers.
+func (a *NodeApi) TransactionsBroadcast(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	tx, err := transactionFromJson(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if ok, err := tx.Valid(); !ok {
+		http.Error(w, fmt.Sprintf("Invalid transaction: %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+	if err := verifySignature(tx); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid transaction: %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+	if err := a.checkFee(tx); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid transaction: %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+	bts, err := tx.MarshalBinary()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to complete request: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	a.peers.EachConnected(func(p peer.Peer, _ *big.Int) {
+		p.SendMessage(&proto.TransactionMessage{Transaction: bts})
+	})
+	sendJson(w, tx)
+}
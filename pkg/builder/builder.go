@@ -0,0 +1,199 @@
+// Package builder provides fluent builders of transactions of all types.
+//
+// The Builder holds settings common to all transactions: the network scheme, the key of the sender, the timestamp,
+// the fee and the version. Methods named after transaction types return builders of specific transactions:
+//
+//	tx, err := builder.New(proto.TestNetScheme).SecretKey(sk).Transfer("alias:T:merry", 100000000).Attachment("hi").Build()
+//
+// Builders are values, each setter returns the modified copy, so the common part could be reused. Errors are
+// reported by Build, which signs the transaction if the secret key is set and checks it with Valid.
+package builder
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/fees"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/wallet"
+)
+
+// Builder holds the settings common to all transactions.
+type Builder struct {
+	scheme    byte
+	timestamp uint64
+	fee       uint64
+	feeAsset  proto.OptionalAsset
+	version   byte
+	sender    crypto.PublicKey
+	secretKey *crypto.SecretKey
+	fees      *fees.Calculator
+	err       error
+}
+
+// New creates the builder of transactions of the network with the scheme.
+// By default the timestamp is the time of building, the fee is the minimal fee calculated offline and the version is
+// the latest one.
+func New(scheme byte) Builder {
+	return Builder{scheme: scheme, fees: fees.NewCalculator(scheme, nil)}
+}
+
+// Timestamp sets the timestamp of transactions.
+func (b Builder) Timestamp(t time.Time) Builder {
+	b.timestamp = proto.NewTimestampFromTime(t)
+	return b
+}
+
+// Fee sets the fee instead of the minimal one.
+func (b Builder) Fee(fee uint64) Builder {
+	b.fee = fee
+	return b
+}
+
+// FeeState sets the state the minimal fee is calculated with, it's required to account scripts of the sender and
+// assets and to convert the fee to the sponsored asset.
+func (b Builder) FeeState(state fees.State) Builder {
+	b.fees = fees.NewCalculator(b.scheme, state)
+	return b
+}
+
+// FeeAsset sets the sponsored asset the fee is paid in, the asset is used by transactions that support it.
+func (b Builder) FeeAsset(asset proto.OptionalAsset) Builder {
+	b.feeAsset = asset
+	return b
+}
+
+// Version sets the version of transactions, zero means the latest version of the transaction type.
+func (b Builder) Version(v byte) Builder {
+	b.version = v
+	return b
+}
+
+// Sender sets the public key of the sender, transactions are built unsigned.
+func (b Builder) Sender(pk crypto.PublicKey) Builder {
+	b.sender = pk
+	b.secretKey = nil
+	return b
+}
+
+// SecretKey sets the key transactions are signed with, the sender is the owner of the key.
+func (b Builder) SecretKey(sk crypto.SecretKey) Builder {
+	b.sender = crypto.GeneratePublicKey(sk)
+	b.secretKey = &sk
+	return b
+}
+
+// Account sets the key of the wallet account transactions are signed with.
+func (b Builder) Account(w *wallet.Wallet, account wallet.Account) Builder {
+	sk, _, err := w.KeyPair(account)
+	if err != nil {
+		b.fail(err)
+		return b
+	}
+	return b.SecretKey(sk)
+}
+
+// fail records the first error of the builder. Values that may fail are resolved before the builder is copied
+// into the builder of transaction, the order of evaluation of composite literals is unspecified.
+func (b *Builder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+func (b Builder) ts() uint64 {
+	if b.timestamp == 0 {
+		return proto.NewTimestampFromTime(time.Now())
+	}
+	return b.timestamp
+}
+
+// versionOf returns the version of the transaction that has versions up to the latest.
+func (b *Builder) versionOf(latest byte) byte {
+	switch {
+	case b.version == 0:
+		return latest
+	case b.version > latest:
+		b.fail(errors.Errorf("unsupported version %d, the latest version is %d", b.version, latest))
+	}
+	return b.version
+}
+
+// recipient parses the address or the alias. The alias is either in form "alias:<scheme>:<alias>" or just the alias
+// of the network of the builder.
+func (b *Builder) recipient(s string) proto.Recipient {
+	if strings.HasPrefix(s, "alias:") {
+		a, err := proto.NewAliasFromString(s)
+		if err != nil {
+			b.fail(err)
+			return proto.Recipient{}
+		}
+		if a.Scheme != b.scheme {
+			b.fail(errors.Errorf("alias '%s' belongs to other network", s))
+		}
+		return proto.NewRecipientFromAlias(*a)
+	}
+	if a, err := proto.NewAddressFromString(s); err == nil {
+		return proto.NewRecipientFromAddress(b.check(a))
+	}
+	return proto.NewRecipientFromAlias(*proto.NewAlias(b.scheme, s))
+}
+
+func (b *Builder) address(s string) proto.Address {
+	a, err := proto.NewAddressFromString(s)
+	if err != nil {
+		b.fail(errors.Wrapf(err, "invalid address '%s'", s))
+		return proto.Address{}
+	}
+	return b.check(a)
+}
+
+func (b *Builder) check(a proto.Address) proto.Address {
+	if ok, err := a.Valid(); !ok {
+		b.fail(errors.Wrapf(err, "invalid address '%s'", a.String()))
+	} else if a[1] != b.scheme {
+		b.fail(errors.Errorf("address '%s' belongs to other network", a.String()))
+	}
+	return a
+}
+
+type signer interface {
+	Sign(secretKey crypto.SecretKey) error
+}
+
+// finish creates the transaction with the fee, signs and validates it. If the fee is not set the transaction is
+// created twice, the second time with the minimal fee of the first one. The Genesis transaction gets the generated
+// signature instead of signing.
+func (b Builder) finish(create func(fee uint64) proto.Transaction) (proto.Transaction, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	fee := b.fee
+	if fee == 0 {
+		var err error
+		fee, err = b.fees.MinimalFeeInAsset(create(0))
+		if err != nil {
+			return nil, err
+		}
+	}
+	tx := create(fee)
+	if g, ok := tx.(*proto.Genesis); ok {
+		if err := g.GenerateSigID(); err != nil {
+			return nil, err
+		}
+	} else if b.secretKey != nil {
+		s, ok := tx.(signer)
+		if !ok {
+			return nil, errors.Errorf("transaction of type %T can't be signed", tx)
+		}
+		if err := s.Sign(*b.secretKey); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := tx.Valid(); err != nil {
+		return nil, errors.Wrap(err, "invalid transaction")
+	}
+	return tx, nil
+}
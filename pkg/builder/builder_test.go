@@ -0,0 +1,172 @@
+package builder
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/fees"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/wallet"
+)
+
+// feeAndTimestamp reads the common fields of the transaction from its JSON representation.
+func feeAndTimestamp(t *testing.T, tx proto.Transaction) (uint64, uint64) {
+	b, err := json.Marshal(tx)
+	require.NoError(t, err)
+	v := struct {
+		Fee       uint64 `json:"fee"`
+		Timestamp uint64 `json:"timestamp"`
+	}{}
+	require.NoError(t, json.Unmarshal(b, &v))
+	return v.Fee, v.Timestamp
+}
+
+const testSeed = "exile region inmate brass mobile hour best spy gospel gown grace actor armed gift radar"
+
+func testBuilder() (Builder, crypto.SecretKey) {
+	sk, _ := crypto.GenerateKeyPair([]byte("builder"))
+	return New(proto.TestNetScheme).SecretKey(sk).Timestamp(time.Unix(1561000000, 0)), sk
+}
+
+func TestBuilder_AllTypes(t *testing.T) {
+	b, sk := testBuilder()
+	asset, err := crypto.NewDigestFromBase58("GAXAj8T4pSjunDqpz6Q3bit4fJJN9PD4t8AK8JZVSa5u")
+	require.NoError(t, err)
+	address := "3MqBoAUmn1XKCebApkszLSUqFpGf5yQZqeL"
+	sellerSK, _ := crypto.GenerateKeyPair([]byte("seller"))
+	matcherSK, matcherPK := crypto.GenerateKeyPair([]byte("matcher"))
+	pair := proto.AssetPair{AmountAsset: proto.OptionalAsset{Present: true, ID: asset}}
+	ts := proto.NewTimestampFromTime(time.Unix(1561000000, 0))
+	buy := proto.NewUnsignedOrderV2(crypto.GeneratePublicKey(sk), matcherPK, pair.AmountAsset, pair.PriceAsset, proto.Buy, 100000000, 10, ts, ts+100000, 300000)
+	require.NoError(t, buy.Sign(sk))
+	sell := proto.NewUnsignedOrderV2(crypto.GeneratePublicKey(sellerSK), matcherPK, pair.AmountAsset, pair.PriceAsset, proto.Sell, 100000000, 10, ts, ts+100000, 300000)
+	require.NoError(t, sell.Sign(sellerSK))
+
+	for _, test := range []struct {
+		build interface {
+			Build() (proto.Transaction, error)
+		}
+		tx  proto.Transaction
+		fee uint64
+	}{
+		{b.Genesis(address, 100), &proto.Genesis{}, 0},
+		{b.Payment(address, 100), &proto.Payment{}, 100000},
+		{b.Issue("asset", "", 1000, 2).Reissuable(true), &proto.IssueV2{}, 100000000},
+		{b.Version(1).Issue("asset", "", 1000, 2), &proto.IssueV1{}, 100000000},
+		{b.Transfer(address, 100).Asset(asset).Attachment("hi"), &proto.TransferV2{}, 100000},
+		{b.Version(1).Transfer("merry", 100), &proto.TransferV1{}, 100000},
+		{b.Reissue(asset, 100), &proto.ReissueV2{}, 100000000},
+		{b.Burn(asset, 100), &proto.BurnV2{}, 100000},
+		{b.SecretKey(matcherSK).Exchange(buy, sell, 100000000, 10), &proto.ExchangeV2{}, 300000},
+		{b.Lease("alias:T:merry", 100), &proto.LeaseV2{}, 100000},
+		{b.Version(1).Lease(address, 100), &proto.LeaseV1{}, 100000},
+		{b.LeaseCancel(asset), &proto.LeaseCancelV2{}, 100000},
+		{b.CreateAlias("merry"), &proto.CreateAliasV2{}, 100000},
+		{b.MassTransfer().Add(address, 1).Add("merry", 2).Add(address, 3), &proto.MassTransferV1{}, 300000},
+		{b.Data().Integer("i", 1).Boolean("b", true).Binary("x", []byte{1}).String("s", "s"), &proto.DataV1{}, 100000},
+		{b.SetScript([]byte{1, 6, 183, 111, 203, 71}), &proto.SetScriptV1{}, 1000000},
+		{b.Sponsorship(asset, 1000), &proto.SponsorshipV1{}, 100000000},
+		{b.SetAssetScript(asset, []byte{1, 6, 183, 111, 203, 71}), &proto.SetAssetScriptV1{}, 100000000},
+		{b.InvokeScript(address, "call", proto.IntegerArgument{Value: 1}).Payment(100, proto.OptionalAsset{}), &proto.InvokeScriptV1{}, 500000},
+	} {
+		tx, err := test.build.Build()
+		require.NoError(t, err, "%T", test.build)
+		assert.IsType(t, test.tx, tx)
+		fee, timestamp := feeAndTimestamp(t, tx)
+		assert.Equal(t, test.fee, fee, "%T", test.build)
+		assert.Equal(t, ts, timestamp, "%T", test.build)
+		assert.NotNil(t, tx.GetID(), "%T", test.build)
+	}
+}
+
+func TestBuilder_Transfer(t *testing.T) {
+	b, sk := testBuilder()
+	tx, err := b.Fee(200000).Transfer("alias:T:merry", 100000000).Attachment("hi").Build()
+	require.NoError(t, err)
+	tr := tx.(*proto.TransferV2)
+	assert.Equal(t, uint64(200000), tr.Fee)
+	assert.Equal(t, "alias:T:merry", tr.Recipient.String())
+	ok, err := tr.Verify(crypto.GeneratePublicKey(sk))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// The builder is not modified by setters of derived builders.
+	tx, err = b.Transfer("merry", 1).Build()
+	require.NoError(t, err)
+	fee, _ := feeAndTimestamp(t, tx)
+	assert.Equal(t, uint64(100000), fee)
+}
+
+func TestBuilder_FeeState(t *testing.T) {
+	b, sk := testBuilder()
+	asset, err := crypto.NewDigestFromBase58("GAXAj8T4pSjunDqpz6Q3bit4fJJN9PD4t8AK8JZVSa5u")
+	require.NoError(t, err)
+	address, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, crypto.GeneratePublicKey(sk))
+	require.NoError(t, err)
+	state := fees.StaticState{
+		SmartAccounts: map[proto.Address]bool{address: true},
+		Sponsorships:  map[crypto.Digest]uint64{asset: 7},
+	}
+	tx, err := b.FeeState(state).FeeAsset(proto.OptionalAsset{Present: true, ID: asset}).Transfer("merry", 1).Build()
+	require.NoError(t, err)
+	fee, _ := feeAndTimestamp(t, tx)
+	assert.Equal(t, uint64(35), fee)
+}
+
+func TestBuilder_Unsigned(t *testing.T) {
+	_, pk := crypto.GenerateKeyPair([]byte("builder"))
+	tx, err := New(proto.TestNetScheme).Sender(pk).Lease("merry", 100).Build()
+	require.NoError(t, err)
+	l := tx.(*proto.LeaseV2)
+	assert.Equal(t, pk, l.SenderPK)
+	assert.Nil(t, l.Proofs)
+}
+
+func TestBuilder_Account(t *testing.T) {
+	w, err := wallet.NewWalletFromSeedPhrase(testSeed)
+	require.NoError(t, err)
+	acc := w.Accounts()[0]
+	tx, err := New(proto.TestNetScheme).Account(w, acc).CreateAlias("merry").Build()
+	require.NoError(t, err)
+	pk, err := crypto.NewPublicKeyFromBase58("HvytViu98HfyhP4VZCRwYrDihnPQmAmjRyRiJPYpuZng")
+	require.NoError(t, err)
+	ok, err := tx.(*proto.CreateAliasV2).Verify(pk)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestBuilder_MassTransferFee(t *testing.T) {
+	b, _ := testBuilder()
+	mt := b.MassTransfer()
+	for i := 0; i < 10; i++ {
+		mt = mt.Add("merry", 1)
+	}
+	tx, err := mt.Build()
+	require.NoError(t, err)
+	fee, _ := feeAndTimestamp(t, tx)
+	assert.Equal(t, uint64(600000), fee)
+}
+
+func TestBuilder_Errors(t *testing.T) {
+	b, _ := testBuilder()
+	for _, test := range []struct {
+		build interface {
+			Build() (proto.Transaction, error)
+		}
+		err string
+	}{
+		{b.Transfer("alias:W:merry", 1), "alias 'alias:W:merry' belongs to other network"},
+		{b.Transfer("3PAWwWa6GbwcJaFzwqXQN5KQm7H96Y7SHTQ", 1), "address '3PAWwWa6GbwcJaFzwqXQN5KQm7H96Y7SHTQ' belongs to other network"},
+		{b.Version(3).Lease("merry", 1), "unsupported version 3, the latest version is 2"},
+		{b.Version(1).Issue("asset", "", 1, 0).Script([]byte{1}), "script of asset is not supported by version 1"},
+		{b.Transfer("merry", 0), "invalid transaction: amount should be positive"},
+	} {
+		_, err := test.build.Build()
+		require.Error(t, err)
+		assert.Equal(t, test.err, err.Error())
+	}
+}
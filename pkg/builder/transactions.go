@@ -0,0 +1,520 @@
+package builder
+
+import (
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// GenesisBuilder builds the Genesis transaction.
+type GenesisBuilder struct {
+	Builder
+	recipient proto.Address
+	amount    uint64
+}
+
+// Genesis returns the builder of the Genesis transaction, which has no sender and no fee.
+func (b Builder) Genesis(recipient string, amount uint64) GenesisBuilder {
+	r := b.address(recipient)
+	return GenesisBuilder{Builder: b, recipient: r, amount: amount}
+}
+
+// Build creates the Genesis transaction with generated signature and ID.
+func (b GenesisBuilder) Build() (proto.Transaction, error) {
+	ts := b.ts()
+	return b.finish(func(uint64) proto.Transaction {
+		return proto.NewUnsignedGenesis(b.recipient, b.amount, ts)
+	})
+}
+
+// PaymentBuilder builds the Payment transaction.
+type PaymentBuilder struct {
+	Builder
+	recipient proto.Address
+	amount    uint64
+}
+
+// Payment returns the builder of the Payment transaction.
+func (b Builder) Payment(recipient string, amount uint64) PaymentBuilder {
+	r := b.address(recipient)
+	return PaymentBuilder{Builder: b, recipient: r, amount: amount}
+}
+
+// Build creates the Payment transaction.
+func (b PaymentBuilder) Build() (proto.Transaction, error) {
+	ts := b.ts()
+	return b.finish(func(fee uint64) proto.Transaction {
+		return proto.NewUnsignedPayment(b.sender, b.recipient, b.amount, fee, ts)
+	})
+}
+
+// IssueBuilder builds the Issue transaction.
+type IssueBuilder struct {
+	Builder
+	name        string
+	description string
+	quantity    uint64
+	decimals    byte
+	reissuable  bool
+	script      []byte
+}
+
+// Issue returns the builder of the Issue transaction of the non-reissuable asset without script.
+func (b Builder) Issue(name, description string, quantity uint64, decimals byte) IssueBuilder {
+	return IssueBuilder{Builder: b, name: name, description: description, quantity: quantity, decimals: decimals}
+}
+
+// Reissuable allows the further reissue of the asset.
+func (b IssueBuilder) Reissuable(reissuable bool) IssueBuilder {
+	b.reissuable = reissuable
+	return b
+}
+
+// Script sets the script of the smart asset, available since version 2.
+func (b IssueBuilder) Script(script []byte) IssueBuilder {
+	b.script = script
+	return b
+}
+
+// Build creates the Issue transaction.
+func (b IssueBuilder) Build() (proto.Transaction, error) {
+	ts := b.ts()
+	v := b.versionOf(2)
+	if v == 1 && len(b.script) > 0 {
+		b.fail(errors.New("script of asset is not supported by version 1"))
+	}
+	return b.finish(func(fee uint64) proto.Transaction {
+		if v == 1 {
+			return proto.NewUnsignedIssueV1(b.sender, b.name, b.description, b.quantity, b.decimals, b.reissuable, ts, fee)
+		}
+		return proto.NewUnsignedIssueV2(b.scheme, b.sender, b.name, b.description, b.quantity, b.decimals, b.reissuable, b.script, ts, fee)
+	})
+}
+
+// TransferBuilder builds the Transfer transaction.
+type TransferBuilder struct {
+	Builder
+	recipient  proto.Recipient
+	amount     uint64
+	asset      proto.OptionalAsset
+	attachment string
+}
+
+// Transfer returns the builder of the Transfer transaction of WAVES to the address or the alias.
+func (b Builder) Transfer(recipient string, amount uint64) TransferBuilder {
+	r := b.recipient(recipient)
+	return TransferBuilder{Builder: b, recipient: r, amount: amount}
+}
+
+// Asset sets the transferred asset instead of WAVES.
+func (b TransferBuilder) Asset(asset crypto.Digest) TransferBuilder {
+	b.asset = proto.OptionalAsset{Present: true, ID: asset}
+	return b
+}
+
+// Attachment sets the attachment of the transfer.
+func (b TransferBuilder) Attachment(attachment string) TransferBuilder {
+	b.attachment = attachment
+	return b
+}
+
+// Build creates the Transfer transaction.
+func (b TransferBuilder) Build() (proto.Transaction, error) {
+	ts := b.ts()
+	v := b.versionOf(2)
+	return b.finish(func(fee uint64) proto.Transaction {
+		if v == 1 {
+			return proto.NewUnsignedTransferV1(b.sender, b.asset, b.feeAsset, ts, b.amount, fee, b.recipient, b.attachment)
+		}
+		return proto.NewUnsignedTransferV2(b.sender, b.asset, b.feeAsset, ts, b.amount, fee, b.recipient, b.attachment)
+	})
+}
+
+// ReissueBuilder builds the Reissue transaction.
+type ReissueBuilder struct {
+	Builder
+	asset      crypto.Digest
+	quantity   uint64
+	reissuable bool
+}
+
+// Reissue returns the builder of the Reissue transaction, the asset stays reissuable by default.
+func (b Builder) Reissue(asset crypto.Digest, quantity uint64) ReissueBuilder {
+	return ReissueBuilder{Builder: b, asset: asset, quantity: quantity, reissuable: true}
+}
+
+// Reissuable sets whether the asset could be reissued afterwards.
+func (b ReissueBuilder) Reissuable(reissuable bool) ReissueBuilder {
+	b.reissuable = reissuable
+	return b
+}
+
+// Build creates the Reissue transaction.
+func (b ReissueBuilder) Build() (proto.Transaction, error) {
+	ts := b.ts()
+	v := b.versionOf(2)
+	return b.finish(func(fee uint64) proto.Transaction {
+		if v == 1 {
+			return proto.NewUnsignedReissueV1(b.sender, b.asset, b.quantity, b.reissuable, ts, fee)
+		}
+		return proto.NewUnsignedReissueV2(b.scheme, b.sender, b.asset, b.quantity, b.reissuable, ts, fee)
+	})
+}
+
+// BurnBuilder builds the Burn transaction.
+type BurnBuilder struct {
+	Builder
+	asset  crypto.Digest
+	amount uint64
+}
+
+// Burn returns the builder of the Burn transaction.
+func (b Builder) Burn(asset crypto.Digest, amount uint64) BurnBuilder {
+	return BurnBuilder{Builder: b, asset: asset, amount: amount}
+}
+
+// Build creates the Burn transaction.
+func (b BurnBuilder) Build() (proto.Transaction, error) {
+	ts := b.ts()
+	v := b.versionOf(2)
+	return b.finish(func(fee uint64) proto.Transaction {
+		if v == 1 {
+			return proto.NewUnsignedBurnV1(b.sender, b.asset, b.amount, ts, fee)
+		}
+		return proto.NewUnsignedBurnV2(b.scheme, b.sender, b.asset, b.amount, ts, fee)
+	})
+}
+
+// ExchangeBuilder builds the Exchange transaction.
+type ExchangeBuilder struct {
+	Builder
+	buy            proto.Order
+	sell           proto.Order
+	price          uint64
+	amount         uint64
+	buyMatcherFee  uint64
+	sellMatcherFee uint64
+}
+
+// Exchange returns the builder of the Exchange transaction of the signed orders, the sender is the matcher.
+// By default the matcher fees are the full fees of orders.
+func (b Builder) Exchange(buy, sell proto.Order, price, amount uint64) ExchangeBuilder {
+	buy, sell = order(buy), order(sell)
+	return ExchangeBuilder{
+		Builder:        b,
+		buy:            buy,
+		sell:           sell,
+		price:          price,
+		amount:         amount,
+		buyMatcherFee:  b.matcherFee(buy),
+		sellMatcherFee: b.matcherFee(sell),
+	}
+}
+
+// order returns the order by value, as the binary representation of Exchange supports orders of value types only.
+func order(o proto.Order) proto.Order {
+	switch o := o.(type) {
+	case *proto.OrderV1:
+		return *o
+	case *proto.OrderV2:
+		return *o
+	}
+	return o
+}
+
+func (b *Builder) matcherFee(o proto.Order) uint64 {
+	body, err := proto.OrderToOrderBody(o)
+	if err != nil {
+		b.fail(err)
+	}
+	return body.MatcherFee
+}
+
+// MatcherFees sets the matcher fees charged from the buyer and the seller.
+func (b ExchangeBuilder) MatcherFees(buy, sell uint64) ExchangeBuilder {
+	b.buyMatcherFee = buy
+	b.sellMatcherFee = sell
+	return b
+}
+
+// Build creates the Exchange transaction.
+func (b ExchangeBuilder) Build() (proto.Transaction, error) {
+	ts := b.ts()
+	v := b.versionOf(2)
+	buy, ok1 := b.buy.(proto.OrderV1)
+	sell, ok2 := b.sell.(proto.OrderV1)
+	if v == 1 && (!ok1 || !ok2) {
+		b.fail(errors.New("version 1 supports only orders of version 1"))
+	}
+	return b.finish(func(fee uint64) proto.Transaction {
+		if v == 1 {
+			return proto.NewUnsignedExchangeV1(buy, sell, b.price, b.amount, b.buyMatcherFee, b.sellMatcherFee, fee, ts)
+		}
+		return proto.NewUnsignedExchangeV2(b.buy, b.sell, b.price, b.amount, b.buyMatcherFee, b.sellMatcherFee, fee, ts)
+	})
+}
+
+// LeaseBuilder builds the Lease transaction.
+type LeaseBuilder struct {
+	Builder
+	recipient proto.Recipient
+	amount    uint64
+}
+
+// Lease returns the builder of the Lease transaction to the address or the alias.
+func (b Builder) Lease(recipient string, amount uint64) LeaseBuilder {
+	r := b.recipient(recipient)
+	return LeaseBuilder{Builder: b, recipient: r, amount: amount}
+}
+
+// Build creates the Lease transaction.
+func (b LeaseBuilder) Build() (proto.Transaction, error) {
+	ts := b.ts()
+	v := b.versionOf(2)
+	return b.finish(func(fee uint64) proto.Transaction {
+		if v == 1 {
+			return proto.NewUnsignedLeaseV1(b.sender, b.recipient, b.amount, fee, ts)
+		}
+		return proto.NewUnsignedLeaseV2(b.sender, b.recipient, b.amount, fee, ts)
+	})
+}
+
+// LeaseCancelBuilder builds the LeaseCancel transaction.
+type LeaseCancelBuilder struct {
+	Builder
+	lease crypto.Digest
+}
+
+// LeaseCancel returns the builder of the LeaseCancel transaction of the lease with the ID.
+func (b Builder) LeaseCancel(lease crypto.Digest) LeaseCancelBuilder {
+	return LeaseCancelBuilder{Builder: b, lease: lease}
+}
+
+// Build creates the LeaseCancel transaction.
+func (b LeaseCancelBuilder) Build() (proto.Transaction, error) {
+	ts := b.ts()
+	v := b.versionOf(2)
+	return b.finish(func(fee uint64) proto.Transaction {
+		if v == 1 {
+			return proto.NewUnsignedLeaseCancelV1(b.sender, b.lease, fee, ts)
+		}
+		return proto.NewUnsignedLeaseCancelV2(b.scheme, b.sender, b.lease, fee, ts)
+	})
+}
+
+// CreateAliasBuilder builds the CreateAlias transaction.
+type CreateAliasBuilder struct {
+	Builder
+	alias proto.Alias
+}
+
+// CreateAlias returns the builder of the CreateAlias transaction of the alias of the network of the builder.
+func (b Builder) CreateAlias(alias string) CreateAliasBuilder {
+	return CreateAliasBuilder{Builder: b, alias: *proto.NewAlias(b.scheme, alias)}
+}
+
+// Build creates the CreateAlias transaction.
+func (b CreateAliasBuilder) Build() (proto.Transaction, error) {
+	ts := b.ts()
+	v := b.versionOf(2)
+	return b.finish(func(fee uint64) proto.Transaction {
+		if v == 1 {
+			return proto.NewUnsignedCreateAliasV1(b.sender, b.alias, fee, ts)
+		}
+		return proto.NewUnsignedCreateAliasV2(b.sender, b.alias, fee, ts)
+	})
+}
+
+// MassTransferBuilder builds the MassTransfer transaction.
+type MassTransferBuilder struct {
+	Builder
+	asset      proto.OptionalAsset
+	transfers  []proto.MassTransferEntry
+	attachment string
+}
+
+// MassTransfer returns the builder of the MassTransfer transaction of WAVES without transfers.
+func (b Builder) MassTransfer() MassTransferBuilder {
+	return MassTransferBuilder{Builder: b}
+}
+
+// Add adds the transfer to the address or the alias.
+func (b MassTransferBuilder) Add(recipient string, amount uint64) MassTransferBuilder {
+	e := proto.MassTransferEntry{Recipient: b.recipient(recipient), Amount: amount}
+	b.transfers = append(b.transfers[:len(b.transfers):len(b.transfers)], e)
+	return b
+}
+
+// Asset sets the transferred asset instead of WAVES.
+func (b MassTransferBuilder) Asset(asset crypto.Digest) MassTransferBuilder {
+	b.asset = proto.OptionalAsset{Present: true, ID: asset}
+	return b
+}
+
+// Attachment sets the attachment of transfers.
+func (b MassTransferBuilder) Attachment(attachment string) MassTransferBuilder {
+	b.attachment = attachment
+	return b
+}
+
+// Build creates the MassTransfer transaction.
+func (b MassTransferBuilder) Build() (proto.Transaction, error) {
+	ts := b.ts()
+	b.versionOf(1)
+	return b.finish(func(fee uint64) proto.Transaction {
+		return proto.NewUnsignedMassTransferV1(b.sender, b.asset, b.transfers, fee, ts, b.attachment)
+	})
+}
+
+// DataBuilder builds the Data transaction.
+type DataBuilder struct {
+	Builder
+	entries []proto.DataEntry
+}
+
+// Data returns the builder of the Data transaction without entries.
+func (b Builder) Data() DataBuilder {
+	return DataBuilder{Builder: b}
+}
+
+// Entry adds the data entry.
+func (b DataBuilder) Entry(entry proto.DataEntry) DataBuilder {
+	b.entries = append(b.entries[:len(b.entries):len(b.entries)], entry)
+	return b
+}
+
+// Integer adds the integer entry.
+func (b DataBuilder) Integer(key string, value int64) DataBuilder {
+	return b.Entry(&proto.IntegerDataEntry{Key: key, Value: value})
+}
+
+// Boolean adds the boolean entry.
+func (b DataBuilder) Boolean(key string, value bool) DataBuilder {
+	return b.Entry(&proto.BooleanDataEntry{Key: key, Value: value})
+}
+
+// Binary adds the binary entry.
+func (b DataBuilder) Binary(key string, value []byte) DataBuilder {
+	return b.Entry(&proto.BinaryDataEntry{Key: key, Value: value})
+}
+
+// String adds the string entry.
+func (b DataBuilder) String(key string, value string) DataBuilder {
+	return b.Entry(&proto.StringDataEntry{Key: key, Value: value})
+}
+
+// Build creates the Data transaction.
+func (b DataBuilder) Build() (proto.Transaction, error) {
+	ts := b.ts()
+	b.versionOf(1)
+	create := func(fee uint64) (*proto.DataV1, error) {
+		tx := proto.NewUnsignedData(b.sender, fee, ts)
+		for _, e := range b.entries {
+			if err := tx.AppendEntry(e); err != nil {
+				return nil, err
+			}
+		}
+		return tx, nil
+	}
+	if _, err := create(0); err != nil {
+		b.fail(err)
+	}
+	return b.finish(func(fee uint64) proto.Transaction {
+		tx, _ := create(fee)
+		return tx
+	})
+}
+
+// SetScriptBuilder builds the SetScript transaction.
+type SetScriptBuilder struct {
+	Builder
+	script []byte
+}
+
+// SetScript returns the builder of the SetScript transaction, the empty script removes the script of account.
+func (b Builder) SetScript(script []byte) SetScriptBuilder {
+	return SetScriptBuilder{Builder: b, script: script}
+}
+
+// Build creates the SetScript transaction.
+func (b SetScriptBuilder) Build() (proto.Transaction, error) {
+	ts := b.ts()
+	b.versionOf(1)
+	return b.finish(func(fee uint64) proto.Transaction {
+		return proto.NewUnsignedSetScriptV1(b.scheme, b.sender, b.script, fee, ts)
+	})
+}
+
+// SponsorshipBuilder builds the Sponsorship transaction.
+type SponsorshipBuilder struct {
+	Builder
+	asset  crypto.Digest
+	minFee uint64
+}
+
+// Sponsorship returns the builder of the Sponsorship transaction, zero minimal fee cancels the sponsorship.
+func (b Builder) Sponsorship(asset crypto.Digest, minFee uint64) SponsorshipBuilder {
+	return SponsorshipBuilder{Builder: b, asset: asset, minFee: minFee}
+}
+
+// Build creates the Sponsorship transaction.
+func (b SponsorshipBuilder) Build() (proto.Transaction, error) {
+	ts := b.ts()
+	b.versionOf(1)
+	return b.finish(func(fee uint64) proto.Transaction {
+		return proto.NewUnsignedSponsorshipV1(b.sender, b.asset, b.minFee, fee, ts)
+	})
+}
+
+// SetAssetScriptBuilder builds the SetAssetScript transaction.
+type SetAssetScriptBuilder struct {
+	Builder
+	asset  crypto.Digest
+	script []byte
+}
+
+// SetAssetScript returns the builder of the SetAssetScript transaction.
+func (b Builder) SetAssetScript(asset crypto.Digest, script []byte) SetAssetScriptBuilder {
+	return SetAssetScriptBuilder{Builder: b, asset: asset, script: script}
+}
+
+// Build creates the SetAssetScript transaction.
+func (b SetAssetScriptBuilder) Build() (proto.Transaction, error) {
+	ts := b.ts()
+	b.versionOf(1)
+	return b.finish(func(fee uint64) proto.Transaction {
+		return proto.NewUnsignedSetAssetScriptV1(b.scheme, b.sender, b.asset, b.script, fee, ts)
+	})
+}
+
+// InvokeScriptBuilder builds the InvokeScript transaction.
+type InvokeScriptBuilder struct {
+	Builder
+	dApp     proto.Address
+	call     proto.FunctionCall
+	payments proto.ScriptPayments
+}
+
+// InvokeScript returns the builder of the InvokeScript transaction that calls the function of the dApp with the
+// arguments without payments.
+func (b Builder) InvokeScript(dApp string, function string, args ...proto.Argument) InvokeScriptBuilder {
+	call := proto.FunctionCall{Name: function, Arguments: append(proto.Arguments{}, args...)}
+	a := b.address(dApp)
+	return InvokeScriptBuilder{Builder: b, dApp: a, call: call}
+}
+
+// Payment attaches the payment in WAVES or the asset to the invocation.
+func (b InvokeScriptBuilder) Payment(amount uint64, asset proto.OptionalAsset) InvokeScriptBuilder {
+	p := proto.ScriptPayment{Amount: amount, Asset: asset}
+	b.payments = append(b.payments[:len(b.payments):len(b.payments)], p)
+	return b
+}
+
+// Build creates the InvokeScript transaction.
+func (b InvokeScriptBuilder) Build() (proto.Transaction, error) {
+	ts := b.ts()
+	b.versionOf(1)
+	return b.finish(func(fee uint64) proto.Transaction {
+		return proto.NewUnsignedInvokeScriptV1(b.scheme, b.sender, b.dApp, b.call, b.payments, b.feeAsset, fee, ts)
+	})
+}
@@ -95,6 +95,7 @@ type AssetsDetail struct {
 	Decimals             uint64        `json:"decimals"`
 	Reissuable           bool          `json:"reissuable"`
 	Quantity             uint64        `json:"quantity"`
+	Scripted             bool          `json:"scripted"`
 	MinSponsoredAssetFee uint64        `json:"minSponsoredAssetFee"`
 }
 
@@ -142,6 +143,37 @@ func (a *Assets) Distribution(ctx context.Context, assetId crypto.Digest) (Asset
 	return out, response, nil
 }
 
+type assetsDistributionPage struct {
+	HasNext  bool               `json:"hasNext"`
+	LastItem string             `json:"lastItem"`
+	Items    AssetsDistribution `json:"items"`
+}
+
+// distributionPage requests balances of the asset at the height that go after the address, if it's not empty
+func (a *Assets) distributionPage(ctx context.Context, assetId crypto.Digest, height, limit uint64, after string) (*assetsDistributionPage, *Response, error) {
+	path := fmt.Sprintf("/assets/%s/distribution/%d/limit/%d", assetId.String(), height, limit)
+	if after != "" {
+		path += "?after=" + after
+	}
+	url, err := joinUrl(a.options.BaseUrl, path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequest("GET", url.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out := new(assetsDistributionPage)
+	response, err := doHttp(ctx, a.options, req, out)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return out, response, nil
+}
+
 type AssetsIssueReq struct {
 	Sender      proto.Address `json:"sender"`
 	Name        string        `json:"name"`
@@ -101,6 +101,7 @@ var assertDetailsJson = `
   "decimals": 1,
   "reissuable": true,
   "quantity": 1906756656,
+  "scripted": true,
   "minSponsoredAssetFee": null
 }`
 
@@ -117,6 +118,7 @@ func TestAssets_Details(t *testing.T) {
 	assert.NotNil(t, resp)
 	assert.Equal(t, assetId, body.AssetId)
 	assert.EqualValues(t, 1906756656, body.Quantity)
+	assert.True(t, body.Scripted)
 	assert.Equal(t, "https://testnode1.wavesnodes.com/assets/details/CMBHKDtyE8GMbZAZANNeE5n2HU4VDpsQaBLmfCw9ASbf", resp.Request.URL.String())
 }
 
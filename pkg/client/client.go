@@ -41,6 +41,7 @@ type Client struct {
 	Utils        *Utils
 	Leasing      *Leasing
 	Debug        *Debug
+	Matcher      *Matcher
 }
 
 type Response struct {
@@ -88,6 +89,7 @@ func NewClient(options ...Options) (*Client, error) {
 		Utils:        NewUtils(opts),
 		Leasing:      NewLeasing(opts),
 		Debug:        NewDebug(opts),
+		Matcher:      NewMatcher(opts),
 	}
 
 	return c, nil
@@ -27,5 +27,38 @@ Simple example of client usage:
 	}
 	...
 
+Matcher API is available through the Matcher service, if the matcher is served by the separate host
+the service is created with its URL:
+
+	m := client.NewMatcher(client.Options{
+		Client:  &http.Client{Timeout: 30 * time.Second},
+		BaseUrl: "https://matcher.wavesplatform.com",
+	})
+	book, response, err := m.OrderBook(context.Background(), pair, 10)
+	...
+
+Client could send requests to the pool of nodes, the requests go to the healthy nodes and idempotent ones are retried:
+
+	c, pool, err := client.NewPoolClient(client.PoolOptions{
+		Nodes:  []string{"https://nodes.wavesnodes.com", "https://nodes.wavesplatform.com"},
+		Quorum: 2,
+	})
+	go pool.Run(ctx, time.Minute)
+	height, response, err := c.Blocks.Height(ctx)
+	...
+	stats := pool.Stats()
+
+Long ranges of blocks, transactions of the address and distribution of the asset are iterated page by page:
+
+	it := c.Blocks.SeqIterator(ctx, 1, 10000, client.IteratorOptions{Prefetch: 2})
+	defer it.Release()
+	for it.Next() {
+		block := it.Block()
+		...
+	}
+	if err := it.Error(); err != nil {
+		...
+	}
+
 */
 package client
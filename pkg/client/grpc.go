@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/grpc/pb"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// GrpcClient is a client of node's gRPC blockchain API.
+type GrpcClient struct {
+	conn *grpc.ClientConn
+	api  pb.BlockchainApiClient
+}
+
+// DialGrpc connects to gRPC API of the node at given address.
+func DialGrpc(address string, opts ...grpc.DialOption) (*GrpcClient, error) {
+	if len(opts) == 0 {
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+	conn, err := grpc.Dial(address, opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to dial '%s'", address)
+	}
+	return NewGrpcClient(conn), nil
+}
+
+// NewGrpcClient creates client which uses already established connection.
+func NewGrpcClient(conn *grpc.ClientConn) *GrpcClient {
+	return &GrpcClient{conn: conn, api: pb.NewBlockchainApiClient(conn)}
+}
+
+func (a *GrpcClient) Close() error {
+	return a.conn.Close()
+}
+
+func (a *GrpcClient) BlockAt(ctx context.Context, height uint64, withTransactions bool) (*pb.Block, error) {
+	return a.api.GetBlock(ctx, &pb.BlockRequest{
+		Request:             &pb.BlockRequest_Height{Height: height},
+		IncludeTransactions: withTransactions,
+	})
+}
+
+func (a *GrpcClient) BlockBySignature(ctx context.Context, id crypto.Signature, withTransactions bool) (*pb.Block, error) {
+	return a.api.GetBlock(ctx, &pb.BlockRequest{
+		Request:             &pb.BlockRequest_Id{Id: id.Bytes()},
+		IncludeTransactions: withTransactions,
+	})
+}
+
+// Balance returns balance of address in given asset, nil asset ID stands for WAVES.
+func (a *GrpcClient) Balance(ctx context.Context, addr proto.Address, assetID *crypto.Digest) (uint64, error) {
+	req := &pb.BalanceRequest{Address: addr.Bytes()}
+	if assetID != nil {
+		req.AssetId = assetID.Bytes()
+	}
+	b, err := a.api.GetBalance(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	return b.Amount, nil
+}
+
+func (a *GrpcClient) AssetInfo(ctx context.Context, assetID crypto.Digest) (*pb.AssetInfo, error) {
+	return a.api.GetAssetInfo(ctx, &pb.AssetInfoRequest{AssetId: assetID.Bytes()})
+}
+
+func (a *GrpcClient) TransactionInfo(ctx context.Context, id crypto.Digest) (proto.Transaction, error) {
+	tx, err := a.api.GetTransaction(ctx, &pb.TransactionRequest{Id: id.Bytes()})
+	if err != nil {
+		return nil, err
+	}
+	return proto.BytesToTransaction(tx.Body)
+}
+
+// BlockUpdates subscribes to updates of node's blockchain, the subscription is canceled with the context.
+func (a *GrpcClient) BlockUpdates(ctx context.Context) (<-chan *pb.BlockUpdate, <-chan error, error) {
+	stream, err := a.api.BlockUpdates(ctx, &pb.BlockUpdatesRequest{})
+	if err != nil {
+		return nil, nil, err
+	}
+	updates := make(chan *pb.BlockUpdate)
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(updates)
+		for {
+			u, err := stream.Recv()
+			if err != nil {
+				errCh <- err
+				return
+			}
+			select {
+			case updates <- u:
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			}
+		}
+	}()
+	return updates, errCh, nil
+}
@@ -0,0 +1,212 @@
+package client
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/grpc/pb"
+	"github.com/wavesplatform/gowaves/pkg/grpc/server"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// grpcTestState implements only the part of state used by gRPC server.
+type grpcTestState struct {
+	state.State
+	block  *proto.Block
+	tx     proto.Transaction
+	events chan state.Event
+}
+
+func (a *grpcTestState) BlockByHeight(height uint64) (*proto.Block, error) {
+	if height != 2 {
+		return nil, errors.New("not found")
+	}
+	b := *a.block
+	return &b, nil
+}
+
+func (a *grpcTestState) Block(id crypto.Signature) (*proto.Block, error) {
+	if id != a.block.BlockSignature {
+		return nil, errors.New("not found")
+	}
+	b := *a.block
+	return &b, nil
+}
+
+func (a *grpcTestState) BlockIDToHeight(id crypto.Signature) (uint64, error) {
+	return 2, nil
+}
+
+func (a *grpcTestState) AccountBalance(addr proto.Address, asset []byte) (uint64, error) {
+	if asset == nil {
+		return 100, nil
+	}
+	return 5, nil
+}
+
+func (a *grpcTestState) AssetInfo(assetID crypto.Digest) (*state.AssetInfo, error) {
+	return &state.AssetInfo{Name: "ASSET", Description: "test asset", Decimals: 2, Quantity: 1000, Reissuable: true}, nil
+}
+
+func (a *grpcTestState) TransactionByID(id []byte) (proto.Transaction, error) {
+	return a.tx, nil
+}
+
+func (a *grpcTestState) SubscribeEvents(buffer int) (<-chan state.Event, func()) {
+	return a.events, func() {}
+}
+
+func newGrpcTestClient(t *testing.T) (*GrpcClient, *grpcTestState, func()) {
+	addr, err := proto.NewAddressFromString("3P2HNUd5VUPLMQkJmctTPEeeHumiPN2GkTb")
+	require.NoError(t, err)
+	tx := proto.NewUnsignedGenesis(addr, 100, 1465742577614)
+	require.NoError(t, tx.GenerateSigID())
+	txBytes, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	txs := make([]byte, 4)
+	binary.BigEndian.PutUint32(txs, uint32(len(txBytes)))
+	txs = append(txs, txBytes...)
+	block := &proto.Block{
+		BlockHeader: proto.BlockHeader{
+			Version:          3,
+			Timestamp:        1465742577614,
+			Features:         []int16{1, 2},
+			NxtConsensus:     proto.NxtConsensus{BaseTarget: 153722867},
+			TransactionCount: 1,
+			BlockSignature:   crypto.Signature{1, 2, 3},
+		},
+		Transactions: txs,
+	}
+	st := &grpcTestState{block: block, tx: tx, events: make(chan state.Event, 2)}
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	pb.RegisterBlockchainApiServer(srv, server.NewServer(st))
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	dialer := func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.Dial()
+	}
+	c, err := DialGrpc("bufnet", grpc.WithContextDialer(dialer), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	return c, st, func() {
+		_ = c.Close()
+		srv.Stop()
+	}
+}
+
+func TestGrpcClient_Blocks(t *testing.T) {
+	c, st, stop := newGrpcTestClient(t)
+	defer stop()
+
+	b, err := c.BlockAt(context.Background(), 2, true)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, b.Header.Height)
+	assert.EqualValues(t, 3, b.Header.Version)
+	assert.EqualValues(t, 153722867, b.Header.BaseTarget)
+	assert.Equal(t, []int32{1, 2}, b.Header.Features)
+	assert.Equal(t, st.block.BlockSignature.Bytes(), b.Header.Signature)
+	require.Len(t, b.Transactions, 1)
+	assert.EqualValues(t, proto.GenesisTransaction, b.Transactions[0].Type)
+	assert.EqualValues(t, 1, b.Transactions[0].Version)
+	assert.Equal(t, st.tx.GetID(), b.Transactions[0].Id)
+	assert.Empty(t, b.Transactions[0].SenderPublicKey)
+	assert.EqualValues(t, 1465742577614, b.Transactions[0].Timestamp)
+	assert.EqualValues(t, 100, b.Transactions[0].Amount)
+	assert.Equal(t, [][]byte{st.tx.GetID()}, b.Transactions[0].Proofs)
+	assert.Equal(t, "3P2HNUd5VUPLMQkJmctTPEeeHumiPN2GkTb", mustAddress(t, b.Transactions[0].Recipient.GetAddress()))
+
+	b, err = c.BlockBySignature(context.Background(), st.block.BlockSignature, false)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, b.Header.Height)
+	assert.Empty(t, b.Transactions)
+
+	_, err = c.BlockAt(context.Background(), 3, false)
+	assert.Error(t, err)
+}
+
+func TestGrpcClient_Queries(t *testing.T) {
+	c, st, stop := newGrpcTestClient(t)
+	defer stop()
+	addr, err := proto.NewAddressFromString("3P2HNUd5VUPLMQkJmctTPEeeHumiPN2GkTb")
+	require.NoError(t, err)
+
+	balance, err := c.Balance(context.Background(), addr, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 100, balance)
+	asset := crypto.Digest{1}
+	balance, err = c.Balance(context.Background(), addr, &asset)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, balance)
+
+	info, err := c.AssetInfo(context.Background(), asset)
+	require.NoError(t, err)
+	assert.Equal(t, "ASSET", info.Name)
+	assert.EqualValues(t, 2, info.Decimals)
+	assert.Equal(t, asset.Bytes(), info.AssetId)
+
+	tx, err := c.TransactionInfo(context.Background(), crypto.Digest{})
+	require.NoError(t, err)
+	assert.Equal(t, st.tx.GetID(), tx.GetID())
+}
+
+func mustAddress(t *testing.T, b []byte) string {
+	addr, err := proto.NewAddressFromBytes(b)
+	require.NoError(t, err)
+	return addr.String()
+}
+
+func TestGrpcClient_TransactionFields(t *testing.T) {
+	c, st, stop := newGrpcTestClient(t)
+	defer stop()
+	sk, pk := crypto.GenerateKeyPair([]byte("grpc"))
+	alias := proto.NewAlias(proto.MainNetScheme, "merry")
+	asset := crypto.Digest{1}
+	tx := proto.NewUnsignedTransferV2(pk, proto.OptionalAsset{Present: true, ID: asset}, proto.OptionalAsset{}, 1561000000000, 10, 100000, proto.NewRecipientFromAlias(*alias), "")
+	require.NoError(t, tx.Sign(sk))
+	st.tx = tx
+
+	out, err := c.api.GetTransaction(context.Background(), &pb.TransactionRequest{Id: tx.ID.Bytes()})
+	require.NoError(t, err)
+	assert.EqualValues(t, proto.TransferTransaction, out.Type)
+	assert.EqualValues(t, 2, out.Version)
+	assert.Equal(t, pk[:], out.SenderPublicKey)
+	assert.EqualValues(t, 100000, out.Fee)
+	assert.Empty(t, out.FeeAssetId)
+	assert.EqualValues(t, 1561000000000, out.Timestamp)
+	require.Len(t, out.Proofs, 1)
+	assert.Equal(t, []byte(tx.Proofs.Proofs[0]), out.Proofs[0])
+	assert.Equal(t, "alias:W:merry", out.Recipient.GetAlias())
+	assert.EqualValues(t, 10, out.Amount)
+	assert.Equal(t, asset.Bytes(), out.AssetId)
+}
+
+func TestGrpcClient_BlockUpdates(t *testing.T) {
+	c, st, stop := newGrpcTestClient(t)
+	defer stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates, _, err := c.BlockUpdates(ctx)
+	require.NoError(t, err)
+	st.events <- state.Event{Type: state.BlockApplied, Height: 3, BlockID: crypto.Signature{3}}
+	st.events <- state.Event{Type: state.BlockRolledBack, Height: 3, BlockID: crypto.Signature{3}}
+
+	u := <-updates
+	assert.Equal(t, pb.BlockUpdate_APPLIED, u.Type)
+	assert.EqualValues(t, 3, u.Height)
+	u = <-updates
+	assert.Equal(t, pb.BlockUpdate_ROLLED_BACK, u.Type)
+	assert.Equal(t, crypto.Signature{3}.Bytes(), u.Id)
+}
@@ -0,0 +1,320 @@
+package client
+
+import (
+	"context"
+	"sort"
+
+	"github.com/mr-tron/base58/base58"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+const (
+	// maxBlocksPerRequest is the limit of the node on the number of blocks in /blocks/seq and /blocks/address.
+	maxBlocksPerRequest = 100
+	// maxTransactionsPerRequest is the limit of the node on the number of transactions in /transactions/address.
+	maxTransactionsPerRequest = 1000
+	// maxDistributionPerRequest is the limit of the node on the number of addresses in /assets/{id}/distribution.
+	maxDistributionPerRequest = 1000
+)
+
+type IteratorOptions struct {
+	// PageSize is the number of items requested at once, it can't exceed the limit of the node, which is used
+	// by default.
+	PageSize uint64
+	// Prefetch is the number of pages fetched concurrently ahead of the iteration. Pages are fetched on demand
+	// if it's zero.
+	Prefetch int
+}
+
+func (o IteratorOptions) pageSize(limit uint64) uint64 {
+	if o.PageSize == 0 || o.PageSize > limit {
+		return limit
+	}
+	return o.PageSize
+}
+
+// fetchFunc requests the page of items and returns the function requesting the next page, it's nil for the last page.
+type fetchFunc func(ctx context.Context) ([]interface{}, fetchFunc, error)
+
+type page struct {
+	items []interface{}
+	err   error
+}
+
+// iterator is the base of typed iterators, it goes through items of pages and stops on the first error or
+// when the context is done.
+type iterator struct {
+	parent context.Context
+	ctx    context.Context
+	cancel context.CancelFunc
+	fetch  fetchFunc
+	pages  chan chan page
+	items  []interface{}
+	item   interface{}
+	err    error
+}
+
+func newIterator(ctx context.Context, fetch fetchFunc, options IteratorOptions) iterator {
+	inner, cancel := context.WithCancel(ctx)
+	it := iterator{parent: ctx, ctx: inner, cancel: cancel, fetch: fetch}
+	if options.Prefetch > 0 {
+		it.pages = make(chan chan page, options.Prefetch)
+		go prefetch(inner, it.pages, fetch)
+	}
+	return it
+}
+
+// newRangeIterator creates the iterator over pages that are independent of each other, so they are prefetched
+// concurrently.
+func newRangeIterator(ctx context.Context, pages []func(ctx context.Context) ([]interface{}, error), options IteratorOptions) iterator {
+	if options.Prefetch == 0 {
+		return newIterator(ctx, chain(pages), options)
+	}
+	inner, cancel := context.WithCancel(ctx)
+	queue := make(chan chan page, options.Prefetch)
+	go func() {
+		defer close(queue)
+		for _, fetch := range pages {
+			f := make(chan page, 1)
+			select {
+			case queue <- f:
+			case <-inner.Done():
+				return
+			}
+			go func(fetch func(ctx context.Context) ([]interface{}, error)) {
+				items, err := fetch(inner)
+				f <- page{items: items, err: err}
+			}(fetch)
+		}
+	}()
+	return iterator{parent: ctx, ctx: inner, cancel: cancel, pages: queue}
+}
+
+func chain(pages []func(ctx context.Context) ([]interface{}, error)) fetchFunc {
+	if len(pages) == 0 {
+		return nil
+	}
+	return func(ctx context.Context) ([]interface{}, fetchFunc, error) {
+		items, err := pages[0](ctx)
+		return items, chain(pages[1:]), err
+	}
+}
+
+// prefetch requests pages one after another and queues them until the queue is full.
+func prefetch(ctx context.Context, pages chan chan page, fetch fetchFunc) {
+	defer close(pages)
+	for fetch != nil {
+		items, next, err := fetch(ctx)
+		f := make(chan page, 1)
+		f <- page{items: items, err: err}
+		select {
+		case pages <- f:
+		case <-ctx.Done():
+			return
+		}
+		if err != nil {
+			return
+		}
+		fetch = next
+	}
+}
+
+func (it *iterator) nextPage() (page, bool) {
+	if it.pages == nil {
+		if it.fetch == nil {
+			return page{}, false
+		}
+		items, next, err := it.fetch(it.ctx)
+		it.fetch = next
+		return page{items: items, err: err}, true
+	}
+	select {
+	case f, ok := <-it.pages:
+		if !ok {
+			return page{}, false
+		}
+		select {
+		case p := <-f:
+			return p, true
+		case <-it.ctx.Done():
+			return page{err: it.ctx.Err()}, true
+		}
+	case <-it.ctx.Done():
+		return page{err: it.ctx.Err()}, true
+	}
+}
+
+// Next moves the iterator to the next item, it returns false when there are no more items or the iteration failed.
+func (it *iterator) Next() bool {
+	for len(it.items) == 0 {
+		if it.err != nil {
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.fail(err)
+			return false
+		}
+		p, ok := it.nextPage()
+		if !ok {
+			it.Release()
+			return false
+		}
+		if p.err != nil {
+			it.fail(p.err)
+			return false
+		}
+		it.items = p.items
+	}
+	it.item, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+func (it *iterator) fail(err error) {
+	if perr := it.parent.Err(); perr != nil {
+		err = perr
+	}
+	it.err = err
+	it.items = nil
+	it.Release()
+}
+
+// Error returns the error that stopped the iteration, it's the error of the context if the context is done.
+func (it *iterator) Error() error {
+	return it.err
+}
+
+// Release stops the iteration and prefetching of pages, it must be called if the iteration is stopped before
+// Next returns false.
+func (it *iterator) Release() {
+	it.cancel()
+}
+
+// BlocksIterator iterates over blocks in the order of heights
+type BlocksIterator struct {
+	iterator
+}
+
+// Block returns the current block
+func (it *BlocksIterator) Block() *Block {
+	return it.item.(*Block)
+}
+
+func (a *Blocks) iterate(ctx context.Context, from, to uint64, options IteratorOptions, seq func(ctx context.Context, from, to uint64) ([]*Block, *Response, error)) *BlocksIterator {
+	size := options.pageSize(maxBlocksPerRequest)
+	var pages []func(ctx context.Context) ([]interface{}, error)
+	for start := from; start <= to && start >= from; start += size {
+		end := start + size - 1
+		if end > to || end < start {
+			end = to
+		}
+		start := start
+		pages = append(pages, func(ctx context.Context) ([]interface{}, error) {
+			blocks, _, err := seq(ctx, start, end)
+			if err != nil {
+				return nil, err
+			}
+			items := make([]interface{}, len(blocks))
+			for i, b := range blocks {
+				items[i] = b
+			}
+			return items, nil
+		})
+	}
+	return &BlocksIterator{newRangeIterator(ctx, pages, options)}
+}
+
+// Iterate over blocks from one height to another inclusive, the range is requested by pages of limited size
+func (a *Blocks) SeqIterator(ctx context.Context, from, to uint64, options IteratorOptions) *BlocksIterator {
+	return a.iterate(ctx, from, to, options, a.Seq)
+}
+
+// Iterate over blocks generated by the address between heights inclusive
+func (a *Blocks) AddressIterator(ctx context.Context, addr proto.Address, from, to uint64, options IteratorOptions) *BlocksIterator {
+	return a.iterate(ctx, from, to, options, func(ctx context.Context, from, to uint64) ([]*Block, *Response, error) {
+		return a.Address(ctx, addr, from, to)
+	})
+}
+
+// TransactionsIterator iterates over transactions from the newest to the oldest
+type TransactionsIterator struct {
+	iterator
+}
+
+// Transaction returns the current transaction
+func (it *TransactionsIterator) Transaction() proto.Transaction {
+	return it.item.(proto.Transaction)
+}
+
+// Iterate over all transactions of the address, each next page follows the last transaction of the previous one
+func (a *Transactions) AddressIterator(ctx context.Context, address proto.Address, options IteratorOptions) *TransactionsIterator {
+	size := options.pageSize(maxTransactionsPerRequest)
+	var fetch func(after string) fetchFunc
+	fetch = func(after string) fetchFunc {
+		return func(ctx context.Context) ([]interface{}, fetchFunc, error) {
+			txs, _, err := a.address(ctx, address, uint(size), after)
+			if err != nil {
+				return nil, nil, err
+			}
+			items := make([]interface{}, len(txs))
+			for i, tx := range txs {
+				items[i] = tx
+			}
+			if uint64(len(txs)) < size {
+				return items, nil, nil
+			}
+			return items, fetch(base58.Encode(txs[len(txs)-1].GetID())), nil
+		}
+	}
+	return &TransactionsIterator{newIterator(ctx, fetch(""), options)}
+}
+
+// DistributionIterator iterates over balances of the asset, addresses are sorted within each page
+type DistributionIterator struct {
+	iterator
+}
+
+// Address returns the address of the current balance
+func (it *DistributionIterator) Address() proto.Address {
+	return it.item.(AssetsDistributionItem).Address
+}
+
+// Balance returns the current balance
+func (it *DistributionIterator) Balance() uint64 {
+	return it.item.(AssetsDistributionItem).Balance
+}
+
+type AssetsDistributionItem struct {
+	Address proto.Address
+	Balance uint64
+}
+
+// Iterate over balances of the asset at the height, each next page follows the last address of the previous one
+func (a *Assets) DistributionIterator(ctx context.Context, assetId crypto.Digest, height uint64, options IteratorOptions) *DistributionIterator {
+	size := options.pageSize(maxDistributionPerRequest)
+	var fetch func(after string) fetchFunc
+	fetch = func(after string) fetchFunc {
+		return func(ctx context.Context) ([]interface{}, fetchFunc, error) {
+			out, _, err := a.distributionPage(ctx, assetId, height, size, after)
+			if err != nil {
+				return nil, nil, err
+			}
+			items := make([]interface{}, 0, len(out.Items))
+			for k, v := range out.Items {
+				addr, err := proto.NewAddressFromString(k)
+				if err != nil {
+					return nil, nil, err
+				}
+				items = append(items, AssetsDistributionItem{Address: addr, Balance: v})
+			}
+			sort.Slice(items, func(i, j int) bool {
+				return items[i].(AssetsDistributionItem).Address.String() < items[j].(AssetsDistributionItem).Address.String()
+			})
+			if !out.HasNext || out.LastItem == "" {
+				return items, nil, nil
+			}
+			return items, fetch(out.LastItem), nil
+		}
+	}
+	return &DistributionIterator{newIterator(ctx, fetch(""), options)}
+}
@@ -0,0 +1,183 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mr-tron/base58/base58"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// blocksServer answers with empty blocks of requested heights and fails if the range exceeds the limit of the node.
+func blocksServer(t *testing.T, requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		var from, to uint64
+		var addr string
+		if _, err := fmt.Sscanf(r.URL.Path, "/blocks/seq/%d/%d", &from, &to); err != nil {
+			_, err = fmt.Sscanf(r.URL.Path, "/blocks/address/%35s/%d/%d", &addr, &from, &to)
+			assert.NoError(t, err)
+		}
+		if to-from+1 > maxBlocksPerRequest {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		blocks := make([]*Block, 0, to-from+1)
+		for h := from; h <= to; h++ {
+			blocks = append(blocks, &Block{Headers: Headers{Height: h}})
+		}
+		// the client could go away if the iteration is canceled
+		_ = json.NewEncoder(w).Encode(blocks)
+	}))
+}
+
+func TestBlocks_SeqIterator(t *testing.T) {
+	for _, prefetch := range []int{0, 1, 3} {
+		var requests int32
+		server := blocksServer(t, &requests)
+		c, err := NewClient(Options{BaseUrl: server.URL, Client: server.Client()})
+		require.NoError(t, err)
+
+		it := c.Blocks.SeqIterator(context.Background(), 5, 254, IteratorOptions{Prefetch: prefetch})
+		height := uint64(5)
+		for it.Next() {
+			assert.Equal(t, height, it.Block().Height)
+			height++
+		}
+		require.NoError(t, it.Error())
+		assert.EqualValues(t, 255, height)
+		assert.EqualValues(t, 3, atomic.LoadInt32(&requests))
+		server.Close()
+	}
+}
+
+func TestBlocks_AddressIterator(t *testing.T) {
+	var requests int32
+	server := blocksServer(t, &requests)
+	defer server.Close()
+	c, err := NewClient(Options{BaseUrl: server.URL, Client: server.Client()})
+	require.NoError(t, err)
+	addr, err := proto.NewAddressFromString("3P7qtv5Z7AMhwyvf5sM6nLuWWypyjVKb7Us")
+	require.NoError(t, err)
+
+	it := c.Blocks.AddressIterator(context.Background(), addr, 1, 10, IteratorOptions{PageSize: 4, Prefetch: 2})
+	count := 0
+	for it.Next() {
+		count++
+	}
+	require.NoError(t, it.Error())
+	assert.Equal(t, 10, count)
+	assert.EqualValues(t, 3, atomic.LoadInt32(&requests))
+}
+
+func TestBlocks_SeqIteratorError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	c, err := NewClient(Options{BaseUrl: server.URL, Client: server.Client()})
+	require.NoError(t, err)
+
+	it := c.Blocks.SeqIterator(context.Background(), 1, 1000, IteratorOptions{Prefetch: 4})
+	assert.False(t, it.Next())
+	assert.IsType(t, &RequestError{}, it.Error())
+	assert.False(t, it.Next())
+}
+
+func TestBlocks_SeqIteratorCancel(t *testing.T) {
+	var requests int32
+	server := blocksServer(t, &requests)
+	defer server.Close()
+	c, err := NewClient(Options{BaseUrl: server.URL, Client: server.Client()})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	it := c.Blocks.SeqIterator(ctx, 1, 100000, IteratorOptions{Prefetch: 2})
+	count := 0
+	for it.Next() {
+		count++
+		if count == 150 {
+			cancel()
+		}
+	}
+	assert.Equal(t, context.Canceled, it.Error())
+	assert.True(t, count < 300)
+	assert.True(t, atomic.LoadInt32(&requests) < 10)
+}
+
+func TestTransactions_AddressIterator(t *testing.T) {
+	sk, pk := crypto.GenerateKeyPair([]byte("iterators"))
+	addr, err := proto.NewAddressFromPublicKey(proto.MainNetScheme, pk)
+	require.NoError(t, err)
+	txs := make([]proto.Transaction, 5)
+	for i := range txs {
+		tx := proto.NewUnsignedTransferV1(pk, proto.OptionalAsset{}, proto.OptionalAsset{}, uint64(1561000000000+i), 1, 100000, proto.NewRecipientFromAddress(addr), "")
+		require.NoError(t, tx.Sign(sk))
+		txs[i] = tx
+	}
+	var afters []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, fmt.Sprintf("/transactions/address/%s/limit/2", addr.String()), r.URL.Path)
+		after := r.URL.Query().Get("after")
+		afters = append(afters, after)
+		start := 0
+		for i, tx := range txs {
+			if base58.Encode(tx.GetID()) == after {
+				start = i + 1
+			}
+		}
+		end := start + 2
+		if end > len(txs) {
+			end = len(txs)
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode([][]proto.Transaction{txs[start:end]}))
+	}))
+	defer server.Close()
+	c, err := NewClient(Options{BaseUrl: server.URL, Client: server.Client()})
+	require.NoError(t, err)
+
+	it := c.Transactions.AddressIterator(context.Background(), addr, IteratorOptions{PageSize: 2})
+	var out []proto.Transaction
+	for it.Next() {
+		out = append(out, it.Transaction())
+	}
+	require.NoError(t, it.Error())
+	assert.Equal(t, txs, out)
+	assert.Equal(t, []string{"", base58.Encode(txs[1].GetID()), base58.Encode(txs[3].GetID())}, afters)
+}
+
+func TestAssets_DistributionIterator(t *testing.T) {
+	pages := map[string]string{
+		"":                                    `{"hasNext": true, "lastItem": "3P2HNUd5VUPLMQkJmctTPEeeHumiPN2GkTb", "items": {"3P2HNUd5VUPLMQkJmctTPEeeHumiPN2GkTb": 2, "3P7qtv5Z7AMhwyvf5sM6nLuWWypyjVKb7Us": 1}}`,
+		"3P2HNUd5VUPLMQkJmctTPEeeHumiPN2GkTb": `{"hasNext": false, "lastItem": "3PJaDyprvekvPXPuAtxrapacuDJopgJRaU3", "items": {"3PJaDyprvekvPXPuAtxrapacuDJopgJRaU3": 3}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/assets/AxAmJaro7BJ4KasYiZhw7HkjwgYtt2nekPuF2CN9LMym/distribution/1000/limit/1000", r.URL.Path)
+		fmt.Fprint(w, pages[r.URL.Query().Get("after")])
+	}))
+	defer server.Close()
+	c, err := NewClient(Options{BaseUrl: server.URL, Client: server.Client()})
+	require.NoError(t, err)
+	asset, err := crypto.NewDigestFromBase58("AxAmJaro7BJ4KasYiZhw7HkjwgYtt2nekPuF2CN9LMym")
+	require.NoError(t, err)
+
+	it := c.Assets.DistributionIterator(context.Background(), asset, 1000, IteratorOptions{Prefetch: 1})
+	var addresses []string
+	var balances []uint64
+	for it.Next() {
+		addresses = append(addresses, it.Address().String())
+		balances = append(balances, it.Balance())
+	}
+	require.NoError(t, it.Error())
+	assert.Equal(t, []string{"3P2HNUd5VUPLMQkJmctTPEeeHumiPN2GkTb", "3P7qtv5Z7AMhwyvf5sM6nLuWWypyjVKb7Us", "3PJaDyprvekvPXPuAtxrapacuDJopgJRaU3"}, addresses)
+	assert.Equal(t, []uint64{2, 1, 3}, balances)
+}
@@ -0,0 +1,299 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// Matcher is the client of the matcher (DEX) API. The matcher could be served by the node under the /matcher path
+// or by the separate host, in the latter case the service is created with NewMatcher and the URL of the host.
+type Matcher struct {
+	options Options
+}
+
+// Creates new matcher
+func NewMatcher(options Options) *Matcher {
+	return &Matcher{
+		options: options,
+	}
+}
+
+func pairPath(pair proto.AssetPair) string {
+	return fmt.Sprintf("/matcher/orderbook/%s/%s", pair.AmountAsset.String(), pair.PriceAsset.String())
+}
+
+// signedTimestamp signs the public key of the secret key followed by the timestamp, the way the matcher
+// authenticates requests of the account.
+func signedTimestamp(secretKey crypto.SecretKey, timestamp uint64) (crypto.PublicKey, crypto.Signature) {
+	pk := crypto.GeneratePublicKey(secretKey)
+	buf := make([]byte, crypto.PublicKeySize+8)
+	copy(buf, pk[:])
+	binary.BigEndian.PutUint64(buf[crypto.PublicKeySize:], timestamp)
+	return pk, crypto.Sign(secretKey, buf)
+}
+
+func (a *Matcher) post(ctx context.Context, path string, body interface{}, v interface{}) (*Response, error) {
+	url, err := joinUrl(a.options.BaseUrl, path)
+	if err != nil {
+		return nil, err
+	}
+
+	bts, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", url.String(), bytes.NewReader(bts))
+	if err != nil {
+		return nil, err
+	}
+
+	return doHttp(ctx, a.options, req, v)
+}
+
+func (a *Matcher) get(ctx context.Context, path string, v interface{}) (*Response, error) {
+	url, err := joinUrl(a.options.BaseUrl, path)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", url.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return doHttp(ctx, a.options, req, v)
+}
+
+// Get public key of the matcher
+func (a *Matcher) PublicKey(ctx context.Context) (*crypto.PublicKey, *Response, error) {
+	out := new(crypto.PublicKey)
+	response, err := a.get(ctx, "/matcher", out)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return out, response, nil
+}
+
+type MatcherAssetInfo struct {
+	Decimals uint64 `json:"decimals"`
+}
+
+type MatcherMarket struct {
+	AmountAsset     proto.OptionalAsset `json:"amountAsset"`
+	AmountAssetName string              `json:"amountAssetName"`
+	AmountAssetInfo MatcherAssetInfo    `json:"amountAssetInfo"`
+	PriceAsset      proto.OptionalAsset `json:"priceAsset"`
+	PriceAssetName  string              `json:"priceAssetName"`
+	PriceAssetInfo  MatcherAssetInfo    `json:"priceAssetInfo"`
+	Created         uint64              `json:"created"`
+}
+
+type MatcherMarkets struct {
+	MatcherPublicKey crypto.PublicKey `json:"matcherPublicKey"`
+	Markets          []MatcherMarket  `json:"markets"`
+}
+
+// Get the list of markets of the matcher
+func (a *Matcher) Markets(ctx context.Context) (*MatcherMarkets, *Response, error) {
+	out := new(MatcherMarkets)
+	response, err := a.get(ctx, "/matcher/orderbook", out)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return out, response, nil
+}
+
+type MatcherPriceLevel struct {
+	Amount uint64 `json:"amount"`
+	Price  uint64 `json:"price"`
+}
+
+type MatcherOrderBook struct {
+	Timestamp uint64              `json:"timestamp"`
+	Pair      proto.AssetPair     `json:"pair"`
+	Bids      []MatcherPriceLevel `json:"bids"`
+	Asks      []MatcherPriceLevel `json:"asks"`
+}
+
+// Get the order book of the asset pair, zero depth means the full order book
+func (a *Matcher) OrderBook(ctx context.Context, pair proto.AssetPair, depth uint64) (*MatcherOrderBook, *Response, error) {
+	path := pairPath(pair)
+	if depth > 0 {
+		path += fmt.Sprintf("?depth=%d", depth)
+	}
+
+	out := new(MatcherOrderBook)
+	response, err := a.get(ctx, path, out)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return out, response, nil
+}
+
+type MatcherPlaceOrder struct {
+	Success bool            `json:"success"`
+	Status  string          `json:"status"`
+	Message json.RawMessage `json:"message"`
+}
+
+// Place the signed order
+func (a *Matcher) PlaceOrder(ctx context.Context, order proto.Order) (*MatcherPlaceOrder, *Response, error) {
+	out := new(MatcherPlaceOrder)
+	response, err := a.post(ctx, "/matcher/orderbook", order, out)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return out, response, nil
+}
+
+type MatcherCancelOrder struct {
+	Success bool          `json:"success"`
+	Status  string        `json:"status"`
+	OrderID crypto.Digest `json:"orderId"`
+}
+
+type matcherCancelReq struct {
+	Sender    crypto.PublicKey `json:"sender"`
+	OrderID   *crypto.Digest   `json:"orderId,omitempty"`
+	Timestamp uint64           `json:"timestamp,omitempty"`
+	Signature crypto.Signature `json:"signature"`
+}
+
+// Cancel the order of the asset pair, the request is signed by the secret key of the sender of the order
+func (a *Matcher) CancelOrder(ctx context.Context, pair proto.AssetPair, orderID crypto.Digest, secretKey crypto.SecretKey) (*MatcherCancelOrder, *Response, error) {
+	pk := crypto.GeneratePublicKey(secretKey)
+	buf := make([]byte, crypto.PublicKeySize+crypto.DigestSize)
+	copy(buf, pk[:])
+	copy(buf[crypto.PublicKeySize:], orderID[:])
+	body := matcherCancelReq{
+		Sender:    pk,
+		OrderID:   &orderID,
+		Signature: crypto.Sign(secretKey, buf),
+	}
+
+	out := new(MatcherCancelOrder)
+	response, err := a.post(ctx, pairPath(pair)+"/cancel", body, out)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return out, response, nil
+}
+
+type MatcherCancelAll struct {
+	Success bool   `json:"success"`
+	Status  string `json:"status"`
+}
+
+// Cancel all orders of the account of the secret key
+func (a *Matcher) CancelAll(ctx context.Context, secretKey crypto.SecretKey) (*MatcherCancelAll, *Response, error) {
+	timestamp := NewTimestampFromTime(time.Now())
+	pk, sig := signedTimestamp(secretKey, timestamp)
+	body := matcherCancelReq{
+		Sender:    pk,
+		Timestamp: timestamp,
+		Signature: sig,
+	}
+
+	out := new(MatcherCancelAll)
+	response, err := a.post(ctx, "/matcher/orderbook/cancel", body, out)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return out, response, nil
+}
+
+type MatcherOrderStatus struct {
+	Status       string `json:"status"`
+	FilledAmount uint64 `json:"filledAmount"`
+	FilledFee    uint64 `json:"filledFee"`
+}
+
+// Get the status of the order of the asset pair
+func (a *Matcher) OrderStatus(ctx context.Context, pair proto.AssetPair, orderID crypto.Digest) (*MatcherOrderStatus, *Response, error) {
+	out := new(MatcherOrderStatus)
+	response, err := a.get(ctx, fmt.Sprintf("%s/%s", pairPath(pair), orderID.String()), out)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return out, response, nil
+}
+
+// Get balances of the address available for trading in the asset pair, balances are keyed by asset ID or WAVES
+func (a *Matcher) TradableBalance(ctx context.Context, pair proto.AssetPair, address proto.Address) (map[string]uint64, *Response, error) {
+	out := make(map[string]uint64)
+	response, err := a.get(ctx, fmt.Sprintf("%s/tradableBalance/%s", pairPath(pair), address.String()), &out)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return out, response, nil
+}
+
+type MatcherOrder struct {
+	ID        crypto.Digest   `json:"id"`
+	Type      proto.OrderType `json:"type"`
+	Amount    uint64          `json:"amount"`
+	Price     uint64          `json:"price"`
+	Fee       uint64          `json:"fee"`
+	Timestamp uint64          `json:"timestamp"`
+	Filled    uint64          `json:"filled"`
+	FilledFee uint64          `json:"filledFee"`
+	Status    string          `json:"status"`
+	AssetPair proto.AssetPair `json:"assetPair"`
+}
+
+// Get the history of orders of the account of the secret key, the request is authenticated by the signed timestamp
+func (a *Matcher) OrderHistory(ctx context.Context, secretKey crypto.SecretKey, activeOnly bool) ([]MatcherOrder, *Response, error) {
+	pk := crypto.GeneratePublicKey(secretKey)
+	path := fmt.Sprintf("/matcher/orderbook/%s?activeOnly=%t", pk.String(), activeOnly)
+	return a.orderHistory(ctx, path, secretKey)
+}
+
+// Get the history of orders of the account of the secret key in the asset pair
+func (a *Matcher) OrderHistoryByPair(ctx context.Context, pair proto.AssetPair, secretKey crypto.SecretKey) ([]MatcherOrder, *Response, error) {
+	pk := crypto.GeneratePublicKey(secretKey)
+	path := fmt.Sprintf("%s/publicKey/%s", pairPath(pair), pk.String())
+	return a.orderHistory(ctx, path, secretKey)
+}
+
+func (a *Matcher) orderHistory(ctx context.Context, path string, secretKey crypto.SecretKey) ([]MatcherOrder, *Response, error) {
+	url, err := joinUrl(a.options.BaseUrl, path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequest("GET", url.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	timestamp := NewTimestampFromTime(time.Now())
+	_, sig := signedTimestamp(secretKey, timestamp)
+	req.Header.Set("Timestamp", strconv.FormatUint(timestamp, 10))
+	req.Header.Set("Signature", sig.String())
+
+	var out []MatcherOrder
+	response, err := doHttp(ctx, a.options, req, &out)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return out, response, nil
+}
@@ -0,0 +1,247 @@
+package client
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+func mustDigest(s string) crypto.Digest {
+	d, err := crypto.NewDigestFromBase58(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+var matcherPair = proto.AssetPair{
+	AmountAsset: proto.OptionalAsset{Present: true, ID: mustDigest("8LQW8f7P5d5PZM7GtZEBgaqRPGSzS3DfPuiXrURJ4AJS")},
+}
+
+// newMatcherServer starts the server that answers the request to the path and method with the body.
+func newMatcherServer(method, path, body string, check func(r *http.Request)) (*Matcher, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method || r.URL.Path != path {
+			http.NotFound(w, r)
+			return
+		}
+		if check != nil {
+			check(r)
+		}
+		fmt.Fprint(w, body)
+	}))
+	m := NewMatcher(Options{BaseUrl: server.URL, Client: server.Client()})
+	return m, server.Close
+}
+
+func TestMatcher_PublicKey(t *testing.T) {
+	m, stop := newMatcherServer("GET", "/matcher", `"7kPFrHDiGw1rCm7LPszuECwWYL3dMf6iMifLRDJQZMzy"`, nil)
+	defer stop()
+	pk, _, err := m.PublicKey(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "7kPFrHDiGw1rCm7LPszuECwWYL3dMf6iMifLRDJQZMzy", pk.String())
+}
+
+var matcherMarketsJson = `
+{
+  "matcherPublicKey": "7kPFrHDiGw1rCm7LPszuECwWYL3dMf6iMifLRDJQZMzy",
+  "markets": [
+    {
+      "amountAssetName": "WBTC",
+      "amountAsset": "8LQW8f7P5d5PZM7GtZEBgaqRPGSzS3DfPuiXrURJ4AJS",
+      "amountAssetInfo": {"decimals": 8},
+      "priceAssetName": "WAVES",
+      "priceAsset": "WAVES",
+      "priceAssetInfo": {"decimals": 8},
+      "created": 1549384471426
+    }
+  ]
+}`
+
+func TestMatcher_Markets(t *testing.T) {
+	m, stop := newMatcherServer("GET", "/matcher/orderbook", matcherMarketsJson, nil)
+	defer stop()
+	markets, _, err := m.Markets(context.Background())
+	require.NoError(t, err)
+	require.Len(t, markets.Markets, 1)
+	assert.Equal(t, matcherPair.AmountAsset, markets.Markets[0].AmountAsset)
+	assert.False(t, markets.Markets[0].PriceAsset.Present)
+	assert.Equal(t, "WBTC", markets.Markets[0].AmountAssetName)
+	assert.EqualValues(t, 8, markets.Markets[0].AmountAssetInfo.Decimals)
+}
+
+var matcherOrderBookJson = `
+{
+  "timestamp": 1561000000000,
+  "pair": {"amountAsset": "8LQW8f7P5d5PZM7GtZEBgaqRPGSzS3DfPuiXrURJ4AJS", "priceAsset": "WAVES"},
+  "bids": [{"amount": 100, "price": 90}, {"amount": 200, "price": 80}],
+  "asks": [{"amount": 50, "price": 110}]
+}`
+
+func TestMatcher_OrderBook(t *testing.T) {
+	path := "/matcher/orderbook/8LQW8f7P5d5PZM7GtZEBgaqRPGSzS3DfPuiXrURJ4AJS/WAVES"
+	m, stop := newMatcherServer("GET", path, matcherOrderBookJson, func(r *http.Request) {
+		assert.Equal(t, "2", r.URL.Query().Get("depth"))
+	})
+	defer stop()
+	book, resp, err := m.OrderBook(context.Background(), matcherPair, 2)
+	require.NoError(t, err)
+	assert.Len(t, book.Bids, 2)
+	assert.Equal(t, MatcherPriceLevel{Amount: 50, Price: 110}, book.Asks[0])
+	assert.Equal(t, matcherPair, book.Pair)
+	assert.Equal(t, path+"?depth=2", resp.Request.URL.RequestURI())
+}
+
+func TestMatcher_PlaceOrder(t *testing.T) {
+	sk, pk := crypto.GenerateKeyPair([]byte("matcher test"))
+	_, matcherPK := crypto.GenerateKeyPair([]byte("matcher"))
+	order := proto.NewUnsignedOrderV2(pk, matcherPK, matcherPair.AmountAsset, matcherPair.PriceAsset, proto.Buy, 100000000, 100, 1561000000000, 1562000000000, 300000)
+	require.NoError(t, order.Sign(sk))
+	m, stop := newMatcherServer("POST", "/matcher/orderbook", `{"success": true, "message": {}, "status": "OrderAccepted"}`, func(r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		received := new(proto.OrderV2)
+		require.NoError(t, json.Unmarshal(b, received))
+		assert.Equal(t, order.ID, received.ID)
+		ok, err := received.Verify(pk)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+	defer stop()
+	res, _, err := m.PlaceOrder(context.Background(), order)
+	require.NoError(t, err)
+	assert.True(t, res.Success)
+	assert.Equal(t, "OrderAccepted", res.Status)
+}
+
+func TestMatcher_PlaceOrderRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"success": false, "message": "Not enough tradable balance", "status": "OrderRejected"}`)
+	}))
+	defer server.Close()
+	m := NewMatcher(Options{BaseUrl: server.URL, Client: server.Client()})
+	_, resp, err := m.PlaceOrder(context.Background(), &proto.OrderV2{})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Contains(t, err.Error(), "Not enough tradable balance")
+}
+
+func TestMatcher_CancelOrder(t *testing.T) {
+	sk, pk := crypto.GenerateKeyPair([]byte("matcher test"))
+	id := mustDigest("Bq6oXzVNqSi7RqVGDdxAB7PFUsAnM8GNYDW9cPfTsVXi")
+	path := "/matcher/orderbook/8LQW8f7P5d5PZM7GtZEBgaqRPGSzS3DfPuiXrURJ4AJS/WAVES/cancel"
+	answer := `{"orderId": "Bq6oXzVNqSi7RqVGDdxAB7PFUsAnM8GNYDW9cPfTsVXi", "success": true, "status": "OrderCanceled"}`
+	m, stop := newMatcherServer("POST", path, answer, func(r *http.Request) {
+		req := matcherCancelReq{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, pk, req.Sender)
+		assert.Equal(t, id, *req.OrderID)
+		assert.True(t, crypto.Verify(pk, req.Signature, append(pk[:], id[:]...)))
+	})
+	defer stop()
+	res, _, err := m.CancelOrder(context.Background(), matcherPair, id, sk)
+	require.NoError(t, err)
+	assert.Equal(t, id, res.OrderID)
+	assert.Equal(t, "OrderCanceled", res.Status)
+}
+
+// checkSignedTimestamp checks the signature of the public key and the timestamp.
+func checkSignedTimestamp(t *testing.T, pk crypto.PublicKey, timestamp uint64, sig crypto.Signature) {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, timestamp)
+	assert.True(t, crypto.Verify(pk, sig, append(pk[:], buf...)))
+}
+
+func TestMatcher_CancelAll(t *testing.T) {
+	sk, pk := crypto.GenerateKeyPair([]byte("matcher test"))
+	m, stop := newMatcherServer("POST", "/matcher/orderbook/cancel", `{"success": true, "status": "BatchCancelCompleted"}`, func(r *http.Request) {
+		req := matcherCancelReq{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, pk, req.Sender)
+		assert.Nil(t, req.OrderID)
+		checkSignedTimestamp(t, pk, req.Timestamp, req.Signature)
+	})
+	defer stop()
+	res, _, err := m.CancelAll(context.Background(), sk)
+	require.NoError(t, err)
+	assert.True(t, res.Success)
+}
+
+func TestMatcher_OrderStatus(t *testing.T) {
+	id := mustDigest("Bq6oXzVNqSi7RqVGDdxAB7PFUsAnM8GNYDW9cPfTsVXi")
+	path := "/matcher/orderbook/8LQW8f7P5d5PZM7GtZEBgaqRPGSzS3DfPuiXrURJ4AJS/WAVES/Bq6oXzVNqSi7RqVGDdxAB7PFUsAnM8GNYDW9cPfTsVXi"
+	m, stop := newMatcherServer("GET", path, `{"status": "PartiallyFilled", "filledAmount": 40, "filledFee": 120000}`, nil)
+	defer stop()
+	status, _, err := m.OrderStatus(context.Background(), matcherPair, id)
+	require.NoError(t, err)
+	assert.Equal(t, MatcherOrderStatus{Status: "PartiallyFilled", FilledAmount: 40, FilledFee: 120000}, *status)
+}
+
+func TestMatcher_TradableBalance(t *testing.T) {
+	address, _ := proto.NewAddressFromString("3PAWwWa6GbwcJaFzwqXQN5KQm7H96Y7SHTQ")
+	path := "/matcher/orderbook/8LQW8f7P5d5PZM7GtZEBgaqRPGSzS3DfPuiXrURJ4AJS/WAVES/tradableBalance/3PAWwWa6GbwcJaFzwqXQN5KQm7H96Y7SHTQ"
+	m, stop := newMatcherServer("GET", path, `{"8LQW8f7P5d5PZM7GtZEBgaqRPGSzS3DfPuiXrURJ4AJS": 500, "WAVES": 100000000}`, nil)
+	defer stop()
+	balance, _, err := m.TradableBalance(context.Background(), matcherPair, address)
+	require.NoError(t, err)
+	assert.EqualValues(t, 500, balance["8LQW8f7P5d5PZM7GtZEBgaqRPGSzS3DfPuiXrURJ4AJS"])
+	assert.EqualValues(t, 100000000, balance["WAVES"])
+}
+
+var matcherOrderHistoryJson = `
+[
+  {
+    "id": "Bq6oXzVNqSi7RqVGDdxAB7PFUsAnM8GNYDW9cPfTsVXi",
+    "type": "sell",
+    "amount": 100,
+    "price": 110,
+    "fee": 300000,
+    "timestamp": 1561000000000,
+    "filled": 0,
+    "filledFee": 0,
+    "status": "Accepted",
+    "assetPair": {"amountAsset": "8LQW8f7P5d5PZM7GtZEBgaqRPGSzS3DfPuiXrURJ4AJS", "priceAsset": null}
+  }
+]`
+
+func TestMatcher_OrderHistory(t *testing.T) {
+	sk, pk := crypto.GenerateKeyPair([]byte("matcher test"))
+	check := func(r *http.Request) {
+		timestamp, err := strconv.ParseUint(r.Header.Get("Timestamp"), 10, 64)
+		require.NoError(t, err)
+		sig, err := crypto.NewSignatureFromBase58(r.Header.Get("Signature"))
+		require.NoError(t, err)
+		checkSignedTimestamp(t, pk, timestamp, sig)
+	}
+
+	m, stop := newMatcherServer("GET", "/matcher/orderbook/"+pk.String(), matcherOrderHistoryJson, func(r *http.Request) {
+		assert.Equal(t, "true", r.URL.Query().Get("activeOnly"))
+		check(r)
+	})
+	defer stop()
+	orders, _, err := m.OrderHistory(context.Background(), sk, true)
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	assert.Equal(t, proto.Sell, orders[0].Type)
+	assert.Equal(t, "Accepted", orders[0].Status)
+	assert.Equal(t, matcherPair, orders[0].AssetPair)
+
+	path := "/matcher/orderbook/8LQW8f7P5d5PZM7GtZEBgaqRPGSzS3DfPuiXrURJ4AJS/WAVES/publicKey/" + pk.String()
+	m, stop = newMatcherServer("GET", path, matcherOrderHistoryJson, check)
+	defer stop()
+	orders, _, err = m.OrderHistoryByPair(context.Background(), matcherPair, sk)
+	require.NoError(t, err)
+	assert.Len(t, orders, 1)
+}
@@ -0,0 +1,469 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNoQuorum is returned by the pool if nodes answered differently or not enough nodes answered.
+var ErrNoQuorum = errors.New("nodes failed to reach quorum")
+
+type PoolOptions struct {
+	// Nodes are the URLs of node APIs.
+	Nodes []string
+	// Client sends requests to nodes, by default it's the http.Client with 3 seconds timeout.
+	Client Doer
+	// ApiKey is the API key of nodes.
+	ApiKey string
+	// Retries is the number of retries of the failed idempotent request, 3 by default if zero. Negative value turns
+	// retries off.
+	Retries int
+	// Backoff is the delay before the first retry, it's doubled before each next retry. 100ms by default.
+	Backoff time.Duration
+	// Cooldown is the time the failed node is not used for, 10 seconds by default.
+	Cooldown time.Duration
+	// HealthPath is the path requested to check the health of the node, /blocks/height by default.
+	HealthPath string
+	// Quorum is the number of nodes that must give the same answer to the GET request, the request is sent to the
+	// single node if the quorum is not greater than one.
+	Quorum int
+}
+
+var defaultPoolOptions = PoolOptions{
+	Client:     defaultOptions.Client,
+	Retries:    3,
+	Backoff:    100 * time.Millisecond,
+	Cooldown:   10 * time.Second,
+	HealthPath: "/blocks/height",
+}
+
+// NodeStats are the statistics of requests to the node.
+type NodeStats struct {
+	URL            string
+	Available      bool
+	Requests       uint64
+	Failures       uint64
+	LastLatency    time.Duration
+	AverageLatency time.Duration
+}
+
+type poolNode struct {
+	url *url.URL
+
+	mu    sync.Mutex
+	until time.Time
+	stats NodeStats
+}
+
+func (n *poolNode) available(now time.Time) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return !now.Before(n.until)
+}
+
+// cooldown returns the time the node is not used until.
+func (n *poolNode) cooldown() time.Time {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.until
+}
+
+// fail marks the node that answered the request as failed until the time.
+func (n *poolNode) fail(until time.Time) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.stats.Failures++
+	if until.After(n.until) {
+		n.until = until
+	}
+}
+
+// report accounts the request to the node, the node is not used until the time if it failed.
+func (n *poolNode) report(latency time.Duration, failed bool, until time.Time) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.stats.Requests++
+	n.stats.LastLatency = latency
+	if n.stats.AverageLatency == 0 {
+		n.stats.AverageLatency = latency
+	} else {
+		// exponentially weighted average with the weight of the last request 1/8
+		n.stats.AverageLatency += (latency - n.stats.AverageLatency) / 8
+	}
+	if failed {
+		n.stats.Failures++
+		if until.After(n.until) {
+			n.until = until
+		}
+	} else {
+		n.until = time.Time{}
+	}
+}
+
+// Pool is the Doer that sends requests to several nodes. Each request goes to the available node, the node that
+// fails is not used for the cooldown time and the idempotent request is retried with the next node. Nodes answered
+// with 429 Too Many Requests or 503 Service Unavailable are not used for the time of the Retry-After header.
+// The path of the request is joined with the path of the URL of the node, so nodes could be served under
+// different prefixes.
+//
+// The client on top of the pool is created with NewPoolClient, all services of the client work as usual.
+type Pool struct {
+	options PoolOptions
+	nodes   []*poolNode
+	next    uint32
+}
+
+// Creates new pool of nodes
+func NewPool(options PoolOptions) (*Pool, error) {
+	if len(options.Nodes) == 0 {
+		return nil, errors.New("no nodes provided")
+	}
+	opts := defaultPoolOptions
+	opts.Nodes = options.Nodes
+	opts.ApiKey = options.ApiKey
+	opts.Quorum = options.Quorum
+	if options.Client != nil {
+		opts.Client = options.Client
+	}
+	switch {
+	case options.Retries > 0:
+		opts.Retries = options.Retries
+	case options.Retries < 0:
+		opts.Retries = 0
+	}
+	if options.Backoff > 0 {
+		opts.Backoff = options.Backoff
+	}
+	if options.Cooldown > 0 {
+		opts.Cooldown = options.Cooldown
+	}
+	if options.HealthPath != "" {
+		opts.HealthPath = options.HealthPath
+	}
+	if opts.Quorum > len(opts.Nodes) {
+		return nil, errors.Errorf("quorum %d is greater than the number of nodes %d", opts.Quorum, len(opts.Nodes))
+	}
+
+	nodes := make([]*poolNode, len(opts.Nodes))
+	for i, s := range opts.Nodes {
+		u, err := url.Parse(s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid node URL '%s'", s)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return nil, errors.Errorf("invalid node URL '%s'", s)
+		}
+		nodes[i] = &poolNode{url: u, stats: NodeStats{URL: s}}
+	}
+	return &Pool{options: opts, nodes: nodes}, nil
+}
+
+// Creates new client that sends requests through the pool of nodes
+func NewPoolClient(options PoolOptions) (*Client, *Pool, error) {
+	pool, err := NewPool(options)
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := NewClient(Options{BaseUrl: pool.options.Nodes[0], Client: pool, ApiKey: pool.options.ApiKey})
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, pool, nil
+}
+
+// Stats returns statistics of nodes in the order of the options.
+func (p *Pool) Stats() []NodeStats {
+	now := time.Now()
+	out := make([]NodeStats, len(p.nodes))
+	for i, n := range p.nodes {
+		n.mu.Lock()
+		out[i] = n.stats
+		out[i].Available = !now.Before(n.until)
+		n.mu.Unlock()
+	}
+	return out
+}
+
+// CheckHealth requests the health path of all nodes concurrently, the nodes that fail or answer with other status
+// code than 2xx are not used for the cooldown time.
+func (p *Pool) CheckHealth(ctx context.Context) {
+	u := url.URL{Path: p.options.HealthPath}
+	var wg sync.WaitGroup
+	for _, n := range p.nodes {
+		wg.Add(1)
+		go func(n *poolNode) {
+			defer wg.Done()
+			req, err := http.NewRequest("GET", u.String(), nil)
+			if err != nil {
+				return
+			}
+			resp, err := p.send(n, req.WithContext(ctx))
+			if err != nil {
+				return
+			}
+			resp.Body.Close()
+			if resp.StatusCode < 200 || resp.StatusCode > 299 {
+				n.fail(time.Now().Add(p.options.Cooldown))
+			}
+		}(n)
+	}
+	wg.Wait()
+}
+
+// Run checks the health of nodes with the interval until the context is done.
+func (p *Pool) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		p.CheckHealth(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// pick returns up to count available nodes starting from the next one in round robin order. If there are not
+// enough available nodes, the unavailable ones are returned as well, the ones that become available earlier first.
+func (p *Pool) pick(count int, exclude map[*poolNode]bool) []*poolNode {
+	start := int(atomic.AddUint32(&p.next, 1)-1) % len(p.nodes)
+	now := time.Now()
+	var available, rest []*poolNode
+	for i := range p.nodes {
+		n := p.nodes[(start+i)%len(p.nodes)]
+		switch {
+		case exclude[n]:
+		case n.available(now):
+			available = append(available, n)
+		default:
+			rest = append(rest, n)
+		}
+	}
+	sort.SliceStable(rest, func(i, j int) bool {
+		return rest[i].cooldown().Before(rest[j].cooldown())
+	})
+	out := append(available, rest...)
+	if len(out) > count {
+		out = out[:count]
+	}
+	return out
+}
+
+func retryAfter(resp *http.Response, def time.Duration) time.Duration {
+	if s, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second
+	}
+	if t, err := http.ParseTime(resp.Header.Get("Retry-After")); err == nil {
+		return time.Until(t)
+	}
+	return def
+}
+
+// nodeURL rewrites the URL of the request to the node. The client on top of the pool makes requests to the first
+// node, so its path is replaced with the path of the node.
+func (p *Pool) nodeURL(n *poolNode, u *url.URL) *url.URL {
+	out := *u
+	out.Scheme = n.url.Scheme
+	out.Host = n.url.Host
+	path := u.Path
+	if base := strings.TrimSuffix(p.nodes[0].url.Path, "/"); base != "" && strings.HasPrefix(path, base+"/") {
+		path = strings.TrimPrefix(path, base)
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	out.Path = strings.TrimSuffix(n.url.Path, "/") + path
+	out.RawPath = ""
+	return &out
+}
+
+// send sends the request to the node and accounts the result. Network errors and responses with status codes
+// 429, 502, 503 and 504 are failures, which are returned as errors.
+func (p *Pool) send(n *poolNode, req *http.Request) (*http.Response, error) {
+	r := new(http.Request)
+	*r = *req
+	r.URL = p.nodeURL(n, req.URL)
+	r.Host = ""
+	r.Header = make(http.Header, len(req.Header))
+	for k, v := range req.Header {
+		r.Header[k] = v
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+
+	start := time.Now()
+	resp, err := p.options.Client.Do(r)
+	latency := time.Since(start)
+	if err != nil {
+		n.report(latency, true, time.Now().Add(p.options.Cooldown))
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		n.report(latency, true, time.Now().Add(retryAfter(resp, time.Second)))
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		n.report(latency, true, time.Now().Add(p.options.Cooldown))
+	default:
+		n.report(latency, false, time.Time{})
+		return resp, nil
+	}
+	resp.Body.Close()
+	return nil, &statusError{host: n.url.Host, code: resp.StatusCode}
+}
+
+type statusError struct {
+	host string
+	code int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("node %s answered with status code %d", e.host, e.code)
+}
+
+// rejected tells whether the node refused to process the request, so it's safe to send the request again.
+func rejected(err error) bool {
+	e, ok := err.(*statusError)
+	return ok && (e.code == http.StatusTooManyRequests || e.code == http.StatusServiceUnavailable)
+}
+
+func idempotent(req *http.Request) bool {
+	return req.Method == "GET" || req.Method == "HEAD"
+}
+
+// Do sends the request to the available node. The idempotent request is retried with other nodes with backoff.
+// The request rejected with 429 or 503 was not processed, so it is retried as well.
+func (p *Pool) Do(req *http.Request) (*http.Response, error) {
+	if req.Body != nil && req.GetBody == nil {
+		b, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(b)), nil
+		}
+	}
+	if p.options.Quorum > 1 && idempotent(req) {
+		return p.quorum(req)
+	}
+	return p.retry(req, func() *poolNode { return p.pick(1, nil)[0] }, func(*poolNode) {})
+}
+
+// wait waits for the backoff before the retry with the node, or until the end of the cooldown of the node if it's
+// later. The wait is interrupted when the context is done.
+func wait(ctx context.Context, n *poolNode, backoff time.Duration) error {
+	delay := backoff
+	if d := time.Until(n.cooldown()); d > delay {
+		delay = d
+	}
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// retry sends the request to the node given by next until it succeeds or retries are exhausted. The node that failed
+// is given back with release.
+func (p *Pool) retry(req *http.Request, next func() *poolNode, release func(*poolNode)) (*http.Response, error) {
+	backoff := p.options.Backoff
+	var lastErr error
+	for attempt := 0; attempt <= p.options.Retries; attempt++ {
+		n := next()
+		if attempt > 0 {
+			if err := wait(req.Context(), n, backoff); err != nil {
+				release(n)
+				return nil, err
+			}
+			backoff *= 2
+		}
+		resp, err := p.send(n, req)
+		if err == nil {
+			return resp, nil
+		}
+		release(n)
+		lastErr = err
+		if !idempotent(req) && !rejected(err) {
+			break
+		}
+	}
+	return nil, lastErr
+}
+
+type quorumAnswer struct {
+	resp *http.Response
+	body []byte
+	err  error
+}
+
+// quorum sends the request to quorum different nodes concurrently and returns the answer if all of them are the
+// same. The failed request is retried as by retry with the node that is not used by other requests.
+func (p *Pool) quorum(req *http.Request) (*http.Response, error) {
+	answers := make([]quorumAnswer, p.options.Quorum)
+	var wg sync.WaitGroup
+	// busy are the nodes used by requests, the quorum is not greater than the number of nodes, so there is always
+	// the node that is not busy for the request that failed
+	busy := make(map[*poolNode]bool)
+	var mu sync.Mutex
+	next := func() *poolNode {
+		mu.Lock()
+		defer mu.Unlock()
+		n := p.pick(1, busy)[0]
+		busy[n] = true
+		return n
+	}
+	release := func(n *poolNode) {
+		mu.Lock()
+		defer mu.Unlock()
+		delete(busy, n)
+	}
+	for i := range answers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := p.retry(req, next, release)
+			if err != nil {
+				answers[i].err = err
+				return
+			}
+			defer resp.Body.Close()
+			answers[i].resp = resp
+			answers[i].body, answers[i].err = ioutil.ReadAll(resp.Body)
+		}(i)
+	}
+	wg.Wait()
+
+	first := answers[0]
+	for _, a := range answers {
+		if a.err != nil {
+			return nil, errors.Wrap(ErrNoQuorum, a.err.Error())
+		}
+		if a.resp.StatusCode != first.resp.StatusCode || !bytes.Equal(a.body, first.body) {
+			return nil, ErrNoQuorum
+		}
+	}
+	first.resp.Body = ioutil.NopCloser(bytes.NewReader(first.body))
+	return first.resp, nil
+}
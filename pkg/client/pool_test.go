@@ -0,0 +1,242 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// heightServer answers with the height, the status code of answers is taken from the codes while they last.
+func heightServer(height int, codes ...int) (*httptest.Server, *int32) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := int(atomic.AddInt32(&requests, 1)) - 1
+		if i < len(codes) && codes[i] != http.StatusOK {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(codes[i])
+			return
+		}
+		fmt.Fprintf(w, `{"height": %d}`, height)
+	}))
+	return server, &requests
+}
+
+func TestPool_Failover(t *testing.T) {
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+	up, requests := heightServer(100)
+	defer up.Close()
+
+	c, pool, err := NewPoolClient(PoolOptions{Nodes: []string{down.URL, up.URL}, Backoff: time.Millisecond})
+	require.NoError(t, err)
+	for i := 0; i < 4; i++ {
+		body, _, err := c.Blocks.Height(context.Background())
+		require.NoError(t, err)
+		assert.EqualValues(t, 100, body.Height)
+	}
+	assert.EqualValues(t, 4, atomic.LoadInt32(requests))
+
+	stats := pool.Stats()
+	assert.False(t, stats[0].Available)
+	assert.EqualValues(t, 1, stats[0].Failures, "the failed node is not used during cooldown")
+	assert.True(t, stats[1].Available)
+	assert.EqualValues(t, 4, stats[1].Requests)
+	assert.True(t, stats[1].AverageLatency > 0)
+}
+
+func TestPool_RetryRejected(t *testing.T) {
+	for _, code := range []int{http.StatusTooManyRequests, http.StatusServiceUnavailable} {
+		server, requests := heightServer(100, code, code)
+		c, _, err := NewPoolClient(PoolOptions{Nodes: []string{server.URL}, Backoff: time.Millisecond})
+		require.NoError(t, err)
+		body, _, err := c.Blocks.Height(context.Background())
+		require.NoError(t, err)
+		assert.EqualValues(t, 100, body.Height)
+		assert.EqualValues(t, 3, atomic.LoadInt32(requests))
+		server.Close()
+	}
+}
+
+func TestPool_RetriesExhausted(t *testing.T) {
+	server, requests := heightServer(100, 503, 503, 503)
+	defer server.Close()
+	c, _, err := NewPoolClient(PoolOptions{Nodes: []string{server.URL}, Retries: 2, Backoff: time.Millisecond})
+	require.NoError(t, err)
+	_, _, err = c.Blocks.Height(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status code 503")
+	assert.EqualValues(t, 3, atomic.LoadInt32(requests))
+}
+
+func TestPool_NoRetries(t *testing.T) {
+	server, requests := heightServer(100, 503)
+	defer server.Close()
+	c, _, err := NewPoolClient(PoolOptions{Nodes: []string{server.URL}, Retries: -1, Backoff: time.Millisecond})
+	require.NoError(t, err)
+	_, _, err = c.Blocks.Height(context.Background())
+	require.Error(t, err)
+	assert.EqualValues(t, 1, atomic.LoadInt32(requests))
+}
+
+func TestPool_NotIdempotent(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+	c, _, err := NewPoolClient(PoolOptions{Nodes: []string{server.URL}, Backoff: time.Millisecond})
+	require.NoError(t, err)
+	_, pk := crypto.GenerateKeyPair([]byte("pool"))
+	tx := proto.NewUnsignedPayment(pk, proto.Address{}, 1, 100000, 1561000000000)
+	_, err = c.Transactions.Broadcast(context.Background(), tx)
+	require.Error(t, err)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&requests), "POST is not retried after the node could process it")
+}
+
+func TestPool_Quorum(t *testing.T) {
+	s1, _ := heightServer(100)
+	defer s1.Close()
+	s2, _ := heightServer(100)
+	defer s2.Close()
+	s3, _ := heightServer(99)
+	defer s3.Close()
+
+	c, _, err := NewPoolClient(PoolOptions{Nodes: []string{s1.URL, s2.URL}, Quorum: 2})
+	require.NoError(t, err)
+	body, _, err := c.Blocks.Height(context.Background())
+	require.NoError(t, err)
+	assert.EqualValues(t, 100, body.Height)
+
+	c, _, err = NewPoolClient(PoolOptions{Nodes: []string{s1.URL, s3.URL}, Quorum: 2})
+	require.NoError(t, err)
+	_, _, err = c.Blocks.Height(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, ErrNoQuorum, errors.Cause(err.(*RequestError).Err))
+
+	_, err = NewPool(PoolOptions{Nodes: []string{s1.URL}, Quorum: 2})
+	assert.EqualError(t, err, "quorum 2 is greater than the number of nodes 1")
+}
+
+func TestPool_QuorumFailover(t *testing.T) {
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+	s1, _ := heightServer(100)
+	defer s1.Close()
+	s2, requests := heightServer(100, http.StatusServiceUnavailable)
+	defer s2.Close()
+
+	c, pool, err := NewPoolClient(PoolOptions{Nodes: []string{down.URL, s1.URL, s2.URL}, Quorum: 2, Backoff: time.Millisecond})
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		body, _, err := c.Blocks.Height(context.Background())
+		require.NoError(t, err)
+		assert.EqualValues(t, 100, body.Height)
+	}
+	stats := pool.Stats()
+	assert.False(t, stats[0].Available)
+	assert.True(t, atomic.LoadInt32(requests) >= 3, "the rejected request is retried")
+
+	// There is no other node to replace the failed one, so the request waits for the end of its cooldown
+	c, _, err = NewPoolClient(PoolOptions{Nodes: []string{s1.URL, down.URL}, Quorum: 2, Retries: 1, Backoff: time.Millisecond})
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, _, err = c.Blocks.Height(ctx)
+	require.Error(t, err)
+}
+
+func TestPool_WaitCooldown(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{"height": 100}`)
+	}))
+	defer server.Close()
+
+	c, _, err := NewPoolClient(PoolOptions{Nodes: []string{server.URL}, Backoff: time.Millisecond})
+	require.NoError(t, err)
+	start := time.Now()
+	body, _, err := c.Blocks.Height(context.Background())
+	require.NoError(t, err)
+	assert.EqualValues(t, 100, body.Height)
+	assert.True(t, time.Since(start) >= 900*time.Millisecond, "the retry waits for Retry-After")
+
+	// The wait is bounded by the context of the request
+	atomic.StoreInt32(&requests, 0)
+	c, _, err = NewPoolClient(PoolOptions{Nodes: []string{server.URL}, Backoff: time.Millisecond})
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	_, _, err = c.Blocks.Height(ctx)
+	require.Error(t, err)
+	assert.True(t, time.Since(start) < 900*time.Millisecond)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&requests))
+}
+
+func TestPool_BasePath(t *testing.T) {
+	var paths []string
+	var mu sync.Mutex
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		fmt.Fprint(w, `{"height": 100}`)
+	})
+	s1 := httptest.NewServer(handler)
+	defer s1.Close()
+	s2 := httptest.NewServer(handler)
+	defer s2.Close()
+
+	c, pool, err := NewPoolClient(PoolOptions{Nodes: []string{s1.URL + "/first", s2.URL + "/second/"}})
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		_, _, err := c.Blocks.Height(context.Background())
+		require.NoError(t, err)
+	}
+	pool.CheckHealth(context.Background())
+	sort.Strings(paths)
+	assert.Equal(t, []string{"/first/blocks/height", "/first/blocks/height", "/second/blocks/height", "/second/blocks/height"}, paths)
+}
+
+func TestPool_CheckHealth(t *testing.T) {
+	up, _ := heightServer(100)
+	defer up.Close()
+	down, _ := heightServer(100, http.StatusBadGateway)
+	defer down.Close()
+	failing, _ := heightServer(100, http.StatusInternalServerError)
+	defer failing.Close()
+	missing, _ := heightServer(100, http.StatusNotFound)
+	defer missing.Close()
+
+	pool, err := NewPool(PoolOptions{Nodes: []string{up.URL, down.URL, failing.URL, missing.URL}})
+	require.NoError(t, err)
+	pool.CheckHealth(context.Background())
+	stats := pool.Stats()
+	assert.True(t, stats[0].Available)
+	assert.False(t, stats[1].Available)
+	assert.False(t, stats[2].Available)
+	assert.False(t, stats[3].Available)
+	assert.EqualValues(t, 1, stats[2].Requests)
+	assert.EqualValues(t, 1, stats[2].Failures)
+}
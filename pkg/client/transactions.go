@@ -130,7 +130,16 @@ func (a *Transactions) Info(ctx context.Context, id crypto.Digest) (proto.Transa
 
 // Get list of transactions where specified address has been involved
 func (a *Transactions) Address(ctx context.Context, address proto.Address, limit uint) ([]proto.Transaction, *Response, error) {
-	url, err := joinUrl(a.options.BaseUrl, fmt.Sprintf("/transactions/address/%s/limit/%d", address.String(), limit))
+	return a.address(ctx, address, limit, "")
+}
+
+// address requests transactions of the address that go after the transaction with ID in base58, if it's not empty
+func (a *Transactions) address(ctx context.Context, address proto.Address, limit uint, after string) ([]proto.Transaction, *Response, error) {
+	path := fmt.Sprintf("/transactions/address/%s/limit/%d", address.String(), limit)
+	if after != "" {
+		path += "?after=" + after
+	}
+	url, err := joinUrl(a.options.BaseUrl, path)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -0,0 +1,76 @@
+package fees
+
+import (
+	"context"
+	"sync"
+
+	"github.com/wavesplatform/gowaves/pkg/client"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// ClientState is the State that looks up the facts in the node API. The answers are cached, so the state is meant
+// to be used for a short time, like building a batch of transactions.
+type ClientState struct {
+	ctx    context.Context
+	client *client.Client
+
+	mu       sync.Mutex
+	accounts map[proto.Address]bool
+	assets   map[crypto.Digest]*client.AssetsDetail
+}
+
+// NewClientState creates the state that uses the client with the context.
+func NewClientState(ctx context.Context, c *client.Client) *ClientState {
+	return &ClientState{
+		ctx:      ctx,
+		client:   c,
+		accounts: make(map[proto.Address]bool),
+		assets:   make(map[crypto.Digest]*client.AssetsDetail),
+	}
+}
+
+func (s *ClientState) AccountHasScript(address proto.Address) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if smart, ok := s.accounts[address]; ok {
+		return smart, nil
+	}
+	info, _, err := s.client.Addresses.ScriptInfo(s.ctx, address)
+	if err != nil {
+		return false, err
+	}
+	smart := info.Complexity > 0 || info.ExtraFee > 0
+	s.accounts[address] = smart
+	return smart, nil
+}
+
+func (s *ClientState) asset(id crypto.Digest) (*client.AssetsDetail, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if d, ok := s.assets[id]; ok {
+		return d, nil
+	}
+	d, _, err := s.client.Assets.Details(s.ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.assets[id] = d
+	return d, nil
+}
+
+func (s *ClientState) AssetHasScript(asset crypto.Digest) (bool, error) {
+	d, err := s.asset(asset)
+	if err != nil {
+		return false, err
+	}
+	return d.Scripted, nil
+}
+
+func (s *ClientState) AssetSponsorship(asset crypto.Digest) (uint64, error) {
+	d, err := s.asset(asset)
+	if err != nil {
+		return 0, err
+	}
+	return d.MinSponsoredAssetFee, nil
+}
@@ -0,0 +1,325 @@
+// Package fees calculates the minimal fees of transactions by the rules of the network.
+//
+// The minimal fee is the base fee of the transaction type in fee units of 0.001 WAVES. The MassTransfer transaction
+// costs one unit plus half a unit per transfer, rounded up to the whole unit. The Data transaction costs one unit per
+// started kilobyte of its bytes. Transactions of accounts with script cost extra 0.004 WAVES, the same extra fee is
+// charged for each asset with script the transaction operates with. The fee in the sponsored asset is converted from
+// the fee in WAVES using the minimal sponsored fee of the asset.
+//
+// The facts about scripts and sponsorship come from the State. Without the state the calculation is offline and
+// accounts and assets are considered to be without scripts.
+package fees
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+const (
+	// FeeUnit is the fee of the simple transaction, 0.001 WAVES.
+	FeeUnit = 100000
+	// SmartExtraFee is the extra fee for the account with script and for each asset with script, 0.004 WAVES.
+	SmartExtraFee = 400000
+
+	// unsignedProofsSize is the size of proofs with the single signature, it's used to price unsigned transactions.
+	unsignedProofsSize = 1 + 2 + 2 + crypto.SignatureSize
+)
+
+// ErrInsufficientFee is the cause of the error returned by Check for the transaction with fee less than the minimal.
+var ErrInsufficientFee = errors.New("insufficient fee")
+
+// baseFees are the fees of transaction types in fee units.
+var baseFees = map[proto.TransactionType]uint64{
+	proto.GenesisTransaction:        0,
+	proto.PaymentTransaction:        1,
+	proto.IssueTransaction:          1000,
+	proto.TransferTransaction:       1,
+	proto.ReissueTransaction:        1000,
+	proto.BurnTransaction:           1,
+	proto.ExchangeTransaction:       3,
+	proto.LeaseTransaction:          1,
+	proto.LeaseCancelTransaction:    1,
+	proto.CreateAliasTransaction:    1,
+	proto.MassTransferTransaction:   1,
+	proto.DataTransaction:           1,
+	proto.SetScriptTransaction:      10,
+	proto.SponsorshipTransaction:    1000,
+	proto.SetAssetScriptTransaction: 1000,
+	proto.InvokeScriptTransaction:   5,
+}
+
+// State provides the facts about accounts and assets the minimal fee depends on.
+type State interface {
+	// AccountHasScript tells whether the account has script.
+	AccountHasScript(address proto.Address) (bool, error)
+	// AssetHasScript tells whether the asset has script.
+	AssetHasScript(asset crypto.Digest) (bool, error)
+	// AssetSponsorship returns the fee in the asset that is equivalent to FeeUnit, zero for not sponsored asset.
+	AssetSponsorship(asset crypto.Digest) (uint64, error)
+}
+
+// StaticState is the State of known facts for the offline calculation. Accounts and assets that are absent are
+// considered to be without scripts and assets are considered not sponsored.
+type StaticState struct {
+	SmartAccounts map[proto.Address]bool
+	SmartAssets   map[crypto.Digest]bool
+	Sponsorships  map[crypto.Digest]uint64
+}
+
+func (s StaticState) AccountHasScript(address proto.Address) (bool, error) {
+	return s.SmartAccounts[address], nil
+}
+
+func (s StaticState) AssetHasScript(asset crypto.Digest) (bool, error) {
+	return s.SmartAssets[asset], nil
+}
+
+func (s StaticState) AssetSponsorship(asset crypto.Digest) (uint64, error) {
+	return s.Sponsorships[asset], nil
+}
+
+// Calculator calculates minimal fees of transactions of the network.
+type Calculator struct {
+	scheme byte
+	state  State
+}
+
+// NewCalculator creates the calculator of the network with the scheme, the nil state means the offline calculation.
+func NewCalculator(scheme byte, state State) *Calculator {
+	if state == nil {
+		state = StaticState{}
+	}
+	return &Calculator{scheme: scheme, state: state}
+}
+
+// MinimalFee returns the minimal fee in WAVES of the transaction of the account without script that operates with
+// assets without scripts.
+func MinimalFee(tx proto.Transaction) (uint64, error) {
+	return NewCalculator(proto.MainNetScheme, nil).MinimalFee(tx)
+}
+
+// MinimalFee returns the minimal fee of the transaction in WAVES.
+func (c *Calculator) MinimalFee(tx proto.Transaction) (uint64, error) {
+	d, err := describe(tx)
+	if err != nil {
+		return 0, err
+	}
+	fee := d.units * FeeUnit
+	if d.sender != nil {
+		address, err := proto.NewAddressFromPublicKey(c.scheme, *d.sender)
+		if err != nil {
+			return 0, err
+		}
+		smart, err := c.state.AccountHasScript(address)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to check script of account %s", address.String())
+		}
+		if smart {
+			fee += SmartExtraFee
+		}
+	}
+	for _, a := range d.assets {
+		if !a.Present {
+			continue
+		}
+		smart, err := c.state.AssetHasScript(a.ID)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to check script of asset %s", a.ID.String())
+		}
+		if smart {
+			fee += SmartExtraFee
+		}
+	}
+	return fee, nil
+}
+
+// MinimalFeeInAsset returns the minimal fee of the transaction in the asset the fee is paid in. It's the fee in
+// WAVES converted to the sponsored asset if the transaction pays the fee in the asset.
+func (c *Calculator) MinimalFeeInAsset(tx proto.Transaction) (uint64, error) {
+	fee, err := c.MinimalFee(tx)
+	if err != nil {
+		return 0, err
+	}
+	d, err := describe(tx)
+	if err != nil {
+		return 0, err
+	}
+	if !d.feeAsset.Present {
+		return fee, nil
+	}
+	sponsorship, err := c.state.AssetSponsorship(d.feeAsset.ID)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get sponsorship of asset %s", d.feeAsset.ID.String())
+	}
+	if sponsorship == 0 {
+		return 0, errors.Errorf("asset %s is not sponsored", d.feeAsset.ID.String())
+	}
+	return FromWaves(fee, sponsorship)
+}
+
+// Check returns the error caused by ErrInsufficientFee if the fee of the transaction is less than the minimal one.
+func (c *Calculator) Check(tx proto.Transaction) error {
+	min, err := c.MinimalFeeInAsset(tx)
+	if err != nil {
+		return err
+	}
+	d, err := describe(tx)
+	if err != nil {
+		return err
+	}
+	if d.fee < min {
+		return errors.Wrapf(ErrInsufficientFee, "fee %d is less than minimal fee %d", d.fee, min)
+	}
+	return nil
+}
+
+// Fee returns the fee of the transaction and the asset the fee is paid in.
+func Fee(tx proto.Transaction) (uint64, proto.OptionalAsset, error) {
+	d, err := describe(tx)
+	if err != nil {
+		return 0, proto.OptionalAsset{}, err
+	}
+	return d.fee, d.feeAsset, nil
+}
+
+// FromWaves converts the fee in WAVES to the fee in the sponsored asset, rounding up.
+func FromWaves(fee, sponsorship uint64) (uint64, error) {
+	r := new(big.Int).SetUint64(fee)
+	r.Mul(r, new(big.Int).SetUint64(sponsorship))
+	r.Add(r, big.NewInt(FeeUnit-1))
+	r.Div(r, big.NewInt(FeeUnit))
+	if !r.IsUint64() {
+		return 0, errors.New("fee in asset overflows")
+	}
+	return r.Uint64(), nil
+}
+
+// ToWaves converts the fee in the sponsored asset to the fee in WAVES, rounding down.
+func ToWaves(fee, sponsorship uint64) (uint64, error) {
+	if sponsorship == 0 {
+		return 0, errors.New("asset is not sponsored")
+	}
+	r := new(big.Int).SetUint64(fee)
+	r.Mul(r, big.NewInt(FeeUnit))
+	r.Div(r, new(big.Int).SetUint64(sponsorship))
+	if !r.IsUint64() {
+		return 0, errors.New("fee in WAVES overflows")
+	}
+	return r.Uint64(), nil
+}
+
+// description holds the parts of the transaction the fee depends on.
+type description struct {
+	units    uint64
+	sender   *crypto.PublicKey
+	assets   []proto.OptionalAsset
+	fee      uint64
+	feeAsset proto.OptionalAsset
+}
+
+func asset(id crypto.Digest) proto.OptionalAsset {
+	return proto.OptionalAsset{Present: true, ID: id}
+}
+
+func describe(tx proto.Transaction) (description, error) {
+	var d description
+	var t proto.TransactionType
+	switch tx := tx.(type) {
+	case *proto.Genesis:
+		t = proto.GenesisTransaction
+	case *proto.Payment:
+		t, d.sender, d.fee = proto.PaymentTransaction, &tx.SenderPK, tx.Fee
+	case *proto.IssueV1:
+		t, d.sender, d.fee = proto.IssueTransaction, &tx.SenderPK, tx.Fee
+	case *proto.IssueV2:
+		t, d.sender, d.fee = proto.IssueTransaction, &tx.SenderPK, tx.Fee
+	case *proto.TransferV1:
+		t, d.sender, d.fee, d.feeAsset = proto.TransferTransaction, &tx.SenderPK, tx.Fee, tx.FeeAsset
+		d.assets = []proto.OptionalAsset{tx.AmountAsset}
+	case *proto.TransferV2:
+		t, d.sender, d.fee, d.feeAsset = proto.TransferTransaction, &tx.SenderPK, tx.Fee, tx.FeeAsset
+		d.assets = []proto.OptionalAsset{tx.AmountAsset}
+	case *proto.ReissueV1:
+		t, d.sender, d.fee = proto.ReissueTransaction, &tx.SenderPK, tx.Fee
+		d.assets = []proto.OptionalAsset{asset(tx.AssetID)}
+	case *proto.ReissueV2:
+		t, d.sender, d.fee = proto.ReissueTransaction, &tx.SenderPK, tx.Fee
+		d.assets = []proto.OptionalAsset{asset(tx.AssetID)}
+	case *proto.BurnV1:
+		t, d.sender, d.fee = proto.BurnTransaction, &tx.SenderPK, tx.Fee
+		d.assets = []proto.OptionalAsset{asset(tx.AssetID)}
+	case *proto.BurnV2:
+		t, d.sender, d.fee = proto.BurnTransaction, &tx.SenderPK, tx.Fee
+		d.assets = []proto.OptionalAsset{asset(tx.AssetID)}
+	case *proto.ExchangeV1:
+		t, d.sender, d.fee = proto.ExchangeTransaction, &tx.SenderPK, tx.Fee
+		pair := tx.BuyOrder.GetAssetPair()
+		d.assets = []proto.OptionalAsset{pair.AmountAsset, pair.PriceAsset}
+	case *proto.ExchangeV2:
+		t, d.sender, d.fee = proto.ExchangeTransaction, &tx.SenderPK, tx.Fee
+		if tx.BuyOrder == nil {
+			return d, errors.New("exchange transaction without buy order")
+		}
+		pair := tx.BuyOrder.GetAssetPair()
+		d.assets = []proto.OptionalAsset{pair.AmountAsset, pair.PriceAsset}
+	case *proto.LeaseV1:
+		t, d.sender, d.fee = proto.LeaseTransaction, &tx.SenderPK, tx.Fee
+	case *proto.LeaseV2:
+		t, d.sender, d.fee = proto.LeaseTransaction, &tx.SenderPK, tx.Fee
+	case *proto.LeaseCancelV1:
+		t, d.sender, d.fee = proto.LeaseCancelTransaction, &tx.SenderPK, tx.Fee
+	case *proto.LeaseCancelV2:
+		t, d.sender, d.fee = proto.LeaseCancelTransaction, &tx.SenderPK, tx.Fee
+	case *proto.CreateAliasV1:
+		t, d.sender, d.fee = proto.CreateAliasTransaction, &tx.SenderPK, tx.Fee
+	case *proto.CreateAliasV2:
+		t, d.sender, d.fee = proto.CreateAliasTransaction, &tx.SenderPK, tx.Fee
+	case *proto.MassTransferV1:
+		t, d.sender, d.fee = proto.MassTransferTransaction, &tx.SenderPK, tx.Fee
+		d.assets = []proto.OptionalAsset{tx.Asset}
+		d.units = uint64(len(tx.Transfers)+1) / 2
+	case *proto.DataV1:
+		t, d.sender, d.fee = proto.DataTransaction, &tx.SenderPK, tx.Fee
+		size, err := dataSize(tx)
+		if err != nil {
+			return d, err
+		}
+		d.units = uint64(size-1) / 1024
+	case *proto.SetScriptV1:
+		t, d.sender, d.fee = proto.SetScriptTransaction, &tx.SenderPK, tx.Fee
+	case *proto.SponsorshipV1:
+		t, d.sender, d.fee = proto.SponsorshipTransaction, &tx.SenderPK, tx.Fee
+	case *proto.SetAssetScriptV1:
+		t, d.sender, d.fee = proto.SetAssetScriptTransaction, &tx.SenderPK, tx.Fee
+		d.assets = []proto.OptionalAsset{asset(tx.AssetID)}
+	case *proto.InvokeScriptV1:
+		t, d.sender, d.fee, d.feeAsset = proto.InvokeScriptTransaction, &tx.SenderPK, tx.Fee, tx.FeeAsset
+		for _, p := range tx.Payments {
+			d.assets = append(d.assets, p.Asset)
+		}
+	default:
+		return d, errors.Errorf("unsupported transaction type %T", tx)
+	}
+	d.units += baseFees[t]
+	return d, nil
+}
+
+// dataSize returns the size of the Data transaction in bytes. The unsigned transaction is measured as if it has the
+// single signature.
+func dataSize(tx *proto.DataV1) (int, error) {
+	if tx.Proofs != nil && len(tx.Proofs.Proofs) > 0 {
+		b, err := tx.MarshalBinary()
+		if err != nil {
+			return 0, err
+		}
+		return len(b), nil
+	}
+	b, err := tx.BodyMarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	return 1 + len(b) + unsignedProofsSize, nil
+}
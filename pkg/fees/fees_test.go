@@ -0,0 +1,184 @@
+package fees
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/client"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+const ts = 1561000000000
+
+func testKeys(t *testing.T) (crypto.SecretKey, crypto.PublicKey, proto.Address, crypto.Digest) {
+	sk, pk := crypto.GenerateKeyPair([]byte("fees"))
+	address, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	asset, err := crypto.NewDigestFromBase58("GAXAj8T4pSjunDqpz6Q3bit4fJJN9PD4t8AK8JZVSa5u")
+	require.NoError(t, err)
+	return sk, pk, address, asset
+}
+
+func TestMinimalFee(t *testing.T) {
+	_, pk, address, asset := testKeys(t)
+	rcp := proto.NewRecipientFromAddress(address)
+	waves := proto.OptionalAsset{}
+	transfers := func(n int) []proto.MassTransferEntry {
+		r := make([]proto.MassTransferEntry, n)
+		for i := range r {
+			r[i] = proto.MassTransferEntry{Recipient: rcp, Amount: 1}
+		}
+		return r
+	}
+	data := func(size int) *proto.DataV1 {
+		tx := proto.NewUnsignedData(pk, 0, ts)
+		require.NoError(t, tx.AppendEntry(&proto.BinaryDataEntry{Key: "k", Value: make([]byte, size)}))
+		return tx
+	}
+	for _, test := range []struct {
+		tx  proto.Transaction
+		fee uint64
+	}{
+		{proto.NewUnsignedGenesis(address, 1, ts), 0},
+		{proto.NewUnsignedPayment(pk, address, 1, 0, ts), 100000},
+		{proto.NewUnsignedIssueV2(proto.TestNetScheme, pk, "asset", "", 1, 0, false, nil, ts, 0), 100000000},
+		{proto.NewUnsignedTransferV2(pk, waves, waves, ts, 1, 0, rcp, ""), 100000},
+		{proto.NewUnsignedReissueV1(pk, asset, 1, false, ts, 0), 100000000},
+		{proto.NewUnsignedBurnV2(proto.TestNetScheme, pk, asset, 1, ts, 0), 100000},
+		{proto.NewUnsignedLeaseV2(pk, rcp, 1, 0, ts), 100000},
+		{proto.NewUnsignedLeaseCancelV1(pk, asset, 0, ts), 100000},
+		{proto.NewUnsignedCreateAliasV2(pk, *proto.NewAlias(proto.TestNetScheme, "alias"), 0, ts), 100000},
+		{proto.NewUnsignedMassTransferV1(pk, waves, transfers(1), 0, ts, ""), 200000},
+		{proto.NewUnsignedMassTransferV1(pk, waves, transfers(2), 0, ts, ""), 200000},
+		{proto.NewUnsignedMassTransferV1(pk, waves, transfers(3), 0, ts, ""), 300000},
+		{proto.NewUnsignedMassTransferV1(pk, waves, transfers(100), 0, ts, ""), 5100000},
+		{data(100), 100000},
+		{data(1024), 200000},
+		{data(5000), 600000},
+		{proto.NewUnsignedSetScriptV1(proto.TestNetScheme, pk, nil, 0, ts), 1000000},
+		{proto.NewUnsignedSponsorshipV1(pk, asset, 1, 0, ts), 100000000},
+		{proto.NewUnsignedSetAssetScriptV1(proto.TestNetScheme, pk, asset, nil, 0, ts), 100000000},
+		{proto.NewUnsignedInvokeScriptV1(proto.TestNetScheme, pk, address, proto.FunctionCall{Name: "f"}, nil, waves, 0, ts), 500000},
+	} {
+		fee, err := MinimalFee(test.tx)
+		require.NoError(t, err, "%T", test.tx)
+		assert.Equal(t, test.fee, fee, "%T", test.tx)
+	}
+}
+
+func TestMinimalFee_DataSize(t *testing.T) {
+	sk, pk, _, _ := testKeys(t)
+	tx := proto.NewUnsignedData(pk, 0, ts)
+	// the body of transaction with the entry is 1 + 1 + 32 + 2 + (2 + 1 + 1 + 2 + 880) + 8 + 8 = 938 bytes
+	require.NoError(t, tx.AppendEntry(&proto.BinaryDataEntry{Key: "k", Value: make([]byte, 880)}))
+	unsigned, err := MinimalFee(tx)
+	require.NoError(t, err)
+	require.NoError(t, tx.Sign(sk))
+	signed, err := MinimalFee(tx)
+	require.NoError(t, err)
+	b, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, 1+(uint64(len(b))-1)/1024, signed/FeeUnit)
+	assert.Equal(t, signed, unsigned)
+}
+
+func TestCalculator_Smart(t *testing.T) {
+	_, pk, address, asset := testKeys(t)
+	rcp := proto.NewRecipientFromAddress(address)
+	waves := proto.OptionalAsset{}
+	smartAsset := proto.OptionalAsset{Present: true, ID: asset}
+	c := NewCalculator(proto.TestNetScheme, StaticState{
+		SmartAccounts: map[proto.Address]bool{address: true},
+		SmartAssets:   map[crypto.Digest]bool{asset: true},
+	})
+	for _, test := range []struct {
+		tx  proto.Transaction
+		fee uint64
+	}{
+		{proto.NewUnsignedTransferV2(pk, waves, waves, ts, 1, 0, rcp, ""), 500000},
+		{proto.NewUnsignedTransferV2(pk, smartAsset, waves, ts, 1, 0, rcp, ""), 900000},
+		{proto.NewUnsignedBurnV2(proto.TestNetScheme, pk, asset, 1, ts, 0), 900000},
+		{proto.NewUnsignedInvokeScriptV1(proto.TestNetScheme, pk, address, proto.FunctionCall{Name: "f"},
+			proto.ScriptPayments{{Amount: 1, Asset: smartAsset}}, waves, 0, ts), 1300000},
+	} {
+		fee, err := c.MinimalFee(test.tx)
+		require.NoError(t, err, "%T", test.tx)
+		assert.Equal(t, test.fee, fee, "%T", test.tx)
+	}
+}
+
+func TestCalculator_Sponsorship(t *testing.T) {
+	_, pk, address, asset := testKeys(t)
+	rcp := proto.NewRecipientFromAddress(address)
+	sponsored := proto.OptionalAsset{Present: true, ID: asset}
+	tx := proto.NewUnsignedTransferV2(pk, proto.OptionalAsset{}, sponsored, ts, 1, 20, rcp, "")
+
+	_, err := NewCalculator(proto.TestNetScheme, nil).MinimalFeeInAsset(tx)
+	assert.EqualError(t, err, "asset GAXAj8T4pSjunDqpz6Q3bit4fJJN9PD4t8AK8JZVSa5u is not sponsored")
+
+	c := NewCalculator(proto.TestNetScheme, StaticState{Sponsorships: map[crypto.Digest]uint64{asset: 25}})
+	fee, err := c.MinimalFeeInAsset(tx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(25), fee)
+	err = c.Check(tx)
+	assert.Equal(t, ErrInsufficientFee, errors.Cause(err))
+	assert.EqualError(t, err, "fee 20 is less than minimal fee 25: insufficient fee")
+	tx.Fee = 25
+	assert.NoError(t, c.Check(tx))
+}
+
+func TestConversion(t *testing.T) {
+	for _, test := range []struct {
+		waves, sponsorship, asset uint64
+	}{
+		{100000, 1, 1},
+		{100000, 7, 7},
+		{150000, 3, 5},
+		{500000, 1000, 5000},
+	} {
+		asset, err := FromWaves(test.waves, test.sponsorship)
+		require.NoError(t, err)
+		assert.Equal(t, test.asset, asset)
+		waves, err := ToWaves(asset, test.sponsorship)
+		require.NoError(t, err)
+		assert.True(t, waves >= test.waves)
+	}
+}
+
+func TestClientState(t *testing.T) {
+	_, pk, address, asset := testKeys(t)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/addresses/scriptInfo/"):
+			fmt.Fprintf(w, `{"address": "%s", "complexity": 20, "extraFee": 400000}`, address.String())
+		case strings.HasPrefix(r.URL.Path, "/assets/details/"):
+			fmt.Fprintf(w, `{"assetId": "%s", "scripted": false, "minSponsoredAssetFee": 10}`, asset.String())
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	cl, err := client.NewClient(client.Options{BaseUrl: server.URL, Client: server.Client()})
+	require.NoError(t, err)
+
+	c := NewCalculator(proto.TestNetScheme, NewClientState(context.Background(), cl))
+	sponsored := proto.OptionalAsset{Present: true, ID: asset}
+	tx := proto.NewUnsignedTransferV2(pk, sponsored, sponsored, ts, 1, 0, proto.NewRecipientFromAddress(address), "")
+	fee, err := c.MinimalFeeInAsset(tx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(50), fee)
+	fee, err = c.MinimalFeeInAsset(tx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(50), fee)
+	assert.Equal(t, 2, requests)
+}
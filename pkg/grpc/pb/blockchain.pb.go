@@ -0,0 +1,1096 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11-devel
+// 	protoc        (unknown)
+// source: blockchain.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type BlockUpdate_Type int32
+
+const (
+	BlockUpdate_APPLIED     BlockUpdate_Type = 0
+	BlockUpdate_ROLLED_BACK BlockUpdate_Type = 1
+)
+
+// Enum value maps for BlockUpdate_Type.
+var (
+	BlockUpdate_Type_name = map[int32]string{
+		0: "APPLIED",
+		1: "ROLLED_BACK",
+	}
+	BlockUpdate_Type_value = map[string]int32{
+		"APPLIED":     0,
+		"ROLLED_BACK": 1,
+	}
+)
+
+func (x BlockUpdate_Type) Enum() *BlockUpdate_Type {
+	p := new(BlockUpdate_Type)
+	*p = x
+	return p
+}
+
+func (x BlockUpdate_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BlockUpdate_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_blockchain_proto_enumTypes[0].Descriptor()
+}
+
+func (BlockUpdate_Type) Type() protoreflect.EnumType {
+	return &file_blockchain_proto_enumTypes[0]
+}
+
+func (x BlockUpdate_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BlockUpdate_Type.Descriptor instead.
+func (BlockUpdate_Type) EnumDescriptor() ([]byte, []int) {
+	return file_blockchain_proto_rawDescGZIP(), []int{11, 0}
+}
+
+type BlockHeader struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Version             uint32                 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Timestamp           uint64                 `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Reference           []byte                 `protobuf:"bytes,3,opt,name=reference,proto3" json:"reference,omitempty"`
+	BaseTarget          uint64                 `protobuf:"varint,4,opt,name=base_target,json=baseTarget,proto3" json:"base_target,omitempty"`
+	GenerationSignature []byte                 `protobuf:"bytes,5,opt,name=generation_signature,json=generationSignature,proto3" json:"generation_signature,omitempty"`
+	Features            []int32                `protobuf:"varint,6,rep,packed,name=features,proto3" json:"features,omitempty"`
+	GeneratorPublicKey  []byte                 `protobuf:"bytes,7,opt,name=generator_public_key,json=generatorPublicKey,proto3" json:"generator_public_key,omitempty"`
+	Signature           []byte                 `protobuf:"bytes,8,opt,name=signature,proto3" json:"signature,omitempty"`
+	TransactionCount    uint32                 `protobuf:"varint,9,opt,name=transaction_count,json=transactionCount,proto3" json:"transaction_count,omitempty"`
+	Height              uint64                 `protobuf:"varint,10,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *BlockHeader) Reset() {
+	*x = BlockHeader{}
+	mi := &file_blockchain_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockHeader) ProtoMessage() {}
+
+func (x *BlockHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockHeader.ProtoReflect.Descriptor instead.
+func (*BlockHeader) Descriptor() ([]byte, []int) {
+	return file_blockchain_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BlockHeader) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *BlockHeader) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *BlockHeader) GetReference() []byte {
+	if x != nil {
+		return x.Reference
+	}
+	return nil
+}
+
+func (x *BlockHeader) GetBaseTarget() uint64 {
+	if x != nil {
+		return x.BaseTarget
+	}
+	return 0
+}
+
+func (x *BlockHeader) GetGenerationSignature() []byte {
+	if x != nil {
+		return x.GenerationSignature
+	}
+	return nil
+}
+
+func (x *BlockHeader) GetFeatures() []int32 {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *BlockHeader) GetGeneratorPublicKey() []byte {
+	if x != nil {
+		return x.GeneratorPublicKey
+	}
+	return nil
+}
+
+func (x *BlockHeader) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *BlockHeader) GetTransactionCount() uint32 {
+	if x != nil {
+		return x.TransactionCount
+	}
+	return 0
+}
+
+func (x *BlockHeader) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+// Recipient is either the address or the alias of the account.
+type Recipient struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Recipient:
+	//
+	//	*Recipient_Address
+	//	*Recipient_Alias
+	Recipient     isRecipient_Recipient `protobuf_oneof:"recipient"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Recipient) Reset() {
+	*x = Recipient{}
+	mi := &file_blockchain_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Recipient) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Recipient) ProtoMessage() {}
+
+func (x *Recipient) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Recipient.ProtoReflect.Descriptor instead.
+func (*Recipient) Descriptor() ([]byte, []int) {
+	return file_blockchain_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Recipient) GetRecipient() isRecipient_Recipient {
+	if x != nil {
+		return x.Recipient
+	}
+	return nil
+}
+
+func (x *Recipient) GetAddress() []byte {
+	if x != nil {
+		if x, ok := x.Recipient.(*Recipient_Address); ok {
+			return x.Address
+		}
+	}
+	return nil
+}
+
+func (x *Recipient) GetAlias() string {
+	if x != nil {
+		if x, ok := x.Recipient.(*Recipient_Alias); ok {
+			return x.Alias
+		}
+	}
+	return ""
+}
+
+type isRecipient_Recipient interface {
+	isRecipient_Recipient()
+}
+
+type Recipient_Address struct {
+	Address []byte `protobuf:"bytes,1,opt,name=address,proto3,oneof"`
+}
+
+type Recipient_Alias struct {
+	// Alias in the full form alias:<scheme>:<name>.
+	Alias string `protobuf:"bytes,2,opt,name=alias,proto3,oneof"`
+}
+
+func (*Recipient_Address) isRecipient_Recipient() {}
+
+func (*Recipient_Alias) isRecipient_Recipient() {}
+
+// Transaction holds transaction in the binary form of Waves protocol along with its common fields.
+type Transaction struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      []byte                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type    uint32                 `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"`
+	Version uint32                 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Body    []byte                 `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	// Empty for genesis transactions.
+	SenderPublicKey []byte `protobuf:"bytes,5,opt,name=sender_public_key,json=senderPublicKey,proto3" json:"sender_public_key,omitempty"`
+	Fee             uint64 `protobuf:"varint,6,opt,name=fee,proto3" json:"fee,omitempty"`
+	// Empty fee asset ID stands for WAVES.
+	FeeAssetId []byte `protobuf:"bytes,7,opt,name=fee_asset_id,json=feeAssetId,proto3" json:"fee_asset_id,omitempty"`
+	Timestamp  uint64 `protobuf:"varint,8,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Signature of the transaction of the first version is its single proof.
+	Proofs [][]byte `protobuf:"bytes,9,rep,name=proofs,proto3" json:"proofs,omitempty"`
+	// Recipient of genesis, payment, transfer and lease transactions, dApp of invoke script transaction.
+	Recipient *Recipient `protobuf:"bytes,10,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	// Amount of genesis, payment, transfer, lease and burn transactions, quantity of issue and reissue transactions.
+	Amount uint64 `protobuf:"varint,11,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Asset of the amount, empty asset ID stands for WAVES. It's also the asset of sponsorship, mass transfer and
+	// set asset script transactions.
+	AssetId       []byte `protobuf:"bytes,12,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_blockchain_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_blockchain_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Transaction) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Transaction) GetType() uint32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *Transaction) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Transaction) GetBody() []byte {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+func (x *Transaction) GetSenderPublicKey() []byte {
+	if x != nil {
+		return x.SenderPublicKey
+	}
+	return nil
+}
+
+func (x *Transaction) GetFee() uint64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *Transaction) GetFeeAssetId() []byte {
+	if x != nil {
+		return x.FeeAssetId
+	}
+	return nil
+}
+
+func (x *Transaction) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *Transaction) GetProofs() [][]byte {
+	if x != nil {
+		return x.Proofs
+	}
+	return nil
+}
+
+func (x *Transaction) GetRecipient() *Recipient {
+	if x != nil {
+		return x.Recipient
+	}
+	return nil
+}
+
+func (x *Transaction) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Transaction) GetAssetId() []byte {
+	if x != nil {
+		return x.AssetId
+	}
+	return nil
+}
+
+type Block struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        *BlockHeader           `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Transactions  []*Transaction         `protobuf:"bytes,2,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Block) Reset() {
+	*x = Block{}
+	mi := &file_blockchain_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Block) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Block) ProtoMessage() {}
+
+func (x *Block) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Block.ProtoReflect.Descriptor instead.
+func (*Block) Descriptor() ([]byte, []int) {
+	return file_blockchain_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Block) GetHeader() *BlockHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *Block) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+type BlockRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Request:
+	//
+	//	*BlockRequest_Height
+	//	*BlockRequest_Id
+	Request             isBlockRequest_Request `protobuf_oneof:"request"`
+	IncludeTransactions bool                   `protobuf:"varint,3,opt,name=include_transactions,json=includeTransactions,proto3" json:"include_transactions,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *BlockRequest) Reset() {
+	*x = BlockRequest{}
+	mi := &file_blockchain_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockRequest) ProtoMessage() {}
+
+func (x *BlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockRequest.ProtoReflect.Descriptor instead.
+func (*BlockRequest) Descriptor() ([]byte, []int) {
+	return file_blockchain_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BlockRequest) GetRequest() isBlockRequest_Request {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *BlockRequest) GetHeight() uint64 {
+	if x != nil {
+		if x, ok := x.Request.(*BlockRequest_Height); ok {
+			return x.Height
+		}
+	}
+	return 0
+}
+
+func (x *BlockRequest) GetId() []byte {
+	if x != nil {
+		if x, ok := x.Request.(*BlockRequest_Id); ok {
+			return x.Id
+		}
+	}
+	return nil
+}
+
+func (x *BlockRequest) GetIncludeTransactions() bool {
+	if x != nil {
+		return x.IncludeTransactions
+	}
+	return false
+}
+
+type isBlockRequest_Request interface {
+	isBlockRequest_Request()
+}
+
+type BlockRequest_Height struct {
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3,oneof"`
+}
+
+type BlockRequest_Id struct {
+	Id []byte `protobuf:"bytes,2,opt,name=id,proto3,oneof"`
+}
+
+func (*BlockRequest_Height) isBlockRequest_Request() {}
+
+func (*BlockRequest_Id) isBlockRequest_Request() {}
+
+type BalanceRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Address []byte                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Empty asset ID stands for WAVES.
+	AssetId       []byte `protobuf:"bytes,2,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BalanceRequest) Reset() {
+	*x = BalanceRequest{}
+	mi := &file_blockchain_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalanceRequest) ProtoMessage() {}
+
+func (x *BalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalanceRequest.ProtoReflect.Descriptor instead.
+func (*BalanceRequest) Descriptor() ([]byte, []int) {
+	return file_blockchain_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BalanceRequest) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *BalanceRequest) GetAssetId() []byte {
+	if x != nil {
+		return x.AssetId
+	}
+	return nil
+}
+
+type Balance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       []byte                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	AssetId       []byte                 `protobuf:"bytes,2,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	Amount        uint64                 `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Balance) Reset() {
+	*x = Balance{}
+	mi := &file_blockchain_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Balance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Balance) ProtoMessage() {}
+
+func (x *Balance) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Balance.ProtoReflect.Descriptor instead.
+func (*Balance) Descriptor() ([]byte, []int) {
+	return file_blockchain_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Balance) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *Balance) GetAssetId() []byte {
+	if x != nil {
+		return x.AssetId
+	}
+	return nil
+}
+
+func (x *Balance) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type AssetInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssetId       []byte                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssetInfoRequest) Reset() {
+	*x = AssetInfoRequest{}
+	mi := &file_blockchain_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssetInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetInfoRequest) ProtoMessage() {}
+
+func (x *AssetInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetInfoRequest.ProtoReflect.Descriptor instead.
+func (*AssetInfoRequest) Descriptor() ([]byte, []int) {
+	return file_blockchain_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AssetInfoRequest) GetAssetId() []byte {
+	if x != nil {
+		return x.AssetId
+	}
+	return nil
+}
+
+type AssetInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssetId       []byte                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Decimals      int32                  `protobuf:"varint,4,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	Quantity      uint64                 `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Reissuable    bool                   `protobuf:"varint,6,opt,name=reissuable,proto3" json:"reissuable,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssetInfo) Reset() {
+	*x = AssetInfo{}
+	mi := &file_blockchain_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssetInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetInfo) ProtoMessage() {}
+
+func (x *AssetInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetInfo.ProtoReflect.Descriptor instead.
+func (*AssetInfo) Descriptor() ([]byte, []int) {
+	return file_blockchain_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AssetInfo) GetAssetId() []byte {
+	if x != nil {
+		return x.AssetId
+	}
+	return nil
+}
+
+func (x *AssetInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AssetInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *AssetInfo) GetDecimals() int32 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+func (x *AssetInfo) GetQuantity() uint64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *AssetInfo) GetReissuable() bool {
+	if x != nil {
+		return x.Reissuable
+	}
+	return false
+}
+
+type TransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            []byte                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionRequest) Reset() {
+	*x = TransactionRequest{}
+	mi := &file_blockchain_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionRequest) ProtoMessage() {}
+
+func (x *TransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionRequest.ProtoReflect.Descriptor instead.
+func (*TransactionRequest) Descriptor() ([]byte, []int) {
+	return file_blockchain_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TransactionRequest) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+type BlockUpdatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockUpdatesRequest) Reset() {
+	*x = BlockUpdatesRequest{}
+	mi := &file_blockchain_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockUpdatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUpdatesRequest) ProtoMessage() {}
+
+func (x *BlockUpdatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUpdatesRequest.ProtoReflect.Descriptor instead.
+func (*BlockUpdatesRequest) Descriptor() ([]byte, []int) {
+	return file_blockchain_proto_rawDescGZIP(), []int{10}
+}
+
+type BlockUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          BlockUpdate_Type       `protobuf:"varint,1,opt,name=type,proto3,enum=waves.node.grpc.BlockUpdate_Type" json:"type,omitempty"`
+	Height        uint64                 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Id            []byte                 `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockUpdate) Reset() {
+	*x = BlockUpdate{}
+	mi := &file_blockchain_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUpdate) ProtoMessage() {}
+
+func (x *BlockUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUpdate.ProtoReflect.Descriptor instead.
+func (*BlockUpdate) Descriptor() ([]byte, []int) {
+	return file_blockchain_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BlockUpdate) GetType() BlockUpdate_Type {
+	if x != nil {
+		return x.Type
+	}
+	return BlockUpdate_APPLIED
+}
+
+func (x *BlockUpdate) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *BlockUpdate) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+var File_blockchain_proto protoreflect.FileDescriptor
+
+const file_blockchain_proto_rawDesc = "" +
+	"\n" +
+	"\x10blockchain.proto\x12\x0fwaves.node.grpc\"\xe8\x02\n" +
+	"\vBlockHeader\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\rR\aversion\x12\x1c\n" +
+	"\ttimestamp\x18\x02 \x01(\x04R\ttimestamp\x12\x1c\n" +
+	"\treference\x18\x03 \x01(\fR\treference\x12\x1f\n" +
+	"\vbase_target\x18\x04 \x01(\x04R\n" +
+	"baseTarget\x121\n" +
+	"\x14generation_signature\x18\x05 \x01(\fR\x13generationSignature\x12\x1a\n" +
+	"\bfeatures\x18\x06 \x03(\x05R\bfeatures\x120\n" +
+	"\x14generator_public_key\x18\a \x01(\fR\x12generatorPublicKey\x12\x1c\n" +
+	"\tsignature\x18\b \x01(\fR\tsignature\x12+\n" +
+	"\x11transaction_count\x18\t \x01(\rR\x10transactionCount\x12\x16\n" +
+	"\x06height\x18\n" +
+	" \x01(\x04R\x06height\"L\n" +
+	"\tRecipient\x12\x1a\n" +
+	"\aaddress\x18\x01 \x01(\fH\x00R\aaddress\x12\x16\n" +
+	"\x05alias\x18\x02 \x01(\tH\x00R\x05aliasB\v\n" +
+	"\trecipient\"\xe2\x02\n" +
+	"\vTransaction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\fR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\rR\x04type\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\rR\aversion\x12\x12\n" +
+	"\x04body\x18\x04 \x01(\fR\x04body\x12*\n" +
+	"\x11sender_public_key\x18\x05 \x01(\fR\x0fsenderPublicKey\x12\x10\n" +
+	"\x03fee\x18\x06 \x01(\x04R\x03fee\x12 \n" +
+	"\ffee_asset_id\x18\a \x01(\fR\n" +
+	"feeAssetId\x12\x1c\n" +
+	"\ttimestamp\x18\b \x01(\x04R\ttimestamp\x12\x16\n" +
+	"\x06proofs\x18\t \x03(\fR\x06proofs\x128\n" +
+	"\trecipient\x18\n" +
+	" \x01(\v2\x1a.waves.node.grpc.RecipientR\trecipient\x12\x16\n" +
+	"\x06amount\x18\v \x01(\x04R\x06amount\x12\x19\n" +
+	"\basset_id\x18\f \x01(\fR\aassetId\"\x7f\n" +
+	"\x05Block\x124\n" +
+	"\x06header\x18\x01 \x01(\v2\x1c.waves.node.grpc.BlockHeaderR\x06header\x12@\n" +
+	"\ftransactions\x18\x02 \x03(\v2\x1c.waves.node.grpc.TransactionR\ftransactions\"x\n" +
+	"\fBlockRequest\x12\x18\n" +
+	"\x06height\x18\x01 \x01(\x04H\x00R\x06height\x12\x10\n" +
+	"\x02id\x18\x02 \x01(\fH\x00R\x02id\x121\n" +
+	"\x14include_transactions\x18\x03 \x01(\bR\x13includeTransactionsB\t\n" +
+	"\arequest\"E\n" +
+	"\x0eBalanceRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\fR\aaddress\x12\x19\n" +
+	"\basset_id\x18\x02 \x01(\fR\aassetId\"V\n" +
+	"\aBalance\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\fR\aaddress\x12\x19\n" +
+	"\basset_id\x18\x02 \x01(\fR\aassetId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x04R\x06amount\"-\n" +
+	"\x10AssetInfoRequest\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\fR\aassetId\"\xb4\x01\n" +
+	"\tAssetInfo\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\fR\aassetId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bdecimals\x18\x04 \x01(\x05R\bdecimals\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x04R\bquantity\x12\x1e\n" +
+	"\n" +
+	"reissuable\x18\x06 \x01(\bR\n" +
+	"reissuable\"$\n" +
+	"\x12TransactionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\fR\x02id\"\x15\n" +
+	"\x13BlockUpdatesRequest\"\x92\x01\n" +
+	"\vBlockUpdate\x125\n" +
+	"\x04type\x18\x01 \x01(\x0e2!.waves.node.grpc.BlockUpdate.TypeR\x04type\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x04R\x06height\x12\x0e\n" +
+	"\x02id\x18\x03 \x01(\fR\x02id\"$\n" +
+	"\x04Type\x12\v\n" +
+	"\aAPPLIED\x10\x00\x12\x0f\n" +
+	"\vROLLED_BACK\x10\x012\x95\x03\n" +
+	"\rBlockchainApi\x12A\n" +
+	"\bGetBlock\x12\x1d.waves.node.grpc.BlockRequest\x1a\x16.waves.node.grpc.Block\x12G\n" +
+	"\n" +
+	"GetBalance\x12\x1f.waves.node.grpc.BalanceRequest\x1a\x18.waves.node.grpc.Balance\x12M\n" +
+	"\fGetAssetInfo\x12!.waves.node.grpc.AssetInfoRequest\x1a\x1a.waves.node.grpc.AssetInfo\x12S\n" +
+	"\x0eGetTransaction\x12#.waves.node.grpc.TransactionRequest\x1a\x1c.waves.node.grpc.Transaction\x12T\n" +
+	"\fBlockUpdates\x12$.waves.node.grpc.BlockUpdatesRequest\x1a\x1c.waves.node.grpc.BlockUpdate0\x01B.Z,github.com/wavesplatform/gowaves/pkg/grpc/pbb\x06proto3"
+
+var (
+	file_blockchain_proto_rawDescOnce sync.Once
+	file_blockchain_proto_rawDescData []byte
+)
+
+func file_blockchain_proto_rawDescGZIP() []byte {
+	file_blockchain_proto_rawDescOnce.Do(func() {
+		file_blockchain_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_blockchain_proto_rawDesc), len(file_blockchain_proto_rawDesc)))
+	})
+	return file_blockchain_proto_rawDescData
+}
+
+var file_blockchain_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_blockchain_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_blockchain_proto_goTypes = []any{
+	(BlockUpdate_Type)(0),       // 0: waves.node.grpc.BlockUpdate.Type
+	(*BlockHeader)(nil),         // 1: waves.node.grpc.BlockHeader
+	(*Recipient)(nil),           // 2: waves.node.grpc.Recipient
+	(*Transaction)(nil),         // 3: waves.node.grpc.Transaction
+	(*Block)(nil),               // 4: waves.node.grpc.Block
+	(*BlockRequest)(nil),        // 5: waves.node.grpc.BlockRequest
+	(*BalanceRequest)(nil),      // 6: waves.node.grpc.BalanceRequest
+	(*Balance)(nil),             // 7: waves.node.grpc.Balance
+	(*AssetInfoRequest)(nil),    // 8: waves.node.grpc.AssetInfoRequest
+	(*AssetInfo)(nil),           // 9: waves.node.grpc.AssetInfo
+	(*TransactionRequest)(nil),  // 10: waves.node.grpc.TransactionRequest
+	(*BlockUpdatesRequest)(nil), // 11: waves.node.grpc.BlockUpdatesRequest
+	(*BlockUpdate)(nil),         // 12: waves.node.grpc.BlockUpdate
+}
+var file_blockchain_proto_depIdxs = []int32{
+	2,  // 0: waves.node.grpc.Transaction.recipient:type_name -> waves.node.grpc.Recipient
+	1,  // 1: waves.node.grpc.Block.header:type_name -> waves.node.grpc.BlockHeader
+	3,  // 2: waves.node.grpc.Block.transactions:type_name -> waves.node.grpc.Transaction
+	0,  // 3: waves.node.grpc.BlockUpdate.type:type_name -> waves.node.grpc.BlockUpdate.Type
+	5,  // 4: waves.node.grpc.BlockchainApi.GetBlock:input_type -> waves.node.grpc.BlockRequest
+	6,  // 5: waves.node.grpc.BlockchainApi.GetBalance:input_type -> waves.node.grpc.BalanceRequest
+	8,  // 6: waves.node.grpc.BlockchainApi.GetAssetInfo:input_type -> waves.node.grpc.AssetInfoRequest
+	10, // 7: waves.node.grpc.BlockchainApi.GetTransaction:input_type -> waves.node.grpc.TransactionRequest
+	11, // 8: waves.node.grpc.BlockchainApi.BlockUpdates:input_type -> waves.node.grpc.BlockUpdatesRequest
+	4,  // 9: waves.node.grpc.BlockchainApi.GetBlock:output_type -> waves.node.grpc.Block
+	7,  // 10: waves.node.grpc.BlockchainApi.GetBalance:output_type -> waves.node.grpc.Balance
+	9,  // 11: waves.node.grpc.BlockchainApi.GetAssetInfo:output_type -> waves.node.grpc.AssetInfo
+	3,  // 12: waves.node.grpc.BlockchainApi.GetTransaction:output_type -> waves.node.grpc.Transaction
+	12, // 13: waves.node.grpc.BlockchainApi.BlockUpdates:output_type -> waves.node.grpc.BlockUpdate
+	9,  // [9:14] is the sub-list for method output_type
+	4,  // [4:9] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_blockchain_proto_init() }
+func file_blockchain_proto_init() {
+	if File_blockchain_proto != nil {
+		return
+	}
+	file_blockchain_proto_msgTypes[1].OneofWrappers = []any{
+		(*Recipient_Address)(nil),
+		(*Recipient_Alias)(nil),
+	}
+	file_blockchain_proto_msgTypes[4].OneofWrappers = []any{
+		(*BlockRequest_Height)(nil),
+		(*BlockRequest_Id)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_blockchain_proto_rawDesc), len(file_blockchain_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_blockchain_proto_goTypes,
+		DependencyIndexes: file_blockchain_proto_depIdxs,
+		EnumInfos:         file_blockchain_proto_enumTypes,
+		MessageInfos:      file_blockchain_proto_msgTypes,
+	}.Build()
+	File_blockchain_proto = out.File
+	file_blockchain_proto_goTypes = nil
+	file_blockchain_proto_depIdxs = nil
+}
@@ -0,0 +1,283 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: blockchain.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	BlockchainApi_GetBlock_FullMethodName       = "/waves.node.grpc.BlockchainApi/GetBlock"
+	BlockchainApi_GetBalance_FullMethodName     = "/waves.node.grpc.BlockchainApi/GetBalance"
+	BlockchainApi_GetAssetInfo_FullMethodName   = "/waves.node.grpc.BlockchainApi/GetAssetInfo"
+	BlockchainApi_GetTransaction_FullMethodName = "/waves.node.grpc.BlockchainApi/GetTransaction"
+	BlockchainApi_BlockUpdates_FullMethodName   = "/waves.node.grpc.BlockchainApi/BlockUpdates"
+)
+
+// BlockchainApiClient is the client API for BlockchainApi service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// BlockchainApi provides queries to blockchain state and stream of blockchain updates.
+type BlockchainApiClient interface {
+	GetBlock(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*Block, error)
+	GetBalance(ctx context.Context, in *BalanceRequest, opts ...grpc.CallOption) (*Balance, error)
+	GetAssetInfo(ctx context.Context, in *AssetInfoRequest, opts ...grpc.CallOption) (*AssetInfo, error)
+	GetTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*Transaction, error)
+	// BlockUpdates streams blocks applied to and rolled back from the node's state.
+	BlockUpdates(ctx context.Context, in *BlockUpdatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BlockUpdate], error)
+}
+
+type blockchainApiClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBlockchainApiClient(cc grpc.ClientConnInterface) BlockchainApiClient {
+	return &blockchainApiClient{cc}
+}
+
+func (c *blockchainApiClient) GetBlock(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*Block, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Block)
+	err := c.cc.Invoke(ctx, BlockchainApi_GetBlock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockchainApiClient) GetBalance(ctx context.Context, in *BalanceRequest, opts ...grpc.CallOption) (*Balance, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Balance)
+	err := c.cc.Invoke(ctx, BlockchainApi_GetBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockchainApiClient) GetAssetInfo(ctx context.Context, in *AssetInfoRequest, opts ...grpc.CallOption) (*AssetInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssetInfo)
+	err := c.cc.Invoke(ctx, BlockchainApi_GetAssetInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockchainApiClient) GetTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*Transaction, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, BlockchainApi_GetTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockchainApiClient) BlockUpdates(ctx context.Context, in *BlockUpdatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BlockUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BlockchainApi_ServiceDesc.Streams[0], BlockchainApi_BlockUpdates_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BlockUpdatesRequest, BlockUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BlockchainApi_BlockUpdatesClient = grpc.ServerStreamingClient[BlockUpdate]
+
+// BlockchainApiServer is the server API for BlockchainApi service.
+// All implementations must embed UnimplementedBlockchainApiServer
+// for forward compatibility.
+//
+// BlockchainApi provides queries to blockchain state and stream of blockchain updates.
+type BlockchainApiServer interface {
+	GetBlock(context.Context, *BlockRequest) (*Block, error)
+	GetBalance(context.Context, *BalanceRequest) (*Balance, error)
+	GetAssetInfo(context.Context, *AssetInfoRequest) (*AssetInfo, error)
+	GetTransaction(context.Context, *TransactionRequest) (*Transaction, error)
+	// BlockUpdates streams blocks applied to and rolled back from the node's state.
+	BlockUpdates(*BlockUpdatesRequest, grpc.ServerStreamingServer[BlockUpdate]) error
+	mustEmbedUnimplementedBlockchainApiServer()
+}
+
+// UnimplementedBlockchainApiServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBlockchainApiServer struct{}
+
+func (UnimplementedBlockchainApiServer) GetBlock(context.Context, *BlockRequest) (*Block, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
+}
+func (UnimplementedBlockchainApiServer) GetBalance(context.Context, *BalanceRequest) (*Balance, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalance not implemented")
+}
+func (UnimplementedBlockchainApiServer) GetAssetInfo(context.Context, *AssetInfoRequest) (*AssetInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAssetInfo not implemented")
+}
+func (UnimplementedBlockchainApiServer) GetTransaction(context.Context, *TransactionRequest) (*Transaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
+}
+func (UnimplementedBlockchainApiServer) BlockUpdates(*BlockUpdatesRequest, grpc.ServerStreamingServer[BlockUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method BlockUpdates not implemented")
+}
+func (UnimplementedBlockchainApiServer) mustEmbedUnimplementedBlockchainApiServer() {}
+func (UnimplementedBlockchainApiServer) testEmbeddedByValue()                       {}
+
+// UnsafeBlockchainApiServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BlockchainApiServer will
+// result in compilation errors.
+type UnsafeBlockchainApiServer interface {
+	mustEmbedUnimplementedBlockchainApiServer()
+}
+
+func RegisterBlockchainApiServer(s grpc.ServiceRegistrar, srv BlockchainApiServer) {
+	// If the following call pancis, it indicates UnimplementedBlockchainApiServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BlockchainApi_ServiceDesc, srv)
+}
+
+func _BlockchainApi_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainApiServer).GetBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockchainApi_GetBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainApiServer).GetBlock(ctx, req.(*BlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockchainApi_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainApiServer).GetBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockchainApi_GetBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainApiServer).GetBalance(ctx, req.(*BalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockchainApi_GetAssetInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssetInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainApiServer).GetAssetInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockchainApi_GetAssetInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainApiServer).GetAssetInfo(ctx, req.(*AssetInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockchainApi_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainApiServer).GetTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockchainApi_GetTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainApiServer).GetTransaction(ctx, req.(*TransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockchainApi_BlockUpdates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BlockUpdatesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockchainApiServer).BlockUpdates(m, &grpc.GenericServerStream[BlockUpdatesRequest, BlockUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BlockchainApi_BlockUpdatesServer = grpc.ServerStreamingServer[BlockUpdate]
+
+// BlockchainApi_ServiceDesc is the grpc.ServiceDesc for BlockchainApi service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BlockchainApi_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "waves.node.grpc.BlockchainApi",
+	HandlerType: (*BlockchainApiServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBlock",
+			Handler:    _BlockchainApi_GetBlock_Handler,
+		},
+		{
+			MethodName: "GetBalance",
+			Handler:    _BlockchainApi_GetBalance_Handler,
+		},
+		{
+			MethodName: "GetAssetInfo",
+			Handler:    _BlockchainApi_GetAssetInfo_Handler,
+		},
+		{
+			MethodName: "GetTransaction",
+			Handler:    _BlockchainApi_GetTransaction_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BlockUpdates",
+			Handler:       _BlockchainApi_BlockUpdates_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "blockchain.proto",
+}
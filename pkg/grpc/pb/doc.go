@@ -0,0 +1,4 @@
+// Package pb contains protobuf messages and gRPC service definitions of node's blockchain API.
+package pb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative blockchain.proto
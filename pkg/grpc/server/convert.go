@@ -0,0 +1,265 @@
+package server
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/grpc/pb"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+func headerToProto(h *proto.BlockHeader) *pb.BlockHeader {
+	features := make([]int32, len(h.Features))
+	for i, f := range h.Features {
+		features[i] = int32(f)
+	}
+	return &pb.BlockHeader{
+		Version:             uint32(h.Version),
+		Timestamp:           h.Timestamp,
+		Reference:           h.Parent.Bytes(),
+		BaseTarget:          h.BaseTarget,
+		GenerationSignature: h.GenSignature.Bytes(),
+		Features:            features,
+		GeneratorPublicKey:  h.GenPublicKey[:],
+		Signature:           h.BlockSignature.Bytes(),
+		TransactionCount:    uint32(h.TransactionCount),
+		Height:              h.Height,
+	}
+}
+
+// transactionToProto converts binary transaction to its protobuf representation.
+func transactionToProto(body []byte) (*pb.Transaction, error) {
+	tx, err := proto.BytesToTransaction(body)
+	if err != nil {
+		return nil, err
+	}
+	out := &pb.Transaction{Id: tx.GetID(), Body: body}
+	if body[0] == 0 {
+		// Versioned transactions start with zero byte followed by type and version.
+		if len(body) < 3 {
+			return nil, errors.New("invalid size of transaction bytes")
+		}
+		out.Type = uint32(body[1])
+		out.Version = uint32(body[2])
+	} else {
+		out.Type = uint32(body[0])
+		out.Version = 1
+	}
+	if err := transactionFieldsToProto(tx, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// transactionFieldsToProto sets the common fields of the transaction.
+func transactionFieldsToProto(tx proto.Transaction, out *pb.Transaction) error {
+	if s, ok := tx.(interface{ GetSenderPK() crypto.PublicKey }); ok {
+		pk := s.GetSenderPK()
+		out.SenderPublicKey = pk[:]
+	}
+	if p, ok := tx.(proto.ProvenTransaction); ok {
+		out.Proofs = proofsToProto(p.GetProofs())
+	}
+	switch t := tx.(type) {
+	case *proto.Genesis:
+		out.Proofs = signatureToProto(t.Signature)
+		out.Timestamp = t.Timestamp
+		out.Recipient = addressToProto(t.Recipient)
+		out.Amount = t.Amount
+	case *proto.Payment:
+		out.Proofs = signatureToProto(t.Signature)
+		out.Fee, out.Timestamp = t.Fee, t.Timestamp
+		out.Recipient = addressToProto(t.Recipient)
+		out.Amount = t.Amount
+	case *proto.IssueV1:
+		out.Proofs = signatureToProto(t.Signature)
+		issueToProto(t.ID, &t.Issue, out)
+	case *proto.IssueV2:
+		issueToProto(t.ID, &t.Issue, out)
+	case *proto.TransferV1:
+		out.Proofs = signatureToProto(t.Signature)
+		transferToProto(&t.Transfer, out)
+	case *proto.TransferV2:
+		transferToProto(&t.Transfer, out)
+	case *proto.ReissueV1:
+		out.Proofs = signatureToProto(t.Signature)
+		reissueToProto(&t.Reissue, out)
+	case *proto.ReissueV2:
+		reissueToProto(&t.Reissue, out)
+	case *proto.BurnV1:
+		out.Proofs = signatureToProto(t.Signature)
+		burnToProto(&t.Burn, out)
+	case *proto.BurnV2:
+		burnToProto(&t.Burn, out)
+	case *proto.ExchangeV1:
+		out.Proofs = signatureToProto(t.Signature)
+		out.Fee, out.Timestamp = t.Fee, t.Timestamp
+	case *proto.ExchangeV2:
+		out.Fee, out.Timestamp = t.Fee, t.Timestamp
+	case *proto.LeaseV1:
+		out.Proofs = signatureToProto(t.Signature)
+		leaseToProto(&t.Lease, out)
+	case *proto.LeaseV2:
+		leaseToProto(&t.Lease, out)
+	case *proto.LeaseCancelV1:
+		out.Proofs = signatureToProto(t.Signature)
+		out.Fee, out.Timestamp = t.Fee, t.Timestamp
+	case *proto.LeaseCancelV2:
+		out.Fee, out.Timestamp = t.Fee, t.Timestamp
+	case *proto.CreateAliasV1:
+		out.Proofs = signatureToProto(t.Signature)
+		out.Fee, out.Timestamp = t.Fee, t.Timestamp
+	case *proto.CreateAliasV2:
+		out.Fee, out.Timestamp = t.Fee, t.Timestamp
+	case *proto.MassTransferV1:
+		out.Fee, out.Timestamp = t.Fee, t.Timestamp
+		out.AssetId = optionalAssetToProto(t.Asset)
+	case *proto.DataV1:
+		out.Fee, out.Timestamp = t.Fee, t.Timestamp
+	case *proto.SetScriptV1:
+		out.Fee, out.Timestamp = t.Fee, t.Timestamp
+	case *proto.SponsorshipV1:
+		out.Fee, out.Timestamp = t.Fee, t.Timestamp
+		out.AssetId = t.AssetID.Bytes()
+	case *proto.SetAssetScriptV1:
+		out.Fee, out.Timestamp = t.Fee, t.Timestamp
+		out.AssetId = t.AssetID.Bytes()
+	case *proto.InvokeScriptV1:
+		out.Fee, out.Timestamp = t.Fee, t.Timestamp
+		out.FeeAssetId = optionalAssetToProto(t.FeeAsset)
+		out.Recipient = addressToProto(t.ScriptAddress)
+	default:
+		return errors.Errorf("unsupported transaction type %T", tx)
+	}
+	return nil
+}
+
+func issueToProto(id *crypto.Digest, tx *proto.Issue, out *pb.Transaction) {
+	out.Fee, out.Timestamp = tx.Fee, tx.Timestamp
+	out.Amount = tx.Quantity
+	// ID of the issued asset is the ID of the transaction
+	if id != nil {
+		out.AssetId = id.Bytes()
+	}
+}
+
+func transferToProto(tx *proto.Transfer, out *pb.Transaction) {
+	out.Fee, out.Timestamp = tx.Fee, tx.Timestamp
+	out.FeeAssetId = optionalAssetToProto(tx.FeeAsset)
+	out.Recipient = recipientToProto(tx.Recipient)
+	out.Amount = tx.Amount
+	out.AssetId = optionalAssetToProto(tx.AmountAsset)
+}
+
+func reissueToProto(tx *proto.Reissue, out *pb.Transaction) {
+	out.Fee, out.Timestamp = tx.Fee, tx.Timestamp
+	out.Amount = tx.Quantity
+	out.AssetId = tx.AssetID.Bytes()
+}
+
+func burnToProto(tx *proto.Burn, out *pb.Transaction) {
+	out.Fee, out.Timestamp = tx.Fee, tx.Timestamp
+	out.Amount = tx.Amount
+	out.AssetId = tx.AssetID.Bytes()
+}
+
+func leaseToProto(tx *proto.Lease, out *pb.Transaction) {
+	out.Fee, out.Timestamp = tx.Fee, tx.Timestamp
+	out.Recipient = recipientToProto(tx.Recipient)
+	out.Amount = tx.Amount
+}
+
+func signatureToProto(sig *crypto.Signature) [][]byte {
+	if sig == nil {
+		return nil
+	}
+	return [][]byte{sig.Bytes()}
+}
+
+func proofsToProto(proofs *proto.ProofsV1) [][]byte {
+	if proofs == nil {
+		return nil
+	}
+	out := make([][]byte, len(proofs.Proofs))
+	for i, p := range proofs.Proofs {
+		out[i] = p
+	}
+	return out
+}
+
+func addressToProto(addr proto.Address) *pb.Recipient {
+	return &pb.Recipient{Recipient: &pb.Recipient_Address{Address: addr.Bytes()}}
+}
+
+func recipientToProto(r proto.Recipient) *pb.Recipient {
+	if r.Alias != nil {
+		return &pb.Recipient{Recipient: &pb.Recipient_Alias{Alias: r.Alias.String()}}
+	}
+	if r.Address != nil {
+		return addressToProto(*r.Address)
+	}
+	return nil
+}
+
+// optionalAssetToProto returns the ID of the asset, it's empty for WAVES.
+func optionalAssetToProto(a proto.OptionalAsset) []byte {
+	if !a.Present {
+		return nil
+	}
+	return a.ID.Bytes()
+}
+
+// transactionsToProto splits transactions of block, each of them is prefixed with its size.
+func transactionsToProto(txs proto.TransactionsField) ([]*pb.Transaction, error) {
+	var out []*pb.Transaction
+	for pos := 0; pos < len(txs); {
+		if pos+4 > len(txs) {
+			return nil, errors.New("invalid size of transactions bytes")
+		}
+		size := int(binary.BigEndian.Uint32(txs[pos : pos+4]))
+		pos += 4
+		if pos+size > len(txs) {
+			return nil, errors.New("invalid size of transaction bytes")
+		}
+		tx, err := transactionToProto(txs[pos : pos+size])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, tx)
+		pos += size
+	}
+	return out, nil
+}
+
+func blockToProto(block *proto.Block, includeTransactions bool) (*pb.Block, error) {
+	out := &pb.Block{Header: headerToProto(&block.BlockHeader)}
+	if includeTransactions {
+		txs, err := transactionsToProto(block.Transactions)
+		if err != nil {
+			return nil, err
+		}
+		out.Transactions = txs
+	}
+	return out, nil
+}
+
+func assetInfoToProto(assetID crypto.Digest, info *state.AssetInfo) *pb.AssetInfo {
+	return &pb.AssetInfo{
+		AssetId:     assetID.Bytes(),
+		Name:        info.Name,
+		Description: info.Description,
+		Decimals:    int32(info.Decimals),
+		Quantity:    info.Quantity,
+		Reissuable:  info.Reissuable,
+	}
+}
+
+func eventToProto(e state.Event) *pb.BlockUpdate {
+	t := pb.BlockUpdate_APPLIED
+	if e.Type == state.BlockRolledBack {
+		t = pb.BlockUpdate_ROLLED_BACK
+	}
+	return &pb.BlockUpdate{Type: t, Height: e.Height, Id: e.BlockID.Bytes()}
+}
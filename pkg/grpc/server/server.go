@@ -0,0 +1,146 @@
+// Package server implements gRPC blockchain API of the node.
+package server
+
+import (
+	"context"
+	"net"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/grpc/pb"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Number of events buffered for each subscriber of block updates.
+const updatesBufferSize = 1000
+
+type Server struct {
+	pb.UnimplementedBlockchainApiServer
+	state state.State
+}
+
+func NewServer(state state.State) *Server {
+	return &Server{state: state}
+}
+
+func (s *Server) GetBlock(ctx context.Context, req *pb.BlockRequest) (*pb.Block, error) {
+	var block *proto.Block
+	switch r := req.Request.(type) {
+	case *pb.BlockRequest_Height:
+		b, err := s.state.BlockByHeight(r.Height)
+		if err != nil {
+			return nil, status.Errorf(codes.NotFound, "failed to get block at height %d: %v", r.Height, err)
+		}
+		b.Height = r.Height
+		block = b
+	case *pb.BlockRequest_Id:
+		id, err := crypto.NewSignatureFromBytes(r.Id)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid block ID: %v", err)
+		}
+		b, err := s.state.Block(id)
+		if err != nil {
+			return nil, status.Errorf(codes.NotFound, "failed to get block '%s': %v", id.String(), err)
+		}
+		height, err := s.state.BlockIDToHeight(id)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get height of block '%s': %v", id.String(), err)
+		}
+		b.Height = height
+		block = b
+	default:
+		return nil, status.Error(codes.InvalidArgument, "block height or ID expected")
+	}
+	out, err := blockToProto(block, req.IncludeTransactions)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to convert block: %v", err)
+	}
+	return out, nil
+}
+
+func (s *Server) GetBalance(ctx context.Context, req *pb.BalanceRequest) (*pb.Balance, error) {
+	addr, err := proto.NewAddressFromBytes(req.Address)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid address: %v", err)
+	}
+	var asset []byte
+	if len(req.AssetId) != 0 {
+		id, err := crypto.NewDigestFromBytes(req.AssetId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid asset ID: %v", err)
+		}
+		asset = id.Bytes()
+	}
+	amount, err := s.state.AccountBalance(addr, asset)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get balance: %v", err)
+	}
+	return &pb.Balance{Address: req.Address, AssetId: asset, Amount: amount}, nil
+}
+
+func (s *Server) GetAssetInfo(ctx context.Context, req *pb.AssetInfoRequest) (*pb.AssetInfo, error) {
+	id, err := crypto.NewDigestFromBytes(req.AssetId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid asset ID: %v", err)
+	}
+	info, err := s.state.AssetInfo(id)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "failed to get asset '%s': %v", id.String(), err)
+	}
+	return assetInfoToProto(id, info), nil
+}
+
+func (s *Server) GetTransaction(ctx context.Context, req *pb.TransactionRequest) (*pb.Transaction, error) {
+	tx, err := s.state.TransactionByID(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "failed to get transaction: %v", err)
+	}
+	body, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal transaction: %v", err)
+	}
+	out, err := transactionToProto(body)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to convert transaction: %v", err)
+	}
+	return out, nil
+}
+
+func (s *Server) BlockUpdates(req *pb.BlockUpdatesRequest, stream pb.BlockchainApi_BlockUpdatesServer) error {
+	events, unsubscribe := s.state.SubscribeEvents(updatesBufferSize)
+	defer unsubscribe()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case e, ok := <-events:
+			if !ok {
+				// state is closed or the client doesn't keep up with updates and has to resync
+				return status.Error(codes.Unavailable, "updates stream is closed")
+			}
+			if err := stream.Send(eventToProto(e)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Run serves gRPC API on given address until the context is done.
+func Run(ctx context.Context, address string, s *Server) error {
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	srv := grpc.NewServer()
+	pb.RegisterBlockchainApiServer(srv, s)
+	go func() {
+		<-ctx.Done()
+		zap.S().Info("Shutting down gRPC server...")
+		srv.GracefulStop()
+	}()
+	return srv.Serve(lis)
+}
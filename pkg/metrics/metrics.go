@@ -0,0 +1,196 @@
+// Package metrics collects runtime metrics of gowaves services and exposes them in Prometheus format.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"go.uber.org/zap"
+)
+
+const namespace = "gowaves"
+
+var (
+	blockApplyDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "state",
+		Name:      "block_apply_seconds",
+		Help:      "Time spent applying a batch of blocks to state.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16),
+	})
+	stateHeight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "state",
+		Name:      "height",
+		Help:      "Current height of the blockchain in state.",
+	})
+	messagesReceived = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "p2p",
+		Name:      "messages_received_total",
+		Help:      "Number of network messages received, by content ID.",
+	}, []string{"content"})
+	messagesSent = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "p2p",
+		Name:      "messages_sent_total",
+		Help:      "Number of network messages sent, by content ID.",
+	}, []string{"content"})
+	bytesReceived = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "p2p",
+		Name:      "bytes_received_total",
+		Help:      "Number of bytes received from remote peers.",
+	})
+	bytesSent = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "p2p",
+		Name:      "bytes_sent_total",
+		Help:      "Number of bytes sent to remote peers.",
+	})
+	peersConnected = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "peers",
+		Name:      "connected",
+		Help:      "Number of connected peers.",
+	})
+	peersKnown = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "peers",
+		Name:      "known",
+		Help:      "Number of known peers addresses.",
+	})
+	syncScoreLag = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "score_lag",
+		Help:      "Difference between the highest score of connected peers and the local score.",
+	})
+	syncHeightLag = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "sync",
+		Name:      "height_lag",
+		Help:      "Number of blocks the local storage is behind the source of blocks.",
+	})
+	utxSize = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "utx",
+		Name:      "size",
+		Help:      "Number of unconfirmed transactions in pool.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(
+		blockApplyDuration,
+		stateHeight,
+		messagesReceived,
+		messagesSent,
+		bytesReceived,
+		bytesSent,
+		peersConnected,
+		peersKnown,
+		syncScoreLag,
+		syncHeightLag,
+		utxSize,
+	)
+}
+
+var contentNames = map[uint8]string{
+	proto.ContentIDGetPeers:      "GetPeers",
+	proto.ContentIDPeers:         "Peers",
+	proto.ContentIDGetSignatures: "GetSignatures",
+	proto.ContentIDSignatures:    "Signatures",
+	proto.ContentIDGetBlock:      "GetBlock",
+	proto.ContentIDBlock:         "Block",
+	proto.ContentIDScore:         "Score",
+	proto.ContentIDTransaction:   "Transaction",
+	proto.ContentIDMicroblock:    "Microblock",
+	proto.ContentIDCheckpoint:    "Checkpoint",
+}
+
+func contentName(id uint8) string {
+	if n, ok := contentNames[id]; ok {
+		return n
+	}
+	return fmt.Sprintf("0x%x", id)
+}
+
+// BlocksApplied records the time spent on applying blocks and the new height of state.
+func BlocksApplied(duration time.Duration, height uint64) {
+	blockApplyDuration.Observe(duration.Seconds())
+	stateHeight.Set(float64(height))
+}
+
+// Height sets the current height of state.
+func Height(height uint64) {
+	stateHeight.Set(float64(height))
+}
+
+// MessageReceived accounts received network message of given content ID and size.
+func MessageReceived(contentID uint8, size int) {
+	messagesReceived.WithLabelValues(contentName(contentID)).Inc()
+	bytesReceived.Add(float64(size))
+}
+
+// MessageSent accounts sent network message, the content ID is taken from message header.
+func MessageSent(message []byte) {
+	if len(message) > proto.HeaderContentIDPosition {
+		messagesSent.WithLabelValues(contentName(message[proto.HeaderContentIDPosition])).Inc()
+	}
+	bytesSent.Add(float64(len(message)))
+}
+
+// PeersConnected sets the number of connected peers.
+func PeersConnected(n int) {
+	peersConnected.Set(float64(n))
+}
+
+// PeersKnown sets the number of known peers.
+func PeersKnown(n int) {
+	peersKnown.Set(float64(n))
+}
+
+// SyncScoreLag sets the difference between the best known score and the local one.
+func SyncScoreLag(lag float64) {
+	syncScoreLag.Set(lag)
+}
+
+// SyncHeightLag sets the number of blocks the local storage is behind.
+func SyncHeightLag(lag int) {
+	syncHeightLag.Set(float64(lag))
+}
+
+// UtxSize sets the number of unconfirmed transactions.
+func UtxSize(n int) {
+	utxSize.Set(float64(n))
+}
+
+// Handler returns HTTP handler which serves all collected metrics.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// Run serves metrics on /metrics path of given address until the context is done.
+func Run(ctx context.Context, address string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	srv := &http.Server{Addr: address, Handler: mux}
+	go func() {
+		<-ctx.Done()
+		zap.S().Info("Shutting down metrics server...")
+		if err := srv.Shutdown(context.Background()); err != nil {
+			zap.S().Errorf("Failed to shutdown metrics server: %v", err)
+		}
+	}()
+	err := srv.ListenAndServe()
+	if err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
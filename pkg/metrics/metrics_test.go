@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+func TestContentName(t *testing.T) {
+	assert.Equal(t, "Block", contentName(proto.ContentIDBlock))
+	assert.Equal(t, "0xff", contentName(0xff))
+}
+
+func TestMessages(t *testing.T) {
+	before := testutil.ToFloat64(messagesReceived.WithLabelValues("Score"))
+	MessageReceived(proto.ContentIDScore, 20)
+	assert.Equal(t, before+1, testutil.ToFloat64(messagesReceived.WithLabelValues("Score")))
+
+	msg := make([]byte, 17)
+	msg[proto.HeaderContentIDPosition] = proto.ContentIDTransaction
+	sentBefore := testutil.ToFloat64(bytesSent)
+	MessageSent(msg)
+	assert.Equal(t, sentBefore+17, testutil.ToFloat64(bytesSent))
+	assert.True(t, testutil.ToFloat64(messagesSent.WithLabelValues("Transaction")) >= 1)
+}
+
+func TestHandler(t *testing.T) {
+	BlocksApplied(10*time.Millisecond, 42)
+	UtxSize(3)
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := ioutil.ReadAll(rec.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "gowaves_state_height 42")
+	assert.Contains(t, string(body), "gowaves_utx_size 3")
+	assert.Contains(t, string(body), "gowaves_state_block_apply_seconds_count")
+}
@@ -0,0 +1,197 @@
+// Package multisig helps several parties to sign the transaction of the account protected by the multisig script.
+// The transaction is created without proofs, each party adds the proof at its own position offline and then the
+// transactions with proofs are merged into the one that satisfies the script.
+package multisig
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/ast"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/evaluate"
+	"github.com/wavesplatform/gowaves/pkg/ride/mockstate"
+)
+
+func proven(tx proto.Transaction) (proto.ProvenTransaction, error) {
+	p, ok := tx.(proto.ProvenTransaction)
+	if !ok {
+		return nil, errors.Errorf("transaction of type %T has no proofs", tx)
+	}
+	return p, nil
+}
+
+func body(tx proto.Transaction) ([]byte, error) {
+	p, err := proven(tx)
+	if err != nil {
+		return nil, err
+	}
+	return p.BodyMarshalBinary()
+}
+
+// Proofs returns the proofs of the transaction, empty proofs are set if the transaction has none.
+// Transactions of the first versions, which have the signature instead of proofs, are not supported.
+func Proofs(tx proto.Transaction) (*proto.ProofsV1, error) {
+	p, err := proven(tx)
+	if err != nil {
+		return nil, err
+	}
+	if p.GetProofs() == nil {
+		p.SetProofs(&proto.ProofsV1{Version: 1, Proofs: make([]proto.B58Bytes, 0)})
+	}
+	return p.GetProofs(), nil
+}
+
+// SenderPK returns the public key of the sender of the transaction.
+func SenderPK(tx proto.Transaction) (crypto.PublicKey, error) {
+	s, ok := tx.(interface{ GetSenderPK() crypto.PublicKey })
+	if !ok {
+		return crypto.PublicKey{}, errors.Errorf("transaction of type %T has no sender", tx)
+	}
+	return s.GetSenderPK(), nil
+}
+
+// Prepare sets empty proofs and the ID of the transaction, so the transaction is ready to be signed by parties.
+func Prepare(tx proto.Transaction) error {
+	if _, err := Proofs(tx); err != nil {
+		return err
+	}
+	return tx.(proto.ProvenTransaction).GenerateID()
+}
+
+// setChainID sets the chain ID of transactions that have it in the body.
+func setChainID(tx proto.Transaction, scheme byte) {
+	switch t := tx.(type) {
+	case *proto.IssueV2:
+		t.ChainID = scheme
+	case *proto.ReissueV2:
+		t.ChainID = scheme
+	case *proto.BurnV2:
+		t.ChainID = scheme
+	case *proto.LeaseCancelV2:
+		t.ChainID = scheme
+	case *proto.SetScriptV1:
+		t.ChainID = scheme
+	case *proto.SetAssetScriptV1:
+		t.ChainID = scheme
+	case *proto.InvokeScriptV1:
+		t.ChainID = scheme
+	}
+}
+
+// ReadTransaction reads the transaction from JSON in the format of the node API. The chain ID is not a part of JSON
+// representation, so it is set to the scheme.
+func ReadTransaction(data []byte, scheme byte) (proto.Transaction, error) {
+	tt := proto.TransactionTypeVersion{}
+	if err := json.Unmarshal(data, &tt); err != nil {
+		return nil, errors.Wrap(err, "failed to read transaction type")
+	}
+	tx, err := proto.GuessTransactionType(&tt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, tx); err != nil {
+		return nil, errors.Wrap(err, "failed to read transaction")
+	}
+	setChainID(tx, scheme)
+	if err := Prepare(tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// Sign adds the proof of the party at the position.
+func Sign(tx proto.Transaction, pos int, secretKey crypto.SecretKey) error {
+	if err := Prepare(tx); err != nil {
+		return err
+	}
+	b, err := body(tx)
+	if err != nil {
+		return err
+	}
+	proofs, err := Proofs(tx)
+	if err != nil {
+		return err
+	}
+	return proofs.Sign(pos, secretKey, b)
+}
+
+// Merge adds the proofs of other copies of the transaction to the transaction. Copies must have the same body,
+// the different proofs at the same position are the error.
+func Merge(tx proto.Transaction, others ...proto.Transaction) error {
+	b, err := body(tx)
+	if err != nil {
+		return err
+	}
+	proofs, err := Proofs(tx)
+	if err != nil {
+		return err
+	}
+	for i, other := range others {
+		ob, err := body(other)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(b, ob) {
+			return errors.Errorf("transaction %d differs from the first one", i+1)
+		}
+		op, err := Proofs(other)
+		if err != nil {
+			return err
+		}
+		for pos, p := range op.Proofs {
+			if len(p) == 0 {
+				continue
+			}
+			for len(proofs.Proofs) <= pos {
+				proofs.Proofs = append(proofs.Proofs, proto.B58Bytes{})
+			}
+			switch q := proofs.Proofs[pos]; {
+			case len(q) == 0:
+				proofs.Proofs[pos] = p
+			case !bytes.Equal(p, q):
+				return errors.Errorf("conflicting proofs at position %d", pos)
+			}
+		}
+	}
+	return nil
+}
+
+// VerifyProofs checks the proofs at positions of the public keys. The result for the absent proof is false.
+func VerifyProofs(tx proto.Transaction, keys []crypto.PublicKey) ([]bool, error) {
+	b, err := body(tx)
+	if err != nil {
+		return nil, err
+	}
+	proofs, err := Proofs(tx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]bool, len(keys))
+	for i, pk := range keys {
+		if i >= len(proofs.Proofs) || len(proofs.Proofs[i]) == 0 {
+			continue
+		}
+		ok, err := proofs.Verify(i, pk, b)
+		if err != nil {
+			return nil, errors.Wrapf(err, "proof at position %d", i)
+		}
+		out[i] = ok
+	}
+	return out, nil
+}
+
+// VerifyScript evaluates the script of the sender account against the transaction.
+func VerifyScript(tx proto.Transaction, script *ast.Script, scheme byte, state mockstate.MockState) (bool, error) {
+	pk, err := SenderPK(tx)
+	if err != nil {
+		return false, err
+	}
+	this, err := proto.NewAddressFromPublicKey(scheme, pk)
+	if err != nil {
+		return false, err
+	}
+	return evaluate.Verify(scheme, state, script, this, tx)
+}
@@ -0,0 +1,102 @@
+package multisig
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride/compiler"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/ast"
+	"github.com/wavesplatform/gowaves/pkg/ride/mockstate"
+)
+
+const multisigScript = `
+let alice = base58'%s'
+let bob = base58'%s'
+let cooper = base58'%s'
+let aliceSigned = if (sigVerify(tx.bodyBytes, tx.proofs[0], alice)) then 1 else 0
+let bobSigned = if (sigVerify(tx.bodyBytes, tx.proofs[1], bob)) then 1 else 0
+let cooperSigned = if (sigVerify(tx.bodyBytes, tx.proofs[2], cooper)) then 1 else 0
+aliceSigned + bobSigned + cooperSigned >= 2
+`
+
+func exchange(t *testing.T, tx proto.Transaction) proto.Transaction {
+	b, err := json.Marshal(tx)
+	require.NoError(t, err)
+	r, err := ReadTransaction(b, proto.TestNetScheme)
+	require.NoError(t, err)
+	return r
+}
+
+func TestMultisigWorkflow(t *testing.T) {
+	_, accountPK := crypto.GenerateKeyPair([]byte("account"))
+	var secrets []crypto.SecretKey
+	var keys []crypto.PublicKey
+	for _, seed := range []string{"alice", "bob", "cooper"} {
+		sk, pk := crypto.GenerateKeyPair([]byte(seed))
+		secrets = append(secrets, sk)
+		keys = append(keys, pk)
+	}
+	compiled, err := compiler.Compile(fmt.Sprintf(multisigScript, keys[0].String(), keys[1].String(), keys[2].String()))
+	require.NoError(t, err)
+	script := &ast.Script{Version: compiled.Directives.StdLibVersion, Verifier: compiled.Expr}
+
+	asset, err := crypto.NewDigestFromBase58("8LQW8f7P5d5PZM7GtZEBgaqRPGSzS3DfPuiXrURJ4AJS")
+	require.NoError(t, err)
+	tx := proto.NewUnsignedBurnV2(proto.TestNetScheme, accountPK, asset, 100, 1560000000000, 100000)
+	require.NoError(t, Prepare(tx))
+	unsigned := exchange(t, tx)
+	assert.Equal(t, tx, unsigned)
+
+	// Cooper and Alice sign their copies offline
+	cooperTx := exchange(t, unsigned)
+	require.NoError(t, Sign(cooperTx, 2, secrets[2]))
+	aliceTx := exchange(t, unsigned)
+	require.NoError(t, Sign(aliceTx, 0, secrets[0]))
+	assert.Error(t, Sign(aliceTx, 0, secrets[1]))
+
+	state := mockstate.MockStateImpl{}
+	ok, err := VerifyScript(aliceTx, script, proto.TestNetScheme, state)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	merged := exchange(t, aliceTx)
+	require.NoError(t, Merge(merged, exchange(t, cooperTx), exchange(t, unsigned)))
+	valid, err := VerifyProofs(merged, keys)
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, false, true}, valid)
+	ok, err = VerifyScript(merged, script, proto.TestNetScheme, state)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	_, err = merged.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, tx.ID, merged.(*proto.BurnV2).ID)
+
+	// Proofs of the same party, conflicting proofs and different transactions
+	require.NoError(t, Merge(merged, cooperTx))
+	bobTx := exchange(t, unsigned)
+	require.NoError(t, Sign(bobTx, 2, secrets[1]))
+	assert.EqualError(t, Merge(merged, bobTx), "conflicting proofs at position 2")
+	other := proto.NewUnsignedBurnV2(proto.TestNetScheme, accountPK, asset, 101, 1560000000000, 100000)
+	assert.EqualError(t, Merge(merged, other), "transaction 1 differs from the first one")
+
+	// Chain ID is taken from the scheme
+	b, err := json.Marshal(unsigned)
+	require.NoError(t, err)
+	mainnet, err := ReadTransaction(b, proto.MainNetScheme)
+	require.NoError(t, err)
+	assert.NotEqual(t, unsigned.(*proto.BurnV2).ID, mainnet.(*proto.BurnV2).ID)
+}
+
+func TestUnsupportedTransaction(t *testing.T) {
+	_, pk := crypto.GenerateKeyPair([]byte("account"))
+	tx := proto.NewUnsignedBurnV1(pk, crypto.Digest{}, 100, 1560000000000, 100000)
+	_, err := Proofs(tx)
+	assert.EqualError(t, err, "transaction of type *proto.BurnV1 has no proofs")
+	sk, _ := crypto.GenerateKeyPair([]byte("alice"))
+	assert.Error(t, Sign(tx, 0, sk))
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/state"
 	"math/big"
 	"net"
 )
@@ -39,6 +40,26 @@ func (a *mockStateManager) AccountBalance(addr proto.Address, asset []byte) (uin
 	panic("implement me")
 }
 
+func (a *mockStateManager) EffectiveBalance(addr proto.Address, startHeight, endHeight uint64) (uint64, error) {
+	panic("implement me")
+}
+
+func (a *mockStateManager) TransactionByID(id []byte) (proto.Transaction, error) {
+	panic("implement me")
+}
+
+func (a *mockStateManager) AssetInfo(assetID crypto.Digest) (*state.AssetInfo, error) {
+	panic("implement me")
+}
+
+func (a *mockStateManager) AddrByAlias(alias proto.Alias) (proto.Address, error) {
+	panic("implement me")
+}
+
+func (a *mockStateManager) AliasesByAddr(addr proto.Address) ([]proto.Alias, error) {
+	panic("implement me")
+}
+
 func (a *mockStateManager) AddressesNumber(wavesonly bool) (uint64, error) {
 	panic("implement me")
 }
@@ -79,6 +100,10 @@ func (a *mockStateManager) Peers() ([]proto.TCPAddr, error) {
 	panic("implement me")
 }
 
+func (a *mockStateManager) SubscribeEvents(buffer int) (<-chan state.Event, func()) {
+	panic("implement me")
+}
+
 func (a *mockStateManager) Close() error {
 	panic("implement me")
 }
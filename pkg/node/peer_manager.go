@@ -2,6 +2,7 @@ package node
 
 import (
 	"context"
+	"github.com/wavesplatform/gowaves/pkg/metrics"
 	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/state"
@@ -51,6 +52,12 @@ type PeerManager interface {
 	Disconnect(id string)
 }
 
+// PeerLimits restricts the number of simultaneous connections, zero means no limit.
+type PeerLimits struct {
+	MaxOutgoing int
+	MaxIncoming int
+}
+
 type PeerManagerImpl struct {
 	spawner    PeerSpawner
 	active     map[string]peerInfo //peer.Peer
@@ -58,15 +65,17 @@ type PeerManagerImpl struct {
 	mu         sync.RWMutex
 	state      state.State
 	spawned    map[proto.IpPort]struct{}
+	limits     PeerLimits
 }
 
-func NewPeerManager(spawner PeerSpawner, state state.State) *PeerManagerImpl {
+func NewPeerManager(spawner PeerSpawner, state state.State, limits PeerLimits) *PeerManagerImpl {
 	return &PeerManagerImpl{
 		spawner:    spawner,
 		active:     make(map[string]peerInfo),
 		knownPeers: make(map[string]proto.Version),
 		state:      state,
 		spawned:    make(map[proto.IpPort]struct{}),
+		limits:     limits,
 	}
 }
 
@@ -78,9 +87,10 @@ func (a *PeerManagerImpl) Connected(unique string) (peer.Peer, bool) {
 }
 
 func (a *PeerManagerImpl) AddConnected(peer peer.Peer) {
-	a.mu.RLock()
-	defer a.mu.RUnlock()
+	a.mu.Lock()
+	defer a.mu.Unlock()
 	a.active[peer.ID()] = newPeerInfo(peer)
+	metrics.PeersConnected(len(a.active))
 }
 
 func (a *PeerManagerImpl) PeerWithHighestScore() (peer.Peer, *big.Int, bool) {
@@ -129,8 +139,15 @@ func (a *PeerManagerImpl) UpdateKnownPeers(known []proto.TCPAddr) error {
 	if len(known) == 0 {
 		return nil
 	}
-
-	return a.state.SavePeers(known)
+	if err := a.state.SavePeers(known); err != nil {
+		return err
+	}
+	all, err := a.state.Peers()
+	if err != nil {
+		return err
+	}
+	metrics.PeersKnown(len(all))
+	return nil
 }
 
 func (a *PeerManagerImpl) KnownPeers() ([]proto.TCPAddr, error) {
@@ -180,7 +197,17 @@ func (a *PeerManagerImpl) SpawnOutgoingConnections(ctx context.Context) {
 		}
 	}
 
+	// Spawned connections stay in the spawned set while they are active, count only pending ones.
+	outgoing := a.countActive(peer.Outgoing)
+	for addr := range a.spawned {
+		if _, ok := active[addr]; !ok {
+			outgoing++
+		}
+	}
 	for _, addr := range known {
+		if a.limits.MaxOutgoing > 0 && outgoing >= a.limits.MaxOutgoing {
+			return
+		}
 		if _, ok := active[addr.ToIpPort()]; ok {
 			continue
 		}
@@ -190,6 +217,7 @@ func (a *PeerManagerImpl) SpawnOutgoingConnections(ctx context.Context) {
 		}
 
 		a.spawned[addr.ToIpPort()] = struct{}{}
+		outgoing++
 
 		go func(addr proto.TCPAddr) {
 			defer a.RemoveSpawned(addr)
@@ -202,9 +230,30 @@ func (a *PeerManagerImpl) SpawnOutgoingConnections(ctx context.Context) {
 }
 
 func (a *PeerManagerImpl) SpawnIncomingConnection(ctx context.Context, conn net.Conn) {
+	if a.limits.MaxIncoming > 0 {
+		a.mu.RLock()
+		incoming := a.countActive(peer.Incoming)
+		a.mu.RUnlock()
+		if incoming >= a.limits.MaxIncoming {
+			zap.S().Debugf("incoming connections limit %d reached, rejecting %s", a.limits.MaxIncoming, conn.RemoteAddr())
+			_ = conn.Close()
+			return
+		}
+	}
 	a.spawner.SpawnIncoming(ctx, conn)
 }
 
+// countActive returns the number of active peers with given direction, must be called under lock.
+func (a *PeerManagerImpl) countActive(direction peer.Direction) int {
+	n := 0
+	for _, p := range a.active {
+		if p.peer.Direction() == direction {
+			n++
+		}
+	}
+	return n
+}
+
 func (a *PeerManagerImpl) RemoveSpawned(addr proto.TCPAddr) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
@@ -228,6 +277,7 @@ func (a *PeerManagerImpl) Disconnect(id string) {
 		p.peer.Close()
 		delete(a.active, id)
 	}
+	metrics.PeersConnected(len(a.active))
 }
 
 func (a *PeerManagerImpl) EachConnected(f func(peer peer.Peer, score *big.Int)) {
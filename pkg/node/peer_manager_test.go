@@ -0,0 +1,97 @@
+package node
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wavesplatform/gowaves/pkg/p2p/mock"
+	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+type peersState struct {
+	state.State
+	peers []proto.TCPAddr
+}
+
+func (a *peersState) Peers() ([]proto.TCPAddr, error) {
+	return a.peers, nil
+}
+
+// connectingSpawner connects to every address and keeps connections until the context is done.
+type connectingSpawner struct {
+	pm      *PeerManagerImpl
+	mu      sync.Mutex
+	spawned []string
+	events  chan struct{}
+}
+
+func (a *connectingSpawner) SpawnOutgoing(ctx context.Context, addr proto.TCPAddr) error {
+	a.mu.Lock()
+	a.spawned = append(a.spawned, addr.String())
+	a.mu.Unlock()
+	a.pm.AddConnected(&mock.Peer{Addr: addr.String(), RemoteAddress: addr, DirectionField: peer.Outgoing})
+	a.events <- struct{}{}
+	<-ctx.Done()
+	return nil
+}
+
+func (a *connectingSpawner) SpawnIncoming(ctx context.Context, c net.Conn) {
+}
+
+// wait waits for n connections to be established.
+func (a *connectingSpawner) wait(t *testing.T, n int) {
+	for i := 0; i < n; i++ {
+		select {
+		case <-a.events:
+		case <-time.After(time.Second):
+			t.Fatalf("%d of %d connections are established", i, n)
+		}
+	}
+}
+
+func (a *connectingSpawner) calls() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]string(nil), a.spawned...)
+}
+
+func TestPeerManager_SpawnOutgoingConnectionsLimit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	known := []proto.TCPAddr{
+		proto.NewTCPAddrFromString("1.1.1.1:6868"),
+		proto.NewTCPAddrFromString("2.2.2.2:6868"),
+		proto.NewTCPAddrFromString("3.3.3.3:6868"),
+		proto.NewTCPAddrFromString("4.4.4.4:6868"),
+		proto.NewTCPAddrFromString("5.5.5.5:6868"),
+	}
+	spawner := &connectingSpawner{events: make(chan struct{}, len(known))}
+	st := &peersState{peers: known[:2]}
+	pm := NewPeerManager(spawner, st, PeerLimits{MaxOutgoing: 3})
+	spawner.pm = pm
+
+	// Only two addresses are known, both are connected.
+	pm.SpawnOutgoingConnections(ctx)
+	spawner.wait(t, 2)
+	pm.mu.RLock()
+	assert.Equal(t, 2, pm.countActive(peer.Outgoing))
+	pm.mu.RUnlock()
+
+	// Connected peers take their slots only once, the free slot is filled with the new address.
+	st.peers = known
+	pm.SpawnOutgoingConnections(ctx)
+	spawner.wait(t, 1)
+	pm.mu.RLock()
+	assert.Equal(t, 3, pm.countActive(peer.Outgoing))
+	pm.mu.RUnlock()
+
+	// All slots are busy.
+	pm.SpawnOutgoingConnections(ctx)
+	assert.ElementsMatch(t, []string{"1.1.1.1:6868", "2.2.2.2:6868", "3.3.3.3:6868"}, spawner.calls())
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/go-errors/errors"
 	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/metrics"
 	. "github.com/wavesplatform/gowaves/pkg/p2p/peer"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/state"
@@ -93,8 +94,11 @@ func (a *StateSync) getPeerWithHighestScore() (Peer, error) {
 	}
 
 	if myScore.Cmp(score) >= 0 {
+		metrics.SyncScoreLag(0)
 		return nil, errors.Errorf("we have highest score, nothing to do")
 	}
+	lag, _ := new(big.Float).SetInt(new(big.Int).Sub(score, myScore)).Float64()
+	metrics.SyncScoreLag(lag)
 	return p, nil
 }
 
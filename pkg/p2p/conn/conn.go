@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/wavesplatform/gowaves/pkg/libs/bytespool"
+	"github.com/wavesplatform/gowaves/pkg/metrics"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
@@ -41,7 +42,9 @@ func sendToRemote(closed *atomic.Bool, conn io.Writer, ctx context.Context, toRe
 			_, err := conn.Write(bts)
 			if err != nil {
 				handleErr(err, errCh)
+				continue
 			}
+			metrics.MessageSent(bts)
 		}
 	}
 }
@@ -110,6 +113,7 @@ func recvFromRemote(stopped *atomic.Bool, pool bytespool.Pool, conn io.Reader, f
 			handleErr(err, errCh)
 			continue
 		}
+		metrics.MessageReceived(header.ContentID, int(hl+pl))
 		select {
 		case fromRemoteCh <- b:
 		default:
@@ -12,6 +12,7 @@ type Peer struct {
 	IncomeCh              chan peer.ProtoMessage
 	HandshakeField        proto.Handshake
 	RemoteAddress         proto.TCPAddr
+	DirectionField        peer.Direction
 }
 
 func NewPeer() *Peer {
@@ -22,8 +23,8 @@ func (a Peer) RemoteAddr() proto.TCPAddr {
 	return a.RemoteAddress
 }
 
-func (Peer) Direction() peer.Direction {
-	panic("implement me")
+func (a Peer) Direction() peer.Direction {
+	return a.DirectionField
 }
 
 func (Peer) Reconnect() error {
@@ -154,7 +154,8 @@ func (t *TransactionsField) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-func (t TransactionsField) MarshalJSON() ([]byte, error) {
+// Transactions decodes the transactions of the field.
+func (t TransactionsField) Transactions() ([]Transaction, error) {
 	var transactions []Transaction
 	for pos := 0; pos < len(t); {
 		txSize := int(binary.BigEndian.Uint32(t[pos : pos+4]))
@@ -167,6 +168,14 @@ func (t TransactionsField) MarshalJSON() ([]byte, error) {
 		pos += txSize
 		transactions = append(transactions, tx)
 	}
+	return transactions, nil
+}
+
+func (t TransactionsField) MarshalJSON() ([]byte, error) {
+	transactions, err := t.Transactions()
+	if err != nil {
+		return nil, err
+	}
 	return json.Marshal(transactions)
 }
 
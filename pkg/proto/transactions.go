@@ -90,6 +90,26 @@ type Transaction interface {
 	UnmarshalBinary([]byte) error
 }
 
+// ProvenTransaction is a transaction with proofs instead of the single signature, proofs could be added by several
+// parties and verified by the script of the sender.
+type ProvenTransaction interface {
+	Transaction
+	BodyMarshalBinary() ([]byte, error)
+	GetSenderPK() crypto.PublicKey
+	GetProofs() *ProofsV1
+	SetProofs(proofs *ProofsV1)
+	GenerateID() error
+}
+
+// bodyID calculates the ID of the transaction as the hash of its body bytes.
+func bodyID(body []byte) (*crypto.Digest, error) {
+	d, err := crypto.FastHash(body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to hash transaction body")
+	}
+	return &d, nil
+}
+
 func BytesToTransaction(tx []byte) (Transaction, error) {
 	if len(tx) < 2 {
 		return nil, errors.New("invalid size of transation's bytes slice")
@@ -246,7 +266,7 @@ func (tx Genesis) Valid() (bool, error) {
 	return true, nil
 }
 
-func (tx *Genesis) bodyMarshalBinary() ([]byte, error) {
+func (tx *Genesis) BodyMarshalBinary() ([]byte, error) {
 	buf := make([]byte, genesisBodyLen)
 	buf[0] = byte(tx.Type)
 	binary.BigEndian.PutUint64(buf[1:], tx.Timestamp)
@@ -272,7 +292,7 @@ func (tx *Genesis) bodyUnmarshalBinary(data []byte) error {
 
 //GenerateSigID calculates hash of the transaction and use it as an ID. Also doubled hash is used as a signature.
 func (tx *Genesis) GenerateSigID() error {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return errors.Wrap(err, "failed to generate signature of Genesis transaction")
 	}
@@ -292,7 +312,7 @@ func (tx *Genesis) GenerateSigID() error {
 
 //MarshalBinary writes transaction bytes to slice of bytes.
 func (tx *Genesis) MarshalBinary() ([]byte, error) {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal Genesis transaction to bytes")
 	}
@@ -335,6 +355,10 @@ func (tx Payment) GetID() []byte {
 	return tx.ID.Bytes()
 }
 
+func (tx Payment) GetSenderPK() crypto.PublicKey {
+	return tx.SenderPK
+}
+
 //NewUnsignedPayment creates new Payment transaction with empty Signature and ID fields.
 func NewUnsignedPayment(senderPK crypto.PublicKey, recipient Address, amount, fee, timestamp uint64) *Payment {
 	return &Payment{Type: PaymentTransaction, Version: 1, SenderPK: senderPK, Recipient: recipient, Amount: amount, Fee: fee, Timestamp: timestamp}
@@ -362,7 +386,7 @@ func (tx Payment) Valid() (bool, error) {
 	return true, nil
 }
 
-func (tx *Payment) bodyMarshalBinary() ([]byte, error) {
+func (tx *Payment) BodyMarshalBinary() ([]byte, error) {
 	buf := make([]byte, paymentBodyLen)
 	buf[0] = byte(tx.Type)
 	binary.BigEndian.PutUint64(buf[1:], tx.Timestamp)
@@ -398,7 +422,7 @@ func (tx *Payment) bodyUnmarshalBinary(data []byte) error {
 
 //Sign calculates transaction signature and set it as an ID.
 func (tx *Payment) Sign(secretKey crypto.SecretKey) error {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return errors.Wrap(err, "failed to sign Payment transaction")
 	}
@@ -415,7 +439,7 @@ func (tx *Payment) Verify(publicKey crypto.PublicKey) (bool, error) {
 	if tx.Signature == nil {
 		return false, errors.New("empty signature")
 	}
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return false, errors.Wrap(err, "failed to verify Payment transaction")
 	}
@@ -426,7 +450,7 @@ func (tx *Payment) Verify(publicKey crypto.PublicKey) (bool, error) {
 
 //MarshalBinary returns a bytes representation of Payment transaction.
 func (tx *Payment) MarshalBinary() ([]byte, error) {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 
 	}
@@ -468,6 +492,10 @@ type Issue struct {
 	Fee         uint64           `json:"fee"`
 }
 
+func (i Issue) GetSenderPK() crypto.PublicKey {
+	return i.SenderPK
+}
+
 func (i Issue) Valid() (bool, error) {
 	if i.Quantity <= 0 {
 		return false, errors.New("quantity should be positive")
@@ -559,6 +587,10 @@ type Transfer struct {
 	Attachment  Attachment       `json:"attachment,omitempty"`
 }
 
+func (tr Transfer) GetSenderPK() crypto.PublicKey {
+	return tr.SenderPK
+}
+
 func (tr Transfer) Valid() (bool, error) {
 	if tr.Amount <= 0 {
 		return false, errors.New("amount should be positive")
@@ -692,6 +724,10 @@ type Reissue struct {
 	Fee        uint64           `json:"fee"`
 }
 
+func (r Reissue) GetSenderPK() crypto.PublicKey {
+	return r.SenderPK
+}
+
 func (r Reissue) Valid() (bool, error) {
 	if r.Quantity <= 0 {
 		return false, errors.New("quantity should be positive")
@@ -768,6 +804,10 @@ type Burn struct {
 	Fee       uint64           `json:"fee"`
 }
 
+func (b Burn) GetSenderPK() crypto.PublicKey {
+	return b.SenderPK
+}
+
 func (b Burn) Valid() (bool, error) {
 	if !validJVMLong(b.Amount) {
 		return false, errors.New("amount is too big")
@@ -820,6 +860,10 @@ type Lease struct {
 	Timestamp uint64           `json:"timestamp,omitempty"`
 }
 
+func (l Lease) GetSenderPK() crypto.PublicKey {
+	return l.SenderPK
+}
+
 func (l Lease) Valid() (bool, error) {
 	if ok, err := l.Recipient.Valid(); !ok {
 		return false, errors.Wrap(err, "failed to create new unsigned Lease transaction")
@@ -889,6 +933,10 @@ type LeaseCancel struct {
 	Timestamp uint64           `json:"timestamp,omitempty"`
 }
 
+func (lc LeaseCancel) GetSenderPK() crypto.PublicKey {
+	return lc.SenderPK
+}
+
 func (lc LeaseCancel) Valid() (bool, error) {
 	if lc.Fee <= 0 {
 		return false, errors.New("fee should be positive")
@@ -933,6 +981,10 @@ type CreateAlias struct {
 	Timestamp uint64           `json:"timestamp,omitempty"`
 }
 
+func (ca CreateAlias) GetSenderPK() crypto.PublicKey {
+	return ca.SenderPK
+}
+
 func (ca CreateAlias) Valid() (bool, error) {
 	if ca.Fee <= 0 {
 		return false, errors.New("fee should be positive")
@@ -111,7 +111,7 @@ func NewUnsignedIssueV1(senderPK crypto.PublicKey, name, description string, qua
 	return &IssueV1{Type: IssueTransaction, Version: 1, Issue: i}
 }
 
-func (tx *IssueV1) bodyMarshalBinary() ([]byte, error) {
+func (tx *IssueV1) BodyMarshalBinary() ([]byte, error) {
 	b, err := tx.Issue.marshalBinary()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal IssueV1 body")
@@ -142,7 +142,7 @@ func (tx *IssueV1) bodyUnmarshalBinary(data []byte) error {
 
 //Sign uses secretKey to sing the transaction.
 func (tx *IssueV1) Sign(secretKey crypto.SecretKey) error {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return errors.Wrap(err, "failed to sign IssueV1 transaction")
 	}
@@ -161,7 +161,7 @@ func (tx *IssueV1) Verify(publicKey crypto.PublicKey) (bool, error) {
 	if tx.Signature == nil {
 		return false, errors.New("empty signature")
 	}
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return false, errors.Wrap(err, "failed to verify signature of IssueV1 transaction")
 	}
@@ -171,7 +171,7 @@ func (tx *IssueV1) Verify(publicKey crypto.PublicKey) (bool, error) {
 //MarshalBinary saves transaction's binary representation to slice of bytes.
 func (tx *IssueV1) MarshalBinary() ([]byte, error) {
 	sl := crypto.SignatureSize
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal IssueV1 transaction to bytes")
 	}
@@ -236,7 +236,7 @@ func NewUnsignedTransferV1(senderPK crypto.PublicKey, amountAsset, feeAsset Opti
 	return &TransferV1{Type: TransferTransaction, Version: 1, Transfer: t}
 }
 
-func (tx *TransferV1) bodyMarshalBinary() ([]byte, error) {
+func (tx *TransferV1) BodyMarshalBinary() ([]byte, error) {
 	b, err := tx.Transfer.marshalBinary()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal TransferV1 body")
@@ -267,7 +267,7 @@ func (tx *TransferV1) bodyUnmarshalBinary(data []byte) error {
 
 //Sign calculates a signature and a digest as an ID of the transaction.
 func (tx *TransferV1) Sign(secretKey crypto.SecretKey) error {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return errors.Wrap(err, "failed to sign TransferV1 transaction")
 	}
@@ -286,7 +286,7 @@ func (tx *TransferV1) Verify(publicKey crypto.PublicKey) (bool, error) {
 	if tx.Signature == nil {
 		return false, errors.New("empty signature")
 	}
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return false, errors.Wrap(err, "failed to verify signature of TransferV1 transaction")
 	}
@@ -296,7 +296,7 @@ func (tx *TransferV1) Verify(publicKey crypto.PublicKey) (bool, error) {
 //MarshalBinary saves transaction to its binary representation.
 func (tx *TransferV1) MarshalBinary() ([]byte, error) {
 	sl := crypto.SignatureSize
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal TransferV1 transaction to bytes")
 	}
@@ -359,7 +359,7 @@ func NewUnsignedReissueV1(senderPK crypto.PublicKey, assetID crypto.Digest, quan
 	return &ReissueV1{Type: ReissueTransaction, Version: 1, Reissue: r}
 }
 
-func (tx *ReissueV1) bodyMarshalBinary() ([]byte, error) {
+func (tx *ReissueV1) BodyMarshalBinary() ([]byte, error) {
 	buf := make([]byte, reissueV1BodyLen)
 	buf[0] = byte(tx.Type)
 	b, err := tx.Reissue.marshalBinary()
@@ -391,7 +391,7 @@ func (tx *ReissueV1) bodyUnmarshalBinary(data []byte) error {
 //Sign use given private key to calculate signature of the transaction.
 //This function also calculates digest of transaction data and assigns it to ID field.
 func (tx *ReissueV1) Sign(secretKey crypto.SecretKey) error {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return errors.Wrap(err, "failed to sign ReissueV1 transaction")
 	}
@@ -410,7 +410,7 @@ func (tx *ReissueV1) Verify(publicKey crypto.PublicKey) (bool, error) {
 	if tx.Signature == nil {
 		return false, errors.New("empty signature")
 	}
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return false, errors.Wrap(err, "failed to verify signature of ReissueV1 transaction")
 	}
@@ -420,7 +420,7 @@ func (tx *ReissueV1) Verify(publicKey crypto.PublicKey) (bool, error) {
 //MarshalBinary saves the transaction to its binary representation.
 func (tx *ReissueV1) MarshalBinary() ([]byte, error) {
 	sl := crypto.SignatureSize
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal ReissueV1 transaction to bytes")
 	}
@@ -482,7 +482,7 @@ func NewUnsignedBurnV1(senderPK crypto.PublicKey, assetID crypto.Digest, amount,
 	return &BurnV1{Type: BurnTransaction, Version: 1, Burn: b}
 }
 
-func (tx *BurnV1) bodyMarshalBinary() ([]byte, error) {
+func (tx *BurnV1) BodyMarshalBinary() ([]byte, error) {
 	buf := make([]byte, burnV1BodyLen)
 	buf[0] = byte(tx.Type)
 	b, err := tx.Burn.marshalBinary()
@@ -513,7 +513,7 @@ func (tx *BurnV1) bodyUnmarshalBinary(data []byte) error {
 
 //Sign calculates and sets signature and ID of the transaction.
 func (tx *BurnV1) Sign(secretKey crypto.SecretKey) error {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return errors.Wrap(err, "failed to sign BurnV1 transaction")
 	}
@@ -532,7 +532,7 @@ func (tx *BurnV1) Verify(publicKey crypto.PublicKey) (bool, error) {
 	if tx.Signature == nil {
 		return false, errors.New("empty signature")
 	}
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return false, errors.Wrap(err, "failed to verify signature of BurnV1 transaction")
 	}
@@ -541,7 +541,7 @@ func (tx *BurnV1) Verify(publicKey crypto.PublicKey) (bool, error) {
 
 //MarshalBinary saves transaction to
 func (tx *BurnV1) MarshalBinary() ([]byte, error) {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal BurnV1 transaction to bytes")
 	}
@@ -712,7 +712,7 @@ func (tx ExchangeV1) Valid() (bool, error) {
 	return true, nil
 }
 
-func (tx *ExchangeV1) bodyMarshalBinary() ([]byte, error) {
+func (tx *ExchangeV1) BodyMarshalBinary() ([]byte, error) {
 	bob, err := tx.BuyOrder.MarshalBinary()
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to marshal ExchangeV1 body to bytes")
@@ -796,7 +796,7 @@ func (tx *ExchangeV1) bodyUnmarshalBinary(data []byte) (int, error) {
 
 //Sing calculates ID and Signature of the transaction.
 func (tx *ExchangeV1) Sign(secretKey crypto.SecretKey) error {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return errors.Wrap(err, "failed to sign ExchangeV1 transaction")
 	}
@@ -815,7 +815,7 @@ func (tx *ExchangeV1) Verify(publicKey crypto.PublicKey) (bool, error) {
 	if tx.Signature == nil {
 		return false, errors.New("empty signature")
 	}
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return false, errors.Wrap(err, "failed to verify signature of ExchangeV1 transaction")
 	}
@@ -824,7 +824,7 @@ func (tx *ExchangeV1) Verify(publicKey crypto.PublicKey) (bool, error) {
 
 //MarshalBinary saves the transaction to its binary representation.
 func (tx *ExchangeV1) MarshalBinary() ([]byte, error) {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal ExchangeV1 transaction to bytes")
 	}
@@ -885,7 +885,7 @@ func NewUnsignedLeaseV1(senderPK crypto.PublicKey, recipient Recipient, amount,
 	return &LeaseV1{Type: LeaseTransaction, Version: 1, Lease: l}
 }
 
-func (tx *LeaseV1) bodyMarshalBinary() ([]byte, error) {
+func (tx *LeaseV1) BodyMarshalBinary() ([]byte, error) {
 	rl := tx.Recipient.len
 	buf := make([]byte, leaseV1BodyLen+rl)
 	buf[0] = byte(tx.Type)
@@ -917,7 +917,7 @@ func (tx *LeaseV1) bodyUnmarshalBinary(data []byte) error {
 
 //Sign calculates ID and Signature of the transaction.
 func (tx *LeaseV1) Sign(secretKey crypto.SecretKey) error {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return errors.Wrap(err, "failed to sign LeaseV1 transaction")
 	}
@@ -936,7 +936,7 @@ func (tx *LeaseV1) Verify(publicKey crypto.PublicKey) (bool, error) {
 	if tx.Signature == nil {
 		return false, errors.New("empty signature")
 	}
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return false, errors.Wrap(err, "failed to verify signature of LeaseV1 transaction")
 	}
@@ -945,7 +945,7 @@ func (tx *LeaseV1) Verify(publicKey crypto.PublicKey) (bool, error) {
 
 //MarshalBinary saves the transaction to its binary representation.
 func (tx *LeaseV1) MarshalBinary() ([]byte, error) {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal LeaseV1 transaction to bytes")
 	}
@@ -1006,7 +1006,7 @@ func NewUnsignedLeaseCancelV1(senderPK crypto.PublicKey, leaseID crypto.Digest,
 	return &LeaseCancelV1{Type: LeaseCancelTransaction, Version: 1, LeaseCancel: lc}
 }
 
-func (tx *LeaseCancelV1) bodyMarshalBinary() ([]byte, error) {
+func (tx *LeaseCancelV1) BodyMarshalBinary() ([]byte, error) {
 	buf := make([]byte, leaseCancelV1BodyLen)
 	buf[0] = byte(tx.Type)
 	b, err := tx.LeaseCancel.marshalBinary()
@@ -1037,7 +1037,7 @@ func (tx *LeaseCancelV1) bodyUnmarshalBinary(data []byte) error {
 }
 
 func (tx *LeaseCancelV1) Sign(secretKey crypto.SecretKey) error {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return errors.Wrap(err, "failed to sign LeaseCancelV1 transaction")
 	}
@@ -1056,7 +1056,7 @@ func (tx *LeaseCancelV1) Verify(publicKey crypto.PublicKey) (bool, error) {
 	if tx.Signature == nil {
 		return false, errors.New("empty signature")
 	}
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return false, errors.Wrap(err, "failed to verify signature of LeaseCancelV1 transaction")
 	}
@@ -1065,7 +1065,7 @@ func (tx *LeaseCancelV1) Verify(publicKey crypto.PublicKey) (bool, error) {
 
 //MarshalBinary saves transaction to its binary representation.
 func (tx *LeaseCancelV1) MarshalBinary() ([]byte, error) {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal LeaseCancelV1 transaction to bytes")
 	}
@@ -1122,7 +1122,7 @@ func NewUnsignedCreateAliasV1(senderPK crypto.PublicKey, alias Alias, fee, times
 	return &CreateAliasV1{Type: CreateAliasTransaction, Version: 1, CreateAlias: ca}
 }
 
-func (tx *CreateAliasV1) bodyMarshalBinary() ([]byte, error) {
+func (tx *CreateAliasV1) BodyMarshalBinary() ([]byte, error) {
 	buf := make([]byte, createAliasV1FixedBodyLen+len(tx.Alias.Alias))
 	buf[0] = byte(tx.Type)
 	b, err := tx.CreateAlias.marshalBinary()
@@ -1152,7 +1152,7 @@ func (tx *CreateAliasV1) bodyUnmarshalBinary(data []byte) error {
 }
 
 func (tx *CreateAliasV1) Sign(secretKey crypto.SecretKey) error {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return errors.Wrap(err, "failed to sign CreateAliasV1 transaction")
 	}
@@ -1169,7 +1169,7 @@ func (tx *CreateAliasV1) Verify(publicKey crypto.PublicKey) (bool, error) {
 	if tx.Signature == nil {
 		return false, errors.New("empty signature")
 	}
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return false, errors.Wrap(err, "failed to verify signature of CreateAliasV1 transaction")
 	}
@@ -1177,7 +1177,7 @@ func (tx *CreateAliasV1) Verify(publicKey crypto.PublicKey) (bool, error) {
 }
 
 func (tx *CreateAliasV1) MarshalBinary() ([]byte, error) {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal CreateAliasV1 transaction to bytes")
 	}
@@ -1284,6 +1284,31 @@ func (tx MassTransferV1) GetID() []byte {
 	return tx.ID.Bytes()
 }
 
+func (tx MassTransferV1) GetSenderPK() crypto.PublicKey {
+	return tx.SenderPK
+}
+
+func (tx MassTransferV1) GetProofs() *ProofsV1 {
+	return tx.Proofs
+}
+
+func (tx *MassTransferV1) SetProofs(proofs *ProofsV1) {
+	tx.Proofs = proofs
+}
+
+//GenerateID sets the ID of the transaction to the hash of its body.
+func (tx *MassTransferV1) GenerateID() error {
+	b, err := tx.BodyMarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ID of MassTransferV1 transaction")
+	}
+	tx.ID, err = bodyID(b)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ID of MassTransferV1 transaction")
+	}
+	return nil
+}
+
 //NewUnsignedMassTransferV1 creates new MassTransferV1 transaction structure without signature and ID.
 func NewUnsignedMassTransferV1(senderPK crypto.PublicKey, asset OptionalAsset, transfers []MassTransferEntry, fee, timestamp uint64, attachment string) *MassTransferV1 {
 	return &MassTransferV1{Type: MassTransferTransaction, Version: 1, SenderPK: senderPK, Asset: asset, Transfers: transfers, Fee: fee, Timestamp: timestamp, Attachment: Attachment(attachment)}
@@ -1329,7 +1354,7 @@ func (tx *MassTransferV1) bodyAndAssetLen() (int, int) {
 	return massTransferV1FixedLen + l + n*massTransferEntryLen + rls + al, l
 }
 
-func (tx *MassTransferV1) bodyMarshalBinary() ([]byte, error) {
+func (tx *MassTransferV1) BodyMarshalBinary() ([]byte, error) {
 	var p int
 	n := len(tx.Transfers)
 	bl, al := tx.bodyAndAssetLen()
@@ -1414,7 +1439,7 @@ func (tx *MassTransferV1) bodyUnmarshalBinary(data []byte) error {
 
 //Sign calculates signature and ID of the transaction.
 func (tx *MassTransferV1) Sign(secretKey crypto.SecretKey) error {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return errors.Wrap(err, "failed to sign MassTransferV1 transaction")
 	}
@@ -1425,8 +1450,7 @@ func (tx *MassTransferV1) Sign(secretKey crypto.SecretKey) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to sign MassTransferV1 transaction")
 	}
-	d, err := crypto.FastHash(b)
-	tx.ID = &d
+	tx.ID, err = bodyID(b)
 	if err != nil {
 		return errors.Wrap(err, "failed to sign MassTransferV1 transaction")
 	}
@@ -1435,7 +1459,7 @@ func (tx *MassTransferV1) Sign(secretKey crypto.SecretKey) error {
 
 //Verify checks that the signature is valid for the given public key.
 func (tx *MassTransferV1) Verify(publicKey crypto.PublicKey) (bool, error) {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return false, errors.Wrap(err, "failed to verify signature of MassTransferV1 transaction")
 	}
@@ -1444,7 +1468,7 @@ func (tx *MassTransferV1) Verify(publicKey crypto.PublicKey) (bool, error) {
 
 //MarshalBinary saves the transaction to its binary representation.
 func (tx *MassTransferV1) MarshalBinary() ([]byte, error) {
-	bb, err := tx.bodyMarshalBinary()
+	bb, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal MassTransferV1 transaction to bytes")
 	}
@@ -1508,6 +1532,31 @@ func (tx DataV1) GetID() []byte {
 	return tx.ID.Bytes()
 }
 
+func (tx DataV1) GetSenderPK() crypto.PublicKey {
+	return tx.SenderPK
+}
+
+func (tx DataV1) GetProofs() *ProofsV1 {
+	return tx.Proofs
+}
+
+func (tx *DataV1) SetProofs(proofs *ProofsV1) {
+	tx.Proofs = proofs
+}
+
+//GenerateID sets the ID of the transaction to the hash of its body.
+func (tx *DataV1) GenerateID() error {
+	b, err := tx.BodyMarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ID of DataV1 transaction")
+	}
+	tx.ID, err = bodyID(b)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ID of DataV1 transaction")
+	}
+	return nil
+}
+
 //NewUnsignedData creates new Data transaction without proofs.
 func NewUnsignedData(senderPK crypto.PublicKey, fee, timestamp uint64) *DataV1 {
 	return &DataV1{Type: DataTransaction, Version: 1, SenderPK: senderPK, Fee: fee, Timestamp: timestamp}
@@ -1676,8 +1725,7 @@ func (tx *DataV1) Sign(secretKey crypto.SecretKey) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to sign DataV1 transaction")
 	}
-	d, err := crypto.FastHash(b)
-	tx.ID = &d
+	tx.ID, err = bodyID(b)
 	if err != nil {
 		return errors.Wrap(err, "failed to sign DataV1 transaction")
 	}
@@ -1761,6 +1809,31 @@ func (tx SetScriptV1) GetID() []byte {
 	return tx.ID.Bytes()
 }
 
+func (tx SetScriptV1) GetSenderPK() crypto.PublicKey {
+	return tx.SenderPK
+}
+
+func (tx SetScriptV1) GetProofs() *ProofsV1 {
+	return tx.Proofs
+}
+
+func (tx *SetScriptV1) SetProofs(proofs *ProofsV1) {
+	tx.Proofs = proofs
+}
+
+//GenerateID sets the ID of the transaction to the hash of its body.
+func (tx *SetScriptV1) GenerateID() error {
+	b, err := tx.BodyMarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ID of SetScriptV1 transaction")
+	}
+	tx.ID, err = bodyID(b)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ID of SetScriptV1 transaction")
+	}
+	return nil
+}
+
 //NewUnsignedSetScriptV1 creates new unsigned SetScriptV1 transaction.
 func NewUnsignedSetScriptV1(chain byte, senderPK crypto.PublicKey, script []byte, fee, timestamp uint64) *SetScriptV1 {
 	return &SetScriptV1{Type: SetScriptTransaction, Version: 1, ChainID: chain, SenderPK: senderPK, Script: script, Fee: fee, Timestamp: timestamp}
@@ -1781,7 +1854,7 @@ func (tx *SetScriptV1) NonEmptyScript() bool {
 	return len(tx.Script) != 0
 }
 
-func (tx *SetScriptV1) bodyMarshalBinary() ([]byte, error) {
+func (tx *SetScriptV1) BodyMarshalBinary() ([]byte, error) {
 	var p int
 	sl := 0
 	if tx.NonEmptyScript() {
@@ -1845,7 +1918,7 @@ func (tx *SetScriptV1) bodyUnmarshalBinary(data []byte) error {
 
 //Sign adds signature as a proof at first position.
 func (tx *SetScriptV1) Sign(secretKey crypto.SecretKey) error {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return errors.Wrap(err, "failed to sign SetScriptV1 transaction")
 	}
@@ -1856,8 +1929,7 @@ func (tx *SetScriptV1) Sign(secretKey crypto.SecretKey) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to sign SetScriptV1 transaction")
 	}
-	d, err := crypto.FastHash(b)
-	tx.ID = &d
+	tx.ID, err = bodyID(b)
 	if err != nil {
 		return errors.Wrap(err, "failed to sign SetScriptV1 transaction")
 	}
@@ -1866,7 +1938,7 @@ func (tx *SetScriptV1) Sign(secretKey crypto.SecretKey) error {
 
 //Verify checks that first proof is a valid signature.
 func (tx *SetScriptV1) Verify(publicKey crypto.PublicKey) (bool, error) {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return false, errors.Wrap(err, "failed to verify signature of SetScriptV1 transaction")
 	}
@@ -1875,7 +1947,7 @@ func (tx *SetScriptV1) Verify(publicKey crypto.PublicKey) (bool, error) {
 
 //MarshalBinary writes SetScriptV1 transaction to its bytes representation.
 func (tx *SetScriptV1) MarshalBinary() ([]byte, error) {
-	bb, err := tx.bodyMarshalBinary()
+	bb, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal SetScriptV1 transaction to bytes")
 	}
@@ -1944,6 +2016,31 @@ func (tx SponsorshipV1) GetID() []byte {
 	return tx.ID.Bytes()
 }
 
+func (tx SponsorshipV1) GetSenderPK() crypto.PublicKey {
+	return tx.SenderPK
+}
+
+func (tx SponsorshipV1) GetProofs() *ProofsV1 {
+	return tx.Proofs
+}
+
+func (tx *SponsorshipV1) SetProofs(proofs *ProofsV1) {
+	tx.Proofs = proofs
+}
+
+//GenerateID sets the ID of the transaction to the hash of its body.
+func (tx *SponsorshipV1) GenerateID() error {
+	b, err := tx.BodyMarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ID of SponsorshipV1 transaction")
+	}
+	tx.ID, err = bodyID(b)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ID of SponsorshipV1 transaction")
+	}
+	return nil
+}
+
 //NewUnsignedSponsorshipV1 creates new unsigned SponsorshipV1 transaction
 func NewUnsignedSponsorshipV1(senderPK crypto.PublicKey, assetID crypto.Digest, minAssetFee, fee, timestamp uint64) *SponsorshipV1 {
 	return &SponsorshipV1{Type: SponsorshipTransaction, Version: 1, SenderPK: senderPK, AssetID: assetID, MinAssetFee: minAssetFee, Fee: fee, Timestamp: timestamp}
@@ -1962,7 +2059,7 @@ func (tx SponsorshipV1) Valid() (bool, error) {
 	return true, nil
 }
 
-func (tx *SponsorshipV1) bodyMarshalBinary() ([]byte, error) {
+func (tx *SponsorshipV1) BodyMarshalBinary() ([]byte, error) {
 	var p int
 	buf := make([]byte, sponsorshipV1BodyLen)
 	buf[p] = byte(tx.Type)
@@ -2008,7 +2105,7 @@ func (tx *SponsorshipV1) bodyUnmarshalBinary(data []byte) error {
 
 //Sign adds signature as a proof at first position.
 func (tx *SponsorshipV1) Sign(secretKey crypto.SecretKey) error {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return errors.Wrap(err, "failed to sign SponsorshipV1 transaction")
 	}
@@ -2019,8 +2116,7 @@ func (tx *SponsorshipV1) Sign(secretKey crypto.SecretKey) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to sign SponsorshipV1 transaction")
 	}
-	d, err := crypto.FastHash(b)
-	tx.ID = &d
+	tx.ID, err = bodyID(b)
 	if err != nil {
 		return errors.Wrap(err, "failed to sign SponsorshipV1 transaction")
 	}
@@ -2029,7 +2125,7 @@ func (tx *SponsorshipV1) Sign(secretKey crypto.SecretKey) error {
 
 //Verify checks that first proof is a valid signature.
 func (tx *SponsorshipV1) Verify(publicKey crypto.PublicKey) (bool, error) {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return false, errors.Wrap(err, "failed to verify signature of SponsorshipV1 transaction")
 	}
@@ -2038,7 +2134,7 @@ func (tx *SponsorshipV1) Verify(publicKey crypto.PublicKey) (bool, error) {
 
 //MarshalBinary writes SponsorshipV1 transaction to its bytes representation.
 func (tx *SponsorshipV1) MarshalBinary() ([]byte, error) {
-	bb, err := tx.bodyMarshalBinary()
+	bb, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal SponsorshipV1 transaction to bytes")
 	}
@@ -2115,6 +2211,31 @@ func (tx SetAssetScriptV1) GetID() []byte {
 	return tx.ID.Bytes()
 }
 
+func (tx SetAssetScriptV1) GetSenderPK() crypto.PublicKey {
+	return tx.SenderPK
+}
+
+func (tx SetAssetScriptV1) GetProofs() *ProofsV1 {
+	return tx.Proofs
+}
+
+func (tx *SetAssetScriptV1) SetProofs(proofs *ProofsV1) {
+	tx.Proofs = proofs
+}
+
+//GenerateID sets the ID of the transaction to the hash of its body.
+func (tx *SetAssetScriptV1) GenerateID() error {
+	b, err := tx.BodyMarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ID of SetAssetScriptV1 transaction")
+	}
+	tx.ID, err = bodyID(b)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ID of SetAssetScriptV1 transaction")
+	}
+	return nil
+}
+
 //NewUnsignedSetAssetScriptV1 creates new unsigned SetAssetScriptV1 transaction.
 func NewUnsignedSetAssetScriptV1(chain byte, senderPK crypto.PublicKey, assetID crypto.Digest, script []byte, fee, timestamp uint64) *SetAssetScriptV1 {
 	return &SetAssetScriptV1{Type: SetAssetScriptTransaction, Version: 1, ChainID: chain, SenderPK: senderPK, AssetID: assetID, Script: script, Fee: fee, Timestamp: timestamp}
@@ -2136,7 +2257,7 @@ func (tx *SetAssetScriptV1) NonEmptyScript() bool {
 	return len(tx.Script) != 0
 }
 
-func (tx *SetAssetScriptV1) bodyMarshalBinary() ([]byte, error) {
+func (tx *SetAssetScriptV1) BodyMarshalBinary() ([]byte, error) {
 	var p int
 	sl := 0
 	if tx.NonEmptyScript() {
@@ -2205,7 +2326,7 @@ func (tx *SetAssetScriptV1) bodyUnmarshalBinary(data []byte) error {
 
 //Sign adds signature as a proof at first position.
 func (tx *SetAssetScriptV1) Sign(secretKey crypto.SecretKey) error {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return errors.Wrap(err, "failed to sign SetAssetScriptV1 transaction")
 	}
@@ -2216,8 +2337,7 @@ func (tx *SetAssetScriptV1) Sign(secretKey crypto.SecretKey) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to sign SetAssetScriptV1 transaction")
 	}
-	d, err := crypto.FastHash(b)
-	tx.ID = &d
+	tx.ID, err = bodyID(b)
 	if err != nil {
 		return errors.Wrap(err, "failed to sign SetAssetScriptV1 transaction")
 	}
@@ -2226,7 +2346,7 @@ func (tx *SetAssetScriptV1) Sign(secretKey crypto.SecretKey) error {
 
 //Verify checks that first proof is a valid signature.
 func (tx *SetAssetScriptV1) Verify(publicKey crypto.PublicKey) (bool, error) {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return false, errors.Wrap(err, "failed to verify signature of SetAssetScriptV1 transaction")
 	}
@@ -2235,7 +2355,7 @@ func (tx *SetAssetScriptV1) Verify(publicKey crypto.PublicKey) (bool, error) {
 
 //MarshalBinary writes SetAssetScriptV1 transaction to its bytes representation.
 func (tx *SetAssetScriptV1) MarshalBinary() ([]byte, error) {
-	bb, err := tx.bodyMarshalBinary()
+	bb, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal SetAssetScriptV1 transaction to bytes")
 	}
@@ -2306,6 +2426,31 @@ func (tx InvokeScriptV1) GetID() []byte {
 	return tx.ID.Bytes()
 }
 
+func (tx InvokeScriptV1) GetSenderPK() crypto.PublicKey {
+	return tx.SenderPK
+}
+
+func (tx InvokeScriptV1) GetProofs() *ProofsV1 {
+	return tx.Proofs
+}
+
+func (tx *InvokeScriptV1) SetProofs(proofs *ProofsV1) {
+	tx.Proofs = proofs
+}
+
+//GenerateID sets the ID of the transaction to the hash of its body.
+func (tx *InvokeScriptV1) GenerateID() error {
+	b, err := tx.BodyMarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ID of InvokeScriptV1 transaction")
+	}
+	tx.ID, err = bodyID(b)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ID of InvokeScriptV1 transaction")
+	}
+	return nil
+}
+
 //NewUnsignedSetAssetScriptV1 creates new unsigned SetAssetScriptV1 transaction.
 func NewUnsignedInvokeScriptV1(chain byte, senderPK crypto.PublicKey, scriptAddress Address, call FunctionCall, payments ScriptPayments, feeAsset OptionalAsset, fee, timestamp uint64) *InvokeScriptV1 {
 	return &InvokeScriptV1{
@@ -2359,7 +2504,7 @@ func (tx InvokeScriptV1) Valid() (bool, error) {
 	return true, nil
 }
 
-func (tx *InvokeScriptV1) bodyMarshalBinary() ([]byte, error) {
+func (tx *InvokeScriptV1) BodyMarshalBinary() ([]byte, error) {
 	p := 0
 	buf := make([]byte, invokeScriptV1FixedBodyLen+tx.FunctionCall.binarySize()+tx.Payments.binarySize()+tx.FeeAsset.binarySize())
 	buf[p] = byte(tx.Type)
@@ -2445,7 +2590,7 @@ func (tx *InvokeScriptV1) bodyUnmarshalBinary(data []byte) error {
 
 //Sign adds signature as a proof at first position.
 func (tx *InvokeScriptV1) Sign(secretKey crypto.SecretKey) error {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return errors.Wrap(err, "failed to sign InvokeScriptV1 transaction")
 	}
@@ -2456,8 +2601,7 @@ func (tx *InvokeScriptV1) Sign(secretKey crypto.SecretKey) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to sign InvokeScriptV1 transaction")
 	}
-	d, err := crypto.FastHash(b)
-	tx.ID = &d
+	tx.ID, err = bodyID(b)
 	if err != nil {
 		return errors.Wrap(err, "failed to sign InvokeScriptV1 transaction")
 	}
@@ -2466,7 +2610,7 @@ func (tx *InvokeScriptV1) Sign(secretKey crypto.SecretKey) error {
 
 //Verify checks that first proof is a valid signature.
 func (tx *InvokeScriptV1) Verify(publicKey crypto.PublicKey) (bool, error) {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return false, errors.Wrap(err, "failed to verify signature of InvokeScriptV1 transaction")
 	}
@@ -2475,7 +2619,7 @@ func (tx *InvokeScriptV1) Verify(publicKey crypto.PublicKey) (bool, error) {
 
 //MarshalBinary writes InvokeScriptV1 transaction to its bytes representation.
 func (tx *InvokeScriptV1) MarshalBinary() ([]byte, error) {
-	bb, err := tx.bodyMarshalBinary()
+	bb, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal InvokeScriptV1 transaction to bytes")
 	}
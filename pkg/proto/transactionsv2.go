@@ -43,6 +43,27 @@ func (tx IssueV2) GetID() []byte {
 	return tx.ID.Bytes()
 }
 
+func (tx IssueV2) GetProofs() *ProofsV1 {
+	return tx.Proofs
+}
+
+func (tx *IssueV2) SetProofs(proofs *ProofsV1) {
+	tx.Proofs = proofs
+}
+
+//GenerateID sets the ID of the transaction to the hash of its body.
+func (tx *IssueV2) GenerateID() error {
+	b, err := tx.BodyMarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ID of IssueV2 transaction")
+	}
+	tx.ID, err = bodyID(b)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ID of IssueV2 transaction")
+	}
+	return nil
+}
+
 //func (tx IssueV2) GetSenderPK() crypto.PublicKey {
 //	return tx.SenderPK
 //}
@@ -109,7 +130,7 @@ func (tx *IssueV2) NonEmptyScript() bool {
 	return len(tx.Script) != 0
 }
 
-func (tx *IssueV2) bodyMarshalBinary() ([]byte, error) {
+func (tx *IssueV2) BodyMarshalBinary() ([]byte, error) {
 	var p int
 	nl := len(tx.Name)
 	dl := len(tx.Description)
@@ -201,7 +222,7 @@ func (tx *IssueV2) bodyUnmarshalBinary(data []byte) error {
 
 //Sign calculates transaction signature using given secret key.
 func (tx *IssueV2) Sign(secretKey crypto.SecretKey) error {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return errors.Wrap(err, "failed to sign IssueV2 transaction")
 	}
@@ -212,17 +233,16 @@ func (tx *IssueV2) Sign(secretKey crypto.SecretKey) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to sign IssueV2 transaction")
 	}
-	d, err := crypto.FastHash(b)
+	tx.ID, err = bodyID(b)
 	if err != nil {
 		return errors.Wrap(err, "failed to sign IssueV2 transaction")
 	}
-	tx.ID = &d
 	return nil
 }
 
 //Verify checks that the transaction signature is valid for given public key.
 func (tx *IssueV2) Verify(publicKey crypto.PublicKey) (bool, error) {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return false, errors.Wrap(err, "failed to verify signature of IssueV2 transaction")
 	}
@@ -231,7 +251,7 @@ func (tx *IssueV2) Verify(publicKey crypto.PublicKey) (bool, error) {
 
 //MarshalBinary converts transaction to its binary representation.
 func (tx *IssueV2) MarshalBinary() ([]byte, error) {
-	bb, err := tx.bodyMarshalBinary()
+	bb, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal IssueV2 transaction to bytes")
 	}
@@ -300,6 +320,27 @@ func (tx TransferV2) GetID() []byte {
 	return tx.ID.Bytes()
 }
 
+func (tx TransferV2) GetProofs() *ProofsV1 {
+	return tx.Proofs
+}
+
+func (tx *TransferV2) SetProofs(proofs *ProofsV1) {
+	tx.Proofs = proofs
+}
+
+//GenerateID sets the ID of the transaction to the hash of its body.
+func (tx *TransferV2) GenerateID() error {
+	b, err := tx.BodyMarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ID of TransferV2 transaction")
+	}
+	tx.ID, err = bodyID(b)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ID of TransferV2 transaction")
+	}
+	return nil
+}
+
 //NewUnsignedTransferV2 creates new TransferV2 transaction without proofs and ID.
 func NewUnsignedTransferV2(senderPK crypto.PublicKey, amountAsset, feeAsset OptionalAsset, timestamp, amount, fee uint64, recipient Recipient, attachment string) *TransferV2 {
 	t := Transfer{
@@ -370,8 +411,7 @@ func (tx *TransferV2) Sign(secretKey crypto.SecretKey) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to sign TransferV2 transaction")
 	}
-	d, err := crypto.FastHash(b)
-	tx.ID = &d
+	tx.ID, err = bodyID(b)
 	if err != nil {
 		return errors.Wrap(err, "failed to sign TransferV2 transaction")
 	}
@@ -462,6 +502,27 @@ func (tx ReissueV2) GetID() []byte {
 	return tx.ID.Bytes()
 }
 
+func (tx ReissueV2) GetProofs() *ProofsV1 {
+	return tx.Proofs
+}
+
+func (tx *ReissueV2) SetProofs(proofs *ProofsV1) {
+	tx.Proofs = proofs
+}
+
+//GenerateID sets the ID of the transaction to the hash of its body.
+func (tx *ReissueV2) GenerateID() error {
+	b, err := tx.BodyMarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ID of ReissueV2 transaction")
+	}
+	tx.ID, err = bodyID(b)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ID of ReissueV2 transaction")
+	}
+	return nil
+}
+
 //NewUnsignedReissueV2 creates new ReissueV2 transaction without signature and ID.
 func NewUnsignedReissueV2(chainID byte, senderPK crypto.PublicKey, assetID crypto.Digest, quantity uint64, reissuable bool, timestamp, fee uint64) *ReissueV2 {
 	r := Reissue{
@@ -484,7 +545,7 @@ func (tx ReissueV2) Valid() (bool, error) {
 	return true, nil
 }
 
-func (tx *ReissueV2) bodyMarshalBinary() ([]byte, error) {
+func (tx *ReissueV2) BodyMarshalBinary() ([]byte, error) {
 	buf := make([]byte, reissueV2BodyLen)
 	buf[0] = byte(tx.Type)
 	buf[1] = tx.Version
@@ -521,7 +582,7 @@ func (tx *ReissueV2) bodyUnmarshalBinary(data []byte) error {
 
 //Sign adds signature as a proof at first position.
 func (tx *ReissueV2) Sign(secretKey crypto.SecretKey) error {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return errors.Wrap(err, "failed to sign ReissueV2 transaction")
 	}
@@ -532,8 +593,7 @@ func (tx *ReissueV2) Sign(secretKey crypto.SecretKey) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to sign ReissueV2 transaction")
 	}
-	d, err := crypto.FastHash(b)
-	tx.ID = &d
+	tx.ID, err = bodyID(b)
 	if err != nil {
 		return errors.Wrap(err, "failed to sign ReissueV2 transaction")
 	}
@@ -542,7 +602,7 @@ func (tx *ReissueV2) Sign(secretKey crypto.SecretKey) error {
 
 //Verify checks that first proof is a valid signature.
 func (tx *ReissueV2) Verify(publicKey crypto.PublicKey) (bool, error) {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return false, errors.Wrap(err, "failed to verify signature of ReissueV2 transaction")
 	}
@@ -551,7 +611,7 @@ func (tx *ReissueV2) Verify(publicKey crypto.PublicKey) (bool, error) {
 
 //MarshalBinary writes ReissueV2 transaction to its bytes representation.
 func (tx *ReissueV2) MarshalBinary() ([]byte, error) {
-	bb, err := tx.bodyMarshalBinary()
+	bb, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal ReissueV2 transaction to bytes")
 	}
@@ -613,6 +673,27 @@ func (tx BurnV2) GetID() []byte {
 	return tx.ID.Bytes()
 }
 
+func (tx BurnV2) GetProofs() *ProofsV1 {
+	return tx.Proofs
+}
+
+func (tx *BurnV2) SetProofs(proofs *ProofsV1) {
+	tx.Proofs = proofs
+}
+
+//GenerateID sets the ID of the transaction to the hash of its body.
+func (tx *BurnV2) GenerateID() error {
+	b, err := tx.BodyMarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ID of BurnV2 transaction")
+	}
+	tx.ID, err = bodyID(b)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ID of BurnV2 transaction")
+	}
+	return nil
+}
+
 //NewUnsignedBurnV2 creates new BurnV2 transaction without proofs and ID.
 func NewUnsignedBurnV2(chainID byte, senderPK crypto.PublicKey, assetID crypto.Digest, amount, timestamp, fee uint64) *BurnV2 {
 	b := Burn{
@@ -634,7 +715,7 @@ func (tx BurnV2) Valid() (bool, error) {
 	return true, nil
 }
 
-func (tx *BurnV2) bodyMarshalBinary() ([]byte, error) {
+func (tx *BurnV2) BodyMarshalBinary() ([]byte, error) {
 	buf := make([]byte, burnV2BodyLen)
 	buf[0] = byte(tx.Type)
 	buf[1] = tx.Version
@@ -671,7 +752,7 @@ func (tx *BurnV2) bodyUnmarshalBinary(data []byte) error {
 
 //Sign adds signature as a proof at first position.
 func (tx *BurnV2) Sign(secretKey crypto.SecretKey) error {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return errors.Wrap(err, "failed to sign BurnV2 transaction")
 	}
@@ -682,8 +763,7 @@ func (tx *BurnV2) Sign(secretKey crypto.SecretKey) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to sign BurnV2 transaction")
 	}
-	d, err := crypto.FastHash(b)
-	tx.ID = &d
+	tx.ID, err = bodyID(b)
 	if err != nil {
 		return errors.Wrap(err, "failed to sign BurnV2 transaction")
 	}
@@ -692,7 +772,7 @@ func (tx *BurnV2) Sign(secretKey crypto.SecretKey) error {
 
 //Verify checks that first proof is a valid signature.
 func (tx *BurnV2) Verify(publicKey crypto.PublicKey) (bool, error) {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return false, errors.Wrap(err, "failed to verify signature of BurnV2 transaction")
 	}
@@ -701,7 +781,7 @@ func (tx *BurnV2) Verify(publicKey crypto.PublicKey) (bool, error) {
 
 //MarshalBinary writes BurnV2 transaction to its bytes representation.
 func (tx *BurnV2) MarshalBinary() ([]byte, error) {
-	bb, err := tx.bodyMarshalBinary()
+	bb, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal BurnV2 transaction to bytes")
 	}
@@ -770,6 +850,27 @@ func (tx ExchangeV2) GetID() []byte {
 	return tx.ID.Bytes()
 }
 
+func (tx ExchangeV2) GetProofs() *ProofsV1 {
+	return tx.Proofs
+}
+
+func (tx *ExchangeV2) SetProofs(proofs *ProofsV1) {
+	tx.Proofs = proofs
+}
+
+//GenerateID sets the ID of the transaction to the hash of its body.
+func (tx *ExchangeV2) GenerateID() error {
+	b, err := tx.BodyMarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ID of ExchangeV2 transaction")
+	}
+	tx.ID, err = bodyID(b)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ID of ExchangeV2 transaction")
+	}
+	return nil
+}
+
 func (tx ExchangeV2) GetSenderPK() crypto.PublicKey {
 	return tx.SenderPK
 }
@@ -923,7 +1024,7 @@ func (tx *ExchangeV2) marshalAsOrderV2(order Order) ([]byte, error) {
 	return buf, nil
 }
 
-func (tx *ExchangeV2) bodyMarshalBinary() ([]byte, error) {
+func (tx *ExchangeV2) BodyMarshalBinary() ([]byte, error) {
 	var bob []byte
 	var sob []byte
 	var err error
@@ -1053,7 +1154,7 @@ func (tx *ExchangeV2) bodyUnmarshalBinary(data []byte) (int, error) {
 
 //Sign calculates transaction signature using given secret key.
 func (tx *ExchangeV2) Sign(secretKey crypto.SecretKey) error {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return errors.Wrap(err, "failed to sign ExchangeV2 transaction")
 	}
@@ -1064,17 +1165,16 @@ func (tx *ExchangeV2) Sign(secretKey crypto.SecretKey) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to sign ExchangeV2 transaction")
 	}
-	d, err := crypto.FastHash(b)
+	tx.ID, err = bodyID(b)
 	if err != nil {
 		return errors.Wrap(err, "failed to sign ExchangeV2 transaction")
 	}
-	tx.ID = &d
 	return nil
 }
 
 //Verify checks that the transaction signature is valid for given public key.
 func (tx *ExchangeV2) Verify(publicKey crypto.PublicKey) (bool, error) {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return false, errors.Wrap(err, "failed to verify signature of ExchangeV2 transaction")
 	}
@@ -1083,7 +1183,7 @@ func (tx *ExchangeV2) Verify(publicKey crypto.PublicKey) (bool, error) {
 
 //MarshalBinary saves the transaction to its binary representation.
 func (tx *ExchangeV2) MarshalBinary() ([]byte, error) {
-	bb, err := tx.bodyMarshalBinary()
+	bb, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal ExchangeV2 transaction to bytes")
 	}
@@ -1196,6 +1296,27 @@ func (tx LeaseV2) GetID() []byte {
 	return tx.ID.Bytes()
 }
 
+func (tx LeaseV2) GetProofs() *ProofsV1 {
+	return tx.Proofs
+}
+
+func (tx *LeaseV2) SetProofs(proofs *ProofsV1) {
+	tx.Proofs = proofs
+}
+
+//GenerateID sets the ID of the transaction to the hash of its body.
+func (tx *LeaseV2) GenerateID() error {
+	b, err := tx.BodyMarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ID of LeaseV2 transaction")
+	}
+	tx.ID, err = bodyID(b)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ID of LeaseV2 transaction")
+	}
+	return nil
+}
+
 //NewUnsignedLeaseV2 creates new LeaseV1 transaction without signature and ID set.
 func NewUnsignedLeaseV2(senderPK crypto.PublicKey, recipient Recipient, amount, fee, timestamp uint64) *LeaseV2 {
 	l := Lease{
@@ -1208,7 +1329,7 @@ func NewUnsignedLeaseV2(senderPK crypto.PublicKey, recipient Recipient, amount,
 	return &LeaseV2{Type: LeaseTransaction, Version: 2, Lease: l}
 }
 
-func (tx *LeaseV2) bodyMarshalBinary() ([]byte, error) {
+func (tx *LeaseV2) BodyMarshalBinary() ([]byte, error) {
 	rl := tx.Recipient.len
 	buf := make([]byte, leaseV2BodyLen+rl)
 	buf[0] = byte(tx.Type)
@@ -1245,7 +1366,7 @@ func (tx *LeaseV2) bodyUnmarshalBinary(data []byte) error {
 
 //Sign adds signature as a proof at first position.
 func (tx *LeaseV2) Sign(secretKey crypto.SecretKey) error {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return errors.Wrap(err, "failed to sign LeaseV2 transaction")
 	}
@@ -1256,8 +1377,7 @@ func (tx *LeaseV2) Sign(secretKey crypto.SecretKey) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to sign LeaseV2 transaction")
 	}
-	d, err := crypto.FastHash(b)
-	tx.ID = &d
+	tx.ID, err = bodyID(b)
 	if err != nil {
 		return errors.Wrap(err, "failed to sign LeaseV2 transaction")
 	}
@@ -1266,7 +1386,7 @@ func (tx *LeaseV2) Sign(secretKey crypto.SecretKey) error {
 
 //Verify checks that first proof is a valid signature.
 func (tx *LeaseV2) Verify(publicKey crypto.PublicKey) (bool, error) {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return false, errors.Wrap(err, "failed to verify signature of LeaseV2 transaction")
 	}
@@ -1275,7 +1395,7 @@ func (tx *LeaseV2) Verify(publicKey crypto.PublicKey) (bool, error) {
 
 //MarshalBinary saves the transaction to its binary representation.
 func (tx *LeaseV2) MarshalBinary() ([]byte, error) {
-	bb, err := tx.bodyMarshalBinary()
+	bb, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal LeaseV2 transaction to bytes")
 	}
@@ -1338,6 +1458,27 @@ func (tx LeaseCancelV2) GetID() []byte {
 	return tx.ID.Bytes()
 }
 
+func (tx LeaseCancelV2) GetProofs() *ProofsV1 {
+	return tx.Proofs
+}
+
+func (tx *LeaseCancelV2) SetProofs(proofs *ProofsV1) {
+	tx.Proofs = proofs
+}
+
+//GenerateID sets the ID of the transaction to the hash of its body.
+func (tx *LeaseCancelV2) GenerateID() error {
+	b, err := tx.BodyMarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ID of LeaseCancelV2 transaction")
+	}
+	tx.ID, err = bodyID(b)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ID of LeaseCancelV2 transaction")
+	}
+	return nil
+}
+
 //NewUnsignedLeaseCancelV2 creates new LeaseCancelV2 transaction structure without a signature and an ID.
 func NewUnsignedLeaseCancelV2(chainID byte, senderPK crypto.PublicKey, leaseID crypto.Digest, fee, timestamp uint64) *LeaseCancelV2 {
 	lc := LeaseCancel{
@@ -1358,7 +1499,7 @@ func (tx LeaseCancelV2) Valid() (bool, error) {
 	return true, nil
 }
 
-func (tx *LeaseCancelV2) bodyMarshalBinary() ([]byte, error) {
+func (tx *LeaseCancelV2) BodyMarshalBinary() ([]byte, error) {
 	buf := make([]byte, leaseCancelV2BodyLen)
 	buf[0] = byte(tx.Type)
 	buf[1] = tx.Version
@@ -1396,7 +1537,7 @@ func (tx *LeaseCancelV2) bodyUnmarshalBinary(data []byte) error {
 
 //Sign adds signature as a proof at first position.
 func (tx *LeaseCancelV2) Sign(secretKey crypto.SecretKey) error {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return errors.Wrap(err, "failed to sign LeaseCancelV2 transaction")
 	}
@@ -1407,8 +1548,7 @@ func (tx *LeaseCancelV2) Sign(secretKey crypto.SecretKey) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to sign LeaseCancelV2 transaction")
 	}
-	d, err := crypto.FastHash(b)
-	tx.ID = &d
+	tx.ID, err = bodyID(b)
 	if err != nil {
 		return errors.Wrap(err, "failed to sign LeaseCancelV2 transaction")
 	}
@@ -1417,7 +1557,7 @@ func (tx *LeaseCancelV2) Sign(secretKey crypto.SecretKey) error {
 
 //Verify checks that first proof is a valid signature.
 func (tx *LeaseCancelV2) Verify(publicKey crypto.PublicKey) (bool, error) {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return false, errors.Wrap(err, "failed to verify signature of LeaseCancelV2 transaction")
 	}
@@ -1426,7 +1566,7 @@ func (tx *LeaseCancelV2) Verify(publicKey crypto.PublicKey) (bool, error) {
 
 //MarshalBinary saves the transaction to its binary representation.
 func (tx *LeaseCancelV2) MarshalBinary() ([]byte, error) {
-	bb, err := tx.bodyMarshalBinary()
+	bb, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal LeaseCancelV2 transaction to bytes")
 	}
@@ -1486,6 +1626,24 @@ func (tx CreateAliasV2) GetID() []byte {
 	return tx.ID.Bytes()
 }
 
+func (tx CreateAliasV2) GetProofs() *ProofsV1 {
+	return tx.Proofs
+}
+
+func (tx *CreateAliasV2) SetProofs(proofs *ProofsV1) {
+	tx.Proofs = proofs
+}
+
+//GenerateID sets the ID of the transaction to the hash of its alias.
+func (tx *CreateAliasV2) GenerateID() error {
+	id, err := tx.CreateAlias.id()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ID of CreateAliasV2 transaction")
+	}
+	tx.ID = id
+	return nil
+}
+
 func NewUnsignedCreateAliasV2(senderPK crypto.PublicKey, alias Alias, fee, timestamp uint64) *CreateAliasV2 {
 	ca := CreateAlias{
 		SenderPK:  senderPK,
@@ -1496,7 +1654,7 @@ func NewUnsignedCreateAliasV2(senderPK crypto.PublicKey, alias Alias, fee, times
 	return &CreateAliasV2{Type: CreateAliasTransaction, Version: 2, CreateAlias: ca}
 }
 
-func (tx *CreateAliasV2) bodyMarshalBinary() ([]byte, error) {
+func (tx *CreateAliasV2) BodyMarshalBinary() ([]byte, error) {
 	buf := make([]byte, createAliasV2FixedBodyLen+len(tx.Alias.Alias))
 	buf[0] = byte(tx.Type)
 	buf[1] = tx.Version
@@ -1531,7 +1689,7 @@ func (tx *CreateAliasV2) bodyUnmarshalBinary(data []byte) error {
 
 //Sign adds signature as a proof at first position.
 func (tx *CreateAliasV2) Sign(secretKey crypto.SecretKey) error {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return errors.Wrap(err, "failed to sign CreateAliasV2 transaction")
 	}
@@ -1551,7 +1709,7 @@ func (tx *CreateAliasV2) Sign(secretKey crypto.SecretKey) error {
 
 //Verify checks that first proof is a valid signature.
 func (tx *CreateAliasV2) Verify(publicKey crypto.PublicKey) (bool, error) {
-	b, err := tx.bodyMarshalBinary()
+	b, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return false, errors.Wrap(err, "failed to verify signature of CreateAliasV2 transaction")
 	}
@@ -1560,7 +1718,7 @@ func (tx *CreateAliasV2) Verify(publicKey crypto.PublicKey) (bool, error) {
 
 //MarshalBinary saves the transaction to its binary representation.
 func (tx *CreateAliasV2) MarshalBinary() ([]byte, error) {
-	bb, err := tx.bodyMarshalBinary()
+	bb, err := tx.BodyMarshalBinary()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal CreateAliasV2 transaction to bytes")
 	}
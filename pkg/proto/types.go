@@ -567,7 +567,7 @@ func (o OrderV1) GetExpiration() uint64 {
 	return o.Expiration
 }
 
-func (o *OrderV1) bodyMarshalBinary() ([]byte, error) {
+func (o *OrderV1) BodyMarshalBinary() ([]byte, error) {
 	return o.OrderBody.marshalBinary()
 }
 
@@ -577,7 +577,7 @@ func (o *OrderV1) bodyUnmarshalBinary(data []byte) error {
 
 //Sign adds a signature to the order.
 func (o *OrderV1) Sign(secretKey crypto.SecretKey) error {
-	b, err := o.bodyMarshalBinary()
+	b, err := o.BodyMarshalBinary()
 	if err != nil {
 		return errors.Wrap(err, "failed to sign OrderV1")
 	}
@@ -596,7 +596,7 @@ func (o *OrderV1) Verify(publicKey crypto.PublicKey) (bool, error) {
 	if o.Signature == nil {
 		return false, errors.New("empty signature")
 	}
-	b, err := o.bodyMarshalBinary()
+	b, err := o.BodyMarshalBinary()
 	if err != nil {
 		return false, errors.Wrap(err, "failed to verify signature of OrderV1")
 	}
@@ -605,7 +605,7 @@ func (o *OrderV1) Verify(publicKey crypto.PublicKey) (bool, error) {
 
 //MarshalBinary writes order to its bytes representation.
 func (o *OrderV1) MarshalBinary() ([]byte, error) {
-	b, err := o.bodyMarshalBinary()
+	b, err := o.BodyMarshalBinary()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal OrderV1 to bytes")
 	}
@@ -696,7 +696,7 @@ func (o OrderV2) GetExpiration() uint64 {
 	return o.Expiration
 }
 
-func (o *OrderV2) bodyMarshalBinary() ([]byte, error) {
+func (o *OrderV2) BodyMarshalBinary() ([]byte, error) {
 	aal := 0
 	if o.AssetPair.AmountAsset.Present {
 		aal += crypto.DigestSize
@@ -734,7 +734,7 @@ func (o *OrderV2) bodyUnmarshalBinary(data []byte) error {
 
 //Sign adds a signature to the order.
 func (o *OrderV2) Sign(secretKey crypto.SecretKey) error {
-	b, err := o.bodyMarshalBinary()
+	b, err := o.BodyMarshalBinary()
 	if err != nil {
 		return errors.Wrap(err, "failed to sign OrderV2")
 	}
@@ -755,7 +755,7 @@ func (o *OrderV2) Sign(secretKey crypto.SecretKey) error {
 
 //Verify checks that the order's signature is valid.
 func (o *OrderV2) Verify(publicKey crypto.PublicKey) (bool, error) {
-	b, err := o.bodyMarshalBinary()
+	b, err := o.BodyMarshalBinary()
 	if err != nil {
 		return false, errors.Wrap(err, "failed to verify signature of OrderV2")
 	}
@@ -764,7 +764,7 @@ func (o *OrderV2) Verify(publicKey crypto.PublicKey) (bool, error) {
 
 //MarshalBinary writes order to its bytes representation.
 func (o *OrderV2) MarshalBinary() ([]byte, error) {
-	bb, err := o.bodyMarshalBinary()
+	bb, err := o.BodyMarshalBinary()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal OrderV2 to bytes")
 	}
@@ -904,21 +904,19 @@ func (p *ProofsV1) UnmarshalBinary(data []byte) error {
 }
 
 //Sign creates a signature and stores it as a proof at given position.
+//Proofs before the position are filled with empty proofs if absent, an empty proof at the position is replaced.
 func (p *ProofsV1) Sign(pos int, key crypto.SecretKey, data []byte) error {
-	if pos < 0 || pos > proofsMaxCount {
+	if pos < 0 || pos >= proofsMaxCount {
 		return errors.Errorf("failed to create proof at position %d, allowed positions from 0 to %d", pos, proofsMaxCount-1)
 	}
-	if len(p.Proofs)-1 < pos {
-		s := crypto.Sign(key, data)
-		p.Proofs = append(p.Proofs[:pos], append([]B58Bytes{s[:]}, p.Proofs[pos:]...)...)
-	} else {
-		pr := p.Proofs[pos]
-		if len(pr) > 0 {
-			return errors.Errorf("unable to overwrite non-empty proof at position %d", pos)
-		}
-		s := crypto.Sign(key, data)
-		copy(pr[:], s[:])
+	for len(p.Proofs) <= pos {
+		p.Proofs = append(p.Proofs, B58Bytes{})
+	}
+	if len(p.Proofs[pos]) > 0 {
+		return errors.Errorf("unable to overwrite non-empty proof at position %d", pos)
 	}
+	s := crypto.Sign(key, data)
+	p.Proofs[pos] = s[:]
 	return nil
 }
 
@@ -67,7 +67,7 @@ func TestMainNetOrder(t *testing.T) {
 		aa, _ := NewOptionalAssetFromString(tc.amountAsset)
 		pa, _ := NewOptionalAssetFromString(tc.priceAsset)
 		o := NewUnsignedOrderV1(spk, mpk, *aa, *pa, tc.orderType, tc.price, tc.amount, tc.timestamp, tc.expiration, tc.fee)
-		if b, err := o.bodyMarshalBinary(); assert.NoError(t, err) {
+		if b, err := o.BodyMarshalBinary(); assert.NoError(t, err) {
 			d, _ := crypto.FastHash(b)
 			assert.Equal(t, id, d)
 			assert.True(t, crypto.Verify(spk, sig, b))
@@ -686,6 +686,38 @@ func TestProofsV1UnmarshalJSON(t *testing.T) {
 	}
 }
 
+func TestProofsV1SignPositions(t *testing.T) {
+	sk1, pk1 := crypto.GenerateKeyPair([]byte("first"))
+	sk2, pk2 := crypto.GenerateKeyPair([]byte("second"))
+	data := []byte("data")
+	p := ProofsV1{proofsVersion, make([]B58Bytes, 0)}
+
+	require.NoError(t, p.Sign(2, sk2, data))
+	assert.Equal(t, 3, len(p.Proofs))
+	assert.Empty(t, p.Proofs[0])
+	assert.Empty(t, p.Proofs[1])
+	ok, err := p.Verify(2, pk2, data)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	require.NoError(t, p.Sign(0, sk1, data))
+	ok, err = p.Verify(0, pk1, data)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 3, len(p.Proofs))
+
+	assert.Error(t, p.Sign(0, sk2, data))
+	assert.Error(t, p.Sign(-1, sk2, data))
+	assert.Error(t, p.Sign(proofsMaxCount, sk2, data))
+	require.NoError(t, p.Sign(proofsMaxCount-1, sk2, data))
+
+	b, err := p.MarshalBinary()
+	require.NoError(t, err)
+	var p2 ProofsV1
+	require.NoError(t, p2.UnmarshalBinary(b))
+	assert.Equal(t, p.Proofs, p2.Proofs)
+}
+
 func TestIntegerArgumentBinarySize(t *testing.T) {
 	tests := []int64{12345, -9876543210, 1234567890, 0}
 	for _, tc := range tests {
@@ -0,0 +1,422 @@
+package compiler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/ast"
+)
+
+// Severities of findings.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Codes of findings.
+const (
+	// CodeTypeMismatch is reported for arguments and conditions of wrong types, which fail the evaluation.
+	CodeTypeMismatch = "type-mismatch"
+	// CodeUnknownField is reported for getters of fields that the object may not have.
+	CodeUnknownField = "unknown-field"
+	// CodeUndefinedReference is reported for references to undeclared variables and functions.
+	CodeUndefinedReference = "undefined-reference"
+	// CodeUnavailableFunction is reported for functions and declarations introduced after the version of the script.
+	CodeUnavailableFunction = "unavailable-function"
+	// CodeUnknownFunction is reported for native functions unknown to the analyzer, their calls are not checked.
+	CodeUnknownFunction = "unknown-function"
+	// CodeUnreachableBranch is reported for branches of conditions that are never taken.
+	CodeUnreachableBranch = "unreachable-branch"
+	// CodeUnusedLet is reported for variables that are never referenced.
+	CodeUnusedLet = "unused-let"
+	// CodeUnusedFunction is reported for functions that are never called.
+	CodeUnusedFunction = "unused-function"
+	// CodeMissingSigVerify is reported for account scripts that don't verify signatures.
+	CodeMissingSigVerify = "missing-sigverify"
+)
+
+// Finding is a problem found by the static analysis of the script.
+type Finding struct {
+	Severity string `json:"severity"`
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+	// Path locates the expression in the script, for example "let x > if > then > 'sigVerify' arg 1".
+	Path string `json:"path"`
+}
+
+const (
+	nativeSigVerify = 500
+	nativeRSAVerify = 504
+)
+
+var (
+	nativeFunctions = make(map[int16]namedFunction)
+	userFunctions   = make(map[string][]namedFunction)
+)
+
+// namedFunction is an overload of the library function with the name it is called by in the source code.
+type namedFunction struct {
+	function
+	name string
+}
+
+func init() {
+	for name, overloads := range functions {
+		name = strings.TrimPrefix(name, "unary ")
+		for _, f := range overloads {
+			if f.native >= 0 {
+				nativeFunctions[f.native] = namedFunction{function: f, name: name}
+			} else {
+				userFunctions[f.user] = append(userFunctions[f.user], namedFunction{function: f, name: name})
+			}
+		}
+	}
+}
+
+// binding is a variable or a function declared in the script.
+type binding struct {
+	name string
+	path string
+	// typ is the type of variable or the type of the result of function.
+	typ    Type
+	params int
+	used   *bool
+}
+
+type analyzerScope struct {
+	parent *analyzerScope
+	vars   map[string]binding
+	funcs  map[string]binding
+}
+
+func newAnalyzerScope(parent *analyzerScope) *analyzerScope {
+	return &analyzerScope{parent: parent, vars: make(map[string]binding), funcs: make(map[string]binding)}
+}
+
+func (s *analyzerScope) variable(name string) (binding, bool) {
+	for c := s; c != nil; c = c.parent {
+		if b, ok := c.vars[name]; ok {
+			return b, true
+		}
+	}
+	return binding{}, false
+}
+
+func (s *analyzerScope) function(name string) (binding, bool) {
+	for c := s; c != nil; c = c.parent {
+		if b, ok := c.funcs[name]; ok {
+			return b, true
+		}
+	}
+	return binding{}, false
+}
+
+type analyzer struct {
+	checker
+	findings []Finding
+	verified bool
+}
+
+// Analyze infers types of expressions of the compiled script and reports problems which fail the evaluation or
+// look like mistakes. Script type is either ACCOUNT or ASSET, it defines the global variables and the checks.
+// Parameters of functions declared in the script have unknown types, expressions using them are not checked.
+func Analyze(script *ast.Script, scriptType string) []Finding {
+	a := &analyzer{checker: checker{version: script.Version, scriptType: scriptType}}
+	s := newAnalyzerScope(nil)
+	for name, t := range globals(script.Version, scriptType) {
+		used := true
+		s.vars[name] = binding{name: name, typ: t, used: &used}
+	}
+	t := a.expr(script.Verifier, newAnalyzerScope(s), "")
+	if !assignable(Boolean, t) {
+		a.report(SeverityError, CodeTypeMismatch, "", "script should return Boolean, found %s", t)
+	}
+	if scriptType == "ACCOUNT" && !a.verified {
+		a.report(SeverityWarning, CodeMissingSigVerify, "", "account script doesn't call sigVerify, transactions of the account are not checked for signatures")
+	}
+	return a.findings
+}
+
+func (a *analyzer) report(severity, code, path, format string, args ...interface{}) {
+	a.findings = append(a.findings, Finding{Severity: severity, Code: code, Message: fmt.Sprintf(format, args...), Path: path})
+}
+
+func join(path, segment string) string {
+	if path == "" {
+		return segment
+	}
+	return path + " > " + segment
+}
+
+func (a *analyzer) expr(e ast.Expr, s *analyzerScope, path string) Type {
+	switch v := e.(type) {
+	case *ast.LongExpr:
+		return Int
+	case *ast.BytesExpr:
+		return ByteVector
+	case *ast.StringExpr:
+		return String
+	case *ast.BooleanExpr:
+		return Boolean
+	case *ast.RefExpr:
+		b, ok := s.variable(v.Name)
+		if !ok {
+			a.report(SeverityError, CodeUndefinedReference, path, "undefined variable '%s'", v.Name)
+			return Nothing
+		}
+		*b.used = true
+		return b.typ
+	case *ast.GetterExpr:
+		return a.getter(v, s, path)
+	case *ast.IfExpr:
+		return a.condition(v, s, path)
+	case *ast.Block:
+		if v.BlockV2 && a.version < 3 {
+			a.report(SeverityError, CodeUnavailableFunction, path, "declaration blocks are not supported in version %d", a.version)
+		}
+		letPath := join(path, "let "+v.Let.Name)
+		t := a.expr(v.Let.Value, s, letPath)
+		used := false
+		bs := newAnalyzerScope(s)
+		bs.vars[v.Let.Name] = binding{name: v.Let.Name, path: letPath, typ: t, used: &used}
+		rs := a.expr(v.Body, bs, path)
+		// Variables starting with $ are generated by compilers for match expressions
+		if !used && !strings.HasPrefix(v.Let.Name, "$") {
+			a.report(SeverityWarning, CodeUnusedLet, letPath, "variable '%s' is never used", v.Let.Name)
+		}
+		return rs
+	case *ast.FuncBlock:
+		if a.version < 3 {
+			a.report(SeverityError, CodeUnavailableFunction, path, "declaration blocks are not supported in version %d", a.version)
+		}
+		funcPath := join(path, "func "+v.Func.Name)
+		fs := newAnalyzerScope(s)
+		for _, arg := range v.Func.Args {
+			used := true
+			fs.vars[arg] = binding{name: arg, typ: Nothing, used: &used}
+		}
+		t := a.expr(v.Func.Body, fs, funcPath)
+		used := false
+		bs := newAnalyzerScope(s)
+		bs.funcs[v.Func.Name] = binding{name: v.Func.Name, path: funcPath, typ: t, params: len(v.Func.Args), used: &used}
+		rs := a.expr(v.Body, bs, path)
+		if !used {
+			a.report(SeverityWarning, CodeUnusedFunction, funcPath, "function '%s' is never called", v.Func.Name)
+		}
+		return rs
+	case *ast.FuncCall:
+		return a.call(v, s, path)
+	default:
+		a.report(SeverityError, CodeTypeMismatch, path, "unexpected expression %T", e)
+		return Nothing
+	}
+}
+
+func (a *analyzer) getter(g *ast.GetterExpr, s *analyzerScope, path string) Type {
+	t := a.expr(g.Object, s, path)
+	var types, missing []Type
+	for _, alt := range alternatives(t) {
+		if alt == Nothing {
+			continue
+		}
+		ft, ok := objectFields[alt.String()][g.Key]
+		if !ok {
+			missing = append(missing, alt)
+			continue
+		}
+		types = append(types, ft)
+	}
+	if len(missing) > 0 {
+		a.report(SeverityError, CodeUnknownField, path, "value of type %s has no field '%s'", union(missing...), g.Key)
+	}
+	return union(types...)
+}
+
+// instanceCheck recognizes conditions generated for cases of match: checks that the variable is an instance of one
+// of the types, combined with ||.
+func instanceCheck(e ast.Expr) (string, []string, bool) {
+	switch v := e.(type) {
+	case *ast.FuncCall:
+		f, ok := v.Func.(*ast.NativeFunction)
+		if !ok || f.FunctionID != nativeIsInstanceOf || len(f.Argv) != 2 {
+			return "", nil, false
+		}
+		ref, ok := f.Argv[0].(*ast.RefExpr)
+		if !ok {
+			return "", nil, false
+		}
+		name, ok := f.Argv[1].(*ast.StringExpr)
+		if !ok {
+			return "", nil, false
+		}
+		return ref.Name, []string{name.Value}, true
+	case *ast.IfExpr:
+		if b, ok := v.True.(*ast.BooleanExpr); !ok || !b.Value {
+			return "", nil, false
+		}
+		left, lt, ok := instanceCheck(v.Condition)
+		if !ok {
+			return "", nil, false
+		}
+		right, rt, ok := instanceCheck(v.False)
+		if !ok || left != right {
+			return "", nil, false
+		}
+		return left, append(lt, rt...), true
+	default:
+		return "", nil, false
+	}
+}
+
+func isThrow(e ast.Expr) bool {
+	c, ok := e.(*ast.FuncCall)
+	if !ok {
+		return false
+	}
+	switch f := c.Func.(type) {
+	case *ast.NativeFunction:
+		return f.FunctionID == nativeThrow
+	case *ast.UserFunction:
+		return f.Name == "throw"
+	default:
+		return false
+	}
+}
+
+func (a *analyzer) condition(e *ast.IfExpr, s *analyzerScope, path string) Type {
+	ifPath := join(path, "if")
+	ct := a.expr(e.Condition, s, ifPath)
+	if !assignable(Boolean, ct) {
+		a.report(SeverityError, CodeTypeMismatch, ifPath, "condition should be Boolean, found %s", ct)
+	}
+	ts, fs := s, s
+	thenReachable, elseReachable := true, true
+	if ref, names, ok := instanceCheck(e.Condition); ok {
+		if b, ok := s.variable(ref); ok && b.typ != Nothing {
+			checked := make(map[string]bool)
+			for _, n := range names {
+				checked[n] = true
+			}
+			var matched []Type
+			for _, alt := range alternatives(b.typ) {
+				if checked[alt.String()] {
+					matched = append(matched, alt)
+				}
+			}
+			mt := union(matched...)
+			rest := without(b.typ, mt)
+			if mt == Nothing {
+				thenReachable = false
+				a.report(SeverityWarning, CodeUnreachableBranch, join(path, "then"), "value of type %s is never an instance of %s", b.typ, strings.Join(names, "|"))
+			}
+			// Match without default case throws in the branch that is never taken
+			if rest == Nothing && !isThrow(e.False) {
+				elseReachable = false
+				a.report(SeverityWarning, CodeUnreachableBranch, join(path, "else"), "value of type %s is always an instance of %s", b.typ, strings.Join(names, "|"))
+			}
+			ts, fs = newAnalyzerScope(s), newAnalyzerScope(s)
+			ts.vars[ref] = binding{name: b.name, path: b.path, typ: mt, used: b.used}
+			fs.vars[ref] = binding{name: b.name, path: b.path, typ: rest, used: b.used}
+		}
+	} else if c, ok := e.Condition.(*ast.BooleanExpr); ok {
+		branch := "else"
+		if !c.Value {
+			branch = "then"
+		}
+		a.report(SeverityWarning, CodeUnreachableBranch, join(path, branch), "condition is always %t", c.Value)
+	}
+	tt := a.expr(e.True, ts, join(path, "then"))
+	ft := a.expr(e.False, fs, join(path, "else"))
+	switch {
+	case !thenReachable:
+		return ft
+	case !elseReachable:
+		return tt
+	default:
+		return union(tt, ft)
+	}
+}
+
+func (a *analyzer) call(c *ast.FuncCall, s *analyzerScope, path string) Type {
+	var (
+		name string
+		argv ast.Exprs
+	)
+	switch f := c.Func.(type) {
+	case *ast.NativeFunction:
+		if f.FunctionID == nativeSigVerify || f.FunctionID == nativeRSAVerify {
+			a.verified = true
+		}
+		name, argv = fmt.Sprintf("native function %d", f.FunctionID), f.Argv
+		if nf, ok := nativeFunctions[f.FunctionID]; ok {
+			name = nf.name
+		}
+	case *ast.UserFunction:
+		name, argv = f.Name, f.Argv
+	default:
+		a.report(SeverityError, CodeTypeMismatch, path, "unexpected function %T", c.Func)
+		return Nothing
+	}
+	args := make([]Type, len(argv))
+	for i, arg := range argv {
+		args[i] = a.expr(arg, s, join(path, fmt.Sprintf("'%s' arg %d", name, i+1)))
+	}
+	switch f := c.Func.(type) {
+	case *ast.NativeFunction:
+		if f.FunctionID == nativeIsInstanceOf {
+			if len(args) != 2 || !assignable(String, args[1]) {
+				a.report(SeverityError, CodeTypeMismatch, path, "function '_isInstanceOf' can't be called with arguments of types (%s)", typeNames(args))
+			}
+			return Boolean
+		}
+		nf, ok := nativeFunctions[f.FunctionID]
+		if !ok {
+			a.report(SeverityWarning, CodeUnknownFunction, path, "unknown native function %d", f.FunctionID)
+			return Nothing
+		}
+		if f.FunctionID == nativeEq && len(args) == 2 && !comparableTypes(args[0], args[1]) {
+			a.report(SeverityError, CodeTypeMismatch, path, "values of types %s and %s are never equal", args[0], args[1])
+			return Boolean
+		}
+		return a.overload(path, name, []namedFunction{nf}, args)
+	default:
+		uf := c.Func.(*ast.UserFunction)
+		if b, ok := s.function(uf.Name); ok {
+			*b.used = true
+			if b.params != len(args) {
+				a.report(SeverityError, CodeTypeMismatch, path, "function '%s' requires %d arguments, but %d are provided", uf.Name, b.params, len(args))
+			}
+			return b.typ
+		}
+		overloads, ok := userFunctions[uf.Name]
+		if !ok {
+			a.report(SeverityError, CodeUndefinedReference, path, "undefined function '%s'", uf.Name)
+			return Nothing
+		}
+		if uf.Name == "!=" && len(args) == 2 && !comparableTypes(args[0], args[1]) {
+			a.report(SeverityError, CodeTypeMismatch, path, "values of types %s and %s are always different", args[0], args[1])
+			return Boolean
+		}
+		return a.overload(path, name, overloads, args)
+	}
+}
+
+// overload checks the arguments against the overloads of the library function and returns the type of the result.
+func (a *analyzer) overload(path, name string, overloads []namedFunction, args []Type) Type {
+	available := false
+	for _, f := range overloads {
+		if f.minVersion > a.version {
+			continue
+		}
+		available = true
+		if result, ok := a.match(f.function, args); ok {
+			return result
+		}
+	}
+	if !available {
+		a.report(SeverityError, CodeUnavailableFunction, path, "function '%s' is not available in version %d", name, a.version)
+		return Nothing
+	}
+	a.report(SeverityError, CodeTypeMismatch, path, "function '%s' can't be called with arguments of types (%s)", name, typeNames(args))
+	return Nothing
+}
@@ -0,0 +1,146 @@
+package compiler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/ast"
+	astparser "github.com/wavesplatform/gowaves/pkg/ride/evaluator/parser"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/reader"
+)
+
+func analyzeSource(t *testing.T, code string) []Finding {
+	script, err := Compile(code)
+	require.NoError(t, err, code)
+	return Analyze(&ast.Script{Version: script.Directives.StdLibVersion, Verifier: script.Expr}, script.Directives.ScriptType)
+}
+
+func codes(findings []Finding) []string {
+	out := make([]string, len(findings))
+	for i, f := range findings {
+		out[i] = f.Code
+	}
+	return out
+}
+
+func TestAnalyzeSource(t *testing.T) {
+	for _, tc := range []struct {
+		code     string
+		findings []Finding
+	}{
+		{`sigVerify(tx.bodyBytes, tx.proofs[0], tx.senderPublicKey)`, nil},
+		{`true`, []Finding{
+			{SeverityWarning, CodeMissingSigVerify, "account script doesn't call sigVerify, transactions of the account are not checked for signatures", ""},
+		}},
+		{"{-# SCRIPT_TYPE ASSET #-}\ntrue", nil},
+		{"{-# SCRIPT_TYPE ASSET #-}\nlet x = 1; let y = x + 1; x > 0", []Finding{
+			{SeverityWarning, CodeUnusedLet, "variable 'y' is never used", "let y"},
+		}},
+		{"{-# SCRIPT_TYPE ASSET #-}\nif (true) then height > 0 else false", []Finding{
+			{SeverityWarning, CodeUnreachableBranch, "condition is always true", "else"},
+		}},
+		{"{-# STDLIB_VERSION 3 #-}\n{-# SCRIPT_TYPE ASSET #-}\nfunc f(a: Int) = a + 1; func g() = 1; f(1) == 2", []Finding{
+			{SeverityWarning, CodeUnusedFunction, "function 'g' is never called", "func g"},
+		}},
+		{`match tx {
+  case t: TransferTransaction => sigVerify(t.bodyBytes, t.proofs[0], t.senderPublicKey) && t.amount > 0
+  case o: Order => true
+  case _ => false
+}`, []Finding{
+			{SeverityWarning, CodeUnusedLet, "variable 'o' is never used", "else > then > let o"},
+		}},
+		{`{-# SCRIPT_TYPE ASSET #-}
+match tx {
+  case t: TransferTransaction | MassTransferTransaction => true
+  case _ => false
+}`, []Finding{
+			{SeverityWarning, CodeUnusedLet, "variable 't' is never used", "then > let t"},
+		}},
+	} {
+		assert.Equal(t, tc.findings, analyzeSource(t, tc.code), tc.code)
+	}
+}
+
+func TestAnalyzeMatch(t *testing.T) {
+	// Matching of the narrowed value: the second check of the transfer is never true
+	m := &ast.RefExpr{Name: "$match0"}
+	isTransfer := nativeCall(nativeIsInstanceOf, m, ast.NewString("TransferTransaction"))
+	isOrder := nativeCall(nativeIsInstanceOf, m, ast.NewString("Order"))
+	e := &ast.Block{
+		Let:  ast.NewLet("$match0", &ast.RefExpr{Name: "tx"}),
+		Body: ast.NewIf(isTransfer, ast.NewBoolean(true), ast.NewIf(isTransfer, ast.NewBoolean(false), ast.NewGetterExpr(m, "amount"))),
+	}
+	findings := Analyze(&ast.Script{Version: 2, Verifier: e}, "ASSET")
+	assert.Equal(t, []string{CodeUnreachableBranch, CodeUnknownField, CodeTypeMismatch}, codes(findings))
+	assert.Equal(t, "else > then", findings[0].Path)
+	assert.Equal(t, "else > else", findings[1].Path)
+
+	// Orders are not checked by asset scripts, while account scripts do
+	e = &ast.Block{Let: ast.NewLet("$match0", &ast.RefExpr{Name: "tx"}), Body: ast.NewIf(isOrder, ast.NewBoolean(true), ast.NewBoolean(false))}
+	findings = Analyze(&ast.Script{Version: 1, Verifier: e}, "ASSET")
+	assert.Equal(t, []Finding{{SeverityWarning, CodeUnreachableBranch, "value of type BurnTransaction|CreateAliasTransaction|DataTransaction|ExchangeTransaction|IssueTransaction|LeaseCancelTransaction|LeaseTransaction|MassTransferTransaction|PaymentTransaction|ReissueTransaction|SetScriptTransaction|SponsorFeeTransaction|TransferTransaction is never an instance of Order", "then"}}, findings)
+
+	// Exhaustive match ends with throw, which is not reported
+	e = &ast.Block{Let: ast.NewLet("$match0", ast.NewLong(1)), Body: ast.NewIf(nativeCall(nativeIsInstanceOf, m, ast.NewString("Int")), ast.NewBoolean(true), userCall("throw"))}
+	assert.Empty(t, Analyze(&ast.Script{Version: 1, Verifier: e}, "ASSET"))
+	e = &ast.Block{Let: ast.NewLet("$match0", ast.NewLong(1)), Body: ast.NewIf(nativeCall(nativeIsInstanceOf, m, ast.NewString("Int")), ast.NewBoolean(true), ast.NewBoolean(false))}
+	assert.Equal(t, []string{CodeUnreachableBranch}, codes(Analyze(&ast.Script{Version: 1, Verifier: e}, "ASSET")))
+}
+
+func TestAnalyzeRuntimeErrors(t *testing.T) {
+	for _, tc := range []struct {
+		version  int
+		expr     ast.Expr
+		findings []Finding
+	}{
+		{1, nativeCall(nativeEq, ast.NewLong(1), ast.NewString("1")), []Finding{
+			{SeverityError, CodeTypeMismatch, "values of types Int and String are never equal", ""},
+		}},
+		{1, userCall("!=", ast.NewBytes(nil), ast.NewBoolean(true)), []Finding{
+			{SeverityError, CodeTypeMismatch, "values of types ByteVector and Boolean are always different", ""},
+		}},
+		{1, nativeCall(102, ast.NewGetterExpr(&ast.RefExpr{Name: "tx"}, "amount"), ast.NewLong(0)), []Finding{
+			{SeverityError, CodeUnknownField, "value of type BurnTransaction|CreateAliasTransaction|DataTransaction|IssueTransaction|LeaseCancelTransaction|MassTransferTransaction|ReissueTransaction|SetScriptTransaction|SponsorFeeTransaction has no field 'amount'", "'>' arg 1"},
+		}},
+		{1, nativeCall(nativeEq, &ast.RefExpr{Name: "lastBlock"}, ast.NewLong(0)), []Finding{
+			{SeverityError, CodeUndefinedReference, "undefined variable 'lastBlock'", "'==' arg 1"},
+		}},
+		{2, nativeCall(102, nativeCall(108, ast.NewLong(2), ast.NewLong(0), ast.NewLong(2), ast.NewLong(0), ast.NewLong(0), &ast.RefExpr{Name: "DOWN"}), ast.NewLong(3)), []Finding{
+			{SeverityError, CodeUndefinedReference, "undefined variable 'DOWN'", "'>' arg 1 > 'pow' arg 6"},
+			{SeverityError, CodeUnavailableFunction, "function 'pow' is not available in version 2", "'>' arg 1"},
+		}},
+		{3, nativeCall(102, nativeCall(108, ast.NewLong(2), ast.NewLong(0), ast.NewLong(2), ast.NewLong(0), ast.NewLong(0), &ast.RefExpr{Name: "DOWN"}), ast.NewLong(3)), nil},
+		{3, nativeCall(102, nativeCall(1201, ast.NewString("1")), ast.NewLong(3)), []Finding{
+			{SeverityError, CodeTypeMismatch, "function 'toInt' can't be called with arguments of types (String)", "'>' arg 1"},
+		}},
+		{3, userCall("f"), []Finding{
+			{SeverityError, CodeUndefinedReference, "undefined function 'f'", ""},
+		}},
+		{1, nativeCall(9999), []Finding{
+			{SeverityWarning, CodeUnknownFunction, "unknown native function 9999", ""},
+		}},
+		{1, ast.NewIf(ast.NewLong(1), ast.NewBoolean(true), ast.NewLong(1)), []Finding{
+			{SeverityError, CodeTypeMismatch, "condition should be Boolean, found Int", "if"},
+			{SeverityError, CodeTypeMismatch, "script should return Boolean, found Boolean|Int", ""},
+		}},
+		{2, &ast.FuncBlock{Func: ast.NewFuncDeclaration("f", []string{"a"}, &ast.RefExpr{Name: "a"}), Body: userCall("f", ast.NewLong(1), ast.NewLong(2))}, []Finding{
+			{SeverityError, CodeUnavailableFunction, "declaration blocks are not supported in version 2", ""},
+			{SeverityError, CodeTypeMismatch, "function 'f' requires 1 arguments, but 2 are provided", ""},
+		}},
+		// Parameters of functions have unknown types and are not checked
+		{3, &ast.FuncBlock{Func: ast.NewFuncDeclaration("f", []string{"a"}, ast.NewGetterExpr(&ast.RefExpr{Name: "a"}, "x")), Body: userCall("f", ast.NewLong(1))}, nil},
+	} {
+		assert.Equal(t, tc.findings, Analyze(&ast.Script{Version: tc.version, Verifier: tc.expr}, "ASSET"))
+	}
+}
+
+func TestAnalyzeCompiledScript(t *testing.T) {
+	// Compiled by the reference implementation:
+	// let x = 5; 6 > 4
+	r, err := reader.NewReaderFromBase64("AQQAAAABeAAAAAAAAAAABQkAAGYAAAACAAAAAAAAAAAGAAAAAAAAAAAEYSW6XA==")
+	require.NoError(t, err)
+	script, err := astparser.BuildScript(r)
+	require.NoError(t, err)
+	assert.Equal(t, []string{CodeUnusedLet, CodeMissingSigVerify}, codes(Analyze(script, "ACCOUNT")))
+}
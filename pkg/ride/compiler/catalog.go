@@ -0,0 +1,395 @@
+package compiler
+
+func simple(name string) SimpleType {
+	return SimpleType{Name: name}
+}
+
+var (
+	address        = simple("Address")
+	alias          = simple("Alias")
+	addressOrAlias = union(address, alias)
+	optionalBytes  = union(ByteVector, Unit)
+	dataEntry      = simple("DataEntry")
+	transfer       = simple("Transfer")
+	order          = simple("Order")
+	orderType      = union(simple("Buy"), simple("Sell"))
+)
+
+// caseObject is a global variable of version 3 holding the instance of the object type without fields.
+type caseObject struct {
+	variable string
+	typeName string
+}
+
+var (
+	roundingModes = []caseObject{
+		{"UP", "Up"}, {"DOWN", "Down"}, {"CEILING", "Ceiling"}, {"FLOOR", "Floor"},
+		{"HALFUP", "HalfUp"}, {"HALFDOWN", "HalfDown"}, {"HALFEVEN", "HalfEven"},
+	}
+	digestAlgorithms = []caseObject{
+		{"NOALG", "NoAlg"}, {"MD5", "Md5"}, {"SHA1", "Sha1"}, {"SHA224", "Sha224"}, {"SHA256", "Sha256"},
+		{"SHA384", "Sha384"}, {"SHA512", "Sha512"}, {"SHA3224", "Sha3224"}, {"SHA3256", "Sha3256"},
+		{"SHA3384", "Sha3384"}, {"SHA3512", "Sha3512"},
+	}
+	rounding        = caseObjectsUnion(roundingModes)
+	digestAlgorithm = caseObjectsUnion(digestAlgorithms)
+)
+
+func caseObjectsUnion(objects []caseObject) Type {
+	types := make([]Type, len(objects))
+	for i, o := range objects {
+		types[i] = simple(o.typeName)
+	}
+	return union(types...)
+}
+
+func init() {
+	for _, objects := range [][]caseObject{roundingModes, digestAlgorithms} {
+		for _, o := range objects {
+			objectFields[o.typeName] = map[string]Type{}
+		}
+	}
+}
+
+func header(fields map[string]Type) map[string]Type {
+	out := map[string]Type{
+		"id":              ByteVector,
+		"fee":             Int,
+		"timestamp":       Int,
+		"version":         Int,
+		"sender":          address,
+		"senderPublicKey": ByteVector,
+		"bodyBytes":       ByteVector,
+		"proofs":          ListType{Elem: ByteVector},
+	}
+	for k, v := range fields {
+		out[k] = v
+	}
+	return out
+}
+
+// objectFields describes fields of object types, the same fields are set by the evaluator.
+var objectFields = map[string]map[string]Type{
+	"Address": {"bytes": ByteVector},
+	"Alias":   {"alias": String},
+	"Buy":     {},
+	"Sell":    {},
+	"GenesisTransaction": {
+		"id":        ByteVector,
+		"fee":       Int,
+		"timestamp": Int,
+		"version":   Int,
+		"amount":    Int,
+		"recipient": addressOrAlias,
+	},
+	"PaymentTransaction": header(map[string]Type{
+		"amount":    Int,
+		"recipient": addressOrAlias,
+	}),
+	"IssueTransaction": header(map[string]Type{
+		"quantity":    Int,
+		"name":        ByteVector,
+		"description": ByteVector,
+		"reissuable":  Boolean,
+		"decimals":    Int,
+		"script":      optionalBytes,
+	}),
+	"TransferTransaction": header(map[string]Type{
+		"feeAssetId": optionalBytes,
+		"amount":     Int,
+		"assetId":    optionalBytes,
+		"recipient":  addressOrAlias,
+		"attachment": ByteVector,
+	}),
+	"ReissueTransaction": header(map[string]Type{
+		"quantity":   Int,
+		"assetId":    ByteVector,
+		"reissuable": Boolean,
+	}),
+	"BurnTransaction": header(map[string]Type{
+		"quantity": Int,
+		"assetId":  ByteVector,
+	}),
+	"ExchangeTransaction": header(map[string]Type{
+		"buyOrder":       order,
+		"sellOrder":      order,
+		"price":          Int,
+		"amount":         Int,
+		"buyMatcherFee":  Int,
+		"sellMatcherFee": Int,
+	}),
+	"LeaseTransaction": header(map[string]Type{
+		"amount":    Int,
+		"recipient": addressOrAlias,
+	}),
+	"LeaseCancelTransaction": header(map[string]Type{
+		"leaseId": ByteVector,
+	}),
+	"CreateAliasTransaction": header(map[string]Type{
+		"alias": String,
+	}),
+	"MassTransferTransaction": header(map[string]Type{
+		"assetId":       optionalBytes,
+		"totalAmount":   Int,
+		"transfers":     ListType{Elem: transfer},
+		"transferCount": Int,
+		"attachment":    ByteVector,
+	}),
+	"DataTransaction": header(map[string]Type{
+		"data": ListType{Elem: dataEntry},
+	}),
+	"SetScriptTransaction": header(map[string]Type{
+		"script": optionalBytes,
+	}),
+	"SponsorFeeTransaction": header(map[string]Type{
+		"assetId":              ByteVector,
+		"minSponsoredAssetFee": union(Int, Unit),
+	}),
+	"SetAssetScriptTransaction": header(map[string]Type{
+		"script":  optionalBytes,
+		"assetId": ByteVector,
+	}),
+	"InvokeScriptTransaction": header(map[string]Type{
+		"dApp":       addressOrAlias,
+		"payment":    union(simple("AttachedPayment"), Unit),
+		"feeAssetId": optionalBytes,
+		"function":   String,
+		"args":       ListType{Elem: union(Int, Boolean, ByteVector, String)},
+	}),
+	"Order": {
+		"id":               ByteVector,
+		"sender":           address,
+		"senderPublicKey":  ByteVector,
+		"matcherPublicKey": ByteVector,
+		"assetPair":        simple("AssetPair"),
+		"orderType":        orderType,
+		"price":            Int,
+		"amount":           Int,
+		"timestamp":        Int,
+		"expiration":       Int,
+		"matcherFee":       Int,
+		"bodyBytes":        ByteVector,
+		"proofs":           ListType{Elem: ByteVector},
+	},
+	"AssetPair": {
+		"amountAsset": optionalBytes,
+		"priceAsset":  optionalBytes,
+	},
+	"Transfer": {
+		"recipient": addressOrAlias,
+		"amount":    Int,
+	},
+	"AttachedPayment": {
+		"assetId": optionalBytes,
+		"amount":  Int,
+	},
+	"DataEntry": {
+		"key":   String,
+		"value": union(Int, Boolean, ByteVector, String),
+	},
+	"Asset": {
+		"id":              ByteVector,
+		"quantity":        Int,
+		"decimals":        Int,
+		"issuer":          address,
+		"issuerPublicKey": ByteVector,
+		"reissuable":      Boolean,
+		"scripted":        Boolean,
+		"sponsored":       Boolean,
+	},
+	"BlockInfo": {
+		"timestamp":           Int,
+		"height":              Int,
+		"baseTarget":          Int,
+		"generationSignature": ByteVector,
+		"generator":           address,
+		"generatorPublicKey":  ByteVector,
+	},
+}
+
+var transactionsV1 = []Type{
+	simple("GenesisTransaction"),
+	simple("PaymentTransaction"),
+	simple("IssueTransaction"),
+	simple("TransferTransaction"),
+	simple("ReissueTransaction"),
+	simple("BurnTransaction"),
+	simple("ExchangeTransaction"),
+	simple("LeaseTransaction"),
+	simple("LeaseCancelTransaction"),
+	simple("CreateAliasTransaction"),
+	simple("MassTransferTransaction"),
+	simple("DataTransaction"),
+	simple("SetScriptTransaction"),
+	simple("SponsorFeeTransaction"),
+}
+
+// transactionType is the type of any transaction, including genesis.
+func transactionType(version int) Type {
+	types := append([]Type{}, transactionsV1...)
+	if version >= 3 {
+		types = append(types, simple("SetAssetScriptTransaction"), simple("InvokeScriptTransaction"))
+	}
+	return union(types...)
+}
+
+// outgoingTransactionType is the type of transactions which could be sent from an account, scripts verify only them.
+func outgoingTransactionType(version int) Type {
+	return without(transactionType(version), simple("GenesisTransaction"))
+}
+
+// knownType resolves type by its name in the source code.
+func knownType(name string) (Type, bool) {
+	switch name {
+	case "Int", "Boolean", "ByteVector", "String", "Unit", "Nothing":
+		return simple(name), true
+	}
+	if _, ok := objectFields[name]; ok {
+		return simple(name), true
+	}
+	return nil, false
+}
+
+// globals returns types of global variables available to scripts of given version and type.
+func globals(version int, scriptType string) map[string]Type {
+	tx := outgoingTransactionType(version)
+	if scriptType == "ACCOUNT" {
+		tx = union(tx, order)
+	}
+	out := map[string]Type{
+		"height": Int,
+		"tx":     tx,
+		"unit":   Unit,
+		"Buy":    simple("Buy"),
+		"Sell":   simple("Sell"),
+	}
+	if version >= 3 {
+		out["lastBlock"] = simple("BlockInfo")
+		out["nil"] = ListType{Elem: Nothing}
+		for _, objects := range [][]caseObject{roundingModes, digestAlgorithms} {
+			for _, o := range objects {
+				out[o.variable] = simple(o.typeName)
+			}
+		}
+		if scriptType == "ACCOUNT" {
+			out["this"] = address
+		}
+	}
+	return out
+}
+
+// function is an overload of the function, implemented either by native function with id or user function with name.
+type function struct {
+	params     []Type
+	result     Type
+	native     int16
+	user       string
+	minVersion int
+}
+
+func native(id int16, result Type, params ...Type) function {
+	return function{params: params, result: result, native: id, minVersion: 1}
+}
+
+func user(name string, result Type, params ...Type) function {
+	return function{params: params, result: result, native: -1, user: name, minVersion: 1}
+}
+
+func since(version int, f function) function {
+	f.minVersion = version
+	return f
+}
+
+const (
+	nativeEq           int16 = 0
+	nativeIsInstanceOf int16 = 1
+	nativeThrow        int16 = 2
+	nativeGetList      int16 = 401
+	nativeCons         int16 = 1100
+)
+
+var functions = map[string][]function{
+	"==":       {native(nativeEq, Boolean, T, T)},
+	"!=":       {user("!=", Boolean, T, T)},
+	"+":        {native(100, Int, Int, Int), native(203, ByteVector, ByteVector, ByteVector), native(300, String, String, String)},
+	"-":        {native(101, Int, Int, Int)},
+	">":        {native(102, Boolean, Int, Int)},
+	">=":       {native(103, Boolean, Int, Int)},
+	"*":        {native(104, Int, Int, Int)},
+	"/":        {native(105, Int, Int, Int)},
+	"%":        {native(106, Int, Int, Int)},
+	"unary !":  {user("!", Boolean, Boolean)},
+	"unary -":  {user("-", Int, Int)},
+	"fraction": {native(107, Int, Int, Int, Int)},
+	"pow":      {since(3, native(108, Int, Int, Int, Int, Int, Int, rounding))},
+	"log":      {since(3, native(109, Int, Int, Int, Int, Int, Int, rounding))},
+
+	"size":      {native(200, Int, ByteVector), native(305, Int, String), native(400, Int, ListType{Elem: T})},
+	"take":      {native(201, ByteVector, ByteVector, Int), native(303, String, String, Int)},
+	"drop":      {native(202, ByteVector, ByteVector, Int), native(304, String, String, Int)},
+	"takeRight": {user("takeRightBytes", ByteVector, ByteVector, Int), user("takeRight", String, String, Int)},
+	"dropRight": {user("dropRightBytes", ByteVector, ByteVector, Int), user("dropRight", String, String, Int)},
+
+	"getElement": {native(nativeGetList, T, ListType{Elem: T}, Int)},
+	"toBytes":    {native(410, ByteVector, Int), native(411, ByteVector, String), native(412, ByteVector, Boolean)},
+	"toString":   {native(420, String, Int), native(421, String, Boolean)},
+
+	"sigVerify":  {native(500, Boolean, ByteVector, ByteVector, ByteVector)},
+	"keccak256":  {native(501, ByteVector, ByteVector)},
+	"blake2b256": {native(502, ByteVector, ByteVector)},
+	"sha256":     {native(503, ByteVector, ByteVector)},
+	"rsaVerify":  {since(3, native(504, Boolean, digestAlgorithm, ByteVector, ByteVector, ByteVector))},
+
+	"checkMerkleProof": {since(3, native(700, Boolean, ByteVector, ByteVector, ByteVector))},
+
+	"toBase58String":   {native(600, String, ByteVector)},
+	"fromBase58String": {native(601, ByteVector, String)},
+	"toBase64String":   {native(602, String, ByteVector)},
+	"fromBase64String": {native(603, ByteVector, String)},
+
+	"transactionById":         {native(1000, nil, ByteVector)}, // result depends on version
+	"transactionHeightById":   {native(1001, union(Int, Unit), ByteVector)},
+	"assetBalance":            {native(1003, Int, addressOrAlias, optionalBytes)},
+	"wavesBalance":            {user("wavesBalance", Int, addressOrAlias)},
+	"assetInfo":               {since(3, native(1004, union(simple("Asset"), Unit), ByteVector))},
+	"blockInfoByHeight":       {since(3, native(1005, union(simple("BlockInfo"), Unit), Int))},
+	"transferTransactionById": {since(3, native(1006, union(simple("TransferTransaction"), Unit), ByteVector))},
+
+	"getInteger": {
+		native(1040, union(Int, Unit), ListType{Elem: dataEntry}, String),
+		user("getInteger", union(Int, Unit), ListType{Elem: dataEntry}, Int),
+		native(1050, union(Int, Unit), addressOrAlias, String),
+	},
+	"getBoolean": {
+		native(1041, union(Boolean, Unit), ListType{Elem: dataEntry}, String),
+		user("getBoolean", union(Boolean, Unit), ListType{Elem: dataEntry}, Int),
+		native(1051, union(Boolean, Unit), addressOrAlias, String),
+	},
+	"getBinary": {
+		native(1042, optionalBytes, ListType{Elem: dataEntry}, String),
+		user("getBinary", optionalBytes, ListType{Elem: dataEntry}, Int),
+		native(1052, optionalBytes, addressOrAlias, String),
+	},
+	"getString": {
+		native(1043, union(String, Unit), ListType{Elem: dataEntry}, String),
+		user("getString", union(String, Unit), ListType{Elem: dataEntry}, Int),
+		native(1053, union(String, Unit), addressOrAlias, String),
+	},
+
+	"toUtf8String":  {since(3, native(1200, String, ByteVector))},
+	"toInt":         {since(3, native(1201, Int, ByteVector)), since(3, native(1202, Int, ByteVector, Int))},
+	"indexOf":       {since(3, native(1203, union(Int, Unit), String, String)), since(3, native(1204, union(Int, Unit), String, String, Int))},
+	"split":         {since(3, native(1205, ListType{Elem: String}, String, String))},
+	"parseInt":      {since(3, native(1206, union(Int, Unit), String))},
+	"parseIntValue": {since(3, user("parseIntValue", Int, String))},
+
+	"addressFromRecipient": {native(1060, address, addressOrAlias)},
+	"addressFromString":    {user("addressFromString", union(address, Unit), String)},
+	"addressFromPublicKey": {user("addressFromPublicKey", address, ByteVector)},
+	"Address":              {user("Address", address, ByteVector)},
+	"Alias":                {user("Alias", alias, String)},
+
+	"throw":     {user("throw", Nothing), native(nativeThrow, Nothing, String)},
+	"isDefined": {user("isDefined", Boolean, union(T, Unit))},
+	"extract":   {user("extract", T, union(T, Unit))},
+}
@@ -0,0 +1,479 @@
+package compiler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/ast"
+)
+
+type userFunction struct {
+	params []Type
+	result Type
+}
+
+type scope struct {
+	parent *scope
+	vars   map[string]Type
+	funcs  map[string]userFunction
+}
+
+func newScope(parent *scope) *scope {
+	return &scope{parent: parent, vars: make(map[string]Type), funcs: make(map[string]userFunction)}
+}
+
+func (s *scope) variable(name string) (Type, bool) {
+	for c := s; c != nil; c = c.parent {
+		if t, ok := c.vars[name]; ok {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+func (s *scope) function(name string) (userFunction, bool) {
+	for c := s; c != nil; c = c.parent {
+		if f, ok := c.funcs[name]; ok {
+			return f, true
+		}
+	}
+	return userFunction{}, false
+}
+
+// checker verifies types of the syntax tree and lowers it to the evaluator expressions.
+type checker struct {
+	version    int
+	scriptType string
+	matches    int
+}
+
+func (c *checker) resolveType(r typeRef) (Type, error) {
+	if r.or != nil {
+		types := make([]Type, len(r.or))
+		for i, a := range r.or {
+			t, err := c.resolveType(a)
+			if err != nil {
+				return nil, err
+			}
+			types[i] = t
+		}
+		return union(types...), nil
+	}
+	if r.list != nil {
+		elem, err := c.resolveType(*r.list)
+		if err != nil {
+			return nil, err
+		}
+		return ListType{Elem: elem}, nil
+	}
+	t, ok := knownType(r.name)
+	if !ok {
+		return nil, newError(r.pos, "undefined type '%s'", r.name)
+	}
+	return t, nil
+}
+
+func (c *checker) check(n node, s *scope) (ast.Expr, Type, error) {
+	switch v := n.(type) {
+	case *longNode:
+		return ast.NewLong(v.value), Int, nil
+	case *stringNode:
+		return ast.NewString(v.value), String, nil
+	case *bytesNode:
+		return ast.NewBytes(v.value), ByteVector, nil
+	case *booleanNode:
+		return ast.NewBoolean(v.value), Boolean, nil
+	case *refNode:
+		t, ok := s.variable(v.name)
+		if !ok {
+			return nil, nil, newError(v.pos, "undefined variable '%s'", v.name)
+		}
+		return &ast.RefExpr{Name: v.name}, t, nil
+	case *listNode:
+		return c.checkList(v, s)
+	case *getterNode:
+		return c.checkGetter(v, s)
+	case *indexNode:
+		return c.checkCall(v.pos, "getElement", []node{v.list, v.index}, s)
+	case *callNode:
+		return c.checkCall(v.pos, v.name, v.args, s)
+	case *binaryNode:
+		return c.checkBinary(v, s)
+	case *unaryNode:
+		return c.checkCall(v.pos, "unary "+v.op, []node{v.operand}, s)
+	case *ifNode:
+		return c.checkIf(v, s)
+	case *letNode:
+		return c.checkLet(v, s)
+	case *funcNode:
+		return c.checkFunc(v, s)
+	case *matchNode:
+		return c.checkMatch(v, s)
+	default:
+		return nil, nil, newError(n.position(), "unsupported expression")
+	}
+}
+
+func (c *checker) checkList(n *listNode, s *scope) (ast.Expr, Type, error) {
+	if c.version < 3 {
+		return nil, nil, newError(n.pos, "lists are supported since version 3")
+	}
+	var out ast.Expr = &ast.RefExpr{Name: "nil"}
+	types := make([]Type, len(n.items))
+	exprs := make([]ast.Expr, len(n.items))
+	for i, item := range n.items {
+		e, t, err := c.check(item, s)
+		if err != nil {
+			return nil, nil, err
+		}
+		exprs[i], types[i] = e, t
+	}
+	for i := len(exprs) - 1; i >= 0; i-- {
+		out = nativeCall(nativeCons, exprs[i], out)
+	}
+	return out, ListType{Elem: union(types...)}, nil
+}
+
+func (c *checker) checkGetter(n *getterNode, s *scope) (ast.Expr, Type, error) {
+	obj, t, err := c.check(n.object, s)
+	if err != nil {
+		return nil, nil, err
+	}
+	var types []Type
+	for _, a := range alternatives(t) {
+		fields, ok := objectFields[a.String()]
+		if !ok {
+			return nil, nil, newError(n.pos, "type %s has no fields", a)
+		}
+		ft, ok := fields[n.field]
+		if !ok {
+			return nil, nil, newError(n.pos, "undefined field '%s' of type %s", n.field, t)
+		}
+		types = append(types, ft)
+	}
+	return ast.NewGetterExpr(obj, n.field), union(types...), nil
+}
+
+func nativeCall(id int16, args ...ast.Expr) ast.Expr {
+	return ast.NewFuncCall(ast.NewNativeFunction(id, len(args), args))
+}
+
+func userCall(name string, args ...ast.Expr) ast.Expr {
+	return ast.NewFuncCall(ast.NewUserFunction(name, len(args), args))
+}
+
+func typeNames(types []Type) string {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = t.String()
+	}
+	return strings.Join(names, ", ")
+}
+
+// match checks arguments against the overload and returns the type of the result.
+func (c *checker) match(f function, args []Type) (Type, bool) {
+	if len(f.params) != len(args) || c.version < f.minVersion {
+		return nil, false
+	}
+	var bound Type = Nothing
+	generic := false
+	for i, p := range f.params {
+		if !hasTypeParam(p) {
+			continue
+		}
+		generic = true
+		t, ok := infer(p, args[i])
+		if !ok {
+			return nil, false
+		}
+		bound = union(bound, t)
+	}
+	for i, p := range f.params {
+		if generic {
+			p = bind(p, bound)
+		}
+		if !assignable(p, args[i]) {
+			return nil, false
+		}
+	}
+	result := f.result
+	if result == nil {
+		result = union(transactionType(c.version), Unit)
+	}
+	if generic {
+		result = bind(result, bound)
+	}
+	return result, true
+}
+
+func (c *checker) checkCall(pos Position, name string, argNodes []node, s *scope) (ast.Expr, Type, error) {
+	args := make([]ast.Expr, len(argNodes))
+	types := make([]Type, len(argNodes))
+	for i, a := range argNodes {
+		e, t, err := c.check(a, s)
+		if err != nil {
+			return nil, nil, err
+		}
+		args[i], types[i] = e, t
+	}
+	if f, ok := s.function(name); ok {
+		if len(f.params) != len(args) {
+			return nil, nil, newError(pos, "function '%s' requires %d arguments, but %d are provided", name, len(f.params), len(args))
+		}
+		for i, p := range f.params {
+			if !assignable(p, types[i]) {
+				return nil, nil, newError(argNodes[i].position(), "argument %d of function '%s' should be of type %s, found %s", i+1, name, p, types[i])
+			}
+		}
+		return userCall(name, args...), f.result, nil
+	}
+	overloads, ok := functions[name]
+	if !ok {
+		return nil, nil, newError(pos, "undefined function '%s'", strings.TrimPrefix(name, "unary "))
+	}
+	for _, f := range overloads {
+		result, ok := c.match(f, types)
+		if !ok {
+			continue
+		}
+		if f.native >= 0 {
+			return nativeCall(f.native, args...), result, nil
+		}
+		return userCall(f.user, args...), result, nil
+	}
+	return nil, nil, newError(pos, "can't find a function overload '%s'(%s)", strings.TrimPrefix(name, "unary "), typeNames(types))
+}
+
+func comparableTypes(a, b Type) bool {
+	if a == Nothing || b == Nothing {
+		return true
+	}
+	for _, x := range alternatives(a) {
+		for _, y := range alternatives(b) {
+			if assignableSingle(x, y) || assignableSingle(y, x) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *checker) checkBinary(n *binaryNode, s *scope) (ast.Expr, Type, error) {
+	switch n.op {
+	case "&&", "||":
+		left, lt, err := c.check(n.left, s)
+		if err != nil {
+			return nil, nil, err
+		}
+		right, rt, err := c.check(n.right, s)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !assignable(Boolean, lt) {
+			return nil, nil, newError(n.left.position(), "operand of '%s' should be Boolean, found %s", n.op, lt)
+		}
+		if !assignable(Boolean, rt) {
+			return nil, nil, newError(n.right.position(), "operand of '%s' should be Boolean, found %s", n.op, rt)
+		}
+		if n.op == "&&" {
+			return ast.NewIf(left, right, ast.NewBoolean(false)), Boolean, nil
+		}
+		return ast.NewIf(left, ast.NewBoolean(true), right), Boolean, nil
+	case "==", "!=":
+		left, lt, err := c.check(n.left, s)
+		if err != nil {
+			return nil, nil, err
+		}
+		right, rt, err := c.check(n.right, s)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !comparableTypes(lt, rt) {
+			return nil, nil, newError(n.pos, "can't compare values of types %s and %s", lt, rt)
+		}
+		if n.op == "==" {
+			return nativeCall(nativeEq, left, right), Boolean, nil
+		}
+		return userCall("!=", left, right), Boolean, nil
+	case "<":
+		return c.checkCall(n.pos, ">", []node{n.right, n.left}, s)
+	case "<=":
+		return c.checkCall(n.pos, ">=", []node{n.right, n.left}, s)
+	default:
+		return c.checkCall(n.pos, n.op, []node{n.left, n.right}, s)
+	}
+}
+
+func (c *checker) checkIf(n *ifNode, s *scope) (ast.Expr, Type, error) {
+	cond, ct, err := c.check(n.condition, s)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !assignable(Boolean, ct) {
+		return nil, nil, newError(n.condition.position(), "condition should be Boolean, found %s", ct)
+	}
+	ifTrue, tt, err := c.check(n.ifTrue, s)
+	if err != nil {
+		return nil, nil, err
+	}
+	ifFalse, ft, err := c.check(n.ifFalse, s)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ast.NewIf(cond, ifTrue, ifFalse), union(tt, ft), nil
+}
+
+func (c *checker) declare(pos Position, name string, s *scope) error {
+	if _, ok := s.variable(name); ok {
+		return newError(pos, "value '%s' already defined in the scope", name)
+	}
+	return nil
+}
+
+func (c *checker) checkLet(n *letNode, s *scope) (ast.Expr, Type, error) {
+	if err := c.declare(n.pos, n.name, s); err != nil {
+		return nil, nil, err
+	}
+	value, vt, err := c.check(n.value, s)
+	if err != nil {
+		return nil, nil, err
+	}
+	inner := newScope(s)
+	inner.vars[n.name] = vt
+	body, bt, err := c.check(n.body, inner)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &ast.Block{Let: ast.NewLet(n.name, value), Body: body}, bt, nil
+}
+
+func (c *checker) checkFunc(n *funcNode, s *scope) (ast.Expr, Type, error) {
+	if c.version < 3 {
+		return nil, nil, newError(n.pos, "user functions are supported since version 3")
+	}
+	if _, ok := s.function(n.name); ok {
+		return nil, nil, newError(n.pos, "function '%s' already defined in the scope", n.name)
+	}
+	fs := newScope(s)
+	names := make([]string, len(n.params))
+	params := make([]Type, len(n.params))
+	for i, p := range n.params {
+		if _, ok := fs.vars[p.name]; ok {
+			return nil, nil, newError(n.pos, "duplicate parameter '%s' of function '%s'", p.name, n.name)
+		}
+		t, err := c.resolveType(p.typ)
+		if err != nil {
+			return nil, nil, err
+		}
+		fs.vars[p.name] = t
+		names[i], params[i] = p.name, t
+	}
+	value, vt, err := c.check(n.value, fs)
+	if err != nil {
+		return nil, nil, err
+	}
+	inner := newScope(s)
+	inner.funcs[n.name] = userFunction{params: params, result: vt}
+	body, bt, err := c.check(n.body, inner)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &ast.FuncBlock{Func: ast.NewFuncDeclaration(n.name, names, value), Body: body}, bt, nil
+}
+
+// isInstanceOf builds condition that the value is an instance of one of the types.
+func isInstanceOf(ref string, types []Type) ast.Expr {
+	var cond ast.Expr
+	for _, t := range types {
+		check := nativeCall(nativeIsInstanceOf, &ast.RefExpr{Name: ref}, ast.NewString(t.String()))
+		if cond == nil {
+			cond = check
+			continue
+		}
+		cond = ast.NewIf(check, ast.NewBoolean(true), cond)
+	}
+	return cond
+}
+
+func (c *checker) checkMatch(n *matchNode, s *scope) (ast.Expr, Type, error) {
+	value, vt, err := c.check(n.value, s)
+	if err != nil {
+		return nil, nil, err
+	}
+	ref := fmt.Sprintf("$match%d", c.matches)
+	c.matches++
+	ms := newScope(s)
+	ms.vars[ref] = vt
+
+	type compiledCase struct {
+		types []Type
+		body  ast.Expr
+	}
+	var (
+		cases      []compiledCase
+		resultType Type = Nothing
+		rest            = vt
+		hasDefault bool
+	)
+	for _, mc := range n.cases {
+		if hasDefault {
+			return nil, nil, newError(mc.pos, "unreachable case after default case")
+		}
+		var types []Type
+		for _, r := range mc.types {
+			t, err := c.resolveType(r)
+			if err != nil {
+				return nil, nil, err
+			}
+			if _, ok := t.(ListType); ok {
+				return nil, nil, newError(r.pos, "matching on type %s is not supported", t)
+			}
+			for _, a := range alternatives(t) {
+				if !assignable(vt, a) {
+					return nil, nil, newError(r.pos, "matching not exhaustive: possible types are %s, while matched is %s", vt, a)
+				}
+				types = append(types, a)
+			}
+		}
+		bound := union(types...)
+		if len(types) == 0 {
+			hasDefault = true
+			bound = rest
+		}
+		cs := newScope(ms)
+		if mc.name != "_" {
+			if err := c.declare(mc.pos, mc.name, cs); err != nil {
+				return nil, nil, err
+			}
+			cs.vars[mc.name] = bound
+		}
+		body, bt, err := c.check(mc.body, cs)
+		if err != nil {
+			return nil, nil, err
+		}
+		if mc.name != "_" {
+			body = &ast.Block{Let: ast.NewLet(mc.name, &ast.RefExpr{Name: ref}), Body: body}
+		}
+		resultType = union(resultType, bt)
+		for _, t := range types {
+			rest = without(rest, t)
+		}
+		cases = append(cases, compiledCase{types: types, body: body})
+	}
+	if !hasDefault && rest != Nothing {
+		return nil, nil, newError(n.pos, "matching not exhaustive: possible types are %s, while matched are %s", vt, without(vt, rest))
+	}
+
+	var out ast.Expr
+	last := len(cases) - 1
+	if hasDefault {
+		out = cases[last].body
+		last--
+	} else {
+		out = userCall("throw")
+	}
+	for i := last; i >= 0; i-- {
+		out = ast.NewIf(isInstanceOf(ref, cases[i].types), cases[i].body, out)
+	}
+	return &ast.Block{Let: ast.NewLet(ref, value), Body: out}, resultType, nil
+}
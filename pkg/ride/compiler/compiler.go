@@ -0,0 +1,64 @@
+// Package compiler translates RIDE source code of expression scripts into the binary format of scripts.
+package compiler
+
+import (
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/ast"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/serializer"
+)
+
+const (
+	minVersion = 1
+	maxVersion = 3
+)
+
+// Script is a compiled script.
+type Script struct {
+	Directives Directives
+	// Expr is the compiled expression, ready to be evaluated.
+	Expr ast.Expr
+	// Bytes is the binary representation of the script: version, expression and checksum.
+	Bytes []byte
+}
+
+// Compile compiles source code of expression script.
+func Compile(src string) (*Script, error) {
+	d, code, err := parseDirectives(src)
+	if err != nil {
+		return nil, err
+	}
+	if d.StdLibVersion < minVersion || d.StdLibVersion > maxVersion {
+		return nil, errors.Errorf("unsupported STDLIB_VERSION %d", d.StdLibVersion)
+	}
+	if d.ContentType != "EXPRESSION" {
+		return nil, errors.Errorf("unsupported CONTENT_TYPE %s, only EXPRESSION scripts could be compiled", d.ContentType)
+	}
+	if d.ScriptType != "ACCOUNT" && d.ScriptType != "ASSET" {
+		return nil, errors.Errorf("unsupported SCRIPT_TYPE %s", d.ScriptType)
+	}
+	tree, err := parse(code)
+	if err != nil {
+		return nil, err
+	}
+	c := &checker{version: d.StdLibVersion, scriptType: d.ScriptType}
+	s := newScope(nil)
+	for name, t := range globals(d.StdLibVersion, d.ScriptType) {
+		s.vars[name] = t
+	}
+	expr, t, err := c.check(tree, newScope(s))
+	if err != nil {
+		return nil, err
+	}
+	if !assignable(Boolean, t) {
+		return nil, newError(tree.position(), "script should return Boolean, found %s", t)
+	}
+	b, err := serializer.SerializeScript(&ast.Script{Version: d.StdLibVersion, Verifier: expr})
+	if err != nil {
+		return nil, err
+	}
+	return &Script{
+		Directives: d,
+		Expr:       expr,
+		Bytes:      b,
+	}, nil
+}
@@ -0,0 +1,152 @@
+package compiler
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/evaluate"
+	astparser "github.com/wavesplatform/gowaves/pkg/ride/evaluator/parser"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/reader"
+	"github.com/wavesplatform/gowaves/pkg/ride/mockstate"
+)
+
+func TestCompile(t *testing.T) {
+	for _, tc := range []struct {
+		code   string
+		base64 string
+	}{
+		{`true`, `AQa3b8tH`},
+		{`false`, `AQfeYll6`},
+		{`let x = 5; 6 > 4`, `AQQAAAABeAAAAAAAAAAABQkAAGYAAAACAAAAAAAAAAAGAAAAAAAAAAAEYSW6XA==`},
+		{`let x = 5; 6 > x`, `AQQAAAABeAAAAAAAAAAABQkAAGYAAAACAAAAAAAAAAAGBQAAAAF4Gh24hw==`},
+		{`let x = 5; 6 >= x`, `AQQAAAABeAAAAAAAAAAABQkAAGcAAAACAAAAAAAAAAAGBQAAAAF4jlxXHA==`},
+		{`let x =  throw(); true`, `AQQAAAABeAkBAAAABXRocm93AAAAAAa7bgf4`},
+		{`let x =  throw();true || x`, `AQQAAAABeAkBAAAABXRocm93AAAAAAMGBgUAAAABeKRnLds=`},
+		{`tx.id == base58''`, `AQkAAAAAAAACCAUAAAACdHgAAAACaWQBAAAAAJBtD70=`},
+		{`let x = tx.id == base58'a';true`, `AQQAAAABeAkAAAAAAAACCAUAAAACdHgAAAACaWQBAAAAASEGjR0kcA==`},
+		{`match transactionById(tx.id) {case  t: Unit => true case _ => false }`, `AQQAAAAHJG1hdGNoMAkAA+gAAAABCAUAAAACdHgAAAACaWQDCQAAAQAAAAIFAAAAByRtYXRjaDACAAAABFVuaXQEAAAAAXQFAAAAByRtYXRjaDAGB1+iIek=`},
+		{`match tx {case t : TransferTransaction => true case _  => false}`, `AQQAAAAHJG1hdGNoMAUAAAACdHgDCQAAAQAAAAIFAAAAByRtYXRjaDACAAAAE1RyYW5zZmVyVHJhbnNhY3Rpb24EAAAAAXQFAAAAByRtYXRjaDAGB5yQ/+k=`},
+		{`true && throw("mess")`, `AQMGCQAAAgAAAAECAAAABG1lc3MH7PDwAQ==`},
+		{`-10 % 6>0`, `AQkAAGYAAAACCQAAagAAAAIA//////////YAAAAAAAAAAAYAAAAAAAAAAAB5rBSH`},
+		{`fraction(10, 5, 2)>0`, `AQkAAGYAAAACCQAAawAAAAMAAAAAAAAAAAoAAAAAAAAAAAUAAAAAAAAAAAIAAAAAAAAAAACRyFu2`},
+		{`size(base58'ab' + base58'cd') > 0`, `AQkAAGYAAAACCQAAyAAAAAEJAADLAAAAAgEAAAACB5wBAAAAAggSAAAAAAAAAAAAo+LRIA==`},
+		{`"ab"+"cd" == "abcd"`, `AQkAAAAAAAACCQABLAAAAAICAAAAAmFiAgAAAAJjZAIAAAAEYWJjZMBJvls=`},
+		{`size(tx.proofs[0]) > 0`, `AQkAAGYAAAACCQAAyAAAAAEJAAGRAAAAAggFAAAAAnR4AAAABnByb29mcwAAAAAAAAAAAAAAAAAAAAAAAFF6iVo=`},
+		{`toBytes("привет") == base58'4wUjatAwfVDjaHQVX'`, `AQkAAAAAAAACCQABmwAAAAECAAAADNC/0YDQuNCy0LXRggEAAAAM0L/RgNC40LLQtdGCuUGFxw==`},
+		{`keccak256(base58'a') != base58'a'`, `AQkBAAAAAiE9AAAAAgkAAfUAAAABAQAAAAEhAQAAAAEhKeR77g==`},
+		{`assetBalance(tx.sender, base58'BXBUNddxTGTQc3G4qHYn5E67SBwMj18zLncUr871iuRD') == 5`, `AQkAAAAAAAACCQAD6wAAAAIIBQAAAAJ0eAAAAAZzZW5kZXIBAAAAIJxQIls8iGUc1935JolBz6bYc37eoPDtScOAM0lTNhY0AAAAAAAAAAAFjp6PBg==`},
+		{`match tx {case t: DataTransaction => getInteger(t.data, "integer") == 100500 case _ => false}`, `AQQAAAAHJG1hdGNoMAUAAAACdHgDCQAAAQAAAAIFAAAAByRtYXRjaDACAAAAD0RhdGFUcmFuc2FjdGlvbgQAAAABdAUAAAAHJG1hdGNoMAkAAAAAAAACCQAEEAAAAAIIBQAAAAF0AAAABGRhdGECAAAAB2ludGVnZXIAAAAAAAABiJQHp2oJqg==`},
+		{`match tx {case t : DataTransaction => getBoolean(t.data, 1) == true case _ => true}`, `AQQAAAAHJG1hdGNoMAUAAAACdHgDCQAAAQAAAAIFAAAAByRtYXRjaDACAAAAD0RhdGFUcmFuc2FjdGlvbgQAAAABdAUAAAAHJG1hdGNoMAkAAAAAAAACCQEAAAAKZ2V0Qm9vbGVhbgAAAAIIBQAAAAF0AAAABGRhdGEAAAAAAAAAAAEGBk7sdw4=`},
+		{"{-# STDLIB_VERSION 3 #-}\nlastBlock.height == height", `AwkAAAAAAAACCAUAAAAJbGFzdEJsb2NrAAAABmhlaWdodAUAAAAGaGVpZ2h0Jgl59Q==`},
+	} {
+		script, err := Compile(tc.code)
+		require.NoError(t, err, tc.code)
+		assert.Equal(t, tc.base64, base64.StdEncoding.EncodeToString(script.Bytes), tc.code)
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, tc := range []struct {
+		code  string
+		error string
+	}{
+		{`5`, "1:1: script should return Boolean, found Int"},
+		{`x == 1`, "1:1: undefined variable 'x'"},
+		{`let x = 1; let x = 2; true`, "1:12: value 'x' already defined in the scope"},
+		{`1 + "a" == 2`, "1:3: can't find a function overload '+'(Int, String)"},
+		{`if (1) then true else false`, "1:5: condition should be Boolean, found Int"},
+		{`match tx {case t: TransferTransaction => true}`, "1:1: matching not exhaustive"},
+		{`lastBlock.height > 0`, "1:1: undefined variable 'lastBlock'"},
+		{"{-# STDLIB_VERSION 4 #-}\ntrue", "unsupported STDLIB_VERSION 4"},
+		{"{-# CONTENT_TYPE DAPP #-}\ntrue", "unsupported CONTENT_TYPE DAPP, only EXPRESSION scripts could be compiled"},
+		{`"abc`, "1:1: unterminated string"},
+	} {
+		_, err := Compile(tc.code)
+		require.Error(t, err, tc.code)
+		assert.Contains(t, err.Error(), tc.error, tc.code)
+	}
+}
+
+func TestCompileAndEvaluateV3(t *testing.T) {
+	code := `{-# STDLIB_VERSION 3 #-}
+{-# CONTENT_TYPE EXPRESSION #-}
+{-# SCRIPT_TYPE ACCOUNT #-}
+# user functions and lists are available since version 3
+func sum(a: Int, b: Int) = a + b
+let values = [1, 2, 3]
+let total = sum(values[0], sum(values[1], values[2]))
+match tx {
+  case t: TransferTransaction | MassTransferTransaction => total == 6 && size(values) == 3 && this == tx.sender
+  case _ => false
+}`
+	script, err := Compile(code)
+	require.NoError(t, err)
+	assert.Equal(t, 3, script.Directives.StdLibVersion)
+
+	r, err := reader.NewReaderFromScript(script.Bytes)
+	require.NoError(t, err)
+	s, err := astparser.BuildScript(r)
+	require.NoError(t, err)
+
+	secret, public := crypto.GenerateKeyPair([]byte("test"))
+	sender, err := proto.NewAddressFromPublicKey(proto.MainNetScheme, public)
+	require.NoError(t, err)
+	recipient := proto.NewRecipientFromAddress(sender)
+	tx := proto.NewUnsignedTransferV1(public, proto.OptionalAsset{}, proto.OptionalAsset{}, 1544715621, 15, 10000, recipient, "")
+	require.NoError(t, tx.Sign(secret))
+	state := mockstate.MockStateImpl{
+		CurrentHeight: 1,
+		BlockHeaders:  map[uint64]*proto.BlockHeader{1: {GenPublicKey: public}},
+	}
+
+	rs, err := evaluate.Verify(proto.MainNetScheme, state, s, sender, tx)
+	require.NoError(t, err)
+	assert.True(t, rs)
+
+	rs, err = evaluate.Verify(proto.MainNetScheme, state, s, proto.Address{}, tx)
+	require.NoError(t, err)
+	assert.False(t, rs)
+}
+
+func TestCompileVersionFeatures(t *testing.T) {
+	_, err := Compile(`func f() = true; f()`)
+	assert.Error(t, err)
+	_, err = Compile(`size([1, 2]) == 2`)
+	assert.Error(t, err)
+	_, err = Compile("{-# STDLIB_VERSION 3 #-}\nfunc f(x: Int) = x > 0; f(1)")
+	assert.NoError(t, err)
+}
+
+func TestCompileAndEvaluateStdLibV3(t *testing.T) {
+	code := `{-# STDLIB_VERSION 3 #-}
+let parts = split("12,3456,x", ",")
+let price = pow(parseIntValue(parts[0]), 1, parseIntValue(parts[1]), 3, 2, HALFUP)
+let index = match indexOf(parts[2], "x") {
+  case i: Int => i
+  case _ => -1
+}
+price == 188 && log(625, 0, 5, 0, 0, HALFEVEN) == 4 && index == 0 && parseInt(parts[2]) == unit && toInt(toBytes(7)) == 7`
+	script, err := Compile(code)
+	require.NoError(t, err)
+
+	r, err := reader.NewReaderFromScript(script.Bytes)
+	require.NoError(t, err)
+	s, err := astparser.BuildScript(r)
+	require.NoError(t, err)
+
+	_, public := crypto.GenerateKeyPair([]byte("test"))
+	state := mockstate.MockStateImpl{
+		CurrentHeight: 1,
+		BlockHeaders:  map[uint64]*proto.BlockHeader{1: {GenPublicKey: public}},
+	}
+	rs, err := evaluate.Verify(proto.MainNetScheme, state, s, proto.Address{}, nil)
+	require.NoError(t, err)
+	assert.True(t, rs)
+
+	_, err = Compile("{-# STDLIB_VERSION 2 #-}\npow(2, 0, 2, 0, 0, DOWN) == 4")
+	assert.Error(t, err)
+}
@@ -0,0 +1,265 @@
+package compiler
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenInt
+	tokenString
+	tokenBytes
+	tokenKeyword
+	tokenPunct
+)
+
+var keywords = map[string]bool{
+	"let":   true,
+	"func":  true,
+	"match": true,
+	"case":  true,
+	"if":    true,
+	"then":  true,
+	"else":  true,
+	"true":  true,
+	"false": true,
+}
+
+// Punctuation sorted by length, so the longest one matches first.
+var punctuation = []string{
+	"==", "!=", "<=", ">=", "&&", "||", "=>",
+	"(", ")", "{", "}", "[", "]", ",", ".", ":", ";", "=", "<", ">", "+", "-", "*", "/", "%", "!", "|",
+}
+
+// Position is a line and column in the source code, both start from 1.
+type Position struct {
+	Line   int
+	Column int
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+type token struct {
+	kind  tokenKind
+	text  string
+	pos   Position
+	bytes []byte // decoded value of byte vector literals
+}
+
+func (t token) is(kind tokenKind, text string) bool {
+	return t.kind == kind && t.text == text
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of script"
+	}
+	return fmt.Sprintf("'%s'", t.text)
+}
+
+// Error is a compilation error with position in the source code.
+type Error struct {
+	Pos     Position
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Pos, e.Message)
+}
+
+func newError(pos Position, format string, args ...interface{}) *Error {
+	return &Error{Pos: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+type lexer struct {
+	src  []rune
+	pos  int
+	line int
+	col  int
+}
+
+func newLexer(src string) *lexer {
+	return &lexer{src: []rune(src), line: 1, col: 1}
+}
+
+func (l *lexer) peek(offset int) rune {
+	if l.pos+offset >= len(l.src) {
+		return 0
+	}
+	return l.src[l.pos+offset]
+}
+
+func (l *lexer) advance() rune {
+	r := l.src[l.pos]
+	l.pos++
+	if r == '\n' {
+		l.line++
+		l.col = 1
+	} else {
+		l.col++
+	}
+	return r
+}
+
+func (l *lexer) position() Position {
+	return Position{Line: l.line, Column: l.col}
+}
+
+func (l *lexer) skipSpacesAndComments() {
+	for l.pos < len(l.src) {
+		r := l.peek(0)
+		switch {
+		case unicode.IsSpace(r):
+			l.advance()
+		case r == '#':
+			for l.pos < len(l.src) && l.peek(0) != '\n' {
+				l.advance()
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) tokens() ([]token, error) {
+	var out []token
+	for {
+		t, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+		if t.kind == tokenEOF {
+			return out, nil
+		}
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipSpacesAndComments()
+	pos := l.position()
+	if l.pos >= len(l.src) {
+		return token{kind: tokenEOF, pos: pos}, nil
+	}
+	r := l.peek(0)
+	switch {
+	case unicode.IsDigit(r):
+		start := l.pos
+		for unicode.IsDigit(l.peek(0)) {
+			l.advance()
+		}
+		return token{kind: tokenInt, text: string(l.src[start:l.pos]), pos: pos}, nil
+	case r == '"':
+		return l.readString(pos)
+	case unicode.IsLetter(r) || r == '_' || r == '$':
+		start := l.pos
+		for p := l.peek(0); unicode.IsLetter(p) || unicode.IsDigit(p) || p == '_' || p == '$'; p = l.peek(0) {
+			l.advance()
+		}
+		text := string(l.src[start:l.pos])
+		if l.peek(0) == '\'' {
+			switch text {
+			case "base58", "base64", "base16":
+				return l.readBytes(text, pos)
+			}
+		}
+		if keywords[text] {
+			return token{kind: tokenKeyword, text: text, pos: pos}, nil
+		}
+		return token{kind: tokenIdent, text: text, pos: pos}, nil
+	}
+	rest := string(l.src[l.pos:minInt(l.pos+2, len(l.src))])
+	for _, p := range punctuation {
+		if strings.HasPrefix(rest, p) {
+			for range p {
+				l.advance()
+			}
+			return token{kind: tokenPunct, text: p, pos: pos}, nil
+		}
+	}
+	return token{}, newError(pos, "unexpected character '%c'", r)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func (l *lexer) readString(pos Position) (token, error) {
+	l.advance()
+	var sb strings.Builder
+	for {
+		if l.pos >= len(l.src) {
+			return token{}, newError(pos, "unterminated string literal")
+		}
+		r := l.advance()
+		switch r {
+		case '"':
+			return token{kind: tokenString, text: sb.String(), pos: pos}, nil
+		case '\\':
+			if l.pos >= len(l.src) {
+				return token{}, newError(pos, "unterminated string literal")
+			}
+			e := l.advance()
+			switch e {
+			case 'n':
+				sb.WriteRune('\n')
+			case 't':
+				sb.WriteRune('\t')
+			case 'r':
+				sb.WriteRune('\r')
+			case 'b':
+				sb.WriteRune('\b')
+			case 'f':
+				sb.WriteRune('\f')
+			case '\\', '"':
+				sb.WriteRune(e)
+			case 'u':
+				if l.pos+4 > len(l.src) {
+					return token{}, newError(pos, "invalid unicode escape sequence")
+				}
+				var code rune
+				if _, err := fmt.Sscanf(string(l.src[l.pos:l.pos+4]), "%04x", &code); err != nil {
+					return token{}, newError(pos, "invalid unicode escape sequence")
+				}
+				for i := 0; i < 4; i++ {
+					l.advance()
+				}
+				sb.WriteRune(code)
+			default:
+				return token{}, newError(pos, "unknown escape sequence '\\%c'", e)
+			}
+		default:
+			sb.WriteRune(r)
+		}
+	}
+}
+
+func (l *lexer) readBytes(encoding string, pos Position) (token, error) {
+	l.advance()
+	start := l.pos
+	for l.pos < len(l.src) && l.peek(0) != '\'' {
+		l.advance()
+	}
+	if l.pos >= len(l.src) {
+		return token{}, newError(pos, "unterminated %s literal", encoding)
+	}
+	text := string(l.src[start:l.pos])
+	l.advance()
+	b, err := decodeBytes(encoding, text)
+	if err != nil {
+		return token{}, newError(pos, "%s", errors.Wrapf(err, "invalid %s literal", encoding).Error())
+	}
+	return token{kind: tokenBytes, text: encoding + "'" + text + "'", pos: pos, bytes: b}, nil
+}
@@ -0,0 +1,641 @@
+package compiler
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mr-tron/base58/base58"
+	"github.com/pkg/errors"
+)
+
+// Nodes of the source code syntax tree.
+type (
+	node interface {
+		position() Position
+	}
+
+	longNode struct {
+		pos   Position
+		value int64
+	}
+
+	stringNode struct {
+		pos   Position
+		value string
+	}
+
+	bytesNode struct {
+		pos   Position
+		value []byte
+	}
+
+	booleanNode struct {
+		pos   Position
+		value bool
+	}
+
+	refNode struct {
+		pos  Position
+		name string
+	}
+
+	listNode struct {
+		pos   Position
+		items []node
+	}
+
+	getterNode struct {
+		pos    Position
+		object node
+		field  string
+	}
+
+	indexNode struct {
+		pos   Position
+		list  node
+		index node
+	}
+
+	callNode struct {
+		pos  Position
+		name string
+		args []node
+	}
+
+	binaryNode struct {
+		pos         Position
+		op          string
+		left, right node
+	}
+
+	unaryNode struct {
+		pos     Position
+		op      string
+		operand node
+	}
+
+	ifNode struct {
+		pos                        Position
+		condition, ifTrue, ifFalse node
+	}
+
+	letNode struct {
+		pos   Position
+		name  string
+		value node
+		body  node
+	}
+
+	funcNode struct {
+		pos    Position
+		name   string
+		params []param
+		value  node
+		body   node
+	}
+
+	matchNode struct {
+		pos   Position
+		value node
+		cases []matchCase
+	}
+)
+
+type param struct {
+	name string
+	typ  typeRef
+}
+
+type matchCase struct {
+	pos   Position
+	name  string    // bound variable name or "_"
+	types []typeRef // empty for default case
+	body  node
+}
+
+// typeRef is a reference to a type in the source code.
+type typeRef struct {
+	pos  Position
+	name string
+	list *typeRef  // element type of List[T]
+	or   []typeRef // alternatives of union type
+}
+
+func (n *longNode) position() Position    { return n.pos }
+func (n *stringNode) position() Position  { return n.pos }
+func (n *bytesNode) position() Position   { return n.pos }
+func (n *booleanNode) position() Position { return n.pos }
+func (n *refNode) position() Position     { return n.pos }
+func (n *listNode) position() Position    { return n.pos }
+func (n *getterNode) position() Position  { return n.pos }
+func (n *indexNode) position() Position   { return n.pos }
+func (n *callNode) position() Position    { return n.pos }
+func (n *binaryNode) position() Position  { return n.pos }
+func (n *unaryNode) position() Position   { return n.pos }
+func (n *ifNode) position() Position      { return n.pos }
+func (n *letNode) position() Position     { return n.pos }
+func (n *funcNode) position() Position    { return n.pos }
+func (n *matchNode) position() Position   { return n.pos }
+
+// Directives of script, like {-# STDLIB_VERSION 3 #-}.
+type Directives struct {
+	StdLibVersion int
+	ContentType   string
+	ScriptType    string
+}
+
+var directiveRegexp = regexp.MustCompile(`\{-#\s*([A-Z_]+)\s+([A-Za-z0-9_]+)\s*#-}`)
+
+// parseDirectives reads directives and replaces them with spaces to keep positions of the rest of the code.
+func parseDirectives(src string) (Directives, string, error) {
+	d := Directives{StdLibVersion: 1, ContentType: "EXPRESSION", ScriptType: "ACCOUNT"}
+	var err error
+	out := directiveRegexp.ReplaceAllStringFunc(src, func(s string) string {
+		m := directiveRegexp.FindStringSubmatch(s)
+		switch m[1] {
+		case "STDLIB_VERSION":
+			v, e := strconv.Atoi(m[2])
+			if e != nil {
+				err = errors.Errorf("invalid STDLIB_VERSION '%s'", m[2])
+			}
+			d.StdLibVersion = v
+		case "CONTENT_TYPE":
+			d.ContentType = m[2]
+		case "SCRIPT_TYPE":
+			d.ScriptType = m[2]
+		default:
+			err = errors.Errorf("unknown directive '%s'", m[1])
+		}
+		return strings.Map(func(r rune) rune {
+			if r == '\n' {
+				return r
+			}
+			return ' '
+		}, s)
+	})
+	if err != nil {
+		return Directives{}, "", err
+	}
+	return d, out, nil
+}
+
+func decodeBytes(encoding, s string) ([]byte, error) {
+	switch encoding {
+	case "base58":
+		if s == "" {
+			return []byte{}, nil
+		}
+		return base58.Decode(s)
+	case "base64":
+		return base64.StdEncoding.DecodeString(s)
+	default:
+		return hex.DecodeString(s)
+	}
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func parse(src string) (node, error) {
+	tokens, err := newLexer(src).tokens()
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	n, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, newError(t.pos, "unexpected %s", t)
+	}
+	return n, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) accept(kind tokenKind, text string) bool {
+	if p.peek().is(kind, text) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(kind tokenKind, text string) (token, error) {
+	t := p.next()
+	if !t.is(kind, text) {
+		return t, newError(t.pos, "expected '%s', found %s", text, t)
+	}
+	return t, nil
+}
+
+func (p *parser) expectIdent() (token, error) {
+	t := p.next()
+	if t.kind != tokenIdent {
+		return t, newError(t.pos, "expected identifier, found %s", t)
+	}
+	return t, nil
+}
+
+func (p *parser) parseExpr() (node, error) {
+	t := p.peek()
+	switch {
+	case t.is(tokenKeyword, "let"):
+		return p.parseLet()
+	case t.is(tokenKeyword, "func"):
+		return p.parseFunc()
+	default:
+		return p.parseOr()
+	}
+}
+
+// parseBody reads the expression following a declaration.
+func (p *parser) parseBody() (node, error) {
+	p.accept(tokenPunct, ";")
+	return p.parseExpr()
+}
+
+func (p *parser) parseLet() (node, error) {
+	t := p.next()
+	name, err := p.expectIdent()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(tokenPunct, "="); err != nil {
+		return nil, err
+	}
+	value, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	body, err := p.parseBody()
+	if err != nil {
+		return nil, err
+	}
+	return &letNode{pos: t.pos, name: name.text, value: value, body: body}, nil
+}
+
+func (p *parser) parseFunc() (node, error) {
+	t := p.next()
+	name, err := p.expectIdent()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(tokenPunct, "("); err != nil {
+		return nil, err
+	}
+	var params []param
+	for !p.accept(tokenPunct, ")") {
+		if len(params) > 0 {
+			if _, err := p.expect(tokenPunct, ","); err != nil {
+				return nil, err
+			}
+		}
+		pn, err := p.expectIdent()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenPunct, ":"); err != nil {
+			return nil, err
+		}
+		pt, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, param{name: pn.text, typ: pt})
+	}
+	if _, err := p.expect(tokenPunct, "="); err != nil {
+		return nil, err
+	}
+	value, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	body, err := p.parseBody()
+	if err != nil {
+		return nil, err
+	}
+	return &funcNode{pos: t.pos, name: name.text, params: params, value: value, body: body}, nil
+}
+
+// parseType reads type like Int, List[String] or Int|Unit.
+func (p *parser) parseType() (typeRef, error) {
+	var alternatives []typeRef
+	for {
+		t, err := p.expectIdent()
+		if err != nil {
+			return typeRef{}, err
+		}
+		r := typeRef{pos: t.pos, name: t.text}
+		if t.text == "List" {
+			if _, err := p.expect(tokenPunct, "["); err != nil {
+				return typeRef{}, err
+			}
+			elem, err := p.parseType()
+			if err != nil {
+				return typeRef{}, err
+			}
+			if _, err := p.expect(tokenPunct, "]"); err != nil {
+				return typeRef{}, err
+			}
+			r.list = &elem
+		}
+		alternatives = append(alternatives, r)
+		if !p.accept(tokenPunct, "|") {
+			break
+		}
+	}
+	if len(alternatives) == 1 {
+		return alternatives[0], nil
+	}
+	return typeRef{pos: alternatives[0].pos, or: alternatives}, nil
+}
+
+type binaryLevel struct {
+	ops []string
+}
+
+// Binary operators from the lowest precedence to the highest.
+var binaryLevels = []binaryLevel{
+	{ops: []string{"||"}},
+	{ops: []string{"&&"}},
+	{ops: []string{"==", "!="}},
+	{ops: []string{"<", "<=", ">", ">="}},
+	{ops: []string{"+", "-"}},
+	{ops: []string{"*", "/", "%"}},
+}
+
+func (p *parser) parseOr() (node, error) {
+	return p.parseBinary(0)
+}
+
+func (p *parser) parseBinary(level int) (node, error) {
+	if level == len(binaryLevels) {
+		return p.parseUnary()
+	}
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		matched := false
+		for _, op := range binaryLevels[level].ops {
+			if t.is(tokenPunct, op) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{pos: t.pos, op: t.text, left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	t := p.peek()
+	if t.is(tokenPunct, "!") || t.is(tokenPunct, "-") {
+		p.next()
+		if t.text == "-" && p.peek().kind == tokenInt {
+			return p.parseLong(p.next(), true)
+		}
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{pos: t.pos, op: t.text, operand: operand}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *parser) parsePostfix() (node, error) {
+	n, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		switch {
+		case t.is(tokenPunct, "."):
+			p.next()
+			field, err := p.expectIdent()
+			if err != nil {
+				return nil, err
+			}
+			if p.peek().is(tokenPunct, "(") {
+				// x.f(y) is the same as f(x, y)
+				args, err := p.parseArgs()
+				if err != nil {
+					return nil, err
+				}
+				n = &callNode{pos: field.pos, name: field.text, args: append([]node{n}, args...)}
+				continue
+			}
+			n = &getterNode{pos: field.pos, object: n, field: field.text}
+		case t.is(tokenPunct, "["):
+			p.next()
+			index, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.expect(tokenPunct, "]"); err != nil {
+				return nil, err
+			}
+			n = &indexNode{pos: t.pos, list: n, index: index}
+		default:
+			return n, nil
+		}
+	}
+}
+
+func (p *parser) parseArgs() ([]node, error) {
+	if _, err := p.expect(tokenPunct, "("); err != nil {
+		return nil, err
+	}
+	var args []node
+	for !p.accept(tokenPunct, ")") {
+		if len(args) > 0 {
+			if _, err := p.expect(tokenPunct, ","); err != nil {
+				return nil, err
+			}
+		}
+		a, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, a)
+	}
+	return args, nil
+}
+
+func (p *parser) parseLong(t token, negative bool) (node, error) {
+	text := t.text
+	if negative {
+		text = "-" + text
+	}
+	v, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return nil, newError(t.pos, "integer %s is out of range [%d, %d]", text, int64(math.MinInt64), int64(math.MaxInt64))
+	}
+	return &longNode{pos: t.pos, value: v}, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenInt:
+		return p.parseLong(t, false)
+	case tokenString:
+		return &stringNode{pos: t.pos, value: t.text}, nil
+	case tokenBytes:
+		return &bytesNode{pos: t.pos, value: t.bytes}, nil
+	case tokenIdent:
+		if p.peek().is(tokenPunct, "(") {
+			args, err := p.parseArgs()
+			if err != nil {
+				return nil, err
+			}
+			return &callNode{pos: t.pos, name: t.text, args: args}, nil
+		}
+		return &refNode{pos: t.pos, name: t.text}, nil
+	case tokenKeyword:
+		switch t.text {
+		case "true", "false":
+			return &booleanNode{pos: t.pos, value: t.text == "true"}, nil
+		case "if":
+			return p.parseIf(t)
+		case "match":
+			return p.parseMatch(t)
+		case "let", "func":
+			p.pos--
+			return p.parseExpr()
+		}
+	case tokenPunct:
+		switch t.text {
+		case "(":
+			n, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.expect(tokenPunct, ")"); err != nil {
+				return nil, err
+			}
+			return n, nil
+		case "{":
+			n, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.expect(tokenPunct, "}"); err != nil {
+				return nil, err
+			}
+			return n, nil
+		case "[":
+			var items []node
+			for !p.accept(tokenPunct, "]") {
+				if len(items) > 0 {
+					if _, err := p.expect(tokenPunct, ","); err != nil {
+						return nil, err
+					}
+				}
+				item, err := p.parseExpr()
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, item)
+			}
+			return &listNode{pos: t.pos, items: items}, nil
+		}
+	}
+	return nil, newError(t.pos, "unexpected %s", t)
+}
+
+func (p *parser) parseIf(t token) (node, error) {
+	cond, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(tokenKeyword, "then"); err != nil {
+		return nil, err
+	}
+	ifTrue, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(tokenKeyword, "else"); err != nil {
+		return nil, err
+	}
+	ifFalse, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	return &ifNode{pos: t.pos, condition: cond, ifTrue: ifTrue, ifFalse: ifFalse}, nil
+}
+
+func (p *parser) parseMatch(t token) (node, error) {
+	value, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(tokenPunct, "{"); err != nil {
+		return nil, err
+	}
+	var cases []matchCase
+	for !p.accept(tokenPunct, "}") {
+		ct, err := p.expect(tokenKeyword, "case")
+		if err != nil {
+			return nil, err
+		}
+		name, err := p.expectIdent()
+		if err != nil {
+			return nil, err
+		}
+		c := matchCase{pos: ct.pos, name: name.text}
+		if p.accept(tokenPunct, ":") {
+			tr, err := p.parseType()
+			if err != nil {
+				return nil, err
+			}
+			if tr.or != nil {
+				c.types = tr.or
+			} else {
+				c.types = []typeRef{tr}
+			}
+		}
+		if _, err := p.expect(tokenPunct, "=>"); err != nil {
+			return nil, err
+		}
+		c.body, err = p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		cases = append(cases, c)
+	}
+	if len(cases) == 0 {
+		return nil, newError(t.pos, "match without cases")
+	}
+	return &matchNode{pos: t.pos, value: value, cases: cases}, nil
+}
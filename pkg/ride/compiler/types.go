@@ -0,0 +1,209 @@
+package compiler
+
+import (
+	"sort"
+	"strings"
+)
+
+// Type is a type of RIDE expression.
+type Type interface {
+	String() string
+}
+
+// SimpleType is a primitive or an object type, identified by its name.
+type SimpleType struct {
+	Name string
+}
+
+func (t SimpleType) String() string {
+	return t.Name
+}
+
+type ListType struct {
+	Elem Type
+}
+
+func (t ListType) String() string {
+	return "List[" + t.Elem.String() + "]"
+}
+
+// UnionType holds alternatives, none of which is a union itself.
+type UnionType struct {
+	Types []Type
+}
+
+func (t UnionType) String() string {
+	names := make([]string, len(t.Types))
+	for i, a := range t.Types {
+		names[i] = a.String()
+	}
+	return strings.Join(names, "|")
+}
+
+// typeParam is a placeholder of the type in signatures of generic functions.
+type typeParam struct{}
+
+func (typeParam) String() string {
+	return "T"
+}
+
+var (
+	Int        = SimpleType{Name: "Int"}
+	Boolean    = SimpleType{Name: "Boolean"}
+	ByteVector = SimpleType{Name: "ByteVector"}
+	String     = SimpleType{Name: "String"}
+	Unit       = SimpleType{Name: "Unit"}
+	Nothing    = SimpleType{Name: "Nothing"}
+	T          = typeParam{}
+)
+
+func alternatives(t Type) []Type {
+	if u, ok := t.(UnionType); ok {
+		return u.Types
+	}
+	return []Type{t}
+}
+
+// union combines types into the union type, removing duplicates and Nothing.
+func union(types ...Type) Type {
+	seen := make(map[string]bool)
+	var out []Type
+	for _, t := range types {
+		for _, a := range alternatives(t) {
+			if a == Nothing || seen[a.String()] {
+				continue
+			}
+			seen[a.String()] = true
+			out = append(out, a)
+		}
+	}
+	switch len(out) {
+	case 0:
+		return Nothing
+	case 1:
+		return out[0]
+	default:
+		sort.Slice(out, func(i, j int) bool { return out[i].String() < out[j].String() })
+		return UnionType{Types: out}
+	}
+}
+
+// assignable checks that the value of type from could be used where the type to is expected.
+func assignable(to, from Type) bool {
+	if from == Nothing {
+		return true
+	}
+	for _, f := range alternatives(from) {
+		ok := false
+		for _, t := range alternatives(to) {
+			if assignableSingle(t, f) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func assignableSingle(to, from Type) bool {
+	if from == Nothing {
+		return true
+	}
+	switch t := to.(type) {
+	case ListType:
+		f, ok := from.(ListType)
+		return ok && assignable(t.Elem, f.Elem)
+	default:
+		return to.String() == from.String()
+	}
+}
+
+// without removes alternatives of type r from alternatives of type t.
+func without(t, r Type) Type {
+	removed := make(map[string]bool)
+	for _, a := range alternatives(r) {
+		removed[a.String()] = true
+	}
+	var out []Type
+	for _, a := range alternatives(t) {
+		if !removed[a.String()] {
+			out = append(out, a)
+		}
+	}
+	return union(out...)
+}
+
+// bind substitutes the type parameter in t with the given type.
+func bind(t Type, with Type) Type {
+	switch v := t.(type) {
+	case typeParam:
+		return with
+	case ListType:
+		return ListType{Elem: bind(v.Elem, with)}
+	case UnionType:
+		types := make([]Type, len(v.Types))
+		for i, a := range v.Types {
+			types[i] = bind(a, with)
+		}
+		return union(types...)
+	default:
+		return t
+	}
+}
+
+// infer finds the type which substituted as type parameter in param makes argument assignable to it.
+func infer(param, arg Type) (Type, bool) {
+	switch p := param.(type) {
+	case typeParam:
+		return arg, true
+	case ListType:
+		if arg == Nothing {
+			return Nothing, true
+		}
+		a, ok := arg.(ListType)
+		if !ok {
+			return nil, false
+		}
+		return infer(p.Elem, a.Elem)
+	case UnionType:
+		// T|Unit and similar: the parameter gets the rest of the argument alternatives
+		var fixed []Type
+		generic := false
+		for _, alt := range p.Types {
+			if alt == T {
+				generic = true
+				continue
+			}
+			fixed = append(fixed, alt)
+		}
+		if !generic {
+			return nil, false
+		}
+		rest := arg
+		for _, f := range fixed {
+			rest = without(rest, f)
+		}
+		return rest, true
+	default:
+		return nil, false
+	}
+}
+
+func hasTypeParam(t Type) bool {
+	switch v := t.(type) {
+	case typeParam:
+		return true
+	case ListType:
+		return hasTypeParam(v.Elem)
+	case UnionType:
+		for _, a := range v.Types {
+			if hasTypeParam(a) {
+				return true
+			}
+		}
+	}
+	return false
+}
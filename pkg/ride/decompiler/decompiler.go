@@ -0,0 +1,333 @@
+// Package decompiler restores readable RIDE source code from the binary representation of scripts.
+package decompiler
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/mr-tron/base58/base58"
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/ast"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/parser"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/reader"
+)
+
+const (
+	indentation = "    "
+	// Longer byte vectors are written as base64 literals.
+	maxBase58Bytes = 1024
+)
+
+// Precedence of expressions, the higher precedence binds tighter.
+const (
+	precLowest = iota
+	precOr
+	precAnd
+	precEquality
+	precComparison
+	precSum
+	precProduct
+	precUnary
+	precPostfix
+)
+
+type operator struct {
+	symbol string
+	prec   int
+}
+
+var nativeOperators = map[int16]operator{
+	0:   {"==", precEquality},
+	100: {"+", precSum},
+	101: {"-", precSum},
+	102: {">", precComparison},
+	103: {">=", precComparison},
+	104: {"*", precProduct},
+	105: {"/", precProduct},
+	106: {"%", precProduct},
+	203: {"+", precSum},
+	300: {"+", precSum},
+}
+
+// Names of user functions used by the Scala compiler for the implementation of the language constructions.
+var (
+	matchPrefixes      = []string{"$match", "_match"}
+	isInstanceOfNames  = []string{"$isInstanceOf", "_isInstanceOf"}
+	userNotEqual       = "!="
+	userUnaryOperators = map[string]string{"!": "!", "-": "-"}
+)
+
+// DecompileBase64 parses the script from the base64 string, optionally prefixed with "base64:", and decompiles it.
+func DecompileBase64(s string) (string, error) {
+	r, err := reader.NewReaderFromBase64(strings.TrimPrefix(strings.TrimSpace(s), "base64:"))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decode script")
+	}
+	script, err := parser.BuildScript(r)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse script")
+	}
+	return Decompile(script)
+}
+
+// Decompile returns the source code of the script with the directive of its version.
+func Decompile(script *ast.Script) (string, error) {
+	body, err := DecompileExpr(script.Verifier)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("{-# STDLIB_VERSION %d #-}\n{-# CONTENT_TYPE EXPRESSION #-}\n%s\n", script.Version, body), nil
+}
+
+// DecompileExpr returns the source code of the expression.
+func DecompileExpr(e ast.Expr) (string, error) {
+	d := &decompiler{}
+	return d.statement(e, 0)
+}
+
+type decompiler struct{}
+
+func indent(level int) string {
+	return strings.Repeat(indentation, level)
+}
+
+// statement writes the expression in the position where declarations are allowed without braces.
+func (d *decompiler) statement(e ast.Expr, level int) (string, error) {
+	switch v := e.(type) {
+	case *ast.Block:
+		if m, ok := recognizeMatch(v); ok {
+			return d.match(m, level)
+		}
+		value, err := d.expr(v.Let.Value, level, precLowest)
+		if err != nil {
+			return "", err
+		}
+		body, err := d.statement(v.Body, level)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("let %s = %s\n%s%s", v.Let.Name, value, indent(level), body), nil
+	case *ast.FuncBlock:
+		value, err := d.expr(v.Func.Body, level, precLowest)
+		if err != nil {
+			return "", err
+		}
+		body, err := d.statement(v.Body, level)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("func %s(%s) = %s\n%s%s", v.Func.Name, strings.Join(v.Func.Args, ", "), value, indent(level), body), nil
+	default:
+		return d.expr(e, level, precLowest)
+	}
+}
+
+// braced writes declarations as the expression enclosed in braces.
+func (d *decompiler) braced(e ast.Expr, level int) (string, error) {
+	s, err := d.statement(e, level+1)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("{\n%s%s\n%s}", indent(level+1), s, indent(level)), nil
+}
+
+func parens(s string, prec, min int) string {
+	if prec < min {
+		return "(" + s + ")"
+	}
+	return s
+}
+
+// expr writes the expression in the position where expression of at least precedence min is expected.
+func (d *decompiler) expr(e ast.Expr, level, min int) (string, error) {
+	switch v := e.(type) {
+	case *ast.LongExpr:
+		return fmt.Sprintf("%d", v.Value), nil
+	case *ast.BytesExpr:
+		return bytesLiteral(v.Value), nil
+	case *ast.StringExpr:
+		return stringLiteral(v.Value), nil
+	case *ast.BooleanExpr:
+		return fmt.Sprintf("%t", v.Value), nil
+	case *ast.RefExpr:
+		return v.Name, nil
+	case *ast.GetterExpr:
+		obj, err := d.expr(v.Object, level, precPostfix)
+		if err != nil {
+			return "", err
+		}
+		return obj + "." + v.Key, nil
+	case *ast.Block, *ast.FuncBlock:
+		return d.braced(e, level)
+	case *ast.IfExpr:
+		return d.ifExpr(v, level, min)
+	case *ast.FuncCall:
+		return d.call(v, level, min)
+	default:
+		return "", errors.Errorf("unsupported expression %T", e)
+	}
+}
+
+func (d *decompiler) ifExpr(e *ast.IfExpr, level, min int) (string, error) {
+	if b, ok := e.True.(*ast.BooleanExpr); ok && b.Value {
+		return d.binary("||", precOr, e.Condition, e.False, level, min)
+	}
+	if b, ok := e.False.(*ast.BooleanExpr); ok && !b.Value {
+		return d.binary("&&", precAnd, e.Condition, e.True, level, min)
+	}
+	cond, err := d.expr(e.Condition, level, precLowest)
+	if err != nil {
+		return "", err
+	}
+	t, err := d.expr(e.True, level+1, precLowest)
+	if err != nil {
+		return "", err
+	}
+	f, err := d.expr(e.False, level+1, precLowest)
+	if err != nil {
+		return "", err
+	}
+	var s string
+	if strings.Contains(cond+t+f, "\n") {
+		s = fmt.Sprintf("if (%s)\n%sthen %s\n%selse %s", cond, indent(level+1), t, indent(level+1), f)
+	} else {
+		s = fmt.Sprintf("if (%s) then %s else %s", cond, t, f)
+	}
+	return parens(s, precLowest, min), nil
+}
+
+func (d *decompiler) binary(op string, prec int, left, right ast.Expr, level, min int) (string, error) {
+	l, err := d.expr(left, level, prec)
+	if err != nil {
+		return "", err
+	}
+	r, err := d.expr(right, level, prec+1)
+	if err != nil {
+		return "", err
+	}
+	return parens(l+" "+op+" "+r, prec, min), nil
+}
+
+func (d *decompiler) args(args ast.Exprs, level int) (string, error) {
+	out := make([]string, len(args))
+	for i, a := range args {
+		s, err := d.expr(a, level, precLowest)
+		if err != nil {
+			return "", err
+		}
+		out[i] = s
+	}
+	return strings.Join(out, ", "), nil
+}
+
+func (d *decompiler) call(e *ast.FuncCall, level, min int) (string, error) {
+	switch f := e.Func.(type) {
+	case *ast.NativeFunction:
+		if op, ok := nativeOperators[f.FunctionID]; ok && len(f.Argv) == 2 {
+			return d.binary(op.symbol, op.prec, f.Argv[0], f.Argv[1], level, min)
+		}
+		if f.FunctionID == 401 && len(f.Argv) == 2 {
+			list, err := d.expr(f.Argv[0], level, precPostfix)
+			if err != nil {
+				return "", err
+			}
+			index, err := d.expr(f.Argv[1], level, precLowest)
+			if err != nil {
+				return "", err
+			}
+			return list + "[" + index + "]", nil
+		}
+		if f.FunctionID == 1100 {
+			if items, ok := listItems(e); ok {
+				s, err := d.args(items, level)
+				if err != nil {
+					return "", err
+				}
+				return "[" + s + "]", nil
+			}
+		}
+		name, ok := ast.NativeFunctionName(f.FunctionID)
+		if !ok {
+			return "", errors.Errorf("unknown native function %d", f.FunctionID)
+		}
+		args, err := d.args(f.Argv, level)
+		if err != nil {
+			return "", err
+		}
+		return name + "(" + args + ")", nil
+	case *ast.UserFunction:
+		if f.Name == userNotEqual && len(f.Argv) == 2 {
+			return d.binary(f.Name, precEquality, f.Argv[0], f.Argv[1], level, min)
+		}
+		if op, ok := userUnaryOperators[f.Name]; ok && len(f.Argv) == 1 {
+			arg, err := d.expr(f.Argv[0], level, precUnary)
+			if err != nil {
+				return "", err
+			}
+			return parens(op+arg, precUnary, min), nil
+		}
+		args, err := d.args(f.Argv, level)
+		if err != nil {
+			return "", err
+		}
+		return f.Name + "(" + args + ")", nil
+	default:
+		return "", errors.Errorf("unsupported function %T", e.Func)
+	}
+}
+
+// listItems collects elements of the list built by the chain of cons calls ending with nil.
+func listItems(e ast.Expr) (ast.Exprs, bool) {
+	var items ast.Exprs
+	for {
+		switch v := e.(type) {
+		case *ast.RefExpr:
+			return items, v.Name == "nil"
+		case *ast.FuncCall:
+			f, ok := v.Func.(*ast.NativeFunction)
+			if !ok || f.FunctionID != 1100 || len(f.Argv) != 2 {
+				return nil, false
+			}
+			items = append(items, f.Argv[0])
+			e = f.Argv[1]
+		default:
+			return nil, false
+		}
+	}
+}
+
+func bytesLiteral(b []byte) string {
+	if len(b) > maxBase58Bytes {
+		return "base64'" + base64.StdEncoding.EncodeToString(b) + "'"
+	}
+	return "base58'" + base58.Encode(b) + "'"
+}
+
+func stringLiteral(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			if unicode.IsPrint(r) {
+				sb.WriteRune(r)
+			} else {
+				fmt.Fprintf(&sb, `\u%04x`, r)
+			}
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
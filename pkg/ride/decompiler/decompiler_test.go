@@ -0,0 +1,66 @@
+package decompiler
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/ride/compiler"
+)
+
+func TestDecompileBase64(t *testing.T) {
+	for _, tc := range []struct {
+		script string
+		source string
+	}{
+		{`AQa3b8tH`, "true"},
+		{`AQQAAAABeAAAAAAAAAAABQkAAGYAAAACAAAAAAAAAAAGBQAAAAF4Gh24hw==`, "let x = 5\n6 > x"},
+		{`AQQAAAABeAkBAAAABXRocm93AAAAAAMGBgUAAAABeKRnLds=`, "let x = throw()\ntrue || x"},
+		{`AQkAAGYAAAACCQAAagAAAAIA//////////YAAAAAAAAAAAYAAAAAAAAAAAB5rBSH`, "-10 % 6 > 0"},
+		{`AQkBAAAAAiE9AAAAAgkAAfUAAAABAQAAAAEhAQAAAAEhKeR77g==`, "keccak256(base58'a') != base58'a'"},
+		{`AQkAAGYAAAACCQAAyAAAAAEJAAGRAAAAAggFAAAAAnR4AAAABnByb29mcwAAAAAAAAAAAAAAAAAAAAAAAFF6iVo=`, "size(tx.proofs[0]) > 0"},
+		{`AQkAAAAAAAACCQABmwAAAAECAAAADNC/0YDQuNCy0LXRggEAAAAM0L/RgNC40LLQtdGCuUGFxw==`, `toBytes("привет") == base58'4wUjatAwfVDjaHQVX'`},
+		{`AQQAAAAHJG1hdGNoMAkAA+gAAAABCAUAAAACdHgAAAACaWQDCQAAAQAAAAIFAAAAByRtYXRjaDACAAAABFVuaXQEAAAAAXQFAAAAByRtYXRjaDAGB1+iIek=`, "match transactionById(tx.id) {\n    case t: Unit => true\n    case _ => false\n}"},
+		{`AQQAAAAHJG1hdGNoMAUAAAACdHgDCQAAAQAAAAIFAAAAByRtYXRjaDACAAAAD0RhdGFUcmFuc2FjdGlvbgQAAAABdAUAAAAHJG1hdGNoMAkAAAAAAAACCQAEEAAAAAIIBQAAAAF0AAAABGRhdGECAAAAB2ludGVnZXIAAAAAAAABiJQHp2oJqg==`, "match tx {\n    case t: DataTransaction => getInteger(t.data, \"integer\") == 100500\n    case _ => false\n}"},
+	} {
+		src, err := DecompileBase64(tc.script)
+		require.NoError(t, err, tc.script)
+		assert.Equal(t, "{-# STDLIB_VERSION 1 #-}\n{-# CONTENT_TYPE EXPRESSION #-}\n"+tc.source+"\n", src)
+	}
+}
+
+func TestDecompileRoundTrip(t *testing.T) {
+	for _, code := range []string{
+		`let x = 5; let y = if (x > 4) then "a\"b\n" else "c"; y == "c" || x * (2 + 3) == 25`,
+		`let a = { let b = 1; b + 1 }; a == 2 && !(a > 3) && -a < 0`,
+		`match tx {
+			case t: TransferTransaction | MassTransferTransaction => t.attachment == base64'aGVsbG8='
+			case o: Order => if (o.orderType == Buy) then true else throw("sell orders are not allowed")
+			case t => size(t.proofs) == 1
+		}`,
+		`match tx {
+			case t: DataTransaction => getBoolean(t.data, 0) == true && extract(getString(t.data, "s")) != ""
+			case _: Order | ExchangeTransaction => sigVerify(tx.bodyBytes, tx.proofs[0], tx.senderPublicKey)
+			case _ => false
+		}`,
+		"{-# STDLIB_VERSION 3 #-}\nlet values = [1, 2, 3]; values[1] == 2 && lastBlock.height > 0 && this == tx.sender",
+		"{-# STDLIB_VERSION 2 #-}\nmatch transactionById(tx.id) { case t: TransferTransaction => true case _: Unit => false case _ => true }",
+	} {
+		compiled, err := compiler.Compile(code)
+		require.NoError(t, err, code)
+		src, err := DecompileBase64(base64.StdEncoding.EncodeToString(compiled.Bytes))
+		require.NoError(t, err, code)
+		recompiled, err := compiler.Compile(src)
+		require.NoError(t, err, src)
+		assert.Equal(t, compiled.Bytes, recompiled.Bytes, src)
+	}
+}
+
+func TestDecompileFunctions(t *testing.T) {
+	compiled, err := compiler.Compile("{-# STDLIB_VERSION 3 #-}\nfunc inc(x: Int) = { let one = 1; x + one }\ninc(height) > 1")
+	require.NoError(t, err)
+	src, err := DecompileExpr(compiled.Expr)
+	require.NoError(t, err)
+	assert.Equal(t, "func inc(x) = {\n    let one = 1\n    x + one\n}\ninc(height) > 1", src)
+}
@@ -0,0 +1,203 @@
+package decompiler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/ast"
+)
+
+// matchCase is a case of the pattern matching, empty types mean default case.
+type matchCase struct {
+	name  string
+	types []string
+	body  ast.Expr
+}
+
+type matchExpr struct {
+	value ast.Expr
+	cases []matchCase
+}
+
+// recognizeMatch restores the pattern matching, compiled into the block with hidden variable $match0 and the chain of
+// isInstanceOf checks. The last alternative is either the default case or throw() if there is no default case.
+func recognizeMatch(b *ast.Block) (*matchExpr, bool) {
+	if !hasPrefix(b.Let.Name, matchPrefixes) {
+		return nil, false
+	}
+	ref := b.Let.Name
+	m := &matchExpr{value: b.Let.Value}
+	e := b.Body
+	for {
+		v, ok := e.(*ast.IfExpr)
+		if !ok {
+			break
+		}
+		types, ok := instanceTypes(v.Condition, ref)
+		if !ok {
+			break
+		}
+		name, body := caseBody(v.True, ref)
+		m.cases = append(m.cases, matchCase{name: name, types: types, body: body})
+		e = v.False
+	}
+	if isThrowWithoutMessage(e) {
+		if len(m.cases) == 0 {
+			return nil, false
+		}
+	} else {
+		name, body := caseBody(e, ref)
+		if len(m.cases) == 0 && name == "_" {
+			// Not a pattern matching, just a block with unusual name of the variable
+			return nil, false
+		}
+		m.cases = append(m.cases, matchCase{name: name, body: body})
+	}
+	if referenced(m, ref) {
+		return nil, false
+	}
+	return m, true
+}
+
+func hasPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func isRef(e ast.Expr, name string) bool {
+	r, ok := e.(*ast.RefExpr)
+	return ok && r.Name == name
+}
+
+// instanceTypes returns the types checked by the condition of the case, in the order of the source code.
+func instanceTypes(e ast.Expr, ref string) ([]string, bool) {
+	switch v := e.(type) {
+	case *ast.FuncCall:
+		var args ast.Exprs
+		switch f := v.Func.(type) {
+		case *ast.NativeFunction:
+			if f.FunctionID != 1 {
+				return nil, false
+			}
+			args = f.Argv
+		case *ast.UserFunction:
+			if !hasPrefix(f.Name, isInstanceOfNames) {
+				return nil, false
+			}
+			args = f.Argv
+		default:
+			return nil, false
+		}
+		if len(args) != 2 || !isRef(args[0], ref) {
+			return nil, false
+		}
+		s, ok := args[1].(*ast.StringExpr)
+		if !ok {
+			return nil, false
+		}
+		return []string{s.Value}, true
+	case *ast.IfExpr:
+		// Union of types is checked as: if (isInstanceOf(T2)) then true else isInstanceOf(T1)
+		b, ok := v.True.(*ast.BooleanExpr)
+		if !ok || !b.Value {
+			return nil, false
+		}
+		last, ok := instanceTypes(v.Condition, ref)
+		if !ok {
+			return nil, false
+		}
+		first, ok := instanceTypes(v.False, ref)
+		if !ok {
+			return nil, false
+		}
+		return append(first, last...), true
+	default:
+		return nil, false
+	}
+}
+
+// caseBody extracts the name bound by the case and its body.
+func caseBody(e ast.Expr, ref string) (string, ast.Expr) {
+	if b, ok := e.(*ast.Block); ok && isRef(b.Let.Value, ref) {
+		return b.Let.Name, b.Body
+	}
+	return "_", e
+}
+
+func isThrowWithoutMessage(e ast.Expr) bool {
+	c, ok := e.(*ast.FuncCall)
+	if !ok {
+		return false
+	}
+	f, ok := c.Func.(*ast.UserFunction)
+	return ok && f.Name == "throw" && len(f.Argv) == 0
+}
+
+// referenced checks that the bodies of cases don't use the hidden variable with the matched value.
+func referenced(m *matchExpr, ref string) bool {
+	for _, c := range m.cases {
+		if uses(c.body, ref) {
+			return true
+		}
+	}
+	return false
+}
+
+func uses(e ast.Expr, name string) bool {
+	switch v := e.(type) {
+	case *ast.RefExpr:
+		return v.Name == name
+	case *ast.GetterExpr:
+		return uses(v.Object, name)
+	case *ast.IfExpr:
+		return uses(v.Condition, name) || uses(v.True, name) || uses(v.False, name)
+	case *ast.Block:
+		return uses(v.Let.Value, name) || (v.Let.Name != name && uses(v.Body, name))
+	case *ast.FuncBlock:
+		return uses(v.Func.Body, name) || uses(v.Body, name)
+	case *ast.FuncCall:
+		var args ast.Exprs
+		switch f := v.Func.(type) {
+		case *ast.NativeFunction:
+			args = f.Argv
+		case *ast.UserFunction:
+			args = f.Argv
+		}
+		for _, a := range args {
+			if uses(a, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (d *decompiler) match(m *matchExpr, level int) (string, error) {
+	value, err := d.expr(m.value, level, precLowest)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "match %s {", value)
+	for _, c := range m.cases {
+		pattern := c.name
+		if len(c.types) > 0 {
+			pattern += ": " + strings.Join(c.types, " | ")
+		}
+		body, err := d.statement(c.body, level+2)
+		if err != nil {
+			return "", err
+		}
+		if strings.Contains(body, "\n") {
+			fmt.Fprintf(&sb, "\n%scase %s =>\n%s%s", indent(level+1), pattern, indent(level+2), body)
+		} else {
+			fmt.Fprintf(&sb, "\n%scase %s => %s", indent(level+1), pattern, body)
+		}
+	}
+	fmt.Fprintf(&sb, "\n%s}", indent(level))
+	return sb.String(), nil
+}
@@ -0,0 +1,143 @@
+package estimator
+
+// Function is the name and the cost of a call of the native function.
+type Function struct {
+	Name string
+	Cost int64
+}
+
+// CostTable holds costs of native functions by their ids and user functions by their names.
+type CostTable struct {
+	Native map[int16]Function
+	User   map[string]int64
+}
+
+// CostsV1 returns costs of functions of the first version of the standard library.
+func CostsV1() *CostTable {
+	return &CostTable{
+		Native: map[int16]Function{
+			0:   {"==", 1},
+			1:   {"isInstanceOf", 1},
+			2:   {"throw", 1},
+			100: {"+", 1},
+			101: {"-", 1},
+			102: {">", 1},
+			103: {">=", 1},
+			104: {"*", 1},
+			105: {"/", 1},
+			106: {"%", 1},
+			107: {"fraction", 1},
+
+			200: {"size", 1},
+			201: {"take", 1},
+			202: {"drop", 1},
+			203: {"+", 10},
+			300: {"+", 10},
+			303: {"take", 1},
+			304: {"drop", 1},
+			305: {"size", 1},
+
+			400: {"size", 2},
+			401: {"getElement", 2},
+			410: {"toBytes", 1},
+			411: {"toBytes", 1},
+			412: {"toBytes", 1},
+			420: {"toString", 1},
+			421: {"toString", 1},
+
+			500: {"sigVerify", 100},
+			501: {"keccak256", 10},
+			502: {"blake2b256", 10},
+			503: {"sha256", 10},
+
+			600: {"toBase58String", 10},
+			601: {"fromBase58String", 10},
+			602: {"toBase64String", 10},
+			603: {"fromBase64String", 10},
+
+			1000: {"transactionById", 100},
+			1001: {"transactionHeightById", 100},
+			1003: {"assetBalance", 100},
+
+			1040: {"getInteger", 10},
+			1041: {"getBoolean", 10},
+			1042: {"getBinary", 10},
+			1043: {"getString", 10},
+			1050: {"getInteger", 100},
+			1051: {"getBoolean", 100},
+			1052: {"getBinary", 100},
+			1053: {"getString", 100},
+			1060: {"addressFromRecipient", 100},
+		},
+		User: map[string]int64{
+			"!=":                   26,
+			"!":                    11,
+			"-":                    9,
+			"throw":                2,
+			"isDefined":            35,
+			"extract":              13,
+			"takeRight":            19,
+			"dropRight":            19,
+			"takeRightBytes":       19,
+			"dropRightBytes":       19,
+			"getInteger":           30,
+			"getBoolean":           30,
+			"getBinary":            30,
+			"getString":            30,
+			"addressFromPublicKey": 82,
+			"addressFromString":    124,
+			"wavesBalance":         109,
+			"Address":              1,
+			"Alias":                1,
+		},
+	}
+}
+
+// CostsV2 returns costs of functions of the second version of the standard library, they are the same as in version 1.
+func CostsV2() *CostTable {
+	return CostsV1()
+}
+
+// CostsV3 returns costs of functions of the third version of the standard library.
+func CostsV3() *CostTable {
+	t := CostsV2()
+	t.Native[108] = Function{"pow", 100}
+	t.Native[109] = Function{"log", 100}
+	t.Native[504] = Function{"rsaVerify", 300}
+	t.Native[700] = Function{"checkMerkleProof", 30}
+	t.Native[1004] = Function{"assetInfo", 100}
+	t.Native[1005] = Function{"blockInfoByHeight", 100}
+	t.Native[1006] = Function{"transferTransactionById", 100}
+	t.Native[1100] = Function{"cons", 2}
+	t.Native[1200] = Function{"toUtf8String", 20}
+	t.Native[1201] = Function{"toInt", 10}
+	t.Native[1202] = Function{"toInt", 10}
+	t.Native[1203] = Function{"indexOf", 20}
+	t.Native[1204] = Function{"indexOf", 20}
+	t.Native[1205] = Function{"split", 100}
+	t.Native[1206] = Function{"parseInt", 20}
+	t.User["parseIntValue"] = 20
+	return t
+}
+
+// CostsByVersion returns costs of functions of the given version of the standard library.
+func CostsByVersion(version int) (*CostTable, bool) {
+	switch version {
+	case 1:
+		return CostsV1(), true
+	case 2:
+		return CostsV2(), true
+	case 3:
+		return CostsV3(), true
+	default:
+		return nil, false
+	}
+}
+
+// MaxComplexity returns the maximal allowed complexity of scripts of the given version.
+func MaxComplexity(version int) int64 {
+	if version >= 3 {
+		return 4000
+	}
+	return 2000
+}
@@ -0,0 +1,231 @@
+// Package estimator calculates the complexity of scripts, the worst case cost of their evaluation.
+package estimator
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/ast"
+)
+
+// Costs of language constructions.
+const (
+	constCost  = 1
+	refCost    = 2
+	getterCost = 2
+	ifCost     = 1
+	blockCost  = 5
+)
+
+// Estimation is the result of estimation of the script.
+type Estimation struct {
+	Complexity int64
+	// Functions holds the cost of a single call of every function called by the script, including declared ones.
+	Functions map[string]int64
+}
+
+// Estimate calculates the complexity of the script using the cost table of the script version.
+func Estimate(script *ast.Script) (*Estimation, error) {
+	costs, ok := CostsByVersion(script.Version)
+	if !ok {
+		return nil, errors.Errorf("unsupported script version %d", script.Version)
+	}
+	return EstimateExpr(costs, script.Verifier)
+}
+
+// EstimateExpr calculates the complexity of the expression using the given cost table.
+func EstimateExpr(costs *CostTable, e ast.Expr) (*Estimation, error) {
+	est := &estimator{costs: costs, functions: make(map[string]int64)}
+	c, err := est.estimate(e, newScope(nil), make(usage))
+	if err != nil {
+		return nil, err
+	}
+	return &Estimation{Complexity: c, Functions: est.functions}, nil
+}
+
+// Check returns an error if the complexity of the script exceeds the limit of its version.
+func Check(script *ast.Script) error {
+	e, err := Estimate(script)
+	if err != nil {
+		return err
+	}
+	if max := MaxComplexity(script.Version); e.Complexity > max {
+		return errors.Errorf("script complexity %d exceeds maximum allowed complexity %d", e.Complexity, max)
+	}
+	return nil
+}
+
+// let is the declared variable, its value is estimated in the declaring scope on the first reference.
+type let struct {
+	value ast.Expr
+	scope *scope
+}
+
+// usage marks variables which values are already counted.
+type usage map[*let]bool
+
+func (u usage) clone() usage {
+	out := make(usage, len(u))
+	for k, v := range u {
+		out[k] = v
+	}
+	return out
+}
+
+type scope struct {
+	parent *scope
+	lets   map[string]*let
+	funcs  map[string]int64
+}
+
+func newScope(parent *scope) *scope {
+	return &scope{parent: parent, lets: make(map[string]*let), funcs: make(map[string]int64)}
+}
+
+func (s *scope) let(name string) (*let, bool) {
+	for c := s; c != nil; c = c.parent {
+		if l, ok := c.lets[name]; ok {
+			return l, true
+		}
+	}
+	return nil, false
+}
+
+func (s *scope) function(name string) (int64, bool) {
+	for c := s; c != nil; c = c.parent {
+		if f, ok := c.funcs[name]; ok {
+			return f, true
+		}
+	}
+	return 0, false
+}
+
+type estimator struct {
+	costs     *CostTable
+	functions map[string]int64
+}
+
+func (e *estimator) estimate(expr ast.Expr, s *scope, u usage) (int64, error) {
+	switch v := expr.(type) {
+	case *ast.LongExpr, *ast.BytesExpr, *ast.StringExpr, *ast.BooleanExpr:
+		return constCost, nil
+	case *ast.RefExpr:
+		l, ok := s.let(v.Name)
+		if !ok || u[l] {
+			// Global variables and already evaluated values
+			return refCost, nil
+		}
+		u[l] = true
+		c, err := e.estimate(l.value, l.scope, u)
+		if err != nil {
+			return 0, err
+		}
+		return refCost + c, nil
+	case *ast.GetterExpr:
+		c, err := e.estimate(v.Object, s, u)
+		if err != nil {
+			return 0, err
+		}
+		return getterCost + c, nil
+	case *ast.IfExpr:
+		cond, err := e.estimate(v.Condition, s, u)
+		if err != nil {
+			return 0, err
+		}
+		// Only one branch is evaluated, the most expensive one and values evaluated by it are taken
+		tu := u.clone()
+		t, err := e.estimate(v.True, s, tu)
+		if err != nil {
+			return 0, err
+		}
+		fu := u.clone()
+		f, err := e.estimate(v.False, s, fu)
+		if err != nil {
+			return 0, err
+		}
+		branch, bu := t, tu
+		if f > t {
+			branch, bu = f, fu
+		}
+		for k := range bu {
+			u[k] = true
+		}
+		return ifCost + cond + branch, nil
+	case *ast.Block:
+		inner := newScope(s)
+		inner.lets[v.Let.Name] = &let{value: v.Let.Value, scope: s}
+		c, err := e.estimate(v.Body, inner, u)
+		if err != nil {
+			return 0, err
+		}
+		return blockCost + c, nil
+	case *ast.FuncBlock:
+		f, err := e.declaration(v.Func, s)
+		if err != nil {
+			return 0, err
+		}
+		inner := newScope(s)
+		inner.funcs[v.Func.Name] = f
+		c, err := e.estimate(v.Body, inner, u)
+		if err != nil {
+			return 0, err
+		}
+		return blockCost + c, nil
+	case *ast.FuncCall:
+		return e.call(v, s, u)
+	default:
+		return 0, errors.Errorf("unsupported expression %T", expr)
+	}
+}
+
+// declaration estimates the cost of the call of declared function, arguments are already evaluated on the call.
+func (e *estimator) declaration(f *ast.FuncDeclaration, s *scope) (int64, error) {
+	inner := newScope(s)
+	u := make(usage)
+	for _, a := range f.Args {
+		l := &let{scope: s}
+		inner.lets[a] = l
+		u[l] = true
+	}
+	c, err := e.estimate(f.Body, inner, u)
+	if err != nil {
+		return 0, errors.Wrapf(err, "function '%s'", f.Name)
+	}
+	e.functions[f.Name] = c
+	return c, nil
+}
+
+func (e *estimator) call(c *ast.FuncCall, s *scope, u usage) (int64, error) {
+	var cost int64
+	var args ast.Exprs
+	switch f := c.Func.(type) {
+	case *ast.NativeFunction:
+		n, ok := e.costs.Native[f.FunctionID]
+		if !ok {
+			return 0, errors.Errorf("unknown native function %d", f.FunctionID)
+		}
+		cost = n.Cost
+		e.functions[fmt.Sprintf("%s (%d)", n.Name, f.FunctionID)] = n.Cost
+		args = f.Argv
+	case *ast.UserFunction:
+		if d, ok := s.function(f.Name); ok {
+			cost = d
+		} else if d, ok := e.costs.User[f.Name]; ok {
+			cost = d
+			e.functions[f.Name] = d
+		} else {
+			return 0, errors.Errorf("unknown function '%s'", f.Name)
+		}
+		args = f.Argv
+	default:
+		return 0, errors.Errorf("unsupported function %T", c.Func)
+	}
+	for _, a := range args {
+		ac, err := e.estimate(a, s, u)
+		if err != nil {
+			return 0, err
+		}
+		cost += ac
+	}
+	return cost, nil
+}
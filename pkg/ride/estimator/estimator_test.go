@@ -0,0 +1,69 @@
+package estimator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/ride/compiler"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/ast"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/parser"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/reader"
+)
+
+func TestEstimate(t *testing.T) {
+	for _, tc := range []struct {
+		code       string
+		complexity int64
+	}{
+		{`true`, 1},
+		// block 5 + gt 1 + const 1 + ref 2 + let value 1
+		{`let x = 5; 6 > x`, 10},
+		// the value of the variable is counted only once
+		{`let x = 5; x == x`, 5 + 1 + 2 + 1 + 2},
+		// unused variables cost nothing
+		{`let x = sigVerify(tx.bodyBytes, tx.proofs[0], tx.senderPublicKey); true`, 6},
+		// the most expensive branch is taken: if 1 + cond 1 + max(1, sigVerify 100 + args 4 + 7 + 4)
+		{`if (true) then false else sigVerify(tx.bodyBytes, tx.proofs[0], tx.senderPublicKey)`, 117},
+		// variable used in both branches is counted in each of them
+		{`let x = 1 + 1; if (x > 0) then x == 2 else false`, 5 + 1 + (1 + 2 + (1 + 1 + 1) + 1) + (1 + 2 + 1)},
+		// after the branch the variables evaluated by it are known
+		{`let x = 1 + 1; (if (true) then x == 2 else false) || x == 3`, 5 + 1 + (1 + 1 + (1 + 2 + 3 + 1)) + (1 + 2 + 1)},
+		{"{-# STDLIB_VERSION 3 #-}\nfunc f(a: Int) = a + 1; f(1) == f(2)", 5 + 1 + 2*((1+2+1)+1)},
+	} {
+		script, err := compiler.Compile(tc.code)
+		require.NoError(t, err, tc.code)
+		r, err := reader.NewReaderFromScript(script.Bytes)
+		require.NoError(t, err, tc.code)
+		s, err := parser.BuildScript(r)
+		require.NoError(t, err, tc.code)
+		e, err := Estimate(s)
+		require.NoError(t, err, tc.code)
+		assert.Equal(t, tc.complexity, e.Complexity, tc.code)
+	}
+}
+
+func TestEstimateFunctions(t *testing.T) {
+	script, err := compiler.Compile("{-# STDLIB_VERSION 3 #-}\nfunc f(a: ByteVector) = sha256(a) != a; f(tx.id)")
+	require.NoError(t, err)
+	e, err := Estimate(&ast.Script{Version: 3, Verifier: script.Expr})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{
+		"sha256 (503)": 10,
+		"!=":           26,
+		"f":            26 + 10 + 2 + 2,
+	}, e.Functions)
+}
+
+func TestCheck(t *testing.T) {
+	call := ast.NewFuncCall(ast.NewNativeFunction(500, 3, ast.NewExprs(ast.NewBytes(nil), ast.NewBytes(nil), ast.NewBytes(nil))))
+	var expr ast.Expr = ast.NewBoolean(true)
+	for i := 0; i < 20; i++ {
+		expr = &ast.IfExpr{Condition: call, True: expr, False: ast.NewBoolean(false)}
+	}
+	assert.Error(t, Check(&ast.Script{Version: 1, Verifier: expr}))
+	assert.NoError(t, Check(&ast.Script{Version: 3, Verifier: expr}))
+
+	unknown := ast.NewFuncCall(ast.NewNativeFunction(9999, 0, nil))
+	assert.Error(t, Check(&ast.Script{Version: 3, Verifier: unknown}))
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"io"
+	"strings"
 )
 
 const InstanceFieldName = "$instance"
@@ -26,8 +27,9 @@ func (a Exprs) Write(w io.Writer) {
 	}
 }
 
+// Evaluate returns the list itself, elements of the list are already evaluated values.
 func (a Exprs) Evaluate(s Scope) (Expr, error) {
-	return nil, errors.New("Exprs Evaluate")
+	return a, nil
 }
 
 func (a Exprs) EvaluateAll(s Scope) (Exprs, error) {
@@ -58,6 +60,8 @@ func NewExprs(e ...Expr) Exprs {
 type Block struct {
 	Let  *LetExpr
 	Body Expr
+	// BlockV2 is set if the block is encoded as the declaration block of version 3, it doesn't affect the evaluation.
+	BlockV2 bool
 }
 
 func (a *Block) Write(w io.Writer) {
@@ -67,7 +71,7 @@ func (a *Block) Write(w io.Writer) {
 }
 
 func (a *Block) Evaluate(s Scope) (Expr, error) {
-	s.AddValue(a.Let.Name, a.Let.Value)
+	s.AddValue(a.Let.Name, &letValue{name: a.Let.Name, expr: a.Let.Value, scope: s})
 	return a.Body.Evaluate(s.Clone())
 }
 
@@ -96,6 +100,111 @@ func NewLet(name string, value Expr) *LetExpr {
 	}
 }
 
+// letValue evaluates expression of let lazily, at most once, in the scope the let is declared in.
+type letValue struct {
+	name   string
+	expr   Expr
+	scope  Scope
+	cached bool
+	cache  RefCache
+}
+
+func (a *letValue) Write(w io.Writer) {
+	a.expr.Write(w)
+}
+
+func (a *letValue) Evaluate(Scope) (Expr, error) {
+	if !a.cached {
+		var node *TraceNode
+		t := a.scope.Tracer()
+		if t != nil {
+			node = &TraceNode{Kind: TraceLet, Name: a.name}
+			t.Enter(node)
+		}
+		rs, err := a.expr.Evaluate(a.scope.Clone())
+		a.cache = RefCache{
+			Expr: rs,
+			Err:  err,
+		}
+		a.cached = true
+		if t != nil {
+			node.Result, node.Err = rs, err
+			t.Exit(node)
+		}
+	}
+	return a.cache.Expr, a.cache.Err
+}
+
+func (a *letValue) Eq(other Expr) (bool, error) {
+	return false, errors.Errorf("trying to compare %T with %T", a, other)
+}
+
+func (a *letValue) InstanceOf() string {
+	return "Let"
+}
+
+// FuncDeclaration is a function declared in script.
+type FuncDeclaration struct {
+	Name string
+	Args []string
+	Body Expr
+}
+
+func NewFuncDeclaration(name string, args []string, body Expr) *FuncDeclaration {
+	return &FuncDeclaration{
+		Name: name,
+		Args: args,
+		Body: body,
+	}
+}
+
+func (a *FuncDeclaration) Write(w io.Writer) {
+	_, _ = fmt.Fprintf(w, "func %s(%s) = ", a.Name, strings.Join(a.Args, ", "))
+	a.Body.Write(w)
+}
+
+// callable binds evaluated arguments to names of parameters and evaluates the body in the declaration scope.
+func (a *FuncDeclaration) callable(declaration Scope) Callable {
+	return func(s Scope, e Exprs) (Expr, error) {
+		if l := len(e); l != len(a.Args) {
+			return nil, errors.Errorf("function %s: invalid params, expected %d, passed %d", a.Name, len(a.Args), l)
+		}
+		values, err := e.EvaluateAll(s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "function %s", a.Name)
+		}
+		fs := declaration.Clone()
+		for i, name := range a.Args {
+			fs.AddValue(name, values[i])
+		}
+		return a.Body.Evaluate(fs)
+	}
+}
+
+type FuncBlock struct {
+	Func *FuncDeclaration
+	Body Expr
+}
+
+func (a *FuncBlock) Write(w io.Writer) {
+	a.Func.Write(w)
+	_, _ = fmt.Fprintf(w, "\n")
+	a.Body.Write(w)
+}
+
+func (a *FuncBlock) Evaluate(s Scope) (Expr, error) {
+	s.AddFunction(a.Func.Name, a.Func.callable(s))
+	return a.Body.Evaluate(s.Clone())
+}
+
+func (a *FuncBlock) Eq(other Expr) (bool, error) {
+	return false, errors.Errorf("trying to compare %T with %T", a, other)
+}
+
+func (a *FuncBlock) InstanceOf() string {
+	return "FuncBlock"
+}
+
 type LongExpr struct {
 	Value int64
 }
@@ -123,7 +232,7 @@ func (a *LongExpr) Eq(other Expr) (bool, error) {
 }
 
 func (a *LongExpr) InstanceOf() string {
-	return "Long"
+	return "Int"
 }
 
 type BooleanExpr struct {
@@ -207,6 +316,9 @@ func (a *NativeFunction) Evaluate(s Scope) (Expr, error) {
 		return nil, errors.Errorf("evaluate native function: function id %d not found in scope", a.FunctionID)
 	}
 
+	if t := s.Tracer(); t != nil {
+		return traceCall(t, &TraceNode{Kind: TraceCall, Name: nativeName(a.FunctionID), FunctionID: a.FunctionID}, f, s.Clone(), a.Argv)
+	}
 	return f(s.Clone(), a.Argv)
 }
 
@@ -246,6 +358,9 @@ func (a *UserFunction) Evaluate(s Scope) (Expr, error) {
 		return nil, errors.Errorf("evaluate user function: function name %s not found in scope", a.Name)
 	}
 
+	if t := s.Tracer(); t != nil {
+		return traceCall(t, &TraceNode{Kind: TraceCall, Name: a.Name, FunctionID: -1}, f, s.Clone(), a.Argv)
+	}
 	return f(s.Clone(), a.Argv)
 }
 
@@ -258,9 +373,7 @@ func (a *UserFunction) InstanceOf() string {
 }
 
 type RefExpr struct {
-	Name   string
-	cached bool
-	cache  RefCache
+	Name string
 }
 
 type RefCache struct {
@@ -273,25 +386,12 @@ func (a *RefExpr) Write(w io.Writer) {
 }
 
 func (a *RefExpr) Evaluate(s Scope) (Expr, error) {
-
-	if a.cached {
-		return a.cache.Expr, a.cache.Err
-	}
-
 	expr, ok := s.Value(a.Name)
 	if !ok {
 		return nil, errors.Errorf("RefExpr evaluate: not found expr by name %s", a.Name)
 	}
 
-	rs, err := expr.Evaluate(s.Clone())
-
-	a.cache = RefCache{
-		Expr: rs,
-		Err:  err,
-	}
-	a.cached = true
-
-	return a.cache.Expr, a.cache.Err
+	return expr.Evaluate(s.Clone())
 }
 
 func (a *RefExpr) Eq(other Expr) (bool, error) {
@@ -337,11 +437,19 @@ func (a *IfExpr) Evaluate(s Scope) (Expr, error) {
 		return nil, errors.Errorf("IfExpr evaluate: expected bool in condition found %T", cond)
 	}
 
+	branch := a.False
 	if b.Value {
-		return a.True.Evaluate(s.Clone())
-	} else {
-		return a.False.Evaluate(s.Clone())
+		branch = a.True
+	}
+	t := s.Tracer()
+	if t == nil {
+		return branch.Evaluate(s.Clone())
 	}
+	node := &TraceNode{Kind: TraceIf, Branch: b.Value}
+	t.Enter(node)
+	node.Result, node.Err = branch.Evaluate(s.Clone())
+	t.Exit(node)
+	return node.Result, node.Err
 }
 
 func (a *IfExpr) Eq(other Expr) (bool, error) {
@@ -380,7 +488,7 @@ func (a *BytesExpr) Eq(other Expr) (bool, error) {
 }
 
 func (a *BytesExpr) InstanceOf() string {
-	return "Bytes"
+	return "ByteVector"
 }
 
 type GetterExpr struct {
@@ -535,7 +643,7 @@ func (a AddressExpr) Eq(other Expr) (bool, error) {
 }
 
 func (a AddressExpr) InstanceOf() string {
-	return "AddressExpr"
+	return "Address"
 }
 
 func NewAddressFromString(s string) (AddressExpr, error) {
@@ -1,9 +1,12 @@
 package ast
 
 import (
+	"testing"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/wavesplatform/gowaves/pkg/proto"
-	"testing"
+	"github.com/wavesplatform/gowaves/pkg/ride/mockstate"
 )
 
 func TestBooleanExpr_Eq(t *testing.T) {
@@ -27,3 +30,15 @@ func TestDataEntryListExpr_Get(t *testing.T) {
 	lst := NewDataEntryList(d)
 	assert.Equal(t, NewLong(100500), lst.Get("integer", proto.Integer))
 }
+
+func TestFuncBlock_Evaluate(t *testing.T) {
+	// func inc(x: Int) = x + 1; let y = 10; inc(y) == 11
+	sum := NewFuncCall(NewNativeFunction(100, 2, NewExprs(&RefExpr{Name: "x"}, NewLong(1))))
+	call := NewFuncCall(NewUserFunction("inc", 1, NewExprs(&RefExpr{Name: "y"})))
+	body := &Block{Let: NewLet("y", NewLong(10)), Body: NewFuncCall(NewNativeFunction(0, 2, NewExprs(call, NewLong(11))))}
+	block := &FuncBlock{Func: NewFuncDeclaration("inc", []string{"x"}, sum), Body: body}
+
+	rs, err := block.Evaluate(NewScope(proto.MainNetScheme, mockstate.MockStateImpl{}, FuncsV3(), nil))
+	require.NoError(t, err)
+	assert.Equal(t, NewBoolean(true), rs)
+}
@@ -0,0 +1,114 @@
+package ast
+
+import (
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride/mockstate"
+)
+
+// Script is a parsed expression script.
+type Script struct {
+	Version  int
+	Verifier Expr
+}
+
+func NewObjectFromAssetInfo(info *mockstate.AssetInfo) *ObjectExpr {
+	return NewObject(map[string]Expr{
+		"id":              NewBytes(info.ID.Bytes()),
+		"quantity":        NewLong(int64(info.Quantity)),
+		"decimals":        NewLong(int64(info.Decimals)),
+		"issuer":          NewAddressFromProtoAddress(info.Issuer),
+		"issuerPublicKey": NewBytes(info.IssuerPublicKey.Bytes()),
+		"reissuable":      NewBoolean(info.Reissuable),
+		"scripted":        NewBoolean(info.Scripted),
+		"sponsored":       NewBoolean(info.Sponsored),
+		InstanceFieldName: NewString("Asset"),
+	})
+}
+
+func NewObjectFromBlockHeader(scheme byte, height uint64, header *proto.BlockHeader) (*ObjectExpr, error) {
+	generator, err := proto.NewAddressFromPublicKey(scheme, header.GenPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return NewObject(map[string]Expr{
+		"timestamp":           NewLong(int64(header.Timestamp)),
+		"height":              NewLong(int64(height)),
+		"baseTarget":          NewLong(int64(header.BaseTarget)),
+		"generationSignature": NewBytes(header.GenSignature.Bytes()),
+		"generator":           NewAddressFromProtoAddress(generator),
+		"generatorPublicKey":  NewBytes(header.GenPublicKey.Bytes()),
+		InstanceFieldName:     NewString("BlockInfo"),
+	}), nil
+}
+
+// VariablesV1 returns global variables of scripts of versions 1 and 2.
+// Variable tx is not set if transaction is nil.
+func VariablesV1(scheme byte, state mockstate.MockState, tx proto.Transaction) (map[string]Expr, error) {
+	height, err := state.Height()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get height")
+	}
+	out := map[string]Expr{
+		"height": NewLong(int64(height)),
+		"unit":   NewUnit(),
+		"Buy":    NewObject(map[string]Expr{InstanceFieldName: NewString("Buy")}),
+		"Sell":   NewObject(map[string]Expr{InstanceFieldName: NewString("Sell")}),
+	}
+	if tx != nil {
+		vars, err := NewVariablesFromTransaction(scheme, tx)
+		if err != nil {
+			return nil, err
+		}
+		out["tx"] = NewObject(vars)
+	}
+	return out, nil
+}
+
+// VariablesV3 extends variables of version 1 with the last block info, the address of the script owner, the empty list,
+// rounding modes and digest algorithms.
+func VariablesV3(scheme byte, state mockstate.MockState, this proto.Address, tx proto.Transaction) (map[string]Expr, error) {
+	out, err := VariablesV1(scheme, state, tx)
+	if err != nil {
+		return nil, err
+	}
+	height, err := state.Height()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get height")
+	}
+	header, err := state.BlockHeaderByHeight(height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get block header at height %d", height)
+	}
+	lastBlock, err := NewObjectFromBlockHeader(scheme, height, header)
+	if err != nil {
+		return nil, err
+	}
+	out["lastBlock"] = lastBlock
+	out["this"] = NewAddressFromProtoAddress(this)
+	out["nil"] = Exprs{}
+	for k, v := range variablesV3Constants() {
+		out[k] = v
+	}
+	return out, nil
+}
+
+// NewScopeByVersion creates scope with functions and global variables available to scripts of given version.
+// The address this is the address of account the script is attached to.
+func NewScopeByVersion(version int, scheme byte, state mockstate.MockState, this proto.Address, tx proto.Transaction) (*ScopeImpl, error) {
+	funcs, err := FuncsByVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	var vars map[string]Expr
+	switch version {
+	case 1, 2:
+		vars, err = VariablesV1(scheme, state, tx)
+	default:
+		vars, err = VariablesV3(scheme, state, this, tx)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create script variables")
+	}
+	return NewScope(scheme, state, funcs, vars), nil
+}
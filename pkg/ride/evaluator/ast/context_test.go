@@ -0,0 +1,162 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride/mockstate"
+)
+
+func newBlockHeader(t *testing.T) *proto.BlockHeader {
+	_, public := crypto.GenerateKeyPair([]byte("generator"))
+	gs, err := crypto.NewDigestFromBase58("B2u2TBpTYHWCuMuKLnbQfLvdLJ3zjgPiy3iMS2TSYugZ")
+	require.NoError(t, err)
+	return &proto.BlockHeader{
+		Timestamp:    1544715621,
+		NxtConsensus: proto.NxtConsensus{BaseTarget: 100, GenSignature: gs},
+		GenPublicKey: public,
+	}
+}
+
+func TestFuncsByVersion(t *testing.T) {
+	for _, v := range []int{1, 2} {
+		f, err := FuncsByVersion(v)
+		require.NoError(t, err)
+		_, ok := f.GetByShort(1000)
+		assert.True(t, ok)
+		_, ok = f.GetByShort(1004)
+		assert.False(t, ok, "assetInfo must not be available in version %d", v)
+	}
+	f, err := FuncsByVersion(3)
+	require.NoError(t, err)
+	for _, id := range []int16{1000, 1004, 1005, 1006, 1060} {
+		_, ok := f.GetByShort(id)
+		assert.True(t, ok, "function %d", id)
+	}
+	_, err = FuncsByVersion(4)
+	assert.Error(t, err)
+}
+
+func TestNewScopeByVersion(t *testing.T) {
+	this, err := proto.NewAddressFromString("3N9WtaPoD1tMrDZRG26wA142Byd35tLhnLU")
+	require.NoError(t, err)
+	header := newBlockHeader(t)
+	s := mockstate.MockStateImpl{
+		CurrentHeight: 10,
+		BlockHeaders:  map[uint64]*proto.BlockHeader{10: header},
+	}
+
+	v1, err := NewScopeByVersion(1, proto.MainNetScheme, s, this, nil)
+	require.NoError(t, err)
+	height, ok := v1.Value("height")
+	require.True(t, ok)
+	assert.Equal(t, NewLong(10), height)
+	_, ok = v1.Value("lastBlock")
+	assert.False(t, ok)
+	_, ok = v1.Value("this")
+	assert.False(t, ok)
+	_, ok = v1.Value("tx")
+	assert.False(t, ok)
+
+	v3, err := NewScopeByVersion(3, proto.MainNetScheme, s, this, nil)
+	require.NoError(t, err)
+	thisExpr, ok := v3.Value("this")
+	require.True(t, ok)
+	assert.Equal(t, NewAddressFromProtoAddress(this), thisExpr)
+	lastBlock, ok := v3.Value("lastBlock")
+	require.True(t, ok)
+	h, err := lastBlock.(*ObjectExpr).Get("height")
+	require.NoError(t, err)
+	assert.Equal(t, NewLong(10), h)
+	gen, err := lastBlock.(*ObjectExpr).Get("generatorPublicKey")
+	require.NoError(t, err)
+	assert.Equal(t, NewBytes(header.GenPublicKey.Bytes()), gen)
+}
+
+func TestNativeAssetInfo(t *testing.T) {
+	d, err := crypto.NewDigestFromBase58("B2u2TBpTYHWCuMuKLnbQfLvdLJ3zjgPiy3iMS2TSYugZ")
+	require.NoError(t, err)
+	s := mockstate.MockStateImpl{
+		Assets: map[string]mockstate.AssetInfo{d.String(): {ID: d, Quantity: 1000, Decimals: 2, Reissuable: true}},
+	}
+
+	rs, err := NativeAssetInfo(newScopeWithState(s), Params(NewBytes(d.Bytes())))
+	require.NoError(t, err)
+	obj, ok := rs.(*ObjectExpr)
+	require.True(t, ok)
+	assert.Equal(t, "Asset", obj.InstanceOf())
+	q, err := obj.Get("quantity")
+	require.NoError(t, err)
+	assert.Equal(t, NewLong(1000), q)
+
+	rs, err = NativeAssetInfo(newScopeWithState(s), Params(NewBytes([]byte{1, 2, 3})))
+	require.NoError(t, err)
+	assert.Equal(t, NewUnit(), rs)
+}
+
+func TestNativeBlockInfoByHeight(t *testing.T) {
+	s := mockstate.MockStateImpl{
+		BlockHeaders: map[uint64]*proto.BlockHeader{5: newBlockHeader(t)},
+	}
+
+	rs, err := NativeBlockInfoByHeight(newScopeWithState(s), Params(NewLong(5)))
+	require.NoError(t, err)
+	obj, ok := rs.(*ObjectExpr)
+	require.True(t, ok)
+	assert.Equal(t, "BlockInfo", obj.InstanceOf())
+	bt, err := obj.Get("baseTarget")
+	require.NoError(t, err)
+	assert.Equal(t, NewLong(100), bt)
+
+	rs, err = NativeBlockInfoByHeight(newScopeWithState(s), Params(NewLong(6)))
+	require.NoError(t, err)
+	assert.Equal(t, NewUnit(), rs)
+}
+
+func TestNativeTransferTransactionByID(t *testing.T) {
+	secret, public := crypto.GenerateKeyPair([]byte("abcde"))
+	addr, err := proto.NewAddressFromPublicKey(proto.MainNetScheme, public)
+	require.NoError(t, err)
+	transfer := proto.NewUnsignedTransferV1(public, proto.OptionalAsset{}, proto.OptionalAsset{}, 1544715621, 1, 10000, proto.NewRecipientFromAddress(addr), "")
+	require.NoError(t, transfer.Sign(secret))
+	lease := proto.NewUnsignedLeaseV1(public, proto.NewRecipientFromAddress(addr), 1, 10000, 1544715621)
+	require.NoError(t, lease.Sign(secret))
+
+	s := mockstate.MockStateImpl{
+		TransactionsByID: map[string]proto.Transaction{
+			transfer.ID.String(): transfer,
+			lease.ID.String():    lease,
+		},
+	}
+
+	rs, err := NativeTransferTransactionByID(newScopeWithState(s), Params(NewBytes(transfer.ID.Bytes())))
+	require.NoError(t, err)
+	assert.Equal(t, "TransferTransaction", rs.InstanceOf())
+
+	rs, err = NativeTransferTransactionByID(newScopeWithState(s), Params(NewBytes(lease.ID.Bytes())))
+	require.NoError(t, err)
+	assert.Equal(t, NewUnit(), rs)
+}
+
+func TestNativeAddressFromRecipient_Alias(t *testing.T) {
+	addr, err := proto.NewAddressFromString("3N9WtaPoD1tMrDZRG26wA142Byd35tLhnLU")
+	require.NoError(t, err)
+	alias := proto.NewAlias(proto.MainNetScheme, "test")
+	s := mockstate.MockStateImpl{
+		Aliases: map[string]proto.Address{alias.String(): addr},
+	}
+
+	rs, err := NativeAddressFromRecipient(newScopeWithState(s), Params(NewAliasFromProtoAlias(*alias)))
+	require.NoError(t, err)
+	assert.Equal(t, NewAddressFromProtoAddress(addr), rs)
+
+	rs, err = NativeAddressFromRecipient(newScopeWithState(s), Params(NewRecipientFromProtoRecipient(proto.NewRecipientFromAlias(*alias))))
+	require.NoError(t, err)
+	assert.Equal(t, NewAddressFromProtoAddress(addr), rs)
+
+	_, err = NativeAddressFromRecipient(newScopeWithState(s), Params(NewAliasFromProtoAlias(*proto.NewAlias(proto.MainNetScheme, "unknown"))))
+	assert.IsType(t, Throw{}, err)
+}
@@ -109,6 +109,34 @@ func NativeGetList(s Scope, e Exprs) (Expr, error) {
 	return lst[lng.Value], nil
 }
 
+// Prepend element to the list
+func NativeCons(s Scope, e Exprs) (Expr, error) {
+	funcName := "NativeCons"
+
+	if l := len(e); l != 2 {
+		return nil, errors.Errorf("%s: invalid params, expected 2, passed %d", funcName, l)
+	}
+
+	first, err := e[0].Evaluate(s.Clone())
+	if err != nil {
+		return nil, err
+	}
+
+	second, err := e[1].Evaluate(s.Clone())
+	if err != nil {
+		return nil, err
+	}
+
+	lst, ok := second.(Exprs)
+	if !ok {
+		return nil, errors.Errorf("%s: expected second argument Exprs, got %T", funcName, second)
+	}
+
+	out := make(Exprs, 0, len(lst)+1)
+	out = append(out, first)
+	return append(out, lst...), nil
+}
+
 // Internal function to check value type
 func NativeIsInstanceOf(s Scope, e Exprs) (Expr, error) {
 	funcName := "NativeIsInstanceOf"
@@ -277,9 +305,10 @@ func NativeSigVerify(s Scope, e Exprs) (Expr, error) {
 		return nil, errors.Wrap(err, funcName)
 	}
 
+	// Signature of wrong size, for example the absent proof, is not valid
 	signature, err := crypto.NewSignatureFromBytes(signatureExpr.Value)
 	if err != nil {
-		return nil, errors.Wrap(err, funcName)
+		return NewBoolean(false), nil
 	}
 
 	out := crypto.Verify(pk, signature, bytesExpr.Value)
@@ -415,6 +444,112 @@ func NativeTransactionByID(s Scope, e Exprs) (Expr, error) {
 	return NewObject(vars), nil
 }
 
+// Lookup transfer transaction, other types of transactions are not returned
+func NativeTransferTransactionByID(s Scope, e Exprs) (Expr, error) {
+	funcName := "NativeTransferTransactionByID"
+
+	if l := len(e); l != 1 {
+		return nil, errors.Errorf("%s: invalid params, expected 1, passed %d", funcName, l)
+	}
+
+	rs, err := e[0].Evaluate(s.Clone())
+	if err != nil {
+		return nil, errors.Wrap(err, funcName)
+	}
+
+	bts, ok := rs.(*BytesExpr)
+	if !ok {
+		return nil, errors.Errorf("%s: expected first argument to be *BytesExpr, got %T", funcName, rs)
+	}
+
+	tx, err := s.State().TransactionByID(bts.Value)
+	if err != nil {
+		if err == mockstate.ErrNotFound {
+			return Unit{}, nil
+		}
+		return nil, errors.Wrap(err, funcName)
+	}
+
+	switch tx.(type) {
+	case *proto.TransferV1, *proto.TransferV2:
+	default:
+		return Unit{}, nil
+	}
+
+	vars, err := NewVariablesFromTransaction(s.Scheme(), tx)
+	if err != nil {
+		return nil, errors.Wrap(err, funcName)
+	}
+
+	return NewObject(vars), nil
+}
+
+// Lookup asset info
+func NativeAssetInfo(s Scope, e Exprs) (Expr, error) {
+	funcName := "NativeAssetInfo"
+
+	if l := len(e); l != 1 {
+		return nil, errors.Errorf("%s: invalid params, expected 1, passed %d", funcName, l)
+	}
+
+	rs, err := e[0].Evaluate(s.Clone())
+	if err != nil {
+		return nil, errors.Wrap(err, funcName)
+	}
+
+	bts, ok := rs.(*BytesExpr)
+	if !ok {
+		return nil, errors.Errorf("%s: expected first argument to be *BytesExpr, got %T", funcName, rs)
+	}
+
+	info, err := s.State().AssetInfo(bts.Value)
+	if err != nil {
+		if err == mockstate.ErrNotFound {
+			return Unit{}, nil
+		}
+		return nil, errors.Wrap(err, funcName)
+	}
+
+	return NewObjectFromAssetInfo(info), nil
+}
+
+// Lookup block info by height
+func NativeBlockInfoByHeight(s Scope, e Exprs) (Expr, error) {
+	funcName := "NativeBlockInfoByHeight"
+
+	if l := len(e); l != 1 {
+		return nil, errors.Errorf("%s: invalid params, expected 1, passed %d", funcName, l)
+	}
+
+	rs, err := e[0].Evaluate(s.Clone())
+	if err != nil {
+		return nil, errors.Wrap(err, funcName)
+	}
+
+	height, ok := rs.(*LongExpr)
+	if !ok {
+		return nil, errors.Errorf("%s: expected first argument to be *LongExpr, got %T", funcName, rs)
+	}
+
+	if height.Value <= 0 {
+		return Unit{}, nil
+	}
+
+	header, err := s.State().BlockHeaderByHeight(uint64(height.Value))
+	if err != nil {
+		if err == mockstate.ErrNotFound {
+			return Unit{}, nil
+		}
+		return nil, errors.Wrap(err, funcName)
+	}
+
+	obj, err := NewObjectFromBlockHeader(s.Scheme(), uint64(height.Value), header)
+	if err != nil {
+		return nil, errors.Wrap(err, funcName)
+	}
+	return obj, nil
+}
+
 // Size of bytes vector
 func NativeSizeBytes(s Scope, e Exprs) (Expr, error) {
 	funcName := "NativeSizeBytes"
@@ -1101,12 +1236,35 @@ func NativeAddressFromRecipient(s Scope, e Exprs) (Expr, error) {
 		return nil, errors.Wrap(err, funcName)
 	}
 
-	recipient, ok := first.(RecipientExpr)
-	if !ok {
-		return nil, errors.Errorf("%s expected first argument to be RecipientExpr, found %T", funcName, recipient)
+	var r proto.Recipient
+	switch a := first.(type) {
+	case AddressExpr:
+		return a, nil
+	case AliasExpr:
+		r = proto.NewRecipientFromAlias(proto.Alias(a))
+	case RecipientExpr:
+		r = proto.Recipient(a)
+	default:
+		return nil, errors.Errorf("%s expected first argument to be AddressExpr, AliasExpr or RecipientExpr, found %T", funcName, first)
 	}
 
-	return NewAddressFromProtoAddress(s.State().Account(proto.Recipient(recipient)).Address()), nil
+	if r.Address != nil {
+		return NewAddressFromProtoAddress(*r.Address), nil
+	}
+
+	addr, err := s.State().AddressByAlias(*r.Alias)
+	if err != nil {
+		if err == mockstate.ErrNotFound {
+			// Account registered in state could also be found by alias
+			if acc := s.State().Account(r); acc != nil {
+				return NewAddressFromProtoAddress(acc.Address()), nil
+			}
+			return nil, Throw{Message: fmt.Sprintf("Alias '%s' does not exist", r.Alias.Alias)}
+		}
+		return nil, errors.Wrap(err, funcName)
+	}
+
+	return NewAddressFromProtoAddress(addr), nil
 }
 
 // Fail script without message (default will be used)
@@ -1433,6 +1591,77 @@ func UserWavesBalance(s Scope, e Exprs) (Expr, error) {
 	return NativeAssetBalance(s, append(e, NewUnit()))
 }
 
+// nativeFunctionNames maps identifiers of native functions to their names in RIDE.
+var nativeFunctionNames = map[int16]string{
+	0:    "==",
+	1:    "_isInstanceOf",
+	2:    "throw",
+	100:  "+",
+	101:  "-",
+	102:  ">",
+	103:  ">=",
+	104:  "*",
+	105:  "/",
+	106:  "%",
+	107:  "fraction",
+	108:  "pow",
+	109:  "log",
+	200:  "size",
+	201:  "take",
+	202:  "drop",
+	203:  "+",
+	300:  "+",
+	303:  "take",
+	304:  "drop",
+	305:  "size",
+	400:  "size",
+	401:  "getElement",
+	410:  "toBytes",
+	411:  "toBytes",
+	412:  "toBytes",
+	420:  "toString",
+	421:  "toString",
+	500:  "sigVerify",
+	501:  "keccak256",
+	502:  "blake2b256",
+	503:  "sha256",
+	504:  "rsaVerify",
+	600:  "toBase58String",
+	601:  "fromBase58String",
+	602:  "toBase64String",
+	603:  "fromBase64String",
+	700:  "checkMerkleProof",
+	1000: "transactionById",
+	1001: "transactionHeightById",
+	1003: "assetBalance",
+	1004: "assetInfo",
+	1005: "blockInfoByHeight",
+	1006: "transferTransactionById",
+	1040: "getInteger",
+	1041: "getBoolean",
+	1042: "getBinary",
+	1043: "getString",
+	1050: "getInteger",
+	1051: "getBoolean",
+	1052: "getBinary",
+	1053: "getString",
+	1060: "addressFromRecipient",
+	1100: "cons",
+	1200: "toUtf8String",
+	1201: "toInt",
+	1202: "toInt",
+	1203: "indexOf",
+	1204: "indexOf",
+	1205: "split",
+	1206: "parseInt",
+}
+
+// NativeFunctionName returns the name of native function in RIDE, false if the function is unknown.
+func NativeFunctionName(id int16) (string, bool) {
+	name, ok := nativeFunctionNames[id]
+	return name, ok
+}
+
 func prefix(w io.Writer, name string, e Exprs) {
 	_, _ = fmt.Fprintf(w, "%s(", name)
 	last := len(e) - 1
@@ -1462,6 +1691,10 @@ func writeNativeFunction(w io.Writer, id int16, e Exprs) {
 		prefix(w, "throw", e)
 	case 103:
 		infix(w, ">=", e)
+	case 108:
+		prefix(w, "pow", e)
+	case 109:
+		prefix(w, "log", e)
 	case 200:
 		prefix(w, "size", e)
 	case 203, 300:
@@ -1485,18 +1718,40 @@ func writeNativeFunction(w io.Writer, id int16, e Exprs) {
 		prefix(w, "blake2b256", e)
 	case 503:
 		prefix(w, "sha256", e)
+	case 504:
+		prefix(w, "rsaVerify", e)
 	case 600:
 		prefix(w, "toBase58String", e)
 	case 601:
 		prefix(w, "fromBase58String", e)
+	case 700:
+		prefix(w, "checkMerkleProof", e)
 	case 1000:
 		prefix(w, "transactionById", e)
 	case 1001:
 		prefix(w, "transactionHeightById", e)
 	case 1003:
 		prefix(w, "assetBalance", e)
+	case 1004:
+		prefix(w, "assetInfo", e)
+	case 1005:
+		prefix(w, "blockInfoByHeight", e)
+	case 1006:
+		prefix(w, "transferTransactionById", e)
 	case 1060:
 		prefix(w, "addressFromRecipient", e)
+	case 1100:
+		prefix(w, "cons", e)
+	case 1200:
+		prefix(w, "toUtf8String", e)
+	case 1201, 1202:
+		prefix(w, "toInt", e)
+	case 1203, 1204:
+		prefix(w, "indexOf", e)
+	case 1205:
+		prefix(w, "split", e)
+	case 1206:
+		prefix(w, "parseInt", e)
 	default:
 		prefix(w, fmt.Sprintf("FUNCTION_%d(", id), e)
 	}
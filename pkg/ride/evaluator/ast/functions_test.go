@@ -602,3 +602,12 @@ func TestUserAlias(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, NewAliasFromProtoAlias(*alias), rs1)
 }
+
+func TestNativeCons(t *testing.T) {
+	rs, err := NativeCons(newEmptyScope(), NewExprs(NewLong(1), NewExprs(NewLong(2), NewLong(3))))
+	require.NoError(t, err)
+	assert.Equal(t, NewExprs(NewLong(1), NewLong(2), NewLong(3)), rs)
+
+	_, err = NativeCons(newEmptyScope(), NewExprs(NewLong(1), NewLong(2)))
+	require.Error(t, err)
+}
@@ -0,0 +1,586 @@
+package ast
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/binary"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"golang.org/x/crypto/sha3"
+)
+
+// Functions of the third version of the standard library. Strings are indexed by characters, the same way as by
+// take, drop and size functions.
+
+type roundingMode struct {
+	variable string
+	instance string
+	mode     RoundingMode
+}
+
+// roundingModes are global variables of rounding modes of functions pow and log.
+var roundingModes = []roundingMode{
+	{"UP", "Up", RoundUp},
+	{"DOWN", "Down", RoundDown},
+	{"CEILING", "Ceiling", RoundCeiling},
+	{"FLOOR", "Floor", RoundFloor},
+	{"HALFUP", "HalfUp", RoundHalfUp},
+	{"HALFDOWN", "HalfDown", RoundHalfDown},
+	{"HALFEVEN", "HalfEven", RoundHalfEven},
+}
+
+type digestAlgorithm struct {
+	variable string
+	instance string
+	hash     func([]byte) []byte
+	// prefix is DER encoded DigestInfo header of the signed digest.
+	prefix []byte
+}
+
+// digestAlgorithms are global variables of digest algorithms of function rsaVerify.
+var digestAlgorithms = []digestAlgorithm{
+	{"NOALG", "NoAlg", func(b []byte) []byte { return b }, nil},
+	{"MD5", "Md5", func(b []byte) []byte { d := md5.Sum(b); return d[:] },
+		[]byte{0x30, 0x20, 0x30, 0x0c, 0x06, 0x08, 0x2a, 0x86, 0x48, 0x86, 0xf7, 0x0d, 0x02, 0x05, 0x05, 0x00, 0x04, 0x10}},
+	{"SHA1", "Sha1", func(b []byte) []byte { d := sha1.Sum(b); return d[:] },
+		[]byte{0x30, 0x21, 0x30, 0x09, 0x06, 0x05, 0x2b, 0x0e, 0x03, 0x02, 0x1a, 0x05, 0x00, 0x04, 0x14}},
+	{"SHA224", "Sha224", func(b []byte) []byte { d := sha256.Sum224(b); return d[:] }, digestInfoPrefix(0x04, 28)},
+	{"SHA256", "Sha256", func(b []byte) []byte { d := sha256.Sum256(b); return d[:] }, digestInfoPrefix(0x01, 32)},
+	{"SHA384", "Sha384", func(b []byte) []byte { d := sha512.Sum384(b); return d[:] }, digestInfoPrefix(0x02, 48)},
+	{"SHA512", "Sha512", func(b []byte) []byte { d := sha512.Sum512(b); return d[:] }, digestInfoPrefix(0x03, 64)},
+	{"SHA3224", "Sha3224", func(b []byte) []byte { d := sha3.Sum224(b); return d[:] }, digestInfoPrefix(0x07, 28)},
+	{"SHA3256", "Sha3256", func(b []byte) []byte { d := sha3.Sum256(b); return d[:] }, digestInfoPrefix(0x08, 32)},
+	{"SHA3384", "Sha3384", func(b []byte) []byte { d := sha3.Sum384(b); return d[:] }, digestInfoPrefix(0x09, 48)},
+	{"SHA3512", "Sha3512", func(b []byte) []byte { d := sha3.Sum512(b); return d[:] }, digestInfoPrefix(0x0a, 64)},
+}
+
+// digestInfoPrefix returns DigestInfo header for hash algorithms with OID 2.16.840.1.101.3.4.2.id.
+func digestInfoPrefix(id byte, size byte) []byte {
+	return []byte{0x30, 0x11 + size, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, id, 0x05, 0x00, 0x04, size}
+}
+
+func newCaseObject(instance string) *ObjectExpr {
+	return NewObject(map[string]Expr{InstanceFieldName: NewString(instance)})
+}
+
+// variablesV3Constants returns global variables of rounding modes and digest algorithms.
+func variablesV3Constants() map[string]Expr {
+	out := make(map[string]Expr, len(roundingModes)+len(digestAlgorithms))
+	for _, r := range roundingModes {
+		out[r.variable] = newCaseObject(r.instance)
+	}
+	for _, d := range digestAlgorithms {
+		out[d.variable] = newCaseObject(d.instance)
+	}
+	return out
+}
+
+func evaluateLongs(funcName string, s Scope, e Exprs, n int) ([]int64, Exprs, error) {
+	if l := len(e); l < n {
+		return nil, nil, errors.Errorf("%s: invalid params, expected at least %d, passed %d", funcName, n, l)
+	}
+	rs, err := e.EvaluateAll(s.Clone())
+	if err != nil {
+		return nil, nil, errors.Wrap(err, funcName)
+	}
+	out := make([]int64, n)
+	for i := 0; i < n; i++ {
+		v, ok := rs[i].(*LongExpr)
+		if !ok {
+			return nil, nil, errors.Errorf("%s: expected argument %d to be *LongExpr, found %T", funcName, i+1, rs[i])
+		}
+		out[i] = v.Value
+	}
+	return out, rs, nil
+}
+
+func decimalFunction(funcName string, f func(b, bp, e, ep, rp int64, mode RoundingMode) (int64, error), s Scope, e Exprs) (Expr, error) {
+	if l := len(e); l != 6 {
+		return nil, errors.Errorf("%s: invalid params, expected 6, passed %d", funcName, l)
+	}
+	v, rs, err := evaluateLongs(funcName, s, e, 5)
+	if err != nil {
+		return nil, err
+	}
+	mode, ok := findRoundingMode(rs[5].InstanceOf())
+	if !ok {
+		return nil, errors.Errorf("%s: unknown rounding mode %s", funcName, rs[5].InstanceOf())
+	}
+	r, err := f(v[0], v[1], v[2], v[3], v[4], mode)
+	if err != nil {
+		return nil, errors.Wrap(err, funcName)
+	}
+	return NewLong(r), nil
+}
+
+func findRoundingMode(instance string) (RoundingMode, bool) {
+	for _, r := range roundingModes {
+		if r.instance == instance {
+			return r.mode, true
+		}
+	}
+	return 0, false
+}
+
+// Power of decimal numbers, pow(base, baseDecimals, exponent, exponentDecimals, resultDecimals, rounding)
+func NativePowLong(s Scope, e Exprs) (Expr, error) {
+	return decimalFunction("NativePowLong", pow, s, e)
+}
+
+// Logarithm of decimal numbers, log(value, valueDecimals, base, baseDecimals, resultDecimals, rounding)
+func NativeLogLong(s Scope, e Exprs) (Expr, error) {
+	return decimalFunction("NativeLogLong", log, s, e)
+}
+
+// Verify RSA signature of the message, the public key is X.509 encoded
+func NativeRSAVerify(s Scope, e Exprs) (Expr, error) {
+	funcName := "NativeRSAVerify"
+
+	if l := len(e); l != 4 {
+		return nil, errors.Errorf("%s: invalid params, expected 4, passed %d", funcName, l)
+	}
+
+	rs, err := e.EvaluateAll(s.Clone())
+	if err != nil {
+		return nil, errors.Wrap(err, funcName)
+	}
+
+	var alg *digestAlgorithm
+	for i := range digestAlgorithms {
+		if digestAlgorithms[i].instance == rs[0].InstanceOf() {
+			alg = &digestAlgorithms[i]
+			break
+		}
+	}
+	if alg == nil {
+		return nil, errors.Errorf("%s: unknown digest algorithm %s", funcName, rs[0].InstanceOf())
+	}
+
+	var args [3][]byte
+	for i := range args {
+		b, ok := rs[i+1].(*BytesExpr)
+		if !ok {
+			return nil, errors.Errorf("%s: expected argument %d to be *BytesExpr, found %T", funcName, i+2, rs[i+1])
+		}
+		args[i] = b.Value
+	}
+	message, signature, pkBytes := args[0], args[1], args[2]
+
+	pk, err := x509.ParsePKIXPublicKey(pkBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, funcName)
+	}
+	rsaPK, ok := pk.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.Errorf("%s: not an RSA public key", funcName)
+	}
+
+	// Digest with DigestInfo header is signed as is
+	digest := append(append([]byte{}, alg.prefix...), alg.hash(message)...)
+	return NewBoolean(rsa.VerifyPKCS1v15(rsaPK, 0, digest, signature) == nil), nil
+}
+
+// Check that the value is in the Merkle tree with the given root
+func NativeCheckMerkleProof(s Scope, e Exprs) (Expr, error) {
+	funcName := "NativeCheckMerkleProof"
+
+	if l := len(e); l != 3 {
+		return nil, errors.Errorf("%s: invalid params, expected 3, passed %d", funcName, l)
+	}
+
+	rs, err := e.EvaluateAll(s.Clone())
+	if err != nil {
+		return nil, errors.Wrap(err, funcName)
+	}
+
+	var args [3][]byte
+	for i := range args {
+		b, ok := rs[i].(*BytesExpr)
+		if !ok {
+			return nil, errors.Errorf("%s: expected argument %d to be *BytesExpr, found %T", funcName, i+1, rs[i])
+		}
+		args[i] = b.Value
+	}
+
+	ok, err := checkMerkleProof(args[0], args[1], args[2])
+	if err != nil {
+		return nil, errors.Wrap(err, funcName)
+	}
+	return NewBoolean(ok), nil
+}
+
+const (
+	merkleLeafPrefix     = 0
+	merkleInternalPrefix = 1
+	merkleLeftSide       = 0
+)
+
+// checkMerkleProof verifies the proof, which is the sequence of levels: side byte, hash length byte and hash.
+// Malformed root or proof are not errors, the check just fails.
+func checkMerkleProof(root, proof, value []byte) (bool, error) {
+	if len(root) != crypto.DigestSize {
+		return false, nil
+	}
+	h, err := crypto.FastHash(append([]byte{merkleLeafPrefix}, value...))
+	if err != nil {
+		return false, err
+	}
+	current := h.Bytes()
+	for len(proof) > 0 {
+		if len(proof) < 2 {
+			return false, nil
+		}
+		side, size := proof[0], int(int8(proof[1]))
+		if size < 0 {
+			size = 0
+		}
+		if size > len(proof)-2 {
+			size = len(proof) - 2
+		}
+		hash := proof[2 : 2+size]
+		proof = proof[2+size:]
+		buf := make([]byte, 0, 1+len(current)+len(hash))
+		buf = append(buf, merkleInternalPrefix)
+		if side == merkleLeftSide {
+			buf = append(append(buf, current...), hash...)
+		} else {
+			buf = append(append(buf, hash...), current...)
+		}
+		h, err := crypto.FastHash(buf)
+		if err != nil {
+			return false, err
+		}
+		current = h.Bytes()
+	}
+	return bytes.Equal(current, root), nil
+}
+
+// Decode bytes as UTF-8 string, malformed sequences are replaced with U+FFFD
+func NativeToUtf8String(s Scope, e Exprs) (Expr, error) {
+	funcName := "NativeToUtf8String"
+
+	if l := len(e); l != 1 {
+		return nil, errors.Errorf("%s: invalid params, expected 1, passed %d", funcName, l)
+	}
+
+	rs, err := e[0].Evaluate(s.Clone())
+	if err != nil {
+		return nil, errors.Wrap(err, funcName)
+	}
+
+	b, ok := rs.(*BytesExpr)
+	if !ok {
+		return nil, errors.Errorf("%s: expected first argument to be *BytesExpr, found %T", funcName, rs)
+	}
+
+	return NewString(decodeUTF8(b.Value)), nil
+}
+
+// decodeUTF8 replaces every maximal invalid subsequence with U+FFFD, as Java does.
+func decodeUTF8(b []byte) string {
+	if utf8.Valid(b) {
+		return string(b)
+	}
+	var sb strings.Builder
+	for len(b) > 0 {
+		r, size := utf8.DecodeRune(b)
+		if r != utf8.RuneError || size > 1 {
+			sb.WriteRune(r)
+			b = b[size:]
+			continue
+		}
+		sb.WriteRune(utf8.RuneError)
+		b = b[malformedLength(b):]
+	}
+	return sb.String()
+}
+
+// malformedLength returns the length of the maximal prefix of the malformed sequence which could start a valid one.
+func malformedLength(b []byte) int {
+	var n int
+	lo, hi := byte(0x80), byte(0xbf)
+	switch c := b[0]; {
+	case c >= 0xc2 && c <= 0xdf:
+		n = 2
+	case c >= 0xe0 && c <= 0xef:
+		n = 3
+		if c == 0xe0 {
+			lo = 0xa0
+		} else if c == 0xed {
+			hi = 0x9f
+		}
+	case c >= 0xf0 && c <= 0xf4:
+		n = 4
+		if c == 0xf0 {
+			lo = 0x90
+		} else if c == 0xf4 {
+			hi = 0x8f
+		}
+	default:
+		return 1
+	}
+	i := 1
+	for ; i < n && i < len(b); i++ {
+		if b[i] < lo || b[i] > hi {
+			break
+		}
+		lo, hi = 0x80, 0xbf
+	}
+	return i
+}
+
+// Read big endian long from the first 8 bytes
+func NativeBytesToLong(s Scope, e Exprs) (Expr, error) {
+	funcName := "NativeBytesToLong"
+
+	if l := len(e); l != 1 {
+		return nil, errors.Errorf("%s: invalid params, expected 1, passed %d", funcName, l)
+	}
+
+	rs, err := e[0].Evaluate(s.Clone())
+	if err != nil {
+		return nil, errors.Wrap(err, funcName)
+	}
+
+	b, ok := rs.(*BytesExpr)
+	if !ok {
+		return nil, errors.Errorf("%s: expected first argument to be *BytesExpr, found %T", funcName, rs)
+	}
+
+	if len(b.Value) < 8 {
+		return nil, errors.Errorf("%s: not enough bytes, expected at least 8, found %d", funcName, len(b.Value))
+	}
+
+	return NewLong(int64(binary.BigEndian.Uint64(b.Value))), nil
+}
+
+// Read big endian long from 8 bytes starting at the offset
+func NativeBytesToLongWithOffset(s Scope, e Exprs) (Expr, error) {
+	funcName := "NativeBytesToLongWithOffset"
+
+	if l := len(e); l != 2 {
+		return nil, errors.Errorf("%s: invalid params, expected 2, passed %d", funcName, l)
+	}
+
+	rs, err := e.EvaluateAll(s.Clone())
+	if err != nil {
+		return nil, errors.Wrap(err, funcName)
+	}
+
+	b, ok := rs[0].(*BytesExpr)
+	if !ok {
+		return nil, errors.Errorf("%s: expected first argument to be *BytesExpr, found %T", funcName, rs[0])
+	}
+
+	offset, ok := rs[1].(*LongExpr)
+	if !ok {
+		return nil, errors.Errorf("%s: expected second argument to be *LongExpr, found %T", funcName, rs[1])
+	}
+
+	if offset.Value < 0 || offset.Value > int64(len(b.Value))-8 {
+		return nil, errors.Errorf("%s: offset %d out of bounds", funcName, offset.Value)
+	}
+
+	return NewLong(int64(binary.BigEndian.Uint64(b.Value[offset.Value:]))), nil
+}
+
+// Index of the first occurrence of substring
+func NativeIndexOf(s Scope, e Exprs) (Expr, error) {
+	funcName := "NativeIndexOf"
+
+	if l := len(e); l != 2 {
+		return nil, errors.Errorf("%s: invalid params, expected 2, passed %d", funcName, l)
+	}
+
+	str, sub, err := evaluateStrings(funcName, s, e)
+	if err != nil {
+		return nil, err
+	}
+
+	return indexOf(str, sub, 0), nil
+}
+
+// Index of the first occurrence of substring starting at the offset
+func NativeIndexOfWithOffset(s Scope, e Exprs) (Expr, error) {
+	funcName := "NativeIndexOfWithOffset"
+
+	if l := len(e); l != 3 {
+		return nil, errors.Errorf("%s: invalid params, expected 3, passed %d", funcName, l)
+	}
+
+	str, sub, err := evaluateStrings(funcName, s, e[:2])
+	if err != nil {
+		return nil, err
+	}
+
+	rs, err := e[2].Evaluate(s.Clone())
+	if err != nil {
+		return nil, errors.Wrap(err, funcName)
+	}
+
+	offset, ok := rs.(*LongExpr)
+	if !ok {
+		return nil, errors.Errorf("%s: expected third argument to be *LongExpr, found %T", funcName, rs)
+	}
+
+	if offset.Value < 0 || offset.Value > int64(utf8.RuneCountInString(str)) {
+		return NewUnit(), nil
+	}
+
+	return indexOf(str, sub, int(offset.Value)), nil
+}
+
+func evaluateStrings(funcName string, s Scope, e Exprs) (string, string, error) {
+	rs, err := e.EvaluateAll(s.Clone())
+	if err != nil {
+		return "", "", errors.Wrap(err, funcName)
+	}
+
+	first, ok := rs[0].(*StringExpr)
+	if !ok {
+		return "", "", errors.Errorf("%s: expected first argument to be *StringExpr, found %T", funcName, rs[0])
+	}
+
+	second, ok := rs[1].(*StringExpr)
+	if !ok {
+		return "", "", errors.Errorf("%s: expected second argument to be *StringExpr, found %T", funcName, rs[1])
+	}
+
+	return first.Value, second.Value, nil
+}
+
+// indexOf returns the index in characters of the substring, starting the search at the offset in characters.
+func indexOf(str, sub string, offset int) Expr {
+	runes := []rune(str)
+	tail := string(runes[offset:])
+	i := strings.Index(tail, sub)
+	if i < 0 {
+		return NewUnit()
+	}
+	return NewLong(int64(offset + utf8.RuneCountInString(tail[:i])))
+}
+
+// Split string by separator, empty separator splits string into characters
+func NativeSplitString(s Scope, e Exprs) (Expr, error) {
+	funcName := "NativeSplitString"
+
+	if l := len(e); l != 2 {
+		return nil, errors.Errorf("%s: invalid params, expected 2, passed %d", funcName, l)
+	}
+
+	str, sep, err := evaluateStrings(funcName, s, e)
+	if err != nil {
+		return nil, err
+	}
+
+	var parts []string
+	if str == "" {
+		parts = []string{""}
+	} else {
+		parts = strings.Split(str, sep)
+	}
+
+	out := make(Exprs, len(parts))
+	for i, p := range parts {
+		out[i] = NewString(p)
+	}
+	return out, nil
+}
+
+// Parse decimal integer, returns unit if string is not a number or it doesn't fit into long
+func NativeParseInt(s Scope, e Exprs) (Expr, error) {
+	funcName := "NativeParseInt"
+
+	if l := len(e); l != 1 {
+		return nil, errors.Errorf("%s: invalid params, expected 1, passed %d", funcName, l)
+	}
+
+	rs, err := e[0].Evaluate(s.Clone())
+	if err != nil {
+		return nil, errors.Wrap(err, funcName)
+	}
+
+	str, ok := rs.(*StringExpr)
+	if !ok {
+		return nil, errors.Errorf("%s: expected first argument to be *StringExpr, found %T", funcName, rs)
+	}
+
+	v, ok := parseLong(str.Value)
+	if !ok {
+		return NewUnit(), nil
+	}
+	return NewLong(v), nil
+}
+
+// parseLong parses the string like java.lang.Long.parseLong, which accepts the sign and decimal digits of any script.
+func parseLong(s string) (int64, bool) {
+	runes := []rune(s)
+	negative := false
+	if len(runes) > 0 && (runes[0] == '-' || runes[0] == '+') {
+		negative = runes[0] == '-'
+		runes = runes[1:]
+	}
+	if len(runes) == 0 {
+		return 0, false
+	}
+	// Accumulate negative value to fit math.MinInt64
+	var v int64
+	for _, r := range runes {
+		d, ok := digitValue(r)
+		if !ok {
+			return 0, false
+		}
+		if v < (math.MinInt64+int64(d))/10 {
+			return 0, false
+		}
+		v = v*10 - int64(d)
+	}
+	if negative {
+		return v, true
+	}
+	if v == math.MinInt64 {
+		return 0, false
+	}
+	return -v, true
+}
+
+// digitValue returns the value of the decimal digit, digits of every script are encoded as contiguous ranges from 0 to 9.
+func digitValue(r rune) (int, bool) {
+	if r >= '0' && r <= '9' {
+		return int(r - '0'), true
+	}
+	if !unicode.IsDigit(r) {
+		return 0, false
+	}
+	first := r
+	for unicode.IsDigit(first - 1) {
+		first--
+	}
+	return int(r-first) % 10, true
+}
+
+// Parse decimal integer or fail
+func UserParseIntValue(s Scope, e Exprs) (Expr, error) {
+	funcName := "UserParseIntValue"
+
+	rs, err := NativeParseInt(s, e)
+	if err != nil {
+		return nil, errors.Wrap(err, funcName)
+	}
+
+	if rs.InstanceOf() == (Unit{}).InstanceOf() {
+		return NativeThrow(s.Clone(), Params(NewString("extract() called on unit value")))
+	}
+
+	return rs, nil
+}
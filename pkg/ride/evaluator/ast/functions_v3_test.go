@@ -0,0 +1,353 @@
+package ast
+
+import (
+	"encoding/base64"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride/mockstate"
+)
+
+func newV3Scope() Scope {
+	return NewScope(proto.MainNetScheme, mockstate.MockStateImpl{}, FuncsV3(), variablesV3Constants())
+}
+
+func roundingRef(name string) Expr {
+	return &RefExpr{Name: name}
+}
+
+// Expected values are calculated with decimal arithmetic of 34 significant digits and HALF_EVEN rounding of
+// intermediate results, the same as the reference implementation does with java.math.BigDecimal.
+func TestNativePowLog(t *testing.T) {
+	for _, test := range []struct {
+		function         string
+		b, bp, e, ep, rp int64
+		rounding         string
+		result           int64
+		err              bool
+	}{
+		{"pow", 12, 1, 3456, 3, 2, "UP", 188, false},
+		{"pow", 12, 1, 3456, 3, 2, "DOWN", 187, false},
+		{"pow", 12, 1, 3456, 3, 2, "FLOOR", 187, false},
+		{"pow", 12, 1, 3456, 3, 2, "HALFEVEN", 188, false},
+		{"pow", 2, 0, 10, 0, 0, "UP", 1024, false},
+		{"pow", 16, 0, 5, 1, 4, "UP", 40000, false},
+		{"pow", 3, 0, -2, 0, 8, "UP", 11111112, false},
+		{"pow", 3, 0, -2, 0, 8, "DOWN", 11111111, false},
+		{"pow", 3, 0, -2, 0, 8, "FLOOR", 11111111, false},
+		{"pow", 3, 0, -2, 0, 8, "HALFEVEN", 11111111, false},
+		{"pow", 98765, 2, 314, 2, 8, "UP", 252961037456580891, false},
+		{"pow", 98765, 2, 314, 2, 8, "DOWN", 252961037456580890, false},
+		{"pow", 98765, 2, 314, 2, 8, "FLOOR", 252961037456580890, false},
+		{"pow", 98765, 2, 314, 2, 8, "HALFEVEN", 252961037456580891, false},
+		{"pow", 5, 0, 5, 1, 8, "UP", 223606798, false},
+		{"pow", 5, 0, 5, 1, 8, "DOWN", 223606797, false},
+		{"pow", 5, 0, 5, 1, 8, "FLOOR", 223606797, false},
+		{"pow", 5, 0, 5, 1, 8, "HALFEVEN", 223606798, false},
+		{"pow", 100, 0, -5, 1, 5, "UP", 10000, false},
+		{"pow", 1, 8, 5, 1, 8, "UP", 10000, false},
+		{"pow", 123456789, 8, 100, 0, 0, "UP", 1417417261, false},
+		{"pow", 123456789, 8, 100, 0, 0, "DOWN", 1417417260, false},
+		{"pow", 123456789, 8, 100, 0, 0, "FLOOR", 1417417260, false},
+		{"pow", 123456789, 8, 100, 0, 0, "HALFEVEN", 1417417260, false},
+		{"pow", -2, 0, 3, 0, 0, "UP", -8, false},
+		{"pow", 10, 0, 18, 0, 0, "UP", 1000000000000000000, false},
+		{"pow", 10, 0, 19, 0, 0, "UP", 0, true},
+		{"pow", 2, 0, 62, 0, 0, "UP", 4611686018427387904, false},
+		{"pow", 15, 1, -300, 2, 8, "UP", 29629630, false},
+		{"pow", 15, 1, -300, 2, 8, "DOWN", 29629629, false},
+		{"pow", 15, 1, -300, 2, 8, "FLOOR", 29629629, false},
+		{"pow", 15, 1, -300, 2, 8, "HALFEVEN", 29629630, false},
+		{"log", 16, 0, 2, 0, 0, "UP", 4, false},
+		{"log", 16, 0, 2, 0, 0, "DOWN", 3, false},
+		{"log", 16, 0, 2, 0, 0, "FLOOR", 3, false},
+		{"log", 16, 0, 2, 0, 0, "HALFEVEN", 4, false},
+		{"log", 100, 0, 10, 0, 0, "UP", 2, false},
+		{"log", 625, 0, 5, 0, 8, "UP", 400000000, false},
+		{"log", 625, 0, 5, 0, 8, "DOWN", 399999999, false},
+		{"log", 625, 0, 5, 0, 8, "FLOOR", 399999999, false},
+		{"log", 625, 0, 5, 0, 8, "HALFEVEN", 400000000, false},
+		{"log", 2, 0, 10, 0, 8, "UP", 30103000, false},
+		{"log", 2, 0, 10, 0, 8, "DOWN", 30102999, false},
+		{"log", 2, 0, 10, 0, 8, "FLOOR", 30102999, false},
+		{"log", 2, 0, 10, 0, 8, "HALFEVEN", 30103000, false},
+		{"log", 12345, 3, 27182818, 7, 8, "UP", 251325115, false},
+		{"log", 12345, 3, 27182818, 7, 8, "DOWN", 251325114, false},
+		{"log", 12345, 3, 27182818, 7, 8, "FLOOR", 251325114, false},
+		{"log", 12345, 3, 27182818, 7, 8, "HALFEVEN", 251325115, false},
+		{"log", 1, 8, 2, 0, 8, "UP", -2657542476, false},
+		{"log", 1, 8, 2, 0, 8, "DOWN", -2657542475, false},
+		{"log", 1, 8, 2, 0, 8, "FLOOR", -2657542476, false},
+		{"log", 1, 8, 2, 0, 8, "HALFEVEN", -2657542476, false},
+		{"log", 3, 0, 9, 0, 8, "UP", 50000001, false},
+		{"log", 3, 0, 9, 0, 8, "DOWN", 50000000, false},
+		{"log", 3, 0, 9, 0, 8, "FLOOR", 50000000, false},
+		{"log", 3, 0, 9, 0, 8, "HALFEVEN", 50000000, false},
+		{"log", 1000000001, 8, 10, 0, 8, "UP", 100000001, false},
+		{"log", 1000000001, 8, 10, 0, 8, "DOWN", 100000000, false},
+		{"log", 1000000001, 8, 10, 0, 8, "FLOOR", 100000000, false},
+		{"log", 1000000001, 8, 10, 0, 8, "HALFEVEN", 100000000, false},
+		{"log", 993909, 2, 611099, 0, 8, "UP", 69085198, false},
+		{"log", 991710, 1, 765, 0, 6, "UP", 1732654, false},
+		{"pow", 13661115788, 3, -30, 1, 0, "HALFUP", 0, false},
+		{"log", 58118295907, 5, 717, 0, 4, "DOWN", 20186, false},
+		{"pow", 448955963, 2, -840633, 7, 6, "UP", 275927, false},
+		{"log", 615281917, 5, 36, 0, 7, "UP", 24346644, false},
+		{"pow", 8, 5, 1908, 7, 0, "DOWN", 0, false},
+		{"log", 434855194500, 6, 298327497, 0, 7, "FLOOR", 6653154, false},
+		{"pow", 25, 1, 2, 0, 1, "HALFUP", 63, false},
+		{"pow", 25, 1, 2, 0, 1, "HALFDOWN", 62, false},
+		{"pow", 25, 1, 2, 0, 1, "HALFEVEN", 62, false},
+		{"pow", 35, 1, 2, 0, 1, "HALFEVEN", 122, false},
+		{"pow", -15, 1, 3, 0, 2, "UP", -338, false},
+		{"pow", -15, 1, 3, 0, 2, "DOWN", -337, false},
+		{"pow", -15, 1, 3, 0, 2, "CEILING", -337, false},
+		{"pow", -15, 1, 3, 0, 2, "FLOOR", -338, false},
+		{"pow", -15, 1, 3, 0, 2, "HALFUP", -338, false},
+		{"pow", -15, 1, 3, 0, 2, "HALFDOWN", -337, false},
+		{"pow", 0, 0, 0, 0, 0, "DOWN", 1, false},
+		{"pow", 0, 0, 5, 1, 8, "DOWN", 0, false},
+		{"pow", 0, 0, -1, 0, 0, "DOWN", 0, true},
+		{"pow", -2, 0, 5, 1, 0, "DOWN", 0, true},
+		{"pow", 2, 9, 2, 0, 0, "DOWN", 0, true},
+		{"pow", 2, 0, 2, 0, -1, "DOWN", 0, true},
+		{"pow", math.MaxInt64, 0, 2, 0, 0, "DOWN", 0, true},
+		{"pow", 5, 1, math.MaxInt64, 0, 8, "UP", 1, false},
+		{"pow", 5, 1, math.MaxInt64, 0, 8, "DOWN", 0, false},
+		{"log", 0, 0, 2, 0, 0, "DOWN", 0, true},
+		{"log", -2, 0, 2, 0, 0, "DOWN", 0, true},
+		{"log", 2, 0, 1, 0, 0, "DOWN", 0, true},
+		{"log", 1, 0, 2, 0, 8, "DOWN", 0, false},
+		{"log", 2, 0, 2, 9, 0, "DOWN", 0, true},
+	} {
+		f := NativePowLong
+		if test.function == "log" {
+			f = NativeLogLong
+		}
+		args := Params(NewLong(test.b), NewLong(test.bp), NewLong(test.e), NewLong(test.ep), NewLong(test.rp), roundingRef(test.rounding))
+		rs, err := f(newV3Scope(), args)
+		if test.err {
+			assert.Error(t, err, "%s(%d, %d, %d, %d, %d, %s)", test.function, test.b, test.bp, test.e, test.ep, test.rp, test.rounding)
+			continue
+		}
+		require.NoError(t, err, "%s(%d, %d, %d, %d, %d, %s)", test.function, test.b, test.bp, test.e, test.ep, test.rp, test.rounding)
+		assert.Equal(t, NewLong(test.result), rs, "%s(%d, %d, %d, %d, %d, %s)", test.function, test.b, test.bp, test.e, test.ep, test.rp, test.rounding)
+	}
+}
+
+func TestNativeRSAVerify(t *testing.T) {
+	pk, err := base64.StdEncoding.DecodeString("MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA7NFtCjh4zuU4QnT4rsFlRKzCLsnvYGcvaFCW8Gz9VmvamBZDE1FULvi9m7r+Oj3eAUVp+6qj+E5eBaG8VxyP+zq9RjKkUsWS91T5I+cHsazlJ882KrvzAkoDzJ9lUbZpE2t7i9WaJdXnySPPUvVRFJCgBI/f7k83/QcYiW+mn1hqndMKoEHsV2UEuuIh0T9Z2csLaStQeG+AERAo0fHZYjUa4UCyAAqeAPkj1BgmL3uXWxJUfRbPdlnxR1ggtpQa7km9YPMVRhrg+Bo7dTChnr4K1U7tvSUdZTldzuOQxAmqvh4yQB6h85AVD1iRo6mkGF/nY8fKAtrpzwSMClk5FwIDAQAB")
+	require.NoError(t, err)
+	message := []byte("Hello, RIDE!")
+	// Signatures made with OpenSSL
+	signatures := map[string]string{
+		"SHA256":  "5Ne+lMrM8+7+aMwieqi6jfmHDG5XcFQzZQ+su8RyetMs2utn9f+acypNOKgGBPyhEzDKiXvNPFZ2T7GWBryU8FJyfScSkhCdvOAvoRHD+MbVnPjkA72/2F1JJZ9ATfQtafXUmOZjL2pxc+zZ07MysK3WOT5t6YH2reLIl9lgeiQxfMgqnkOATYPxWIL04MZjoPCmuKsiNSO9lW33RQxAJv0lvtA1wZggQsbNcbi71tAzmuGS8MYBBKMqOFuHhV0sXBsXB1aoKbbO3hkSyzFOOkxPBw9svjQJgPPd8qmBG0YInua6CRQrOZv8GtKH4MPQdsZJXSvxeNhHQRTT/imAJA==",
+		"SHA3256": "zkcULIZeREmozeJjjAVIno6KXCMcHGx55CjR7GSoXBw5SGxqIOfxblap+mgdT71nO/fPB+7vd5JDJPvgoIbMEu9Zf4zHAppmK3PG+8iBxyUeaSegBJFoTa3sHSz3XNfkTzTzGgQlWwSOZq5sKBB2nyErAXjQ/2JLRy2nSJcR1hC4VzKBave6q1CaDUsSmlwYLTpj6JXfc6ybL3lheACPFjtmfZIp2AhtWJmcY9a9XvjjqRcIMLP+iAh6U/rtUV4sRYHiGcQElDBLDwyv6DHMOlK3TLmqFpkTLVmd5NixaWOlhDR2Lh7bpvy/9yRjEXX263ZtDMuoQ0BVSHW4/xnfTg==",
+		"MD5":     "AqkjC+rC+g99FJskxiF0CV2JT02V/yi10Jv7NbTz2kXxXl7YyMD+YrXW8YRHp2yIfpOXS662THMUH1aQdf5prjTKvNLbPbs8MBlY80Otu5vD09rZUISTf3Jje38MuF+G2AUKJ7FTgewnwImWI+h3khjE/kQXSJFLx1Cl88dpmWqlGha6iJys/oyJnpEjKPEtgW4pHtSwh3b7MckDpeVKF82LUtiij7tYzHTnVaq2DSW57B7+CRMBYoN3QlViWnLB3IMTKQ8FE3u53E+Y/cVEyj45wAeIAxaHftuORjyWJGmz/qL3quFAgmW7Ye5RRfnF6rfndcoPM+xfA7ThF+IKRQ==",
+		"SHA512":  "BwPGQc8m8H30n721NzdphP33mFi/0fVvsrcvEQgORdI9tnx3U05to1ePxNBNpPVGzGZVsesFMyphCXu0tgoOoSLkm+APqd7INNGSt45SxbN7Tv1OPvgIILW9d4haUvNaqSNSnIsX1C8evaaKWWjUOFlRmX1FWWmR8sn4TW0XmQrPj0SAJLNTMkvqw2J/Sd8ZdrerCXTvsCweK3EWMtzYOjQtRjY7+Lk6mJnvhM2ADvBeW5T3FFfxZbuUv3KWkWruTTcMCvf4MR1jEOvBIhGW8PoiMO2+EnD2emOfCwqNVq0c65RVKe1Qn6k98JPBLczwuLFMbLnCET7JzeD/s4aaYQ==",
+		"NOALG":   "MuaHpgAUpsucHlWBpj2XCN0wwCW+LjvrUxKvlkYMBDbJozDxkcagWoZQ4fJ0d6GdlCzThQVXhAPGOHrPjqR1gFeeg5J0bsm2yUGCa9XArJ4LGaSC9LcgzwHuA3gpaEzfN2n9MIBT8BkIyW1yw3PdZC+kK6AfKcv4YN0QlvyMWKaXL7hBF5qPmdwV/wV7vHyfAePSuH88Vql6V6bcKcxTAp4VxpUiBeiIx1kMj6+ykfNe2ugIyXET8zNqaO/AgmU6ElIrNfj3MRKiwVQ4hYIcDD84/HCLgnqPOxrsz59IN5/fBPJcp+EQygRvaSK7WnpzAgiJh/2jgFeG8Gb+4M++Tw==",
+	}
+	for alg, s := range signatures {
+		sig, err := base64.StdEncoding.DecodeString(s)
+		require.NoError(t, err)
+		rs, err := NativeRSAVerify(newV3Scope(), Params(roundingRef(alg), NewBytes(message), NewBytes(sig), NewBytes(pk)))
+		require.NoError(t, err)
+		assert.Equal(t, NewBoolean(true), rs, alg)
+
+		rs, err = NativeRSAVerify(newV3Scope(), Params(roundingRef(alg), NewBytes([]byte("Hello, RIDE?")), NewBytes(sig), NewBytes(pk)))
+		require.NoError(t, err)
+		assert.Equal(t, NewBoolean(false), rs, alg)
+
+		// Signature of the other digest algorithm
+		rs, err = NativeRSAVerify(newV3Scope(), Params(roundingRef("SHA384"), NewBytes(message), NewBytes(sig), NewBytes(pk)))
+		require.NoError(t, err)
+		assert.Equal(t, NewBoolean(false), rs, alg)
+	}
+	_, err = NativeRSAVerify(newV3Scope(), Params(roundingRef("SHA256"), NewBytes(message), NewBytes(message), NewBytes(message)))
+	assert.Error(t, err)
+	_, err = NativeRSAVerify(newV3Scope(), Params(roundingRef("HALFUP"), NewBytes(message), NewBytes(message), NewBytes(pk)))
+	assert.Error(t, err)
+}
+
+func TestNativeCheckMerkleProof(t *testing.T) {
+	// Tree of leaves "one", "two", "three" and "four" hashed with BLAKE2b-256, leaves prefixed with 0, nodes with 1
+	root, _ := base64.StdEncoding.DecodeString("vSHPUVJCm+RiuqOHpPtTxPWlfSpeegwxq1vkL+uWDE4=")
+	proofThree, _ := base64.StdEncoding.DecodeString("ACDFNqa4s+qMMjyHDkd3wALrBuWlReRrEMUhEGINmPxBtgEgd9bgB9if3XVM9fe+48c4/VSEdfmqTSMTWkpJxUqLsVw=")
+	proofTwo, _ := base64.StdEncoding.DecodeString("ASAG92VR0XqYKEIc/+gu9XPZder2cGNMiK/Hx6RTHK/glwAgqV3r5um7EyOBsrm5cNxe166J2UTv0ecd4YAjwYvhw9g=")
+	for _, test := range []struct {
+		root, proof, value []byte
+		result             bool
+	}{
+		{root, proofThree, []byte("three"), true},
+		{root, proofTwo, []byte("two"), true},
+		{root, proofThree, []byte("two"), false},
+		{root, proofTwo, []byte("three"), false},
+		{root[:31], proofThree, []byte("three"), false},
+		{root, proofThree[:40], []byte("three"), false},
+		{root, []byte{0}, []byte("three"), false},
+		{root, nil, []byte("three"), false},
+	} {
+		rs, err := NativeCheckMerkleProof(newV3Scope(), Params(NewBytes(test.root), NewBytes(test.proof), NewBytes(test.value)))
+		require.NoError(t, err)
+		assert.Equal(t, NewBoolean(test.result), rs)
+	}
+}
+
+func TestNativeToUtf8String(t *testing.T) {
+	for _, test := range []struct {
+		bytes  []byte
+		result string
+	}{
+		{[]byte("Hello"), "Hello"},
+		{[]byte("Привет, 世界"), "Привет, 世界"},
+		{[]byte{}, ""},
+		{[]byte{0x41, 0xff, 0x42}, "A�B"},
+		{[]byte{0x41, 0xe2, 0x82, 0x42}, "A�B"},
+		{[]byte{0xf0, 0x9f, 0x98}, "�"},
+		{[]byte{0xed, 0xa0, 0x80}, "���"},
+		{[]byte{0xc0, 0xaf}, "��"},
+		{[]byte{0xe0, 0x80, 0x80}, "���"},
+	} {
+		rs, err := NativeToUtf8String(newV3Scope(), Params(NewBytes(test.bytes)))
+		require.NoError(t, err)
+		assert.Equal(t, NewString(test.result), rs)
+	}
+}
+
+func TestNativeBytesToLong(t *testing.T) {
+	b := []byte{0, 0, 0, 0, 0, 0, 0, 1, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	rs, err := NativeBytesToLong(newV3Scope(), Params(NewBytes(b)))
+	require.NoError(t, err)
+	assert.Equal(t, NewLong(1), rs)
+	_, err = NativeBytesToLong(newV3Scope(), Params(NewBytes(b[:7])))
+	assert.Error(t, err)
+
+	for _, test := range []struct {
+		offset int64
+		result int64
+		err    bool
+	}{
+		{0, 1, false},
+		{8, -1, false},
+		{7, 0x1ffffffffffffff, false},
+		{9, 0, true},
+		{-1, 0, true},
+	} {
+		rs, err := NativeBytesToLongWithOffset(newV3Scope(), Params(NewBytes(b), NewLong(test.offset)))
+		if test.err {
+			assert.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, NewLong(test.result), rs)
+	}
+}
+
+func TestNativeIndexOf(t *testing.T) {
+	for _, test := range []struct {
+		str, sub string
+		result   Expr
+	}{
+		{"hello world", "o", NewLong(4)},
+		{"hello world", "world", NewLong(6)},
+		{"hello world", "", NewLong(0)},
+		{"hello world", "x", NewUnit()},
+		{"привет мир", "мир", NewLong(7)},
+	} {
+		rs, err := NativeIndexOf(newV3Scope(), Params(NewString(test.str), NewString(test.sub)))
+		require.NoError(t, err)
+		assert.Equal(t, test.result, rs)
+	}
+	for _, test := range []struct {
+		str, sub string
+		offset   int64
+		result   Expr
+	}{
+		{"hello world", "o", 0, NewLong(4)},
+		{"hello world", "o", 5, NewLong(7)},
+		{"hello world", "o", 8, NewUnit()},
+		{"hello world", "", 11, NewLong(11)},
+		{"hello world", "o", 12, NewUnit()},
+		{"hello world", "o", -1, NewUnit()},
+		{"привет мир привет", "привет", 1, NewLong(11)},
+	} {
+		rs, err := NativeIndexOfWithOffset(newV3Scope(), Params(NewString(test.str), NewString(test.sub), NewLong(test.offset)))
+		require.NoError(t, err)
+		assert.Equal(t, test.result, rs)
+	}
+}
+
+func TestNativeSplitString(t *testing.T) {
+	for _, test := range []struct {
+		str, sep string
+		result   []string
+	}{
+		{"a,b,c", ",", []string{"a", "b", "c"}},
+		{",a,,b,", ",", []string{"", "a", "", "b", ""}},
+		{"abc", "", []string{"a", "b", "c"}},
+		{"", ",", []string{""}},
+		{"", "", []string{""}},
+		{"a--b--c", "--", []string{"a", "b", "c"}},
+		{"мир", "", []string{"м", "и", "р"}},
+	} {
+		rs, err := NativeSplitString(newV3Scope(), Params(NewString(test.str), NewString(test.sep)))
+		require.NoError(t, err)
+		expected := make(Exprs, len(test.result))
+		for i, s := range test.result {
+			expected[i] = NewString(s)
+		}
+		assert.Equal(t, expected, rs)
+	}
+}
+
+func TestNativeParseInt(t *testing.T) {
+	for _, test := range []struct {
+		str    string
+		result Expr
+	}{
+		{"12345", NewLong(12345)},
+		{"-12345", NewLong(-12345)},
+		{"+12345", NewLong(12345)},
+		{"007", NewLong(7)},
+		{"9223372036854775807", NewLong(math.MaxInt64)},
+		{"-9223372036854775808", NewLong(math.MinInt64)},
+		{"9223372036854775808", NewUnit()},
+		{"-9223372036854775809", NewUnit()},
+		{"", NewUnit()},
+		{"-", NewUnit()},
+		{"12a", NewUnit()},
+		{" 12", NewUnit()},
+		{"1.5", NewUnit()},
+		{"١٢٣", NewLong(123)},
+		{"１２", NewLong(12)},
+	} {
+		rs, err := NativeParseInt(newV3Scope(), Params(NewString(test.str)))
+		require.NoError(t, err)
+		assert.Equal(t, test.result, rs, test.str)
+	}
+
+	rs, err := UserParseIntValue(newV3Scope(), Params(NewString("42")))
+	require.NoError(t, err)
+	assert.Equal(t, NewLong(42), rs)
+	_, err = UserParseIntValue(newV3Scope(), Params(NewString("forty two")))
+	assert.IsType(t, Throw{}, err)
+}
+
+func TestInstanceOfTypeNames(t *testing.T) {
+	// Names of types match the names used by the compiler in _isInstanceOf calls
+	for expr, name := range map[Expr]string{
+		NewLong(1):       "Int",
+		NewBytes(nil):    "ByteVector",
+		NewString(""):    "String",
+		NewBoolean(true): "Boolean",
+		NewUnit():        "Unit",
+		NewAddressFromProtoAddress(proto.Address{}): "Address",
+	} {
+		rs, err := NativeIsInstanceOf(newV3Scope(), Params(expr, NewString(name)))
+		require.NoError(t, err)
+		assert.Equal(t, NewBoolean(true), rs, name)
+	}
+}
@@ -0,0 +1,407 @@
+package ast
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Functions pow and log follow the reference implementation, which calculates with java.math.BigDecimal:
+// intermediate values are rounded to 34 significant digits with HALF_EVEN mode (MathContext.DECIMAL128) and only
+// then the result is rounded to the requested number of decimals with the rounding mode given by the script.
+
+// RoundingMode is the rounding mode of java.math.BigDecimal.
+type RoundingMode byte
+
+const (
+	RoundUp RoundingMode = iota
+	RoundDown
+	RoundCeiling
+	RoundFloor
+	RoundHalfUp
+	RoundHalfDown
+	RoundHalfEven
+)
+
+const (
+	// decimal128Digits is the precision of MathContext.DECIMAL128.
+	decimal128Digits = 34
+	// floatPrecision is the precision in bits of calculation of logarithms and exponents, enough to round the result
+	// to 34 decimal digits correctly.
+	floatPrecision = 512
+	// maxExactPowBits limits the size of the exact integer power.
+	maxExactPowBits = 1 << 12
+	// maxFloatExp is the binary exponent of floats beyond which results don't fit into long or are rounded to zero.
+	maxFloatExp = 1 << 10
+	// maxDecimals is the maximal number of decimals of arguments and results of pow and log.
+	maxDecimals = 8
+)
+
+var (
+	bigOne = big.NewInt(1)
+	bigTen = big.NewInt(10)
+)
+
+// decimal is the number unscaled * 10^-scale, the same representation as java.math.BigDecimal.
+type decimal struct {
+	unscaled *big.Int
+	scale    int
+}
+
+func newDecimal(unscaled int64, scale int) decimal {
+	return decimal{unscaled: big.NewInt(unscaled), scale: scale}
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// digits returns the number of decimal digits of the absolute value.
+func digits(v *big.Int) int {
+	if v.Sign() == 0 {
+		return 1
+	}
+	return len(new(big.Int).Abs(v).Text(10))
+}
+
+// divRound divides x by y rounding the quotient with the given mode.
+func divRound(x, y *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(x, y, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	// Sign of the exact quotient
+	sign := x.Sign() * y.Sign()
+	var away bool
+	switch mode {
+	case RoundUp:
+		away = true
+	case RoundDown:
+		away = false
+	case RoundCeiling:
+		away = sign > 0
+	case RoundFloor:
+		away = sign < 0
+	default:
+		// Compare doubled remainder with divisor to find the nearest
+		c := new(big.Int).Abs(new(big.Int).Lsh(r, 1)).Cmp(new(big.Int).Abs(y))
+		switch {
+		case c > 0:
+			away = true
+		case c < 0:
+			away = false
+		default:
+			switch mode {
+			case RoundHalfUp:
+				away = true
+			case RoundHalfDown:
+				away = false
+			default:
+				away = q.Bit(0) == 1
+			}
+		}
+	}
+	if away {
+		if sign > 0 {
+			q.Add(q, bigOne)
+		} else {
+			q.Sub(q, bigOne)
+		}
+	}
+	return q
+}
+
+// setScale changes the scale of the number rounding it with the given mode if needed.
+func (d decimal) setScale(scale int, mode RoundingMode) decimal {
+	switch {
+	case scale == d.scale:
+		return d
+	case scale > d.scale:
+		return decimal{unscaled: new(big.Int).Mul(d.unscaled, pow10(scale-d.scale)), scale: scale}
+	default:
+		return decimal{unscaled: divRound(d.unscaled, pow10(d.scale-scale), mode), scale: scale}
+	}
+}
+
+// round rounds the number to the given number of significant digits with HALF_EVEN mode.
+func (d decimal) round(precision int) decimal {
+	drop := digits(d.unscaled) - precision
+	if drop <= 0 {
+		return d
+	}
+	r := decimal{unscaled: divRound(d.unscaled, pow10(drop), RoundHalfEven), scale: d.scale - drop}
+	if digits(r.unscaled) > precision {
+		// Rounding up added one more digit, like 999 -> 1000
+		r = decimal{unscaled: new(big.Int).Quo(r.unscaled, bigTen), scale: r.scale - 1}
+	}
+	return r
+}
+
+func (d decimal) rat() *big.Rat {
+	r := new(big.Rat).SetInt(d.unscaled)
+	if d.scale > 0 {
+		return r.Quo(r, new(big.Rat).SetInt(pow10(d.scale)))
+	}
+	return r.Mul(r, new(big.Rat).SetInt(pow10(-d.scale)))
+}
+
+func (d decimal) float() *big.Float {
+	return new(big.Float).SetPrec(floatPrecision).SetRat(d.rat())
+}
+
+// decimalFromRat rounds the rational number to the given number of significant digits with HALF_EVEN mode.
+func decimalFromRat(r *big.Rat, precision int) decimal {
+	if r.Sign() == 0 {
+		return newDecimal(0, 0)
+	}
+	num, den := r.Num(), r.Denom()
+	// The quotient scaled by 10^scale has precision or precision+1 digits in the integer part
+	scale := precision - (digits(num) - digits(den))
+	if digits(scaledQuo(num, den, scale, RoundDown)) > precision {
+		scale--
+	}
+	return decimal{unscaled: scaledQuo(num, den, scale, RoundHalfEven), scale: scale}.round(precision)
+}
+
+// scaledQuo returns the quotient num*10^scale/den rounded with the given mode.
+func scaledQuo(num, den *big.Int, scale int, mode RoundingMode) *big.Int {
+	if scale >= 0 {
+		return divRound(new(big.Int).Mul(num, pow10(scale)), den, mode)
+	}
+	return divRound(num, new(big.Int).Mul(den, pow10(-scale)), mode)
+}
+
+// decimalFromFloat rounds the float to the given number of significant digits with HALF_EVEN mode.
+func decimalFromFloat(f *big.Float, precision int) (decimal, error) {
+	if f.IsInf() {
+		return decimal{}, errors.New("overflow")
+	}
+	if f.Sign() == 0 {
+		return newDecimal(0, 0), nil
+	}
+	// Conversion of numbers with huge exponents to decimal is slow, they either overflow or are too small to matter
+	if exp := f.MantExp(nil); exp > maxFloatExp {
+		return decimal{}, errors.New("overflow")
+	} else if exp < -maxFloatExp {
+		return decimal{unscaled: big.NewInt(int64(f.Sign())), scale: maxFloatExp}, nil
+	}
+	// Text rounds the exact decimal expansion of the float half to even
+	s := f.Text('e', precision-1)
+	i := strings.IndexByte(s, 'e')
+	exp, err := strconv.Atoi(s[i+1:])
+	if err != nil {
+		return decimal{}, err
+	}
+	mantissa := strings.Replace(s[:i], ".", "", 1)
+	u, ok := new(big.Int).SetString(mantissa, 10)
+	if !ok {
+		return decimal{}, errors.Errorf("invalid number %s", s)
+	}
+	return decimal{unscaled: u, scale: precision - 1 - exp}, nil
+}
+
+// long returns the unscaled value of the number with the given scale, it fails if the value doesn't fit into int64
+// like BigInteger.longValueExact.
+func (d decimal) long(scale int, mode RoundingMode) (int64, error) {
+	// Avoid calculations with huge powers of ten for too big and too small numbers
+	if magnitude := digits(d.unscaled) - d.scale; magnitude+scale > 20 {
+		return 0, errors.New("overflow")
+	} else if magnitude+scale < -1 {
+		d = decimal{unscaled: big.NewInt(int64(d.unscaled.Sign())), scale: scale + 2}
+	}
+	r := d.setScale(scale, mode)
+	if !r.unscaled.IsInt64() {
+		return 0, errors.New("overflow")
+	}
+	return r.unscaled.Int64(), nil
+}
+
+// ln2 calculates the natural logarithm of 2 as 2*atanh(1/3).
+func ln2(prec uint) *big.Float {
+	z := new(big.Float).SetPrec(prec).Quo(new(big.Float).SetPrec(prec).SetInt64(1), new(big.Float).SetPrec(prec).SetInt64(3))
+	return atanhDouble(z, prec)
+}
+
+// atanhDouble calculates 2*atanh(z) = 2*(z + z^3/3 + z^5/5 + ...) for small z.
+func atanhDouble(z *big.Float, prec uint) *big.Float {
+	sum := new(big.Float).SetPrec(prec).Set(z)
+	z2 := new(big.Float).SetPrec(prec).Mul(z, z)
+	term := new(big.Float).SetPrec(prec).Set(z)
+	t := new(big.Float).SetPrec(prec)
+	for n := int64(3); ; n += 2 {
+		term.Mul(term, z2)
+		t.Quo(term, new(big.Float).SetPrec(prec).SetInt64(n))
+		if t.Sign() == 0 || t.MantExp(nil)-sum.MantExp(nil) < -int(prec) {
+			break
+		}
+		sum.Add(sum, t)
+	}
+	return sum.Mul(sum, new(big.Float).SetPrec(prec).SetInt64(2))
+}
+
+// bigLn calculates the natural logarithm of positive x.
+func bigLn(x *big.Float, prec uint) *big.Float {
+	// x = m * 2^e, where m is in [0.5, 1), ln(x) = ln(m) + e*ln(2), ln(m) = 2*atanh((m-1)/(m+1))
+	m := new(big.Float).SetPrec(prec)
+	e := x.MantExp(m)
+	one := new(big.Float).SetPrec(prec).SetInt64(1)
+	z := new(big.Float).SetPrec(prec).Quo(
+		new(big.Float).SetPrec(prec).Sub(m, one),
+		new(big.Float).SetPrec(prec).Add(m, one),
+	)
+	r := atanhDouble(z, prec)
+	if e != 0 {
+		l := ln2(prec)
+		r.Add(r, l.Mul(l, new(big.Float).SetPrec(prec).SetInt64(int64(e))))
+	}
+	return r
+}
+
+// bigExp calculates the exponent of x, the result is infinite on overflow.
+func bigExp(x *big.Float, prec uint) *big.Float {
+	if x.Sign() == 0 {
+		return new(big.Float).SetPrec(prec).SetInt64(1)
+	}
+	f, _ := x.Float64()
+	if f > math.MaxInt32/2 {
+		return new(big.Float).SetPrec(prec).SetInf(false)
+	}
+	if f < -math.MaxInt32/2 {
+		// Too small to be represented, but still positive
+		return new(big.Float).SetPrec(prec).SetMantExp(new(big.Float).SetInt64(1), math.MinInt32/2)
+	}
+	// x = k*ln(2) + r, exp(x) = 2^k * exp(r), where |r| <= ln(2)/2
+	l := ln2(prec)
+	k := new(big.Float).SetPrec(prec).Quo(x, l)
+	kf, _ := k.Float64()
+	ki := int64(math.Round(kf))
+	r := new(big.Float).SetPrec(prec).Sub(x, l.Mul(l, new(big.Float).SetPrec(prec).SetInt64(ki)))
+	// Reduce r further by 2^-16 and square the result 16 times
+	const squarings = 16
+	r.SetMantExp(r, -squarings)
+	sum := new(big.Float).SetPrec(prec).SetInt64(1)
+	term := new(big.Float).SetPrec(prec).SetInt64(1)
+	for n := int64(1); ; n++ {
+		term.Mul(term, r)
+		term.Quo(term, new(big.Float).SetPrec(prec).SetInt64(n))
+		if term.Sign() == 0 || term.MantExp(nil) < -int(prec) {
+			break
+		}
+		sum.Add(sum, term)
+	}
+	for i := 0; i < squarings; i++ {
+		sum.Mul(sum, sum)
+	}
+	return sum.SetMantExp(sum, int(ki))
+}
+
+// lnDecimal calculates the natural logarithm rounded to 34 significant digits.
+func lnDecimal(x decimal) (decimal, error) {
+	if x.unscaled.Sign() <= 0 {
+		return decimal{}, errors.New("logarithm of non-positive number")
+	}
+	if x.rat().Cmp(new(big.Rat).SetInt64(1)) == 0 {
+		return newDecimal(0, 0), nil
+	}
+	return decimalFromFloat(bigLn(x.float(), floatPrecision), decimal128Digits)
+}
+
+// powDecimal calculates x^y rounded to 34 significant digits.
+func powDecimal(x, y decimal) (decimal, error) {
+	yr := y.rat()
+	if yr.Sign() == 0 {
+		return newDecimal(1, 0), nil
+	}
+	if x.unscaled.Sign() == 0 {
+		if yr.Sign() > 0 {
+			return newDecimal(0, 0), nil
+		}
+		return decimal{}, errors.New("division by zero")
+	}
+	if yr.IsInt() {
+		return powInteger(x, yr.Num())
+	}
+	if x.unscaled.Sign() < 0 {
+		return decimal{}, errors.New("non-integer power of negative number")
+	}
+	// x^y = exp(y*ln(x))
+	l := bigLn(x.float(), floatPrecision)
+	l.Mul(l, y.float())
+	return decimalFromFloat(bigExp(l, floatPrecision), decimal128Digits)
+}
+
+// powInteger calculates the integer power exactly if the result is not too big.
+func powInteger(x decimal, n *big.Int) (decimal, error) {
+	if n.IsInt64() && n.Int64() != math.MinInt64 {
+		k := n.Int64()
+		abs := k
+		if abs < 0 {
+			abs = -abs
+		}
+		if bits := int64(x.unscaled.BitLen()+pow10(x.scale).BitLen()) * abs; bits <= maxExactPowBits {
+			num := new(big.Int).Exp(x.unscaled, big.NewInt(abs), nil)
+			den := pow10(x.scale * int(abs))
+			if k < 0 {
+				num, den = den, num
+			}
+			return decimalFromRat(new(big.Rat).SetFrac(num, den), decimal128Digits), nil
+		}
+	}
+	// Too big for the exact calculation, the sign is determined by the parity of the power
+	negative := x.unscaled.Sign() < 0 && n.Bit(0) == 1
+	abs := decimal{unscaled: new(big.Int).Abs(x.unscaled), scale: x.scale}
+	l := bigLn(abs.float(), floatPrecision)
+	l.Mul(l, new(big.Float).SetPrec(floatPrecision).SetInt(n))
+	r, err := decimalFromFloat(bigExp(l, floatPrecision), decimal128Digits)
+	if err != nil {
+		return decimal{}, err
+	}
+	if negative {
+		r.unscaled.Neg(r.unscaled)
+	}
+	return r, nil
+}
+
+// pow calculates (b/10^bp)^(e/10^ep) and returns the result with rp decimals.
+func pow(b, bp, e, ep, rp int64, mode RoundingMode) (int64, error) {
+	if err := checkDecimals(bp, ep, rp); err != nil {
+		return 0, err
+	}
+	r, err := powDecimal(newDecimal(b, int(bp)), newDecimal(e, int(ep)))
+	if err != nil {
+		return 0, err
+	}
+	return r.long(int(rp), mode)
+}
+
+// log calculates the logarithm of b/10^bp with the base e/10^ep and returns the result with rp decimals.
+func log(b, bp, e, ep, rp int64, mode RoundingMode) (int64, error) {
+	if err := checkDecimals(bp, ep, rp); err != nil {
+		return 0, err
+	}
+	n, err := lnDecimal(newDecimal(b, int(bp)))
+	if err != nil {
+		return 0, err
+	}
+	d, err := lnDecimal(newDecimal(e, int(ep)))
+	if err != nil {
+		return 0, err
+	}
+	if d.unscaled.Sign() == 0 {
+		return 0, errors.New("division by zero")
+	}
+	q := decimalFromRat(new(big.Rat).Quo(n.rat(), d.rat()), decimal128Digits)
+	return q.long(int(rp), mode)
+}
+
+func checkDecimals(values ...int64) error {
+	for _, v := range values {
+		if v < 0 || v > maxDecimals {
+			return errors.Errorf("decimals %d out of range 0-%d", v, maxDecimals)
+		}
+	}
+	return nil
+}
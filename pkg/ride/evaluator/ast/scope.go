@@ -1,6 +1,7 @@
 package ast
 
 import (
+	"github.com/pkg/errors"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/ride/mockstate"
 )
@@ -12,19 +13,24 @@ type Account interface {
 type Scope interface {
 	Clone() Scope
 	AddValue(name string, expr Expr)
+	AddFunction(name string, f Callable)
 	FuncByShort(int16) (Callable, bool)
 	FuncByName(string) (Callable, bool)
 	Value(string) (Expr, bool)
 	State() mockstate.MockState
 	Scheme() byte
+	Tracer() Tracer
+	SetTracer(t Tracer)
 }
 
 type ScopeImpl struct {
 	parent    Scope
 	funcs     *FuncScope
+	declared  map[string]Callable
 	variables map[string]Expr
 	state     mockstate.MockState
 	scheme    byte
+	tracer    Tracer
 }
 
 type Callable func(Scope, Exprs) (Expr, error)
@@ -43,6 +49,8 @@ func (a *ScopeImpl) Clone() Scope {
 		funcs:  a.funcs.Clone(),
 		parent: a,
 		state:  a.state,
+		scheme: a.scheme,
+		tracer: a.tracer,
 	}
 }
 
@@ -55,9 +63,23 @@ func (a *ScopeImpl) FuncByShort(id int16) (Callable, bool) {
 }
 
 func (a *ScopeImpl) FuncByName(name string) (Callable, bool) {
+	// functions declared in script take precedence
+	if f, ok := a.declared[name]; ok {
+		return f, true
+	}
+	if a.parent != nil {
+		return a.parent.FuncByName(name)
+	}
 	return a.funcs.GetByName(name)
 }
 
+func (a *ScopeImpl) AddFunction(name string, f Callable) {
+	if a.declared == nil {
+		a.declared = make(map[string]Callable)
+	}
+	a.declared[name] = f
+}
+
 func (a *ScopeImpl) AddValue(name string, value Expr) {
 	if a.variables == nil {
 		a.variables = make(map[string]Expr)
@@ -85,6 +107,16 @@ func (a *ScopeImpl) Scheme() byte {
 	return a.scheme
 }
 
+// Tracer returns the receiver of evaluation events, nil if evaluation is not traced.
+func (a *ScopeImpl) Tracer() Tracer {
+	return a.tracer
+}
+
+// SetTracer enables tracing of evaluation in the scope and its clones created afterwards.
+func (a *ScopeImpl) SetTracer(t Tracer) {
+	a.tracer = t
+}
+
 type FuncScope struct {
 	funcs     map[int16]Callable
 	userFuncs map[string]Callable
@@ -97,7 +129,26 @@ func EmptyFuncScope() *FuncScope {
 	}
 }
 
+// NewFuncScope returns functions of the latest supported version of scripts.
 func NewFuncScope() *FuncScope {
+	return FuncsV3()
+}
+
+// FuncsByVersion returns functions available to scripts of given version.
+func FuncsByVersion(version int) (*FuncScope, error) {
+	switch version {
+	case 1:
+		return FuncsV1(), nil
+	case 2:
+		return FuncsV2(), nil
+	case 3:
+		return FuncsV3(), nil
+	default:
+		return nil, errors.Errorf("unsupported script version %d", version)
+	}
+}
+
+func FuncsV1() *FuncScope {
 
 	funcs := make(map[int16]Callable)
 
@@ -189,6 +240,38 @@ func NewFuncScope() *FuncScope {
 	}
 }
 
+// Version 2 changes only the set of types, functions are the same as in version 1.
+func FuncsV2() *FuncScope {
+	return FuncsV1()
+}
+
+func FuncsV3() *FuncScope {
+	s := FuncsV2()
+
+	s.funcs[108] = NativePowLong
+	s.funcs[109] = NativeLogLong
+
+	s.funcs[504] = NativeRSAVerify
+	s.funcs[700] = NativeCheckMerkleProof
+
+	s.funcs[1004] = NativeAssetInfo
+	s.funcs[1005] = NativeBlockInfoByHeight
+	s.funcs[1006] = NativeTransferTransactionByID
+	s.funcs[1100] = NativeCons
+
+	s.funcs[1200] = NativeToUtf8String
+	s.funcs[1201] = NativeBytesToLong
+	s.funcs[1202] = NativeBytesToLongWithOffset
+	s.funcs[1203] = NativeIndexOf
+	s.funcs[1204] = NativeIndexOfWithOffset
+	s.funcs[1205] = NativeSplitString
+	s.funcs[1206] = NativeParseInt
+
+	s.userFuncs["parseIntValue"] = UserParseIntValue
+
+	return s
+}
+
 func (a *FuncScope) GetByShort(id int16) (Callable, bool) {
 	f, ok := a.funcs[id]
 	return f, ok
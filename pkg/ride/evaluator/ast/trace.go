@@ -0,0 +1,189 @@
+package ast
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type TraceKind byte
+
+const (
+	// TraceLet is the evaluation of the value of let, happens once on the first reference.
+	TraceLet TraceKind = iota
+	// TraceCall is the call of native or user function.
+	TraceCall
+	// TraceIf is the evaluation of condition and the branch taken.
+	TraceIf
+)
+
+// TraceNode is the node of the evaluation trace tree, children are evaluations happened inside the node.
+type TraceNode struct {
+	Kind TraceKind
+	// Name of the let or the function.
+	Name string
+	// FunctionID of the native function, -1 for user functions.
+	FunctionID int16
+	// Args holds values of arguments of the call, nil for arguments not evaluated by the function.
+	Args Exprs
+	// Branch is the value of the condition of if.
+	Branch   bool
+	Result   Expr
+	Err      error
+	Children []*TraceNode
+}
+
+// Tracer receives events of evaluation. Enter is called before the evaluation of node, Exit after it with the result set.
+type Tracer interface {
+	Enter(node *TraceNode)
+	Exit(node *TraceNode)
+}
+
+// Trace records evaluation into the tree of nodes.
+type Trace struct {
+	Nodes []*TraceNode
+	stack []*TraceNode
+}
+
+func NewTrace() *Trace {
+	return &Trace{}
+}
+
+func (a *Trace) Enter(node *TraceNode) {
+	if l := len(a.stack); l > 0 {
+		parent := a.stack[l-1]
+		parent.Children = append(parent.Children, node)
+	} else {
+		a.Nodes = append(a.Nodes, node)
+	}
+	a.stack = append(a.stack, node)
+}
+
+func (a *Trace) Exit(node *TraceNode) {
+	if l := len(a.stack); l > 0 {
+		a.stack = a.stack[:l-1]
+	}
+}
+
+// Depth returns the number of nodes being evaluated at the moment.
+func (a *Trace) Depth() int {
+	return len(a.stack)
+}
+
+// Write writes trace as indented tree.
+func (a *Trace) Write(w io.Writer) {
+	for _, n := range a.Nodes {
+		n.write(w, 0)
+	}
+}
+
+func (a *Trace) String() string {
+	buf := new(bytes.Buffer)
+	a.Write(buf)
+	return buf.String()
+}
+
+func (a *TraceNode) write(w io.Writer, level int) {
+	_, _ = fmt.Fprintf(w, "%s%s\n", strings.Repeat("  ", level), a.Describe())
+	for _, c := range a.Children {
+		c.write(w, level+1)
+	}
+}
+
+// Describe returns one line description of the node.
+func (a *TraceNode) Describe() string {
+	var s string
+	switch a.Kind {
+	case TraceLet:
+		s = "let " + a.Name
+	case TraceCall:
+		args := make([]string, len(a.Args))
+		for i, arg := range a.Args {
+			if arg == nil {
+				args[i] = "_"
+				continue
+			}
+			args[i] = exprString(arg)
+		}
+		s = fmt.Sprintf("%s(%s)", a.Name, strings.Join(args, ", "))
+	case TraceIf:
+		s = fmt.Sprintf("if %t", a.Branch)
+	}
+	switch {
+	case a.Err != nil:
+		return fmt.Sprintf("%s failed: %s", s, errorMessage(a.Err))
+	case a.Result != nil:
+		return fmt.Sprintf("%s = %s", s, exprString(a.Result))
+	default:
+		return s
+	}
+}
+
+func exprString(e Expr) string {
+	buf := new(bytes.Buffer)
+	e.Write(buf)
+	return buf.String()
+}
+
+func errorMessage(err error) string {
+	if t, ok := errors.Cause(err).(Throw); ok {
+		return "throw " + t.Message
+	}
+	return err.Error()
+}
+
+// nativeName returns the name of native function for the trace, like sigVerify.
+func nativeName(id int16) string {
+	if name, ok := NativeFunctionName(id); ok {
+		return name
+	}
+	return fmt.Sprintf("FUNCTION_%d", id)
+}
+
+// tracedArg records the value of the argument of the call when function evaluates it.
+type tracedArg struct {
+	expr  Expr
+	node  *TraceNode
+	index int
+}
+
+func traceArgs(node *TraceNode, args Exprs) Exprs {
+	node.Args = make(Exprs, len(args))
+	out := make(Exprs, len(args))
+	for i, a := range args {
+		out[i] = &tracedArg{expr: a, node: node, index: i}
+	}
+	return out
+}
+
+func (a *tracedArg) Write(w io.Writer) {
+	a.expr.Write(w)
+}
+
+func (a *tracedArg) Evaluate(s Scope) (Expr, error) {
+	rs, err := a.expr.Evaluate(s)
+	if err == nil {
+		a.node.Args[a.index] = rs
+	}
+	return rs, err
+}
+
+func (a *tracedArg) Eq(other Expr) (bool, error) {
+	return a.expr.Eq(other)
+}
+
+func (a *tracedArg) InstanceOf() string {
+	return a.expr.InstanceOf()
+}
+
+// traceCall calls function reporting the call to tracer.
+func traceCall(t Tracer, node *TraceNode, f Callable, s Scope, args Exprs) (Expr, error) {
+	traced := traceArgs(node, args)
+	t.Enter(node)
+	node.Result, node.Err = f(s, traced)
+	t.Exit(node)
+	return node.Result, node.Err
+}
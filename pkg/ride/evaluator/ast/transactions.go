@@ -2,6 +2,7 @@ package ast
 
 import (
 	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 )
 
@@ -13,67 +14,518 @@ func NewVariablesFromTransaction(scheme byte, t proto.Transaction) (map[string]E
 	out["id"] = NewBytes(t.GetID())
 
 	switch tx := t.(type) {
+	case *proto.Genesis:
+		out["amount"] = NewLong(int64(tx.Amount))
+		out["recipient"] = NewRecipientFromProtoRecipient(proto.NewRecipientFromAddress(tx.Recipient))
+		out["fee"] = NewLong(0)
+		out["timestamp"] = NewLong(int64(tx.Timestamp))
+		out["version"] = NewLong(int64(tx.Version))
+		out[InstanceFieldName] = NewString("GenesisTransaction")
+		return out, nil
 	case *proto.Payment:
 		out["id"] = NewBytes(tx.ID.Bytes())
-		return out, nil
-	case *proto.TransferV1:
-		addr, err := proto.NewAddressFromPublicKey(scheme, tx.SenderPK)
+		bts, err := tx.BodyMarshalBinary()
 		if err != nil {
 			return nil, errors.Wrap(err, funcName)
 		}
-		out["sender"] = NewAddressFromProtoAddress(addr)
+		if err := addHeader(scheme, out, tx.SenderPK, tx.Fee, tx.Timestamp, tx.Version, bts, signatureProofs(tx.Signature)); err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
 		out["amount"] = NewLong(int64(tx.Amount))
-		out["timestamp"] = NewLong(int64(tx.Timestamp))
-		bts, err := tx.MarshalBinary()
+		out["recipient"] = NewRecipientFromProtoRecipient(proto.NewRecipientFromAddress(tx.Recipient))
+		out[InstanceFieldName] = NewString("PaymentTransaction")
+		return out, nil
+	case *proto.IssueV1:
+		bts, err := tx.BodyMarshalBinary()
 		if err != nil {
 			return nil, errors.Wrap(err, funcName)
 		}
-		out["bodyBytes"] = NewBytes(bts)
-		out[InstanceFieldName] = NewString("TransferTransaction")
+		if err := addHeader(scheme, out, tx.SenderPK, tx.Fee, tx.Timestamp, tx.Version, bts, signatureProofs(tx.Signature)); err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		addIssueFields(out, &tx.Issue, nil)
+		return out, nil
+	case *proto.IssueV2:
+		bts, err := tx.BodyMarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		if err := addHeader(scheme, out, tx.SenderPK, tx.Fee, tx.Timestamp, tx.Version, bts, proofs(tx.Proofs)); err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		addIssueFields(out, &tx.Issue, tx.Script)
+		return out, nil
+	case *proto.TransferV1:
+		bts, err := tx.BodyMarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		if err := addHeader(scheme, out, tx.SenderPK, tx.Fee, tx.Timestamp, tx.Version, bts, signatureProofs(tx.Signature)); err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		addTransferFields(out, &tx.Transfer)
 		return out, nil
 	case *proto.TransferV2:
-		addr, err := proto.NewAddressFromPublicKey(scheme, tx.SenderPK)
+		bts, err := tx.BodyMarshalBinary()
 		if err != nil {
 			return nil, errors.Wrap(err, funcName)
 		}
-		out["sender"] = NewAddressFromProtoAddress(addr)
-		out["amount"] = NewLong(int64(tx.Amount))
-		out["timestamp"] = NewLong(int64(tx.Timestamp))
+		if err := addHeader(scheme, out, tx.SenderPK, tx.Fee, tx.Timestamp, tx.Version, bts, proofs(tx.Proofs)); err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		addTransferFields(out, &tx.Transfer)
+		return out, nil
+	case *proto.ReissueV1:
 		bts, err := tx.BodyMarshalBinary()
 		if err != nil {
 			return nil, errors.Wrap(err, funcName)
 		}
-		out["bodyBytes"] = NewBytes(bts)
-		out[InstanceFieldName] = NewString("TransferTransaction")
-
-		proofs := Exprs{}
-		for _, row := range tx.Proofs.Proofs {
-			proofs = append(proofs, NewBytes(row.Bytes()))
+		if err := addHeader(scheme, out, tx.SenderPK, tx.Fee, tx.Timestamp, tx.Version, bts, signatureProofs(tx.Signature)); err != nil {
+			return nil, errors.Wrap(err, funcName)
 		}
-		out["proofs"] = proofs
+		addReissueFields(out, &tx.Reissue)
 		return out, nil
-	case *proto.DataV1:
-		addr, err := proto.NewAddressFromPublicKey(scheme, tx.SenderPK)
+	case *proto.ReissueV2:
+		bts, err := tx.BodyMarshalBinary()
 		if err != nil {
 			return nil, errors.Wrap(err, funcName)
 		}
-		out["sender"] = NewAddressFromProtoAddress(addr)
-		out["timestamp"] = NewLong(int64(tx.Timestamp))
+		if err := addHeader(scheme, out, tx.SenderPK, tx.Fee, tx.Timestamp, tx.Version, bts, proofs(tx.Proofs)); err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		addReissueFields(out, &tx.Reissue)
+		return out, nil
+	case *proto.BurnV1:
 		bts, err := tx.BodyMarshalBinary()
 		if err != nil {
 			return nil, errors.Wrap(err, funcName)
 		}
-		out["bodyBytes"] = NewBytes(bts)
-		proofs := Exprs{}
-		for _, row := range tx.Proofs.Proofs {
-			proofs = append(proofs, NewBytes(row.Bytes()))
+		if err := addHeader(scheme, out, tx.SenderPK, tx.Fee, tx.Timestamp, tx.Version, bts, signatureProofs(tx.Signature)); err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		addBurnFields(out, &tx.Burn)
+		return out, nil
+	case *proto.BurnV2:
+		bts, err := tx.BodyMarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		if err := addHeader(scheme, out, tx.SenderPK, tx.Fee, tx.Timestamp, tx.Version, bts, proofs(tx.Proofs)); err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		addBurnFields(out, &tx.Burn)
+		return out, nil
+	case *proto.ExchangeV1:
+		bts, err := tx.BodyMarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		if err := addHeader(scheme, out, tx.SenderPK, tx.Fee, tx.Timestamp, tx.Version, bts, signatureProofs(tx.Signature)); err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		buy, err := newOrderObject(scheme, tx.BuyOrder)
+		if err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		sell, err := newOrderObject(scheme, tx.SellOrder)
+		if err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		addExchangeFields(out, buy, sell, tx.Price, tx.Amount, tx.BuyMatcherFee, tx.SellMatcherFee)
+		return out, nil
+	case *proto.ExchangeV2:
+		bts, err := tx.BodyMarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		if err := addHeader(scheme, out, tx.SenderPK, tx.Fee, tx.Timestamp, tx.Version, bts, proofs(tx.Proofs)); err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		buy, err := newOrderObject(scheme, tx.BuyOrder)
+		if err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		sell, err := newOrderObject(scheme, tx.SellOrder)
+		if err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		addExchangeFields(out, buy, sell, tx.Price, tx.Amount, tx.BuyMatcherFee, tx.SellMatcherFee)
+		return out, nil
+	case *proto.LeaseV1:
+		bts, err := tx.BodyMarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		if err := addHeader(scheme, out, tx.SenderPK, tx.Fee, tx.Timestamp, tx.Version, bts, signatureProofs(tx.Signature)); err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		addLeaseFields(out, &tx.Lease)
+		return out, nil
+	case *proto.LeaseV2:
+		bts, err := tx.BodyMarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		if err := addHeader(scheme, out, tx.SenderPK, tx.Fee, tx.Timestamp, tx.Version, bts, proofs(tx.Proofs)); err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		addLeaseFields(out, &tx.Lease)
+		return out, nil
+	case *proto.LeaseCancelV1:
+		bts, err := tx.BodyMarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		if err := addHeader(scheme, out, tx.SenderPK, tx.Fee, tx.Timestamp, tx.Version, bts, signatureProofs(tx.Signature)); err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		out["leaseId"] = NewBytes(tx.LeaseID.Bytes())
+		out[InstanceFieldName] = NewString("LeaseCancelTransaction")
+		return out, nil
+	case *proto.LeaseCancelV2:
+		bts, err := tx.BodyMarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		if err := addHeader(scheme, out, tx.SenderPK, tx.Fee, tx.Timestamp, tx.Version, bts, proofs(tx.Proofs)); err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		out["leaseId"] = NewBytes(tx.LeaseID.Bytes())
+		out[InstanceFieldName] = NewString("LeaseCancelTransaction")
+		return out, nil
+	case *proto.CreateAliasV1:
+		bts, err := tx.BodyMarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		if err := addHeader(scheme, out, tx.SenderPK, tx.Fee, tx.Timestamp, tx.Version, bts, signatureProofs(tx.Signature)); err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		out["alias"] = NewString(tx.Alias.Alias)
+		out[InstanceFieldName] = NewString("CreateAliasTransaction")
+		return out, nil
+	case *proto.CreateAliasV2:
+		bts, err := tx.BodyMarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		if err := addHeader(scheme, out, tx.SenderPK, tx.Fee, tx.Timestamp, tx.Version, bts, proofs(tx.Proofs)); err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		out["alias"] = NewString(tx.Alias.Alias)
+		out[InstanceFieldName] = NewString("CreateAliasTransaction")
+		return out, nil
+	case *proto.MassTransferV1:
+		bts, err := tx.BodyMarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		if err := addHeader(scheme, out, tx.SenderPK, tx.Fee, tx.Timestamp, tx.Version, bts, proofs(tx.Proofs)); err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		out["assetId"] = optionalAsset(tx.Asset)
+		var total uint64
+		transfers := Exprs{}
+		for _, row := range tx.Transfers {
+			total += row.Amount
+			transfers = append(transfers, NewObject(map[string]Expr{
+				"recipient":       NewRecipientFromProtoRecipient(row.Recipient),
+				"amount":          NewLong(int64(row.Amount)),
+				InstanceFieldName: NewString("Transfer"),
+			}))
+		}
+		out["transfers"] = transfers
+		out["transferCount"] = NewLong(int64(len(tx.Transfers)))
+		out["totalAmount"] = NewLong(int64(total))
+		out["attachment"] = NewBytes([]byte(tx.Attachment))
+		out[InstanceFieldName] = NewString("MassTransferTransaction")
+		return out, nil
+	case *proto.DataV1:
+		bts, err := tx.BodyMarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		if err := addHeader(scheme, out, tx.SenderPK, tx.Fee, tx.Timestamp, tx.Version, bts, proofs(tx.Proofs)); err != nil {
+			return nil, errors.Wrap(err, funcName)
 		}
-		out["proofs"] = proofs
 		out["data"] = NewDataEntryList(tx.Entries)
 		out[InstanceFieldName] = NewString("DataTransaction")
 		return out, nil
+	case *proto.SetScriptV1:
+		bts, err := tx.BodyMarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		if err := addHeader(scheme, out, tx.SenderPK, tx.Fee, tx.Timestamp, tx.Version, bts, proofs(tx.Proofs)); err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		out["script"] = script(tx.Script)
+		out[InstanceFieldName] = NewString("SetScriptTransaction")
+		return out, nil
+	case *proto.SponsorshipV1:
+		bts, err := tx.BodyMarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		if err := addHeader(scheme, out, tx.SenderPK, tx.Fee, tx.Timestamp, tx.Version, bts, proofs(tx.Proofs)); err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		out["assetId"] = NewBytes(tx.AssetID.Bytes())
+		// Zero minimal fee cancels sponsorship.
+		if tx.MinAssetFee == 0 {
+			out["minSponsoredAssetFee"] = NewUnit()
+		} else {
+			out["minSponsoredAssetFee"] = NewLong(int64(tx.MinAssetFee))
+		}
+		out[InstanceFieldName] = NewString("SponsorFeeTransaction")
+		return out, nil
+	case *proto.SetAssetScriptV1:
+		bts, err := tx.BodyMarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		if err := addHeader(scheme, out, tx.SenderPK, tx.Fee, tx.Timestamp, tx.Version, bts, proofs(tx.Proofs)); err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		out["assetId"] = NewBytes(tx.AssetID.Bytes())
+		out["script"] = script(tx.Script)
+		out[InstanceFieldName] = NewString("SetAssetScriptTransaction")
+		return out, nil
+	case *proto.InvokeScriptV1:
+		bts, err := tx.BodyMarshalBinary()
+		if err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		if err := addHeader(scheme, out, tx.SenderPK, tx.Fee, tx.Timestamp, tx.Version, bts, proofs(tx.Proofs)); err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		out["dApp"] = NewRecipientFromProtoRecipient(proto.NewRecipientFromAddress(tx.ScriptAddress))
+		if len(tx.Payments) == 0 {
+			out["payment"] = NewUnit()
+		} else {
+			p := tx.Payments[0]
+			out["payment"] = NewObject(map[string]Expr{
+				"assetId":         optionalAsset(p.Asset),
+				"amount":          NewLong(int64(p.Amount)),
+				InstanceFieldName: NewString("AttachedPayment"),
+			})
+		}
+		out["feeAssetId"] = optionalAsset(tx.FeeAsset)
+		out["function"] = NewString(tx.FunctionCall.Name)
+		args, err := arguments(tx.FunctionCall.Arguments)
+		if err != nil {
+			return nil, errors.Wrap(err, funcName)
+		}
+		out["args"] = args
+		out[InstanceFieldName] = NewString("InvokeScriptTransaction")
+		return out, nil
 	default:
 		return nil, errors.Errorf("NewVariablesFromTransaction not implemented for %T", tx)
 	}
 
 }
+
+// addHeader fills the fields common for all transactions.
+func addHeader(scheme byte, out map[string]Expr, senderPK crypto.PublicKey, fee, timestamp uint64, version byte, bodyBytes []byte, proofs Exprs) error {
+	addr, err := proto.NewAddressFromPublicKey(scheme, senderPK)
+	if err != nil {
+		return err
+	}
+	out["sender"] = NewAddressFromProtoAddress(addr)
+	out["senderPublicKey"] = NewBytes(senderPK[:])
+	out["fee"] = NewLong(int64(fee))
+	out["timestamp"] = NewLong(int64(timestamp))
+	out["version"] = NewLong(int64(version))
+	out["bodyBytes"] = NewBytes(bodyBytes)
+	out["proofs"] = proofs
+	return nil
+}
+
+func addIssueFields(out map[string]Expr, tx *proto.Issue, s proto.Script) {
+	out["quantity"] = NewLong(int64(tx.Quantity))
+	out["name"] = NewBytes([]byte(tx.Name))
+	out["description"] = NewBytes([]byte(tx.Description))
+	out["reissuable"] = NewBoolean(tx.Reissuable)
+	out["decimals"] = NewLong(int64(tx.Decimals))
+	out["script"] = script(s)
+	out[InstanceFieldName] = NewString("IssueTransaction")
+}
+
+func addTransferFields(out map[string]Expr, tx *proto.Transfer) {
+	out["feeAssetId"] = optionalAsset(tx.FeeAsset)
+	out["amount"] = NewLong(int64(tx.Amount))
+	out["assetId"] = optionalAsset(tx.AmountAsset)
+	out["recipient"] = NewRecipientFromProtoRecipient(tx.Recipient)
+	out["attachment"] = NewBytes([]byte(tx.Attachment))
+	out[InstanceFieldName] = NewString("TransferTransaction")
+}
+
+func addReissueFields(out map[string]Expr, tx *proto.Reissue) {
+	out["quantity"] = NewLong(int64(tx.Quantity))
+	out["assetId"] = NewBytes(tx.AssetID.Bytes())
+	out["reissuable"] = NewBoolean(tx.Reissuable)
+	out[InstanceFieldName] = NewString("ReissueTransaction")
+}
+
+func addBurnFields(out map[string]Expr, tx *proto.Burn) {
+	out["quantity"] = NewLong(int64(tx.Amount))
+	out["assetId"] = NewBytes(tx.AssetID.Bytes())
+	out[InstanceFieldName] = NewString("BurnTransaction")
+}
+
+func addLeaseFields(out map[string]Expr, tx *proto.Lease) {
+	out["amount"] = NewLong(int64(tx.Amount))
+	out["recipient"] = NewRecipientFromProtoRecipient(tx.Recipient)
+	out[InstanceFieldName] = NewString("LeaseTransaction")
+}
+
+func addExchangeFields(out map[string]Expr, buy, sell *ObjectExpr, price, amount, buyMatcherFee, sellMatcherFee uint64) {
+	out["buyOrder"] = buy
+	out["sellOrder"] = sell
+	out["price"] = NewLong(int64(price))
+	out["amount"] = NewLong(int64(amount))
+	out["buyMatcherFee"] = NewLong(int64(buyMatcherFee))
+	out["sellMatcherFee"] = NewLong(int64(sellMatcherFee))
+	out[InstanceFieldName] = NewString("ExchangeTransaction")
+}
+
+func newOrderObject(scheme byte, o proto.Order) (*ObjectExpr, error) {
+	var (
+		body      proto.OrderBody
+		bodyBytes []byte
+		id        *crypto.Digest
+		prfs      Exprs
+		err       error
+	)
+	switch order := o.(type) {
+	case proto.OrderV1:
+		body = order.OrderBody
+		bodyBytes, err = order.BodyMarshalBinary()
+		id = order.ID
+		prfs = signatureProofs(order.Signature)
+	case *proto.OrderV1:
+		body = order.OrderBody
+		bodyBytes, err = order.BodyMarshalBinary()
+		id = order.ID
+		prfs = signatureProofs(order.Signature)
+	case proto.OrderV2:
+		body = order.OrderBody
+		bodyBytes, err = order.BodyMarshalBinary()
+		id = order.ID
+		prfs = proofs(order.Proofs)
+	case *proto.OrderV2:
+		body = order.OrderBody
+		bodyBytes, err = order.BodyMarshalBinary()
+		id = order.ID
+		prfs = proofs(order.Proofs)
+	default:
+		return nil, errors.Errorf("unsupported order type %T", o)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if id == nil {
+		d, err := crypto.FastHash(bodyBytes)
+		if err != nil {
+			return nil, err
+		}
+		id = &d
+	}
+	addr, err := proto.NewAddressFromPublicKey(scheme, body.SenderPK)
+	if err != nil {
+		return nil, err
+	}
+	orderType := "Buy"
+	if body.OrderType == proto.Sell {
+		orderType = "Sell"
+	}
+	return NewObject(map[string]Expr{
+		"id":               NewBytes(id.Bytes()),
+		"sender":           NewAddressFromProtoAddress(addr),
+		"senderPublicKey":  NewBytes(body.SenderPK[:]),
+		"matcherPublicKey": NewBytes(body.MatcherPK[:]),
+		"assetPair": NewObject(map[string]Expr{
+			"amountAsset":     optionalAsset(body.AssetPair.AmountAsset),
+			"priceAsset":      optionalAsset(body.AssetPair.PriceAsset),
+			InstanceFieldName: NewString("AssetPair"),
+		}),
+		"orderType":       NewObject(map[string]Expr{InstanceFieldName: NewString(orderType)}),
+		"price":           NewLong(int64(body.Price)),
+		"amount":          NewLong(int64(body.Amount)),
+		"timestamp":       NewLong(int64(body.Timestamp)),
+		"expiration":      NewLong(int64(body.Expiration)),
+		"matcherFee":      NewLong(int64(body.MatcherFee)),
+		"bodyBytes":       NewBytes(bodyBytes),
+		"proofs":          prfs,
+		InstanceFieldName: NewString("Order"),
+	}), nil
+}
+
+// proofsCount is the length of the list of proofs available to scripts, absent proofs are empty byte vectors.
+const proofsCount = 8
+
+func padProofs(out Exprs) Exprs {
+	for len(out) < proofsCount {
+		out = append(out, NewBytes(nil))
+	}
+	return out
+}
+
+func signatureProofs(sig *crypto.Signature) Exprs {
+	if sig == nil {
+		return padProofs(Exprs{})
+	}
+	return padProofs(Exprs{NewBytes(sig.Bytes())})
+}
+
+func proofs(p *proto.ProofsV1) Exprs {
+	out := Exprs{}
+	if p == nil {
+		return padProofs(out)
+	}
+	for _, row := range p.Proofs {
+		out = append(out, NewBytes(row.Bytes()))
+	}
+	return padProofs(out)
+}
+
+func optionalAsset(a proto.OptionalAsset) Expr {
+	if !a.Present {
+		return NewUnit()
+	}
+	return NewBytes(a.ID.Bytes())
+}
+
+func script(s proto.Script) Expr {
+	if len(s) == 0 {
+		return NewUnit()
+	}
+	return NewBytes(s)
+}
+
+func arguments(args proto.Arguments) (Exprs, error) {
+	out := Exprs{}
+	for _, arg := range args {
+		switch a := arg.(type) {
+		case proto.IntegerArgument:
+			out = append(out, NewLong(a.Value))
+		case proto.BooleanArgument:
+			out = append(out, NewBoolean(a.Value))
+		case proto.BinaryArgument:
+			out = append(out, NewBytes(a.Value))
+		case proto.StringArgument:
+			out = append(out, NewString(a.Value))
+		case *proto.IntegerArgument:
+			out = append(out, NewLong(a.Value))
+		case *proto.BooleanArgument:
+			out = append(out, NewBoolean(a.Value))
+		case *proto.BinaryArgument:
+			out = append(out, NewBytes(a.Value))
+		case *proto.StringArgument:
+			out = append(out, NewString(a.Value))
+		default:
+			return nil, errors.Errorf("unsupported argument type %T", arg)
+		}
+	}
+	return out, nil
+}
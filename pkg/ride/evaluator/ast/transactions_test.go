@@ -0,0 +1,187 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+func txVar(t *testing.T, vars map[string]Expr, name string) Expr {
+	v, ok := vars[name]
+	require.True(t, ok, "variable '%s' is not set", name)
+	return v
+}
+
+func TestNewVariablesFromTransaction_TransferV1(t *testing.T) {
+	secret, public := crypto.GenerateKeyPair([]byte("abcde"))
+	sender, err := proto.NewAddressFromPublicKey(proto.MainNetScheme, public)
+	require.NoError(t, err)
+	asset, err := proto.NewOptionalAssetFromString("B2u2TBpTYHWCuMuKLnbQfLvdLJ3zjgPiy3iMS2TSYugZ")
+	require.NoError(t, err)
+
+	tx := proto.NewUnsignedTransferV1(public, *asset, proto.OptionalAsset{}, 1544715621, 100, 10000, proto.NewRecipientFromAddress(sender), "attachment")
+	require.NoError(t, tx.Sign(secret))
+
+	vars, err := NewVariablesFromTransaction(proto.MainNetScheme, tx)
+	require.NoError(t, err)
+
+	body, err := tx.BodyMarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, NewString("TransferTransaction"), txVar(t, vars, InstanceFieldName))
+	assert.Equal(t, NewBytes(tx.ID.Bytes()), txVar(t, vars, "id"))
+	assert.Equal(t, NewAddressFromProtoAddress(sender), txVar(t, vars, "sender"))
+	assert.Equal(t, NewBytes(public[:]), txVar(t, vars, "senderPublicKey"))
+	assert.Equal(t, NewBytes(body), txVar(t, vars, "bodyBytes"))
+	assert.Equal(t, padProofs(Exprs{NewBytes(tx.Signature.Bytes())}), txVar(t, vars, "proofs"))
+	assert.Len(t, txVar(t, vars, "proofs"), 8)
+	assert.Equal(t, NewLong(1), txVar(t, vars, "version"))
+	assert.Equal(t, NewLong(10000), txVar(t, vars, "fee"))
+	assert.Equal(t, NewLong(100), txVar(t, vars, "amount"))
+	assert.Equal(t, NewBytes(asset.ID.Bytes()), txVar(t, vars, "assetId"))
+	assert.Equal(t, NewUnit(), txVar(t, vars, "feeAssetId"))
+	assert.Equal(t, NewBytes([]byte("attachment")), txVar(t, vars, "attachment"))
+}
+
+func TestNewVariablesFromTransaction_ExchangeV2(t *testing.T) {
+	secret, public := crypto.GenerateKeyPair([]byte("abcde"))
+	_, matcher := crypto.GenerateKeyPair([]byte("matcher"))
+	asset, err := proto.NewOptionalAssetFromString("B2u2TBpTYHWCuMuKLnbQfLvdLJ3zjgPiy3iMS2TSYugZ")
+	require.NoError(t, err)
+
+	buy := proto.NewUnsignedOrderV1(public, matcher, *asset, proto.OptionalAsset{}, proto.Buy, 10, 100, 1544715621, 1544715721, 300000)
+	require.NoError(t, buy.Sign(secret))
+	sell := proto.NewUnsignedOrderV2(public, matcher, *asset, proto.OptionalAsset{}, proto.Sell, 10, 100, 1544715621, 1544715721, 300000)
+	require.NoError(t, sell.Sign(secret))
+	tx := proto.NewUnsignedExchangeV2(*buy, *sell, 10, 100, 300000, 300000, 300000, 1544715621)
+	require.NoError(t, tx.Sign(secret))
+
+	vars, err := NewVariablesFromTransaction(proto.MainNetScheme, tx)
+	require.NoError(t, err)
+	assert.Equal(t, NewString("ExchangeTransaction"), txVar(t, vars, InstanceFieldName))
+	assert.Equal(t, NewLong(10), txVar(t, vars, "price"))
+	assert.Equal(t, NewLong(300000), txVar(t, vars, "sellMatcherFee"))
+
+	sellOrder, ok := txVar(t, vars, "sellOrder").(*ObjectExpr)
+	require.True(t, ok)
+	assert.Equal(t, "Order", sellOrder.InstanceOf())
+	orderType, err := sellOrder.Get("orderType")
+	require.NoError(t, err)
+	assert.Equal(t, "Sell", orderType.(*ObjectExpr).InstanceOf())
+	id, err := sellOrder.Get("id")
+	require.NoError(t, err)
+	assert.Equal(t, NewBytes(sell.ID.Bytes()), id)
+	pair, err := sellOrder.Get("assetPair")
+	require.NoError(t, err)
+	amountAsset, err := pair.(*ObjectExpr).Get("amountAsset")
+	require.NoError(t, err)
+	assert.Equal(t, NewBytes(asset.ID.Bytes()), amountAsset)
+	priceAsset, err := pair.(*ObjectExpr).Get("priceAsset")
+	require.NoError(t, err)
+	assert.Equal(t, NewUnit(), priceAsset)
+
+	buyOrder, ok := txVar(t, vars, "buyOrder").(*ObjectExpr)
+	require.True(t, ok)
+	proofs, err := buyOrder.Get("proofs")
+	require.NoError(t, err)
+	assert.Equal(t, padProofs(Exprs{NewBytes(buy.Signature.Bytes())}), proofs)
+}
+
+func TestNewVariablesFromTransaction_MassTransferV1(t *testing.T) {
+	secret, public := crypto.GenerateKeyPair([]byte("abcde"))
+	sender, err := proto.NewAddressFromPublicKey(proto.MainNetScheme, public)
+	require.NoError(t, err)
+	transfers := []proto.MassTransferEntry{
+		{Recipient: proto.NewRecipientFromAddress(sender), Amount: 100},
+		{Recipient: proto.NewRecipientFromAddress(sender), Amount: 200},
+	}
+	tx := proto.NewUnsignedMassTransferV1(public, proto.OptionalAsset{}, transfers, 200000, 1544715621, "")
+	require.NoError(t, tx.Sign(secret))
+
+	vars, err := NewVariablesFromTransaction(proto.MainNetScheme, tx)
+	require.NoError(t, err)
+	assert.Equal(t, NewString("MassTransferTransaction"), txVar(t, vars, InstanceFieldName))
+	assert.Equal(t, NewLong(2), txVar(t, vars, "transferCount"))
+	assert.Equal(t, NewLong(300), txVar(t, vars, "totalAmount"))
+	assert.Equal(t, NewUnit(), txVar(t, vars, "assetId"))
+	list, ok := txVar(t, vars, "transfers").(Exprs)
+	require.True(t, ok)
+	require.Len(t, list, 2)
+	amount, err := list[1].(*ObjectExpr).Get("amount")
+	require.NoError(t, err)
+	assert.Equal(t, NewLong(200), amount)
+}
+
+func TestNewVariablesFromTransaction_InvokeScriptV1(t *testing.T) {
+	secret, public := crypto.GenerateKeyPair([]byte("abcde"))
+	dApp, err := proto.NewAddressFromPublicKey(proto.MainNetScheme, public)
+	require.NoError(t, err)
+	args := proto.Arguments{}
+	args.Append(proto.IntegerArgument{Value: 12345})
+	args.Append(proto.StringArgument{Value: "xxx"})
+	call := proto.FunctionCall{Name: "deposit", Arguments: args}
+	payments := proto.ScriptPayments{{Amount: 1000}}
+	tx := proto.NewUnsignedInvokeScriptV1(proto.MainNetScheme, public, dApp, call, payments, proto.OptionalAsset{}, 500000, 1544715621)
+	require.NoError(t, tx.Sign(secret))
+
+	vars, err := NewVariablesFromTransaction(proto.MainNetScheme, tx)
+	require.NoError(t, err)
+	assert.Equal(t, NewString("InvokeScriptTransaction"), txVar(t, vars, InstanceFieldName))
+	assert.Equal(t, NewString("deposit"), txVar(t, vars, "function"))
+	assert.Equal(t, Exprs{NewLong(12345), NewString("xxx")}, txVar(t, vars, "args"))
+	payment, ok := txVar(t, vars, "payment").(*ObjectExpr)
+	require.True(t, ok)
+	assert.Equal(t, "AttachedPayment", payment.InstanceOf())
+	assetID, err := payment.Get("assetId")
+	require.NoError(t, err)
+	assert.Equal(t, NewUnit(), assetID)
+}
+
+func TestNewVariablesFromTransaction_AllTypes(t *testing.T) {
+	secret, public := crypto.GenerateKeyPair([]byte("abcde"))
+	addr, err := proto.NewAddressFromPublicKey(proto.MainNetScheme, public)
+	require.NoError(t, err)
+	rcp := proto.NewRecipientFromAddress(addr)
+	d, err := crypto.NewDigestFromBase58("B2u2TBpTYHWCuMuKLnbQfLvdLJ3zjgPiy3iMS2TSYugZ")
+	require.NoError(t, err)
+	alias := proto.NewAlias(proto.MainNetScheme, "alias")
+
+	for _, test := range []struct {
+		tx       proto.Transaction
+		instance string
+	}{
+		{proto.NewUnsignedGenesis(addr, 100, 1544715621), "GenesisTransaction"},
+		{proto.NewUnsignedPayment(public, addr, 100, 100000, 1544715621), "PaymentTransaction"},
+		{proto.NewUnsignedIssueV1(public, "name", "desc", 1000, 2, true, 1544715621, 100000), "IssueTransaction"},
+		{proto.NewUnsignedIssueV2(proto.MainNetScheme, public, "name", "desc", 1000, 2, true, nil, 1544715621, 100000), "IssueTransaction"},
+		{proto.NewUnsignedReissueV1(public, d, 1000, true, 1544715621, 100000), "ReissueTransaction"},
+		{proto.NewUnsignedReissueV2(proto.MainNetScheme, public, d, 1000, true, 1544715621, 100000), "ReissueTransaction"},
+		{proto.NewUnsignedBurnV1(public, d, 1000, 1544715621, 100000), "BurnTransaction"},
+		{proto.NewUnsignedBurnV2(proto.MainNetScheme, public, d, 1000, 1544715621, 100000), "BurnTransaction"},
+		{proto.NewUnsignedLeaseV1(public, rcp, 1000, 100000, 1544715621), "LeaseTransaction"},
+		{proto.NewUnsignedLeaseV2(public, rcp, 1000, 100000, 1544715621), "LeaseTransaction"},
+		{proto.NewUnsignedLeaseCancelV1(public, d, 100000, 1544715621), "LeaseCancelTransaction"},
+		{proto.NewUnsignedLeaseCancelV2(proto.MainNetScheme, public, d, 100000, 1544715621), "LeaseCancelTransaction"},
+		{proto.NewUnsignedCreateAliasV1(public, *alias, 100000, 1544715621), "CreateAliasTransaction"},
+		{proto.NewUnsignedCreateAliasV2(public, *alias, 100000, 1544715621), "CreateAliasTransaction"},
+		{proto.NewUnsignedData(public, 100000, 1544715621), "DataTransaction"},
+		{proto.NewUnsignedSetScriptV1(proto.MainNetScheme, public, nil, 100000, 1544715621), "SetScriptTransaction"},
+		{proto.NewUnsignedSponsorshipV1(public, d, 100, 100000, 1544715621), "SponsorFeeTransaction"},
+		{proto.NewUnsignedSetAssetScriptV1(proto.MainNetScheme, public, d, []byte{1, 2, 3}, 100000, 1544715621), "SetAssetScriptTransaction"},
+	} {
+		switch tx := test.tx.(type) {
+		case *proto.Genesis:
+			require.NoError(t, tx.GenerateSigID())
+		case interface{ Sign(crypto.SecretKey) error }:
+			require.NoError(t, tx.Sign(secret))
+		}
+		vars, err := NewVariablesFromTransaction(proto.MainNetScheme, test.tx)
+		require.NoError(t, err, test.instance)
+		assert.Equal(t, NewString(test.instance), vars[InstanceFieldName])
+		assert.Contains(t, vars, "id")
+		assert.Contains(t, vars, "fee")
+		assert.Contains(t, vars, "timestamp")
+	}
+}
@@ -2,13 +2,15 @@ package evaluate
 
 import (
 	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/ast"
+	"github.com/wavesplatform/gowaves/pkg/ride/mockstate"
 )
 
 func Eval(e ast.Expr, s ast.Scope) (bool, error) {
 	rs, err := e.Evaluate(s)
 	if err != nil {
-		if _, ok := err.(ast.Throw); ok {
+		if _, ok := errors.Cause(err).(ast.Throw); ok {
 			// maybe log error
 			return false, nil
 		}
@@ -22,3 +24,36 @@ func Eval(e ast.Expr, s ast.Scope) (bool, error) {
 
 	return b.Value, nil
 }
+
+// Verify evaluates script of the account this against transaction, using functions and variables of the script version.
+func Verify(scheme byte, state mockstate.MockState, script *ast.Script, this proto.Address, tx proto.Transaction) (bool, error) {
+	s, err := ast.NewScopeByVersion(script.Version, scheme, state, this, tx)
+	if err != nil {
+		return false, err
+	}
+	return Eval(script.Verifier, s)
+}
+
+// EvalWithTrace evaluates expression recording the trace of evaluation. The trace is returned even if evaluation fails,
+// the node of the trace failed with throw keeps its message, while the result is false.
+func EvalWithTrace(e ast.Expr, s ast.Scope) (bool, *ast.Trace, error) {
+	trace := ast.NewTrace()
+	rs, err := EvalWithTracer(e, s, trace)
+	return rs, trace, err
+}
+
+// EvalWithTracer evaluates expression reporting events of evaluation to the tracer.
+func EvalWithTracer(e ast.Expr, s ast.Scope, t ast.Tracer) (bool, error) {
+	s.SetTracer(t)
+	defer s.SetTracer(nil)
+	return Eval(e, s)
+}
+
+// VerifyWithTrace is the same as Verify, but also returns the trace of evaluation.
+func VerifyWithTrace(scheme byte, state mockstate.MockState, script *ast.Script, this proto.Address, tx proto.Transaction) (bool, *ast.Trace, error) {
+	s, err := ast.NewScopeByVersion(script.Version, scheme, state, this, tx)
+	if err != nil {
+		return false, nil, err
+	}
+	return EvalWithTrace(script.Verifier, s)
+}
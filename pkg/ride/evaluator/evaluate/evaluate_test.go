@@ -166,7 +166,7 @@ func TestFunctions(t *testing.T) {
 		{304, `DROP_STRING`, `drop("abcd", 2) == "cd"`, `AQkAAAAAAAACCQABMAAAAAICAAAABGFiY2QAAAAAAAAAAAICAAAAAmNkZQdjWQ==`, true},
 		{305, `SIZE_STRING`, `size("abcd") == 4`, `AQkAAAAAAAACCQABMQAAAAECAAAABGFiY2QAAAAAAAAAAAScZzsq`, true},
 
-		{400, `SIZE_LIST`, `size(tx.proofs) == 1`, `AQkAAAAAAAACCQABkAAAAAEIBQAAAAJ0eAAAAAZwcm9vZnMAAAAAAAAAAAGGGXM4`, true},
+		{400, `SIZE_LIST`, `size(tx.proofs) == 8`, `AQkAAAAAAAACCQABkAAAAAEIBQAAAAJ0eAAAAAZwcm9vZnMAAAAAAAAAAAiZ7DFq`, true},
 		{401, `GET_LIST`, `size(tx.proofs[0]) > 0`, `AQkAAGYAAAACCQAAyAAAAAEJAAGRAAAAAggFAAAAAnR4AAAABnByb29mcwAAAAAAAAAAAAAAAAAAAAAAAFF6iVo=`, true},
 		{410, `LONG_TO_BYTES`, `toBytes(1) == base58'11111112'`, `AQkAAAAAAAACCQABmgAAAAEAAAAAAAAAAAEBAAAACAAAAAAAAAABm8cc1g==`, true},
 		{411, `STRING_TO_BYTES`, `toBytes("привет") == base58'4wUjatAwfVDjaHQVX'`, `AQkAAAAAAAACCQABmwAAAAECAAAADNC/0YDQuNCy0LXRggEAAAAM0L/RgNC40LLQtdGCuUGFxw==`, true},
@@ -175,6 +175,7 @@ func TestFunctions(t *testing.T) {
 		{421, `BOOLEAN_TO_STRING`, `toString(true) == "true"`, `AQkAAAAAAAACCQABpQAAAAEGAgAAAAR0cnVlL6ZrWg==`, true},
 
 		{500, `SIGVERIFY`, `sigVerify(tx.bodyBytes, tx.proofs[0], base58'14ovLL9a6xbBfftyxGNLKMdbnzGgnaFQjmgUJGdho6nY')`, `AQkAAfQAAAADCAUAAAACdHgAAAAJYm9keUJ5dGVzCQABkQAAAAIIBQAAAAJ0eAAAAAZwcm9vZnMAAAAAAAAAAAABAAAAIAD5y2Wf7zxfv7l+9tcWxyLAbktd9nCbdvFMnxmREqV1igWi3A==`, true},
+		{500, `SIGVERIFY`, `sigVerify(tx.bodyBytes, tx.proofs[1], base58'14ovLL9a6xbBfftyxGNLKMdbnzGgnaFQjmgUJGdho6nY')`, `AQkAAfQAAAADCAUAAAACdHgAAAAJYm9keUJ5dGVzCQABkQAAAAIIBQAAAAJ0eAAAAAZwcm9vZnMAAAAAAAAAAAEBAAAAIAD5y2Wf7zxfv7l+9tcWxyLAbktd9nCbdvFMnxmREqV16Vbq9Q==`, false},
 		{501, `KECCAK256`, `keccak256(base58'a') != base58'a'`, `AQkBAAAAAiE9AAAAAgkAAfUAAAABAQAAAAEhAQAAAAEhKeR77g==`, true},
 		{502, `BLAKE256`, `blake2b256(base58'a') != base58'a'`, `AQkBAAAAAiE9AAAAAgkAAfYAAAABAQAAAAEhAQAAAAEh50D2WA==`, true},
 		{503, `SHA256`, `sha256(base58'a') != base58'a'`, `AQkBAAAAAiE9AAAAAgkAAfcAAAABAQAAAAEhAQAAAAEhVojmeg==`, true},
@@ -283,3 +284,81 @@ func Benchmark_Verify(b *testing.B) {
 		}
 	}
 }
+
+func TestVerifyV3(t *testing.T) {
+	// lastBlock.height == height
+	r, err := reader.NewReaderFromBase64(`AwkAAAAAAAACCAUAAAAJbGFzdEJsb2NrAAAABmhlaWdodAUAAAAGaGVpZ2h0Jgl59Q==`)
+	require.NoError(t, err)
+	script, err := BuildScript(r)
+	require.NoError(t, err)
+	assert.Equal(t, 3, script.Version)
+
+	_, public := crypto.GenerateKeyPair([]byte(seed))
+	this, err := proto.NewAddressFromPublicKey(proto.MainNetScheme, public)
+	require.NoError(t, err)
+	s := mockstate.MockStateImpl{
+		CurrentHeight: 5,
+		BlockHeaders:  map[uint64]*proto.BlockHeader{5: {GenPublicKey: public}},
+	}
+
+	rs, err := Verify(proto.MainNetScheme, s, script, this, newTransferTransaction())
+	require.NoError(t, err)
+	assert.True(t, rs)
+
+	// lastBlock is not available for scripts of version 1
+	r, err = reader.NewReaderFromBase64(`AwkAAAAAAAACCAUAAAAJbGFzdEJsb2NrAAAABmhlaWdodAUAAAAGaGVpZ2h0Jgl59Q==`)
+	require.NoError(t, err)
+	script, err = BuildScript(r)
+	require.NoError(t, err)
+	script.Version = 1
+	_, err = Verify(proto.MainNetScheme, s, script, this, newTransferTransaction())
+	assert.Error(t, err)
+}
+
+func TestEvalWithTrace(t *testing.T) {
+	// true && throw("mess")
+	r, err := reader.NewReaderFromBase64(`AQMGCQAAAgAAAAECAAAABG1lc3MH7PDwAQ==`)
+	require.NoError(t, err)
+	e, err := BuildAst(r)
+	require.NoError(t, err)
+
+	rs, trace, err := EvalWithTrace(e, defaultScope())
+	require.NoError(t, err)
+	assert.False(t, rs)
+	require.Len(t, trace.Nodes, 1)
+	branch := trace.Nodes[0]
+	assert.Equal(t, TraceIf, branch.Kind)
+	assert.True(t, branch.Branch)
+	require.Len(t, branch.Children, 1)
+	call := branch.Children[0]
+	assert.Equal(t, "throw", call.Name)
+	assert.Equal(t, int16(2), call.FunctionID)
+	assert.Equal(t, Exprs{NewString("mess")}, call.Args)
+	assert.Equal(t, Throw{Message: "mess"}, call.Err)
+	assert.Equal(t, "if true failed: throw mess\n  throw(\"mess\") failed: throw mess\n", trace.String())
+
+	// match tx {case t : TransferTransaction => true case _  => false}
+	r, err = reader.NewReaderFromBase64(`AQQAAAAHJG1hdGNoMAUAAAACdHgDCQAAAQAAAAIFAAAAByRtYXRjaDACAAAAE1RyYW5zZmVyVHJhbnNhY3Rpb24EAAAAAXQFAAAAByRtYXRjaDAGB5yQ/+k=`)
+	require.NoError(t, err)
+	e, err = BuildAst(r)
+	require.NoError(t, err)
+
+	rs, trace, err = EvalWithTrace(e, defaultScope())
+	require.NoError(t, err)
+	assert.True(t, rs)
+	require.Len(t, trace.Nodes, 2)
+	isInstanceOf := trace.Nodes[0]
+	assert.Equal(t, "_isInstanceOf", isInstanceOf.Name)
+	assert.Equal(t, NewBoolean(true), isInstanceOf.Result)
+	require.Len(t, isInstanceOf.Children, 1)
+	assert.Equal(t, TraceLet, isInstanceOf.Children[0].Kind)
+	assert.Equal(t, "$match0", isInstanceOf.Children[0].Name)
+	assert.Equal(t, TraceIf, trace.Nodes[1].Kind)
+	assert.Equal(t, NewBoolean(true), trace.Nodes[1].Result)
+
+	// the tracer is removed from the scope after evaluation
+	s := defaultScope()
+	_, err = EvalWithTracer(e, s, NewTrace())
+	require.NoError(t, err)
+	assert.Nil(t, s.Tracer())
+}
@@ -6,14 +6,36 @@ import (
 	. "github.com/wavesplatform/gowaves/pkg/ride/evaluator/reader"
 )
 
+const (
+	MinScriptVersion = 1
+	MaxScriptVersion = 3
+)
+
 func BuildAst(r *BytesReader) (Expr, error) {
-	b := r.ReadByte()
-	// first byte always should be E_BYTES
-	if b != E_BYTES {
-		return nil, errors.Errorf("BuildAst: invalid format, expected 1, found %d", b)
+	script, err := BuildScript(r)
+	if err != nil {
+		return nil, err
 	}
+	return script.Verifier, nil
+}
 
-	return Walk(r)
+// BuildScript reads script version and expression of verifier.
+// The checksum of the script is validated if the reader holds it.
+func BuildScript(r *BytesReader) (*Script, error) {
+	if err := r.ValidateChecksum(); err != nil {
+		return nil, errors.Wrap(err, "BuildAst")
+	}
+	// first byte always should be script version
+	v := int(r.ReadByte())
+	if v < MinScriptVersion || v > MaxScriptVersion {
+		return nil, errors.Errorf("BuildAst: unsupported script version %d", v)
+	}
+
+	e, err := Walk(r)
+	if err != nil {
+		return nil, err
+	}
+	return &Script{Version: v, Verifier: e}, nil
 }
 
 func Walk(iter *BytesReader) (Expr, error) {
@@ -36,6 +58,8 @@ func Walk(iter *BytesReader) (Expr, error) {
 		return readIf(iter)
 	case E_BLOCK:
 		return readBlock(iter)
+	case E_BLOCKV2:
+		return readBlockV2(iter)
 	case E_REF:
 		return &RefExpr{
 			Name: iter.ReadString(),
@@ -71,6 +95,40 @@ func readBlock(r *BytesReader) (*Block, error) {
 	}, nil
 }
 
+func readBlockV2(r *BytesReader) (Expr, error) {
+	declarationType := r.ReadByte()
+	switch declarationType {
+	case DEC_LET:
+		b, err := readBlock(r)
+		if err != nil {
+			return nil, err
+		}
+		b.BlockV2 = true
+		return b, nil
+	case DEC_FUNC:
+		name := r.ReadString()
+		argc := r.ReadInt()
+		args := make([]string, argc)
+		for i := int32(0); i < argc; i++ {
+			args[i] = r.ReadString()
+		}
+		funcBody, err := Walk(r)
+		if err != nil {
+			return nil, err
+		}
+		body, err := Walk(r)
+		if err != nil {
+			return nil, err
+		}
+		return &FuncBlock{
+			Func: NewFuncDeclaration(name, args, funcBody),
+			Body: body,
+		}, nil
+	default:
+		return nil, errors.Errorf("invalid declaration type, expects 0 or 1, found %d", declarationType)
+	}
+}
+
 func readFuncCAll(iter *BytesReader) (*FuncCall, error) {
 	nativeOrUser := iter.ReadByte()
 	switch nativeOrUser {
@@ -1,10 +1,12 @@
 package reader
 
 import (
+	"bytes"
 	"encoding/base64"
 	"encoding/binary"
 
 	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
 )
 
 const E_LONG byte = 0
@@ -17,16 +19,24 @@ const E_TRUE byte = 6
 const E_FALSE byte = 7
 const E_GETTER byte = 8
 const E_FUNCALL byte = 9
+const E_BLOCKV2 byte = 10
+
+const DEC_LET byte = 0
+const DEC_FUNC byte = 1
 
 const FH_NATIVE byte = 0
 const FH_USER byte = 1
 
+// ChecksumSize is the size of the checksum at the end of the script.
+const ChecksumSize = 4
+
 var ErrUnexpectedEOF = errors.New("unexpected eof")
 
 type BytesReader struct {
-	bytes []byte
-	pos   int
-	len   int
+	bytes    []byte
+	pos      int
+	len      int
+	checksum []byte
 }
 
 func NewBytesReader(bytes []byte) *BytesReader {
@@ -36,19 +46,50 @@ func NewBytesReader(bytes []byte) *BytesReader {
 	}
 }
 
+// NewReaderFromScript creates the reader of the script bytes with the trailing checksum.
+// The checksum is not read as a part of the script, it is validated by the parser.
+func NewReaderFromScript(script []byte) (*BytesReader, error) {
+	l := len(script)
+	if l < ChecksumSize {
+		return nil, errors.Errorf("expected script len at least %d bytes, got %d", ChecksumSize, l)
+	}
+	return &BytesReader{
+		bytes:    script[:l-ChecksumSize],
+		len:      l - ChecksumSize,
+		checksum: script[l-ChecksumSize:],
+	}, nil
+}
+
 func NewReaderFromBase64(base64String string) (*BytesReader, error) {
 	decoded, err := base64.StdEncoding.DecodeString(base64String)
 	if err != nil {
 		return nil, err
 	}
+	return NewReaderFromScript(decoded)
+}
 
-	l := len(decoded)
-
-	if l < 4 {
-		return nil, errors.Errorf("expected script len at least 4 bytes, got %d", l)
+// Checksum calculates the checksum of the script bytes without the checksum.
+func Checksum(script []byte) ([]byte, error) {
+	h, err := crypto.SecureHash(script)
+	if err != nil {
+		return nil, err
 	}
+	return h[:ChecksumSize], nil
+}
 
-	return NewBytesReader(decoded[:l-4]), nil
+// ValidateChecksum checks the checksum of the script if the reader was created with it, otherwise does nothing.
+func (a *BytesReader) ValidateChecksum() error {
+	if a.checksum == nil {
+		return nil
+	}
+	expected, err := Checksum(a.bytes[:a.len])
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate checksum")
+	}
+	if !bytes.Equal(expected, a.checksum) {
+		return errors.Errorf("invalid checksum: expected %x, found %x", expected, a.checksum)
+	}
+	return nil
 }
 
 func (a *BytesReader) Len() int {
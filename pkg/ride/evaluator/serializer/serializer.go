@@ -0,0 +1,170 @@
+// Package serializer writes scripts in the binary format read by the parser.
+package serializer
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/ast"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/parser"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/reader"
+)
+
+// SerializeScript returns the bytes of the script: the version, the expression of verifier and the checksum.
+func SerializeScript(s *ast.Script) ([]byte, error) {
+	if s.Version < parser.MinScriptVersion || s.Version > parser.MaxScriptVersion {
+		return nil, errors.Errorf("unsupported script version %d", s.Version)
+	}
+	w := &writer{version: s.Version}
+	w.byte(byte(s.Version))
+	if err := w.expr(s.Verifier); err != nil {
+		return nil, err
+	}
+	body := w.buf.Bytes()
+	checksum, err := reader.Checksum(body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to calculate checksum")
+	}
+	return append(body, checksum...), nil
+}
+
+// SerializeExpr returns the bytes of the expression without the version and the checksum.
+// Expressions of all versions are allowed.
+func SerializeExpr(e ast.Expr) ([]byte, error) {
+	w := &writer{version: parser.MaxScriptVersion}
+	if err := w.expr(e); err != nil {
+		return nil, err
+	}
+	return w.buf.Bytes(), nil
+}
+
+type writer struct {
+	buf     bytes.Buffer
+	version int
+}
+
+func (w *writer) byte(b byte) {
+	w.buf.WriteByte(b)
+}
+
+func (w *writer) short(v int16) {
+	var b [2]byte
+	binary.BigEndian.PutUint16(b[:], uint16(v))
+	w.buf.Write(b[:])
+}
+
+func (w *writer) int(v int) {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], uint32(v))
+	w.buf.Write(b[:])
+}
+
+func (w *writer) long(v int64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(v))
+	w.buf.Write(b[:])
+}
+
+func (w *writer) bytes(b []byte) {
+	w.int(len(b))
+	w.buf.Write(b)
+}
+
+func (w *writer) string(s string) {
+	w.bytes([]byte(s))
+}
+
+func (w *writer) exprs(exprs ...ast.Expr) error {
+	for _, e := range exprs {
+		if err := w.expr(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *writer) args(args ast.Exprs) error {
+	w.int(len(args))
+	return w.exprs(args...)
+}
+
+// declarationBlock checks that blocks with declarations are supported by the version of the script.
+func (w *writer) declarationBlock() error {
+	if w.version < 3 {
+		return errors.Errorf("declaration blocks are not supported in version %d", w.version)
+	}
+	w.byte(reader.E_BLOCKV2)
+	return nil
+}
+
+func (w *writer) expr(e ast.Expr) error {
+	switch v := e.(type) {
+	case *ast.LongExpr:
+		w.byte(reader.E_LONG)
+		w.long(v.Value)
+	case *ast.BytesExpr:
+		w.byte(reader.E_BYTES)
+		w.bytes(v.Value)
+	case *ast.StringExpr:
+		w.byte(reader.E_STRING)
+		w.string(v.Value)
+	case *ast.BooleanExpr:
+		if v.Value {
+			w.byte(reader.E_TRUE)
+		} else {
+			w.byte(reader.E_FALSE)
+		}
+	case *ast.IfExpr:
+		w.byte(reader.E_IF)
+		return w.exprs(v.Condition, v.True, v.False)
+	case *ast.Block:
+		if v.BlockV2 {
+			if err := w.declarationBlock(); err != nil {
+				return err
+			}
+			w.byte(reader.DEC_LET)
+		} else {
+			w.byte(reader.E_BLOCK)
+		}
+		w.string(v.Let.Name)
+		return w.exprs(v.Let.Value, v.Body)
+	case *ast.FuncBlock:
+		if err := w.declarationBlock(); err != nil {
+			return err
+		}
+		w.byte(reader.DEC_FUNC)
+		w.string(v.Func.Name)
+		w.int(len(v.Func.Args))
+		for _, a := range v.Func.Args {
+			w.string(a)
+		}
+		return w.exprs(v.Func.Body, v.Body)
+	case *ast.RefExpr:
+		w.byte(reader.E_REF)
+		w.string(v.Name)
+	case *ast.GetterExpr:
+		w.byte(reader.E_GETTER)
+		if err := w.expr(v.Object); err != nil {
+			return err
+		}
+		w.string(v.Key)
+	case *ast.FuncCall:
+		w.byte(reader.E_FUNCALL)
+		switch f := v.Func.(type) {
+		case *ast.NativeFunction:
+			w.byte(reader.FH_NATIVE)
+			w.short(f.FunctionID)
+			return w.args(f.Argv)
+		case *ast.UserFunction:
+			w.byte(reader.FH_USER)
+			w.string(f.Name)
+			return w.args(f.Argv)
+		default:
+			return errors.Errorf("unsupported function %T", v.Func)
+		}
+	default:
+		return errors.Errorf("unsupported expression %T", e)
+	}
+	return nil
+}
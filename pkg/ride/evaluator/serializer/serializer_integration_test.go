@@ -0,0 +1,121 @@
+package serializer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/client"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/parser"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/reader"
+)
+
+// Blocks are requested by sequences of the maximal length served by nodes.
+const blocksSeqLength = 100
+
+type chainScript struct {
+	script proto.Script
+	height uint64
+	id     string
+}
+
+func txScript(tx proto.Transaction) (proto.Script, string) {
+	switch tx := tx.(type) {
+	case *proto.SetScriptV1:
+		return tx.Script, tx.ID.String()
+	case *proto.SetAssetScriptV1:
+		return tx.Script, tx.ID.String()
+	case *proto.IssueV2:
+		return tx.Script, tx.ID.String()
+	default:
+		return nil, ""
+	}
+}
+
+func heightEnv(t *testing.T, name string, def uint64) uint64 {
+	s := os.Getenv(name)
+	if s == "" {
+		return def
+	}
+	h, err := strconv.ParseUint(s, 10, 64)
+	require.NoError(t, err, name)
+	return h
+}
+
+// fetchScripts collects distinct scripts of accounts and assets set in blocks of the node in the range of heights.
+func fetchScripts(t *testing.T, node string, from, to uint64) []chainScript {
+	c, err := client.NewClient(client.Options{BaseUrl: node, Client: &http.Client{Timeout: time.Minute}})
+	require.NoError(t, err)
+	ctx := context.Background()
+	if to == 0 {
+		h, _, err := c.Blocks.Height(ctx)
+		require.NoError(t, err)
+		to = h.Height
+	}
+	seen := make(map[string]bool)
+	var scripts []chainScript
+	for start := from; start <= to; start += blocksSeqLength {
+		end := start + blocksSeqLength - 1
+		if end > to {
+			end = to
+		}
+		blocks, _, err := c.Blocks.Seq(ctx, start, end)
+		require.NoError(t, err, "blocks %d-%d", start, end)
+		for _, b := range blocks {
+			for _, tx := range b.Transactions {
+				s, id := txScript(tx)
+				if len(s) == 0 || seen[string(s)] {
+					continue
+				}
+				seen[string(s)] = true
+				scripts = append(scripts, chainScript{script: s, height: b.Height, id: id})
+			}
+		}
+	}
+	return scripts
+}
+
+// TestRoundTripNetwork checks round trip of scripts set on the network of the node given by ScriptsNode variable,
+// e.g. https://nodes.wavesnodes.com. The range of heights is limited by ScriptsFrom and ScriptsTo variables, the whole
+// blockchain is checked by default. The scripts are written in the format of testdata to the file given by ScriptsDump.
+func TestRoundTripNetwork(t *testing.T) {
+	node := os.Getenv("ScriptsNode")
+	if node == "" {
+		t.Skip("no env node provided")
+	}
+	scripts := fetchScripts(t, node, heightEnv(t, "ScriptsFrom", 1), heightEnv(t, "ScriptsTo", 0))
+	dump := new(bytes.Buffer)
+	_, _ = fmt.Fprintf(dump, "# Scripts set on the network of %s.\n", node)
+	dApps := 0
+	for _, s := range scripts {
+		// DApps start with zero byte instead of the version, the parser reads only expression scripts.
+		if s.script[0] == 0 {
+			dApps++
+			continue
+		}
+		_, _ = fmt.Fprintf(dump, "# Transaction %s at height %d.\n%s\n", s.id, s.height, s.script.String())
+		r, err := reader.NewReaderFromScript(s.script)
+		require.NoError(t, err, s.id)
+		script, err := parser.BuildScript(r)
+		if !assert.NoError(t, err, s.id) {
+			continue
+		}
+		rs, err := SerializeScript(script)
+		if assert.NoError(t, err, s.id) {
+			assert.Equal(t, []byte(s.script), rs, s.id)
+		}
+	}
+	t.Logf("%d scripts checked, %d DApps skipped", len(scripts)-dApps, dApps)
+	if name := os.Getenv("ScriptsDump"); name != "" {
+		require.NoError(t, ioutil.WriteFile(name, dump.Bytes(), 0644))
+	}
+}
@@ -0,0 +1,120 @@
+package serializer
+
+import (
+	"bufio"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/ast"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/parser"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/reader"
+)
+
+func loadScripts(t *testing.T) []string {
+	files, err := filepath.Glob(filepath.Join("testdata", "*.txt"))
+	require.NoError(t, err)
+	var scripts []string
+	for _, name := range files {
+		f, err := os.Open(name)
+		require.NoError(t, err)
+		s := bufio.NewScanner(f)
+		s.Buffer(make([]byte, 64*1024), 1024*1024)
+		for s.Scan() {
+			line := strings.TrimSpace(s.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			scripts = append(scripts, strings.TrimPrefix(line, "base64:"))
+		}
+		require.NoError(t, s.Err())
+		require.NoError(t, f.Close())
+	}
+	return scripts
+}
+
+func TestRoundTrip(t *testing.T) {
+	scripts := loadScripts(t)
+	require.NotEmpty(t, scripts)
+	for _, s := range scripts {
+		b, err := base64.StdEncoding.DecodeString(s)
+		require.NoError(t, err, s)
+		r, err := reader.NewReaderFromScript(b)
+		require.NoError(t, err, s)
+		script, err := parser.BuildScript(r)
+		require.NoError(t, err, s)
+		rs, err := SerializeScript(script)
+		require.NoError(t, err, s)
+		assert.Equal(t, b, rs, s)
+	}
+}
+
+func TestRoundTripDeclarations(t *testing.T) {
+	call := ast.NewFuncCall(ast.NewUserFunction("inc", 1, ast.Params(&ast.RefExpr{Name: "x"})))
+	body := ast.NewFuncCall(ast.NewNativeFunction(0, 2, ast.Params(call, ast.NewLong(2))))
+	v2 := &ast.Block{Let: ast.NewLet("x", ast.NewLong(1)), Body: body, BlockV2: true}
+	inc := ast.NewFuncDeclaration("inc", []string{"a"}, ast.NewFuncCall(ast.NewNativeFunction(100, 2, ast.Params(&ast.RefExpr{Name: "a"}, ast.NewLong(1)))))
+	e := &ast.FuncBlock{Func: inc, Body: v2}
+
+	b, err := SerializeScript(&ast.Script{Version: 3, Verifier: e})
+	require.NoError(t, err)
+	r, err := reader.NewReaderFromScript(b)
+	require.NoError(t, err)
+	script, err := parser.BuildScript(r)
+	require.NoError(t, err)
+	assert.Equal(t, 3, script.Version)
+	assert.Equal(t, e, script.Verifier)
+
+	rs, err := SerializeScript(script)
+	require.NoError(t, err)
+	assert.Equal(t, b, rs)
+
+	// Plain let blocks of the first version are kept as they are
+	v2.BlockV2 = false
+	b, err = SerializeExpr(v2)
+	require.NoError(t, err)
+	expr, err := parser.Walk(reader.NewBytesReader(b))
+	require.NoError(t, err)
+	assert.Equal(t, v2, expr)
+}
+
+func TestSerializeErrors(t *testing.T) {
+	f := &ast.FuncBlock{Func: ast.NewFuncDeclaration("f", nil, ast.NewBoolean(true)), Body: ast.NewBoolean(true)}
+	_, err := SerializeScript(&ast.Script{Version: 2, Verifier: f})
+	assert.EqualError(t, err, "declaration blocks are not supported in version 2")
+	_, err = SerializeExpr(f)
+	assert.NoError(t, err)
+
+	let := &ast.Block{Let: ast.NewLet("x", ast.NewLong(1)), Body: ast.NewBoolean(true), BlockV2: true}
+	_, err = SerializeScript(&ast.Script{Version: 1, Verifier: let})
+	assert.Error(t, err)
+
+	_, err = SerializeScript(&ast.Script{Version: 4, Verifier: ast.NewBoolean(true)})
+	assert.EqualError(t, err, "unsupported script version 4")
+
+	_, err = SerializeScript(&ast.Script{Version: 3, Verifier: ast.NewUnit()})
+	assert.EqualError(t, err, "unsupported expression ast.Unit")
+}
+
+func TestBuildScriptChecksum(t *testing.T) {
+	b, err := SerializeScript(&ast.Script{Version: 1, Verifier: ast.NewBoolean(true)})
+	require.NoError(t, err)
+	assert.Equal(t, "AQa3b8tH", base64.StdEncoding.EncodeToString(b))
+
+	b[len(b)-1]++
+	r, err := reader.NewReaderFromScript(b)
+	require.NoError(t, err)
+	_, err = parser.BuildScript(r)
+	assert.EqualError(t, err, "BuildAst: invalid checksum: expected b76fcb47, found b76fcb48")
+
+	// Readers without checksum are not checked
+	_, err = parser.BuildScript(reader.NewBytesReader(b[:len(b)-reader.ChecksumSize]))
+	assert.NoError(t, err)
+
+	_, err = reader.NewReaderFromScript([]byte{1, 6})
+	assert.Error(t, err)
+}
@@ -3,6 +3,7 @@ package mockstate
 import (
 	"github.com/mr-tron/base58/base58"
 	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 )
 
@@ -14,16 +15,36 @@ type Account interface {
 	Address() proto.Address
 }
 
+// AssetInfo describes an issued asset as it is seen by scripts.
+type AssetInfo struct {
+	ID              crypto.Digest
+	Quantity        uint64
+	Decimals        byte
+	Issuer          proto.Address
+	IssuerPublicKey crypto.PublicKey
+	Reissuable      bool
+	Scripted        bool
+	Sponsored       bool
+}
+
 type MockState interface {
 	TransactionByID([]byte) (proto.Transaction, error)
 	TransactionHeightByID([]byte) (uint64, error)
 	Account(proto.Recipient) Account
+	Height() (uint64, error)
+	BlockHeaderByHeight(uint64) (*proto.BlockHeader, error)
+	AssetInfo([]byte) (*AssetInfo, error)
+	AddressByAlias(proto.Alias) (proto.Address, error)
 }
 
 type MockStateImpl struct {
 	TransactionsByID       map[string]proto.Transaction
 	TransactionsHeightByID map[string]uint64
 	Accounts               map[string]Account // recipient to account
+	CurrentHeight          uint64
+	BlockHeaders           map[uint64]*proto.BlockHeader
+	Assets                 map[string]AssetInfo     // base58 encoded asset ID to asset info
+	Aliases                map[string]proto.Address // alias string to address
 }
 
 func (a MockStateImpl) TransactionByID(b []byte) (proto.Transaction, error) {
@@ -46,6 +67,34 @@ func (a MockStateImpl) Account(r proto.Recipient) Account {
 	return a.Accounts[r.String()]
 }
 
+func (a MockStateImpl) Height() (uint64, error) {
+	return a.CurrentHeight, nil
+}
+
+func (a MockStateImpl) BlockHeaderByHeight(height uint64) (*proto.BlockHeader, error) {
+	h, ok := a.BlockHeaders[height]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return h, nil
+}
+
+func (a MockStateImpl) AssetInfo(b []byte) (*AssetInfo, error) {
+	info, ok := a.Assets[base58.Encode(b)]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &info, nil
+}
+
+func (a MockStateImpl) AddressByAlias(alias proto.Alias) (proto.Address, error) {
+	addr, ok := a.Aliases[alias.String()]
+	if !ok {
+		return proto.Address{}, ErrNotFound
+	}
+	return addr, nil
+}
+
 type MockAccount struct {
 	Assets       map[string]uint64
 	DataEntries  []proto.DataEntry
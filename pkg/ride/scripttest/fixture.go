@@ -0,0 +1,182 @@
+// Package scripttest runs RIDE scripts against transactions described in YAML or JSON fixtures and checks results of
+// evaluation. The blockchain state is mocked by the accounts, assets and transactions listed in the fixture, so no
+// node is required.
+package scripttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Fixture describes the script, the state of the blockchain and the test cases to run.
+//
+// Example of YAML fixture:
+//
+//	scheme: W
+//	height: 100
+//	script_file: limit.ride
+//	this: 3P2USE3iYK5w7jNahAUHTytNbVRccGZwQH3
+//	accounts:
+//	  - address: 3P2USE3iYK5w7jNahAUHTytNbVRccGZwQH3
+//	    balances: {WAVES: 100000000}
+//	    data:
+//	      - {key: limit, type: integer, value: 1000}
+//	tests:
+//	  - name: small transfer
+//	    tx: {type: 4, version: 2, ...}
+//	    expect: true
+//	  - name: large transfer
+//	    tx: {type: 4, version: 2, ...}
+//	    throw: amount exceeds limit
+type Fixture struct {
+	Name string `json:"name" yaml:"name"`
+	// Scheme is the address scheme of the blockchain, "W" by default.
+	Scheme string `json:"scheme" yaml:"scheme"`
+	// Height of the blockchain, 1 by default.
+	Height uint64 `json:"height" yaml:"height"`
+	// Script is either the source code or the compiled script as base64 string with "base64:" prefix.
+	Script string `json:"script" yaml:"script"`
+	// ScriptFile is the path to the script relative to the fixture, used if Script is empty.
+	ScriptFile   string             `json:"script_file" yaml:"script_file"`
+	This         string             `json:"this" yaml:"this"`
+	Accounts     []Account          `json:"accounts" yaml:"accounts"`
+	Assets       []Asset            `json:"assets" yaml:"assets"`
+	Transactions []StateTransaction `json:"transactions" yaml:"transactions"`
+	Tests        []Test             `json:"tests" yaml:"tests"`
+
+	dir string
+}
+
+// Account is the account in the state of the blockchain.
+type Account struct {
+	Address string   `json:"address" yaml:"address"`
+	Aliases []string `json:"aliases" yaml:"aliases"`
+	// Balances by asset, "WAVES" or base58 encoded asset ID.
+	Balances map[string]uint64 `json:"balances" yaml:"balances"`
+	// Data entries in the format of data transaction.
+	Data jsonValue `json:"data" yaml:"data"`
+}
+
+// Asset is the asset issued on the blockchain.
+type Asset struct {
+	ID              string `json:"id" yaml:"id"`
+	Quantity        uint64 `json:"quantity" yaml:"quantity"`
+	Decimals        byte   `json:"decimals" yaml:"decimals"`
+	Issuer          string `json:"issuer" yaml:"issuer"`
+	IssuerPublicKey string `json:"issuer_public_key" yaml:"issuer_public_key"`
+	Reissuable      bool   `json:"reissuable" yaml:"reissuable"`
+	Scripted        bool   `json:"scripted" yaml:"scripted"`
+	Sponsored       bool   `json:"sponsored" yaml:"sponsored"`
+}
+
+// StateTransaction is the transaction already stored on the blockchain at the given height.
+type StateTransaction struct {
+	Height uint64    `json:"height" yaml:"height"`
+	Tx     jsonValue `json:"tx" yaml:"tx"`
+}
+
+// Test is the transaction to verify by the script and the expected outcome. Only one of Expect, Throw and Error is
+// checked, if none of them is set the script is expected to return true.
+type Test struct {
+	Name string    `json:"name" yaml:"name"`
+	Tx   jsonValue `json:"tx" yaml:"tx"`
+	// Height overrides the height of the blockchain for this test.
+	Height uint64 `json:"height" yaml:"height"`
+	// Expect is the expected result of the script.
+	Expect *bool `json:"expect" yaml:"expect"`
+	// Throw is the expected message of the exception thrown by the script.
+	Throw *string `json:"throw" yaml:"throw"`
+	// Error is the expected substring of the error of evaluation.
+	Error string `json:"error" yaml:"error"`
+}
+
+// Load reads the fixture from the file, files with extension ".json" are decoded as JSON, others as YAML.
+func Load(path string) (*Fixture, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f *Fixture
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		f, err = ParseJSON(b)
+	} else {
+		f, err = ParseYAML(b)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load fixture %s", path)
+	}
+	f.dir = filepath.Dir(path)
+	if f.Name == "" {
+		f.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return f, nil
+}
+
+// ParseYAML decodes the fixture from YAML. Anchors and merge keys could be used to share transactions between tests.
+func ParseYAML(data []byte) (*Fixture, error) {
+	f := &Fixture{}
+	if err := yaml.Unmarshal(data, f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// ParseJSON decodes the fixture from JSON.
+func ParseJSON(data []byte) (*Fixture, error) {
+	f := &Fixture{}
+	if err := json.Unmarshal(data, f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// jsonValue keeps the part of the fixture as JSON to decode it later with JSON unmarshalers of proto package.
+type jsonValue []byte
+
+func (v *jsonValue) UnmarshalJSON(data []byte) error {
+	*v = append((*v)[:0], data...)
+	return nil
+}
+
+func (v *jsonValue) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var raw interface{}
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+	b, err := json.Marshal(jsonCompatible(raw))
+	if err != nil {
+		return err
+	}
+	*v = b
+	return nil
+}
+
+func (v jsonValue) empty() bool {
+	s := strings.TrimSpace(string(v))
+	return s == "" || s == "null"
+}
+
+// jsonCompatible replaces maps with arbitrary keys produced by YAML decoder with maps with string keys.
+func jsonCompatible(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, e := range t {
+			m[fmt.Sprint(k)] = jsonCompatible(e)
+		}
+		return m
+	case []interface{}:
+		for i, e := range t {
+			t[i] = jsonCompatible(e)
+		}
+		return t
+	default:
+		return v
+	}
+}
@@ -0,0 +1,361 @@
+package scripttest
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride/compiler"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/ast"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/evaluate"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/parser"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/reader"
+	"github.com/wavesplatform/gowaves/pkg/ride/mockstate"
+)
+
+// Result is the outcome of the test.
+type Result struct {
+	Name   string
+	Passed bool
+	// Reason describes why the test failed.
+	Reason string
+	// Value and Err are the result of evaluation of the script.
+	Value bool
+	Err   error
+	Trace *ast.Trace
+}
+
+// Run evaluates the script of the fixture against transactions of all its tests. The error is returned if the
+// fixture itself is invalid, failures of tests are reported in results.
+func Run(f *Fixture) ([]Result, error) {
+	scheme := byte(proto.MainNetScheme)
+	if f.Scheme != "" {
+		if len(f.Scheme) != 1 {
+			return nil, errors.Errorf("invalid scheme '%s'", f.Scheme)
+		}
+		scheme = f.Scheme[0]
+	}
+	script, err := f.script()
+	if err != nil {
+		return nil, err
+	}
+	st, err := f.state(scheme)
+	if err != nil {
+		return nil, err
+	}
+	var this proto.Address
+	if f.This != "" {
+		this, err = proto.NewAddressFromString(f.This)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid address of the account")
+		}
+	}
+	results := make([]Result, len(f.Tests))
+	for i, t := range f.Tests {
+		name := t.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i+1)
+		}
+		tx, _, err := parseTransaction(t.Tx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "test %s", name)
+		}
+		s := st
+		if t.Height != 0 {
+			s = st.atHeight(t.Height)
+		}
+		results[i] = run(name, t, scheme, s, script, this, tx)
+	}
+	return results, nil
+}
+
+func run(name string, t Test, scheme byte, s *state, script *ast.Script, this proto.Address, tx proto.Transaction) Result {
+	r := Result{Name: name}
+	r.Value, r.Trace, r.Err = evaluate.VerifyWithTrace(scheme, s, script, this, tx)
+	thrown, isThrown := thrownMessage(r.Trace)
+	switch {
+	case t.Error != "":
+		switch {
+		case r.Err == nil:
+			r.Reason = fmt.Sprintf("expected error containing '%s', but script returned %t", t.Error, r.Value)
+		case !strings.Contains(r.Err.Error(), t.Error):
+			r.Reason = fmt.Sprintf("expected error containing '%s', got: %s", t.Error, r.Err)
+		}
+	case r.Err != nil:
+		r.Reason = fmt.Sprintf("evaluation failed: %s", r.Err)
+	case t.Throw != nil:
+		switch {
+		case !isThrown:
+			r.Reason = fmt.Sprintf("expected throw '%s', but script returned %t", *t.Throw, r.Value)
+		case thrown != *t.Throw:
+			r.Reason = fmt.Sprintf("expected throw '%s', got throw '%s'", *t.Throw, thrown)
+		}
+	case isThrown:
+		r.Reason = fmt.Sprintf("unexpected throw '%s'", thrown)
+	default:
+		expected := true
+		if t.Expect != nil {
+			expected = *t.Expect
+		}
+		if r.Value != expected {
+			r.Reason = fmt.Sprintf("expected %t, got %t", expected, r.Value)
+		}
+	}
+	r.Passed = r.Reason == ""
+	return r
+}
+
+// thrownMessage returns the message of the exception which terminated the evaluation.
+func thrownMessage(trace *ast.Trace) (string, bool) {
+	if trace == nil {
+		return "", false
+	}
+	for _, n := range trace.Nodes {
+		if n.Err == nil {
+			continue
+		}
+		if t, ok := errors.Cause(n.Err).(ast.Throw); ok {
+			return t.Message, true
+		}
+	}
+	return "", false
+}
+
+// Report writes results of the fixture, traces are written for failed tests or for all tests if verbose is set.
+// It returns the number of passed and failed tests.
+func Report(w io.Writer, fixture string, results []Result, verbose bool) (passed, failed int) {
+	for _, r := range results {
+		if r.Passed {
+			passed++
+			_, _ = fmt.Fprintf(w, "PASS %s/%s\n", fixture, r.Name)
+		} else {
+			failed++
+			_, _ = fmt.Fprintf(w, "FAIL %s/%s: %s\n", fixture, r.Name, r.Reason)
+		}
+		if (verbose || !r.Passed) && r.Trace != nil {
+			writeIndented(w, r.Trace.String(), "    ")
+		}
+	}
+	return passed, failed
+}
+
+func writeIndented(w io.Writer, text, indent string) {
+	sc := bufio.NewScanner(strings.NewReader(text))
+	for sc.Scan() {
+		_, _ = fmt.Fprintf(w, "%s%s\n", indent, sc.Text())
+	}
+}
+
+// script compiles the source code of the script or decodes the compiled script given as base64 string with prefix.
+func (f *Fixture) script() (*ast.Script, error) {
+	text := f.Script
+	if text == "" {
+		if f.ScriptFile == "" {
+			return nil, errors.New("no script in fixture")
+		}
+		path := f.ScriptFile
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(f.dir, path)
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		text = string(b)
+	}
+	var code []byte
+	if t := strings.TrimSpace(text); strings.HasPrefix(t, "base64:") {
+		b, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(t, "base64:"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode script")
+		}
+		code = b
+	} else {
+		script, err := compiler.Compile(text)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to compile script")
+		}
+		code = script.Bytes
+	}
+	r, err := reader.NewReaderFromScript(code)
+	if err != nil {
+		return nil, err
+	}
+	return parser.BuildScript(r)
+}
+
+func (f *Fixture) state(scheme byte) (*state, error) {
+	height := f.Height
+	if height == 0 {
+		height = 1
+	}
+	s := &state{
+		MockStateImpl: mockstate.MockStateImpl{
+			TransactionsByID:       make(map[string]proto.Transaction),
+			TransactionsHeightByID: make(map[string]uint64),
+			Accounts:               make(map[string]mockstate.Account),
+			CurrentHeight:          height,
+			BlockHeaders:           map[uint64]*proto.BlockHeader{height: {}},
+			Assets:                 make(map[string]mockstate.AssetInfo),
+			Aliases:                make(map[string]proto.Address),
+		},
+	}
+	for _, a := range f.Accounts {
+		acc, err := newAccount(a)
+		if err != nil {
+			return nil, errors.Wrapf(err, "account %s", a.Address)
+		}
+		s.Accounts[acc.AddressField.String()] = acc
+		for _, alias := range a.Aliases {
+			s.Aliases[proto.NewAlias(scheme, alias).String()] = acc.AddressField
+		}
+	}
+	for _, a := range f.Assets {
+		info, err := newAssetInfo(a)
+		if err != nil {
+			return nil, errors.Wrapf(err, "asset %s", a.ID)
+		}
+		s.Assets[a.ID] = info
+	}
+	for i, t := range f.Transactions {
+		tx, id, err := parseTransaction(t.Tx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "transaction #%d", i+1)
+		}
+		s.TransactionsByID[id] = tx
+		s.TransactionsHeightByID[id] = t.Height
+	}
+	return s, nil
+}
+
+func newAccount(a Account) (*mockstate.MockAccount, error) {
+	addr, err := proto.NewAddressFromString(a.Address)
+	if err != nil {
+		return nil, err
+	}
+	acc := &mockstate.MockAccount{AddressField: addr, Assets: make(map[string]uint64)}
+	for k, v := range a.Balances {
+		asset, err := proto.NewOptionalAssetFromString(k)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid asset '%s'", k)
+		}
+		acc.Assets[asset.String()] = v
+	}
+	if !a.Data.empty() {
+		var entries proto.DataEntries
+		if err := json.Unmarshal(a.Data, &entries); err != nil {
+			return nil, err
+		}
+		acc.DataEntries = make([]proto.DataEntry, len(entries))
+		for i, e := range entries {
+			acc.DataEntries[i] = dataEntryValue(e)
+		}
+	}
+	return acc, nil
+}
+
+// dataEntryValue converts pointer to the data entry decoded from JSON to the value, the form expected by evaluator.
+func dataEntryValue(e proto.DataEntry) proto.DataEntry {
+	switch v := e.(type) {
+	case *proto.IntegerDataEntry:
+		return *v
+	case *proto.BooleanDataEntry:
+		return *v
+	case *proto.BinaryDataEntry:
+		return *v
+	case *proto.StringDataEntry:
+		return *v
+	default:
+		return e
+	}
+}
+
+func newAssetInfo(a Asset) (mockstate.AssetInfo, error) {
+	id, err := crypto.NewDigestFromBase58(a.ID)
+	if err != nil {
+		return mockstate.AssetInfo{}, errors.Wrap(err, "invalid asset ID")
+	}
+	info := mockstate.AssetInfo{
+		ID:         id,
+		Quantity:   a.Quantity,
+		Decimals:   a.Decimals,
+		Reissuable: a.Reissuable,
+		Scripted:   a.Scripted,
+		Sponsored:  a.Sponsored,
+	}
+	if a.Issuer != "" {
+		info.Issuer, err = proto.NewAddressFromString(a.Issuer)
+		if err != nil {
+			return mockstate.AssetInfo{}, errors.Wrap(err, "invalid issuer")
+		}
+	}
+	if a.IssuerPublicKey != "" {
+		info.IssuerPublicKey, err = crypto.NewPublicKeyFromBase58(a.IssuerPublicKey)
+		if err != nil {
+			return mockstate.AssetInfo{}, errors.Wrap(err, "invalid issuer public key")
+		}
+	}
+	return info, nil
+}
+
+// parseTransaction decodes the transaction and its ID, the ID must be set in the fixture.
+func parseTransaction(v jsonValue) (proto.Transaction, string, error) {
+	if v.empty() {
+		return nil, "", errors.New("no transaction")
+	}
+	tt := proto.TransactionTypeVersion{}
+	if err := json.Unmarshal(v, &tt); err != nil {
+		return nil, "", errors.Wrap(err, "failed to read transaction type")
+	}
+	id := struct {
+		ID *crypto.Digest `json:"id"`
+	}{}
+	if err := json.Unmarshal(v, &id); err != nil {
+		return nil, "", errors.Wrap(err, "failed to read transaction ID")
+	}
+	if id.ID == nil {
+		return nil, "", errors.New("transaction has no ID")
+	}
+	tx, err := proto.GuessTransactionType(&tt)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := json.Unmarshal(v, tx); err != nil {
+		return nil, "", errors.Wrap(err, "failed to read transaction")
+	}
+	return tx, id.ID.String(), nil
+}
+
+// state is the mocked state of the fixture, accounts not listed in the fixture have no balances and no data.
+type state struct {
+	mockstate.MockStateImpl
+}
+
+func (s *state) atHeight(height uint64) *state {
+	c := *s
+	c.CurrentHeight = height
+	c.BlockHeaders = map[uint64]*proto.BlockHeader{height: {}}
+	return &c
+}
+
+func (s *state) Account(r proto.Recipient) mockstate.Account {
+	if r.Alias != nil {
+		addr, err := s.AddressByAlias(*r.Alias)
+		if err != nil {
+			return nil
+		}
+		r = proto.NewRecipientFromAddress(addr)
+	}
+	if acc := s.MockStateImpl.Account(r); acc != nil {
+		return acc
+	}
+	return &mockstate.MockAccount{AddressField: *r.Address}
+}
@@ -0,0 +1,88 @@
+package scripttest
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunYAML(t *testing.T) {
+	f, err := Load(filepath.Join("testdata", "limit.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "limit", f.Name)
+	results, err := Run(f)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	for _, r := range results {
+		assert.True(t, r.Passed, "%s: %s", r.Name, r.Reason)
+		assert.NoError(t, r.Err)
+	}
+	assert.True(t, results[0].Value)
+	assert.False(t, results[1].Value)
+	assert.False(t, results[2].Value)
+}
+
+func TestRunJSON(t *testing.T) {
+	f, err := Load(filepath.Join("testdata", "height.json"))
+	require.NoError(t, err)
+	results, err := Run(f)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, r := range results {
+		assert.True(t, r.Passed, "%s: %s", r.Name, r.Reason)
+	}
+}
+
+func TestRunFailures(t *testing.T) {
+	f, err := Load(filepath.Join("testdata", "limit.yaml"))
+	require.NoError(t, err)
+	yes, no := true, false
+	wrong := "wrong message"
+	f.Tests[0].Expect = &no
+	f.Tests[1].Throw = &wrong
+	f.Tests[2].Expect = &yes
+	results, err := Run(f)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, "expected false, got true", results[0].Reason)
+	assert.Equal(t, "expected throw 'wrong message', got throw 'amount exceeds limit'", results[1].Reason)
+	assert.Equal(t, "expected true, got false", results[2].Reason)
+
+	buf := new(bytes.Buffer)
+	passed, failed := Report(buf, f.Name, results, false)
+	assert.Equal(t, 0, passed)
+	assert.Equal(t, 3, failed)
+	assert.Contains(t, buf.String(), "FAIL limit/small transfer: expected false, got true\n")
+	assert.Contains(t, buf.String(), "throw(\"amount exceeds limit\") failed: throw amount exceeds limit")
+}
+
+func TestRunUnexpectedThrow(t *testing.T) {
+	f, err := Load(filepath.Join("testdata", "limit.yaml"))
+	require.NoError(t, err)
+	f.Tests[1].Throw = nil
+	results, err := Run(f)
+	require.NoError(t, err)
+	assert.False(t, results[1].Passed)
+	assert.Equal(t, "unexpected throw 'amount exceeds limit'", results[1].Reason)
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, test := range []struct {
+		fixture string
+		err     string
+	}{
+		{`tests: [{name: a, tx: {type: 4, version: 2}}]`, "no script in fixture"},
+		{"script: \"true\"\naccounts: [{address: invalid}]", "account invalid"},
+		{"script: \"true\"\ntests: [{name: a, tx: {type: 4, version: 2}}]", "test a: transaction has no ID"},
+		{"script: \"true\"\nscheme: WW", "invalid scheme 'WW'"},
+	} {
+		f, err := ParseYAML([]byte(test.fixture))
+		require.NoError(t, err)
+		_, err = Run(f)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), test.err)
+	}
+}
@@ -91,3 +91,18 @@ var (
 		},
 	}
 )
+
+// AppName returns the application name which nodes of the blockchain use in handshake.
+func (s *BlockchainSettings) AppName() string {
+	return "waves" + string(s.AddressSchemeCharacter)
+}
+
+// CustomSettings returns settings of custom blockchain with given address scheme and genesis block.
+// Functionality settings are taken from TestNet.
+func CustomSettings(scheme byte, genesisCfgPath string) *BlockchainSettings {
+	s := *TestNetSettings
+	s.Type = Custom
+	s.AddressSchemeCharacter = scheme
+	s.GenesisCfgPath = genesisCfgPath
+	return &s
+}
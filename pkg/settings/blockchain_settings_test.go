@@ -0,0 +1,25 @@
+package settings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+func TestAppName(t *testing.T) {
+	assert.Equal(t, "wavesW", MainNetSettings.AppName())
+	assert.Equal(t, "wavesT", TestNetSettings.AppName())
+	assert.Equal(t, "wavesD", CustomSettings('D', "genesis.json").AppName())
+}
+
+func TestCustomSettings(t *testing.T) {
+	s := CustomSettings('D', "genesis.json")
+	assert.Equal(t, Custom, s.Type)
+	assert.Equal(t, byte('D'), s.AddressSchemeCharacter)
+	assert.Equal(t, "genesis.json", s.GenesisCfgPath)
+	assert.Equal(t, TestNetSettings.BlockVersion3AfterHeight, s.BlockVersion3AfterHeight)
+	// Presets must stay untouched.
+	assert.Equal(t, proto.TestNetScheme, TestNetSettings.AddressSchemeCharacter)
+	assert.Equal(t, TestNet, TestNetSettings.Type)
+}
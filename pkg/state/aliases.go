@@ -0,0 +1,193 @@
+package state
+
+import (
+	"github.com/pkg/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state/history"
+)
+
+const (
+	aliasRecordSize = proto.AddressSize + crypto.SignatureSize
+)
+
+// errAliasNotFound is returned for the alias which is not bound to any address.
+var errAliasNotFound = errors.New("alias not found")
+
+// aliasRecord binds alias to address at specific block.
+type aliasRecord struct {
+	address proto.Address
+	blockID crypto.Signature
+}
+
+func (r *aliasRecord) marshalBinary() ([]byte, error) {
+	res := make([]byte, aliasRecordSize)
+	copy(res[:proto.AddressSize], r.address[:])
+	copy(res[proto.AddressSize:], r.blockID[:])
+	return res, nil
+}
+
+func (r *aliasRecord) unmarshalBinary(data []byte) error {
+	if len(data) != aliasRecordSize {
+		return errors.New("invalid data size")
+	}
+	copy(r.address[:], data[:proto.AddressSize])
+	copy(r.blockID[:], data[proto.AddressSize:])
+	return nil
+}
+
+type aliases struct {
+	db      keyvalue.IterableKeyVal
+	dbBatch keyvalue.Batch
+	// Local storage for history, is moved to batch after all the changes are made.
+	// The motivation for this is inability to read from DB batch.
+	localStor map[string][]byte
+
+	bInfo blockInfo
+	// fmt is used for operations on aliases history.
+	fmt *history.HistoryFormatter
+}
+
+func newAliases(
+	db keyvalue.IterableKeyVal,
+	dbBatch keyvalue.Batch,
+	hInfo heightInfo,
+	bInfo blockInfo,
+) (*aliases, error) {
+	fmt, err := history.NewHistoryFormatter(aliasRecordSize, crypto.SignatureSize, hInfo, bInfo)
+	if err != nil {
+		return nil, err
+	}
+	return &aliases{
+		db:        db,
+		dbBatch:   dbBatch,
+		localStor: make(map[string][]byte),
+		bInfo:     bInfo,
+		fmt:       fmt,
+	}, nil
+}
+
+func (a *aliases) createAlias(alias string, record *aliasRecord) error {
+	// Only the alias which is surely not bound is free, failures of storage must not let to bind it twice.
+	if _, err := a.newestAddrByAlias(alias); err == nil {
+		return errors.Errorf("alias %s is already taken", alias)
+	} else if err != errAliasNotFound {
+		return errors.Wrapf(err, "failed to check alias %s", alias)
+	}
+	recordBytes, err := record.marshalBinary()
+	if err != nil {
+		return errors.Errorf("failed to marshal record: %v\n", err)
+	}
+	key := aliasKey{alias: alias}
+	history, _ := a.localStor[string(key.bytes())]
+	history, err = a.fmt.AddRecord(history, recordBytes)
+	if err != nil {
+		return errors.Errorf("failed to add alias record to history: %v\n", err)
+	}
+	a.localStor[string(key.bytes())] = history
+	// Reverse index is validated by block ID on reading.
+	addrKey := addrAliasKey{address: record.address, alias: alias}
+	a.dbBatch.Put(addrKey.bytes(), record.blockID[:])
+	return nil
+}
+
+func (a *aliases) lastRecord(history []byte) (*aliasRecord, error) {
+	last, err := a.fmt.GetLatest(history)
+	if err != nil {
+		return nil, errors.Errorf("failed to get the last record: %v\n", err)
+	}
+	var record aliasRecord
+	if err := record.unmarshalBinary(last); err != nil {
+		return nil, errors.Errorf("failed to unmarshal history record: %v\n", err)
+	}
+	return &record, nil
+}
+
+// Newest address for alias (from local storage, or from DB if given alias has not been changed).
+// This is needed for transactions validation.
+func (a *aliases) newestAddrByAlias(alias string) (*proto.Address, error) {
+	key := aliasKey{alias: alias}
+	history, err := fullHistory(key.bytes(), a.db, a.localStor, a.fmt)
+	if err != nil {
+		return nil, err
+	}
+	if len(history) == 0 {
+		return nil, errAliasNotFound
+	}
+	record, err := a.lastRecord(history)
+	if err != nil {
+		return nil, err
+	}
+	return &record.address, nil
+}
+
+// "Stable" address for alias from database.
+// This should be used by external APIs.
+func (a *aliases) addrByAlias(alias string) (*proto.Address, error) {
+	key := aliasKey{alias: alias}
+	has, err := a.db.Has(key.bytes())
+	if err != nil {
+		return nil, errors.Errorf("failed to check history for given alias: %v\n", err)
+	}
+	if !has {
+		return nil, errAliasNotFound
+	}
+	history, err := a.db.Get(key.bytes())
+	if err != nil {
+		return nil, errors.Errorf("failed to retrieve history for given alias: %v\n", err)
+	}
+	history, err = a.fmt.Normalize(history)
+	if err != nil {
+		return nil, errors.Errorf("failed to normalize history: %v\n", err)
+	}
+	if len(history) == 0 {
+		return nil, errAliasNotFound
+	}
+	record, err := a.lastRecord(history)
+	if err != nil {
+		return nil, err
+	}
+	return &record.address, nil
+}
+
+// aliasesByAddr returns all aliases bound to the address in valid blocks.
+func (a *aliases) aliasesByAddr(addr proto.Address) ([]string, error) {
+	prefix := addrAliasKey{address: addr}
+	iter, err := a.db.NewKeyIterator(prefix.bytes())
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	var res []string
+	for iter.Next() {
+		blockID, err := crypto.NewSignatureFromBytes(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		valid, err := a.bInfo.IsValidBlock(blockID)
+		if err != nil {
+			return nil, err
+		}
+		if !valid {
+			continue
+		}
+		res = append(res, string(iter.Key()[1+proto.AddressSize:]))
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (a *aliases) reset() {
+	a.localStor = make(map[string][]byte)
+}
+
+func (a *aliases) flush() error {
+	if err := addHistoryToBatch(a.db, a.dbBatch, a.localStor, a.fmt); err != nil {
+		return err
+	}
+	return nil
+}
@@ -18,6 +18,8 @@ const (
 	RollbackError
 	// Errors occurring while getting data from database.
 	RetrievalError
+	// Requested data does not exist.
+	NotFoundError
 	// Errors occurring while updating/modifying state data.
 	ModificationError
 	InvalidInputError
@@ -36,6 +38,11 @@ func (err StateError) Error() string {
 	return err.originalError.Error()
 }
 
+// NewStateError returns the error of given type, it's used by implementations of State outside of the package.
+func NewStateError(errorType StateErrorType, err error) StateError {
+	return StateError{errorType: errorType, originalError: err}
+}
+
 func ErrorType(err error) StateErrorType {
 	switch e := err.(type) {
 	case StateError:
@@ -45,6 +52,15 @@ func ErrorType(err error) StateErrorType {
 	}
 }
 
+// AssetInfo is information about asset, which is available through State.
+type AssetInfo struct {
+	Name        string
+	Description string
+	Decimals    int8
+	Quantity    uint64
+	Reissuable  bool
+}
+
 // State represents overall Node's state.
 // Data retrievals (e.g. account balances), as well as modifiers (like adding or rolling back blocks)
 // should all be made using this interface.
@@ -60,6 +76,15 @@ type State interface {
 	// AccountBalance retrieves balance of address in specific currency, asset is asset's ID.
 	// nil asset = Waves.
 	AccountBalance(addr proto.Address, asset []byte) (uint64, error)
+	// EffectiveBalance returns minimal Waves balance of address in given range of heights.
+	EffectiveBalance(addr proto.Address, startHeight, endHeight uint64) (uint64, error)
+	// TransactionByID returns transaction by its ID.
+	TransactionByID(id []byte) (proto.Transaction, error)
+	// AssetInfo returns stable information about asset, asset is asset's ID.
+	AssetInfo(assetID crypto.Digest) (*AssetInfo, error)
+	// Aliases getters.
+	AddrByAlias(alias proto.Alias) (proto.Address, error)
+	AliasesByAddr(addr proto.Address) ([]proto.Alias, error)
 	// AddressesNumber returns total number of addresses in state.
 	// Set wavesOnly to true to only get number of addresses which have Waves.
 	AddressesNumber(wavesOnly bool) (uint64, error)
@@ -87,6 +112,10 @@ type State interface {
 	SavePeers([]proto.TCPAddr) error
 	Peers() ([]proto.TCPAddr, error)
 
+	// SubscribeEvents returns channel of state events with given buffer size and function to cancel subscription.
+	// The channel is closed if the subscriber does not keep up with events, buffered events are still delivered before.
+	SubscribeEvents(buffer int) (<-chan Event, func())
+
 	Close() error
 }
 
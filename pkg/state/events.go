@@ -0,0 +1,89 @@
+package state
+
+import (
+	"sync"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"go.uber.org/zap"
+)
+
+type EventType byte
+
+const (
+	// BlockApplied is published for every block added to state.
+	BlockApplied EventType = iota
+	// BlockRolledBack is published for every block removed from state during rollback.
+	BlockRolledBack
+)
+
+func (t EventType) String() string {
+	switch t {
+	case BlockApplied:
+		return "blockApplied"
+	case BlockRolledBack:
+		return "blockRolledBack"
+	default:
+		return "unknown"
+	}
+}
+
+// Event describes change of blockchain state.
+type Event struct {
+	Type    EventType
+	Height  uint64
+	BlockID crypto.Signature
+}
+
+// events delivers state events to in-process subscribers.
+// Slow subscribers do not block state, when the buffer of the subscriber is full its channel is closed, so it never
+// misses events silently and has to resync and subscribe again.
+type events struct {
+	mu     sync.Mutex
+	nextID int
+	subs   map[int]chan Event
+}
+
+func newEvents() *events {
+	return &events{subs: make(map[int]chan Event)}
+}
+
+func (e *events) subscribe(buffer int) (<-chan Event, func()) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	id := e.nextID
+	e.nextID++
+	ch := make(chan Event, buffer)
+	e.subs[id] = ch
+	unsubscribe := func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		if _, ok := e.subs[id]; ok {
+			delete(e.subs, id)
+			close(ch)
+		}
+	}
+	return ch, unsubscribe
+}
+
+func (e *events) publish(event Event) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for id, ch := range e.subs {
+		select {
+		case ch <- event:
+		default:
+			zap.S().Warnf("State events subscriber %d is too slow, unsubscribing it on %s event at height %d", id, event.Type, event.Height)
+			delete(e.subs, id)
+			close(ch)
+		}
+	}
+}
+
+func (e *events) close() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for id, ch := range e.subs {
+		delete(e.subs, id)
+		close(ch)
+	}
+}
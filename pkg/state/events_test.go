@@ -0,0 +1,70 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+)
+
+func TestEventsPublish(t *testing.T) {
+	e := newEvents()
+	ch1, unsubscribe1 := e.subscribe(2)
+	ch2, unsubscribe2 := e.subscribe(1)
+
+	applied := Event{Type: BlockApplied, Height: 10, BlockID: crypto.Signature{1}}
+	rolledBack := Event{Type: BlockRolledBack, Height: 10, BlockID: crypto.Signature{1}}
+	e.publish(applied)
+	e.publish(rolledBack)
+
+	assert.Equal(t, applied, <-ch1)
+	assert.Equal(t, rolledBack, <-ch1)
+	// Second subscriber has buffer of one event, so it's unsubscribed after the buffered event.
+	assert.Equal(t, applied, <-ch2)
+	_, ok := <-ch2
+	assert.False(t, ok)
+	// Unsubscribe of the closed subscriber does nothing.
+	unsubscribe2()
+
+	e.publish(applied)
+	assert.Equal(t, applied, <-ch1)
+	unsubscribe1()
+	_, ok = <-ch1
+	assert.False(t, ok)
+	// Repeated unsubscribe does nothing.
+	unsubscribe1()
+	e.publish(applied)
+	assert.Len(t, e.subs, 0)
+}
+
+func TestEventsSlowSubscriber(t *testing.T) {
+	e := newEvents()
+	slow, unsubscribeSlow := e.subscribe(1)
+	defer unsubscribeSlow()
+	fast, unsubscribeFast := e.subscribe(1)
+	defer unsubscribeFast()
+
+	for h := uint64(1); h <= 3; h++ {
+		event := Event{Type: BlockApplied, Height: h}
+		e.publish(event)
+		assert.Equal(t, event, <-fast)
+	}
+	// The slow subscriber sees the end of the stream instead of the gap in events
+	assert.Equal(t, Event{Type: BlockApplied, Height: 1}, <-slow)
+	_, ok := <-slow
+	assert.False(t, ok)
+}
+
+func TestEventsClose(t *testing.T) {
+	e := newEvents()
+	ch, unsubscribe := e.subscribe(1)
+	e.close()
+	_, ok := <-ch
+	assert.False(t, ok)
+	unsubscribe()
+}
+
+func TestEventTypeString(t *testing.T) {
+	assert.Equal(t, "blockApplied", BlockApplied.String())
+	assert.Equal(t, "blockRolledBack", BlockRolledBack.String())
+}
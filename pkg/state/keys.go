@@ -34,6 +34,10 @@ const (
 
 	// Known peers
 	knownPeersPrefix
+
+	// Aliases.
+	aliasKeyPrefix
+	addrAliasKeyPrefix
 )
 
 type balanceKey struct {
@@ -121,3 +125,27 @@ func (k *assetHistKey) bytes() []byte {
 	copy(buf[1:], k.assetID[:])
 	return buf
 }
+
+type aliasKey struct {
+	alias string
+}
+
+func (k *aliasKey) bytes() []byte {
+	buf := make([]byte, 1+len(k.alias))
+	buf[0] = aliasKeyPrefix
+	copy(buf[1:], k.alias)
+	return buf
+}
+
+type addrAliasKey struct {
+	address proto.Address
+	alias   string
+}
+
+func (k *addrAliasKey) bytes() []byte {
+	buf := make([]byte, 1+proto.AddressSize+len(k.alias))
+	buf[0] = addrAliasKeyPrefix
+	copy(buf[1:], k.address[:])
+	copy(buf[1+proto.AddressSize:], k.alias)
+	return buf
+}
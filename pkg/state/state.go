@@ -7,11 +7,13 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/wavesplatform/gowaves/pkg/consensus"
 	"github.com/wavesplatform/gowaves/pkg/crypto"
 	"github.com/wavesplatform/gowaves/pkg/keyvalue"
+	"github.com/wavesplatform/gowaves/pkg/metrics"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/settings"
 )
@@ -53,10 +55,12 @@ type stateManager struct {
 	stateDB *stateDB
 
 	assets   *assets
+	aliases  *aliases
 	scores   *scores
 	balances *balances
 	rw       *blockReadWriter
 	peers    *peerStorage
+	events   *events
 
 	settings *settings.BlockchainSettings
 	cv       *consensus.ConsensusValidator
@@ -97,6 +101,7 @@ func newStateManager(dataDir string, params BlockStorageParams, settings *settin
 		scores:   scores,
 		settings: settings,
 		peers:    newPeerStorage(db),
+		events:   newEvents(),
 	}
 	// rw is storage for blocks.
 	rw, err := newBlockReadWriter(blockStorageDir, params.OffsetLen, params.HeaderOffsetLen, db, dbBatch)
@@ -116,6 +121,11 @@ func newStateManager(dataDir string, params BlockStorageParams, settings *settin
 	if err != nil {
 		return nil, StateError{errorType: Other, originalError: errors.Errorf("failed to create assets storage: %v\n", err)}
 	}
+	// aliases is storage for aliases of addresses.
+	aliases, err := newAliases(db, dbBatch, state, state)
+	if err != nil {
+		return nil, StateError{errorType: Other, originalError: errors.Errorf("failed to create aliases storage: %v\n", err)}
+	}
 	// Consensus validator is needed to check block headers.
 	cv, err := consensus.NewConsensusValidator(state)
 	if err != nil {
@@ -123,6 +133,7 @@ func newStateManager(dataDir string, params BlockStorageParams, settings *settin
 	}
 	// Set fields which depend on state.
 	state.assets = assets
+	state.aliases = aliases
 	state.cv = cv
 	state.balances = balances
 	state.rw = rw
@@ -161,7 +172,7 @@ func (s *stateManager) addGenesisBlock() error {
 	if err := s.scores.addScore(&big.Int{}, genesisScore, 1); err != nil {
 		return err
 	}
-	tv, err := newTransactionValidator(s.genesis.BlockSignature, s.balances, s.assets, s.settings)
+	tv, err := newTransactionValidator(s.genesis.BlockSignature, s.balances, s.assets, s.aliases, s.settings)
 	if err != nil {
 		return err
 	}
@@ -264,6 +275,59 @@ func (s *stateManager) AccountBalance(addr proto.Address, asset []byte) (uint64,
 	return balance, nil
 }
 
+func (s *stateManager) TransactionByID(id []byte) (proto.Transaction, error) {
+	txBytes, err := s.rw.readTransaction(id)
+	if err != nil {
+		return nil, StateError{errorType: RetrievalError, originalError: err}
+	}
+	// Transactions are stored with their 4 bytes size prefix.
+	if len(txBytes) < 4 {
+		return nil, StateError{errorType: DeserializationError, originalError: errors.New("invalid transaction size")}
+	}
+	tx, err := proto.BytesToTransaction(txBytes[4:])
+	if err != nil {
+		return nil, StateError{errorType: DeserializationError, originalError: err}
+	}
+	return tx, nil
+}
+
+func (s *stateManager) AssetInfo(assetID crypto.Digest) (*AssetInfo, error) {
+	info, err := s.assets.assetInfo(assetID)
+	if err != nil {
+		return nil, StateError{errorType: RetrievalError, originalError: err}
+	}
+	return &AssetInfo{
+		Name:        info.name,
+		Description: info.description,
+		Decimals:    info.decimals,
+		Quantity:    info.quantity,
+		Reissuable:  info.reissuable,
+	}, nil
+}
+
+func (s *stateManager) AddrByAlias(alias proto.Alias) (proto.Address, error) {
+	addr, err := s.aliases.addrByAlias(alias.Alias)
+	if err == errAliasNotFound {
+		return proto.Address{}, StateError{errorType: NotFoundError, originalError: err}
+	}
+	if err != nil {
+		return proto.Address{}, StateError{errorType: RetrievalError, originalError: err}
+	}
+	return *addr, nil
+}
+
+func (s *stateManager) AliasesByAddr(addr proto.Address) ([]proto.Alias, error) {
+	aliases, err := s.aliases.aliasesByAddr(addr)
+	if err != nil {
+		return nil, StateError{errorType: RetrievalError, originalError: err}
+	}
+	res := make([]proto.Alias, len(aliases))
+	for i, alias := range aliases {
+		res[i] = *proto.NewAlias(s.settings.AddressSchemeCharacter, alias)
+	}
+	return res, nil
+}
+
 func (s *stateManager) AddressesNumber(wavesOnly bool) (uint64, error) {
 	res, err := s.balances.addressesNumber(wavesOnly)
 	if err != nil {
@@ -325,6 +389,7 @@ func (s *stateManager) addNewBlock(tv *transactionValidator, block, parent *prot
 func (s *stateManager) reset() error {
 	s.rw.reset()
 	s.assets.reset()
+	s.aliases.reset()
 	s.balances.reset()
 	s.stateDB.reset()
 	return nil
@@ -337,6 +402,9 @@ func (s *stateManager) flush() error {
 	if err := s.assets.flush(); err != nil {
 		return err
 	}
+	if err := s.aliases.flush(); err != nil {
+		return err
+	}
 	if err := s.balances.flush(); err != nil {
 		return err
 	}
@@ -405,12 +473,13 @@ func (s *stateManager) AddOldBlocks(blocks [][]byte) error {
 }
 
 func (s *stateManager) addBlocks(blocks [][]byte, initialisation bool) error {
+	start := time.Now()
 	blocksNumber := len(blocks)
 	parent, err := s.topBlock()
 	if err != nil {
 		return StateError{errorType: RetrievalError, originalError: err}
 	}
-	tv, err := newTransactionValidator(s.genesis.BlockSignature, s.balances, s.assets, s.settings)
+	tv, err := newTransactionValidator(s.genesis.BlockSignature, s.balances, s.assets, s.aliases, s.settings)
 	if err != nil {
 		return StateError{errorType: Other, originalError: err}
 	}
@@ -455,6 +524,10 @@ func (s *stateManager) addBlocks(blocks [][]byte, initialisation bool) error {
 	if err := s.reset(); err != nil {
 		return StateError{errorType: ModificationError, originalError: err}
 	}
+	metrics.BlocksApplied(time.Since(start), height+uint64(blocksNumber))
+	for i, header := range headers {
+		s.events.publish(Event{Type: BlockApplied, Height: height + uint64(i) + 1, BlockID: header.BlockSignature})
+	}
 	return nil
 }
 
@@ -499,6 +572,7 @@ func (s *stateManager) RollbackTo(removalEdge crypto.Signature) error {
 	if err != nil {
 		return StateError{errorType: RetrievalError, originalError: err}
 	}
+	var removed []Event
 	for height := curHeight; height > 0; height-- {
 		blockID, err := s.rw.blockIDByHeight(height)
 		if err != nil {
@@ -510,6 +584,7 @@ func (s *stateManager) RollbackTo(removalEdge crypto.Signature) error {
 		if err := s.stateDB.rollbackBlock(blockID); err != nil {
 			return StateError{errorType: RollbackError, originalError: err}
 		}
+		removed = append(removed, Event{Type: BlockRolledBack, Height: height, BlockID: blockID})
 	}
 	// Remove scores of deleted blocks.
 	newHeight, err := s.Height()
@@ -524,6 +599,14 @@ func (s *stateManager) RollbackTo(removalEdge crypto.Signature) error {
 	if err := s.rw.rollback(removalEdge, true); err != nil {
 		return StateError{errorType: RollbackError, originalError: err}
 	}
+	height, err := s.Height()
+	if err != nil {
+		return StateError{errorType: RetrievalError, originalError: err}
+	}
+	metrics.Height(height)
+	for _, e := range removed {
+		s.events.publish(e)
+	}
 	return nil
 }
 
@@ -569,7 +652,12 @@ func (s *stateManager) SavePeers(peers []proto.TCPAddr) error {
 
 }
 
+func (s *stateManager) SubscribeEvents(buffer int) (<-chan Event, func()) {
+	return s.events.subscribe(buffer)
+}
+
 func (s *stateManager) Close() error {
+	s.events.close()
 	if err := s.rw.close(); err != nil {
 		return StateError{errorType: ClosureError, originalError: err}
 	}
@@ -111,6 +111,8 @@ func TestStateRollback(t *testing.T) {
 		}
 	}()
 
+	events, unsubscribe := manager.SubscribeEvents(2 * maxRollbackTestBlocks)
+	defer unsubscribe()
 	for _, tc := range tests {
 		height, err := manager.Height()
 		if err != nil {
@@ -128,6 +130,17 @@ func TestStateRollback(t *testing.T) {
 		if err := importer.CheckBalances(manager, tc.balancesPath); err != nil {
 			t.Fatalf("CheckBalances(): %v\n", err)
 		}
+		last := <-events
+		for len(events) > 0 {
+			last = <-events
+		}
+		if tc.nextHeight >= height {
+			assert.Equal(t, BlockApplied, last.Type)
+			assert.Equal(t, tc.nextHeight, last.Height)
+		} else {
+			assert.Equal(t, BlockRolledBack, last.Type)
+			assert.Equal(t, tc.nextHeight+1, last.Height)
+		}
 		if err := manager.RollbackToHeight(tc.minRollbackHeight - 1); err == nil {
 			t.Fatalf("Rollback() did not fail with height less than minimum valid.")
 		}
@@ -8,6 +8,9 @@ import (
 	"github.com/pkg/errors"
 	"github.com/wavesplatform/gowaves/pkg/crypto"
 	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride/estimator"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/parser"
+	"github.com/wavesplatform/gowaves/pkg/ride/evaluator/reader"
 	"github.com/wavesplatform/gowaves/pkg/settings"
 	"github.com/wavesplatform/gowaves/pkg/util"
 )
@@ -173,6 +176,7 @@ type transactionValidator struct {
 	genesis         crypto.Signature
 	balancesChanges *changesStorage
 	assets          *assets
+	aliases         *aliases
 	settings        *settings.BlockchainSettings
 }
 
@@ -180,6 +184,7 @@ func newTransactionValidator(
 	genesis crypto.Signature,
 	balances *balances,
 	assets *assets,
+	aliases *aliases,
 	settings *settings.BlockchainSettings,
 ) (*transactionValidator, error) {
 	balancesChanges, err := newChangesStorage(balances)
@@ -190,6 +195,7 @@ func newTransactionValidator(
 		genesis:         genesis,
 		balancesChanges: balancesChanges,
 		assets:          assets,
+		aliases:         aliases,
 		settings:        settings,
 	}, nil
 }
@@ -287,6 +293,20 @@ func (tv *transactionValidator) checkAsset(asset *proto.OptionalAsset) error {
 	return nil
 }
 
+func (tv *transactionValidator) recipientToAddress(recipient proto.Recipient) (*proto.Address, error) {
+	if recipient.Address != nil {
+		return recipient.Address, nil
+	}
+	if recipient.Alias == nil {
+		return nil, errors.New("empty recipient")
+	}
+	addr, err := tv.aliases.newestAddrByAlias(recipient.Alias.Alias)
+	if err != nil {
+		return nil, errors.Errorf("failed to resolve alias %s: %v", recipient.Alias.String(), err)
+	}
+	return addr, nil
+}
+
 func (tv *transactionValidator) validateTransfer(tx *proto.Transfer, block, parent *proto.Block, initialisation bool) (bool, error) {
 	if ok, err := tv.checkTimestamps(tx.Timestamp, block.Timestamp, parent.Timestamp); !ok {
 		return false, errors.Wrap(err, "invalid timestamp")
@@ -313,11 +333,11 @@ func (tv *transactionValidator) validateTransfer(tx *proto.Transfer, block, pare
 		return false, err
 	}
 	// Update receiver.
-	if tx.Recipient.Address == nil {
-		// TODO implement
-		return false, errors.New("alias without address is not supported yet")
+	recipientAddr, err := tv.recipientToAddress(tx.Recipient)
+	if err != nil {
+		return false, err
 	}
-	receiverKey := balanceKey{address: *tx.Recipient.Address, asset: tx.AmountAsset.ToID()}
+	receiverKey := balanceKey{address: *recipientAddr, asset: tx.AmountAsset.ToID()}
 	receiverBalanceDiff := int64(tx.Amount)
 	if ok, err := tv.addChanges(receiverKey.bytes(), receiverBalanceDiff, block); !ok {
 		return false, err
@@ -387,6 +407,25 @@ func (tv *transactionValidator) validateIssue(tx *proto.Issue, id []byte, block,
 	return true, nil
 }
 
+// validateScript checks that the script could be parsed and its complexity doesn't exceed the limit.
+func (tv *transactionValidator) validateScript(script proto.Script) (bool, error) {
+	if len(script) == 0 {
+		return true, nil
+	}
+	r, err := reader.NewReaderFromScript(script)
+	if err != nil {
+		return false, err
+	}
+	s, err := parser.BuildScript(r)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to parse script")
+	}
+	if err := estimator.Check(s); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func (tv *transactionValidator) validateReissue(tx *proto.Reissue, block, parent *proto.Block, initialisation bool) (bool, error) {
 	if ok, err := tv.checkTimestamps(tx.Timestamp, block.Timestamp, parent.Timestamp); !ok {
 		return false, errors.Wrap(err, "invalid timestamp")
@@ -568,6 +607,38 @@ func (tv *transactionValidator) validateExchange(tx proto.Exchange, block, paren
 	return true, nil
 }
 
+func (tv *transactionValidator) validateCreateAlias(tx *proto.CreateAlias, block, parent *proto.Block, initialisation bool) (bool, error) {
+	if ok, err := tv.checkTimestamps(tx.Timestamp, block.Timestamp, parent.Timestamp); !ok {
+		return false, errors.Wrap(err, "invalid timestamp")
+	}
+	senderAddr, err := proto.NewAddressFromPublicKey(tv.settings.AddressSchemeCharacter, tx.SenderPK)
+	if err != nil {
+		return false, err
+	}
+	// Bind alias to sender.
+	record := &aliasRecord{address: senderAddr, blockID: block.BlockSignature}
+	if err := tv.aliases.createAlias(tx.Alias.Alias, record); err != nil {
+		return false, errors.Wrap(err, "failed to create alias")
+	}
+	// Update sender.
+	senderFeeKey := balanceKey{address: senderAddr}
+	senderFeeBalanceDiff := -int64(tx.Fee)
+	if ok, err := tv.addChanges(senderFeeKey.bytes(), senderFeeBalanceDiff, block); !ok {
+		return false, err
+	}
+	// Update miner.
+	minerAddr, err := proto.NewAddressFromPublicKey(tv.settings.AddressSchemeCharacter, block.GenPublicKey)
+	if err != nil {
+		return false, err
+	}
+	minerKey := balanceKey{address: minerAddr}
+	minerBalanceDiff := int64(tx.Fee)
+	if ok, err := tv.addChanges(minerKey.bytes(), minerBalanceDiff, block); !ok {
+		return false, err
+	}
+	return true, nil
+}
+
 func (tv *transactionValidator) validateTransaction(block, parent *proto.Block, tx proto.Transaction, initialisation bool) error {
 	switch v := tx.(type) {
 	case *proto.Genesis:
@@ -591,6 +662,9 @@ func (tv *transactionValidator) validateTransaction(block, parent *proto.Block,
 			return errors.Wrap(err, "issuev1 validation failed")
 		}
 	case *proto.IssueV2:
+		if ok, err := tv.validateScript(v.Script); !ok {
+			return errors.Wrap(err, "issuev2 validation failed")
+		}
 		if ok, err := tv.validateIssue(&v.Issue, tx.GetID(), block, parent, initialisation); !ok {
 			return errors.Wrap(err, "issuev2 validation failed")
 		}
@@ -618,6 +692,14 @@ func (tv *transactionValidator) validateTransaction(block, parent *proto.Block,
 		if ok, err := tv.validateExchange(v, block, parent, initialisation); !ok {
 			return errors.Wrap(err, "exchange2 validation failed")
 		}
+	case *proto.CreateAliasV1:
+		if ok, err := tv.validateCreateAlias(&v.CreateAlias, block, parent, initialisation); !ok {
+			return errors.Wrap(err, "createaliasv1 validation failed")
+		}
+	case *proto.CreateAliasV2:
+		if ok, err := tv.validateCreateAlias(&v.CreateAlias, block, parent, initialisation); !ok {
+			return errors.Wrap(err, "createaliasv2 validation failed")
+		}
 	default:
 		return errors.Errorf("transaction type %T is not supported\n", v)
 	}
@@ -4,9 +4,12 @@ import (
 	"testing"
 
 	"github.com/mr-tron/base58/base58"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
 	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride/compiler"
 	"github.com/wavesplatform/gowaves/pkg/settings"
 	"github.com/wavesplatform/gowaves/pkg/util"
 )
@@ -35,6 +38,7 @@ var (
 type testObjects struct {
 	assets   *assets
 	balances *balances
+	aliases  *aliases
 	tv       *transactionValidator
 }
 
@@ -45,13 +49,16 @@ func createTestObjects(t *testing.T) (*testObjects, []string) {
 	assert.NoError(t, err, "newBalances() failed")
 	genesisSig, err := crypto.NewSignatureFromBase58(genesisSignature)
 	assert.NoError(t, err, "NewSignatureFromBase58() failed")
-	tv, err := newTransactionValidator(genesisSig, balances, assets, settings.MainNetSettings)
+	aliases, err := newAliases(assets.db, assets.dbBatch, &mock{}, &mockBlockInfo{})
+	assert.NoError(t, err, "newAliases() failed")
+	tv, err := newTransactionValidator(genesisSig, balances, assets, aliases, settings.MainNetSettings)
 	assert.NoError(t, err, "newTransactionValidator() failed")
-	return &testObjects{assets: assets, balances: balances, tv: tv}, path
+	return &testObjects{assets: assets, balances: balances, aliases: aliases, tv: tv}, path
 }
 
 func (to *testObjects) reset() {
 	to.assets.reset()
+	to.aliases.reset()
 	to.balances.reset()
 	to.tv.reset()
 }
@@ -556,6 +563,42 @@ func TestValidateIssueV2(t *testing.T) {
 	assert.Equal(t, assetInfo, *info, "invalid asset info after performing IssueV2 transaction")
 }
 
+func TestValidateScript(t *testing.T) {
+	to, path := createTestObjects(t)
+
+	defer func() {
+		err := to.assets.db.Close()
+		assert.NoError(t, err, "db.Close() failed")
+		err = util.CleanTemporaryDirs(path)
+		assert.NoError(t, err, "failed to clean test data dirs")
+	}()
+
+	ok, err := to.tv.validateScript(nil)
+	assert.True(t, ok, "validateScript() failed for empty script")
+	assert.NoError(t, err)
+
+	simple, err := compiler.Compile(`sigVerify(tx.bodyBytes, tx.proofs[0], tx.senderPublicKey)`)
+	assert.NoError(t, err, "Compile() failed")
+	ok, err = to.tv.validateScript(simple.Bytes)
+	assert.True(t, ok, "validateScript() failed for simple script")
+	assert.NoError(t, err)
+
+	// Every check of signature costs more than 100, 20 of them exceed the limit of 2000.
+	code := "true"
+	for i := 0; i < 20; i++ {
+		code += " && sigVerify(tx.bodyBytes, tx.proofs[0], tx.senderPublicKey)"
+	}
+	complex, err := compiler.Compile(code)
+	assert.NoError(t, err, "Compile() failed")
+	ok, err = to.tv.validateScript(complex.Bytes)
+	assert.False(t, ok, "validateScript() did not fail for too complex script")
+	assert.Error(t, err)
+
+	ok, err = to.tv.validateScript([]byte{1, 2, 3, 4, 5})
+	assert.False(t, ok, "validateScript() did not fail for invalid script")
+	assert.Error(t, err)
+}
+
 func createReissueV1(t *testing.T, assetID crypto.Digest) *proto.ReissueV1 {
 	spk, err := crypto.NewPublicKeyFromBase58(senderPK)
 	assert.NoError(t, err, "NewPublicKeyFromBase58() failed")
@@ -895,3 +938,90 @@ func TestValidateExchangeV2(t *testing.T) {
 	flushAssets(t, to.assets)
 	checkBalances(t, to.balances, balanceDiffs)
 }
+
+func createCreateAliasV1(t *testing.T) *proto.CreateAliasV1 {
+	spk, err := crypto.NewPublicKeyFromBase58(senderPK)
+	assert.NoError(t, err, "NewPublicKeyFromBase58() failed")
+	alias := proto.NewAlias(proto.MainNetScheme, "alias")
+	tx := proto.NewUnsignedCreateAliasV1(spk, *alias, 1, timestamp0)
+	return tx
+}
+
+func TestValidateCreateAliasV1(t *testing.T) {
+	to, path := createTestObjects(t)
+
+	defer func() {
+		err := to.assets.db.Close()
+		assert.NoError(t, err, "db.Close() failed")
+		err = util.CleanTemporaryDirs(path)
+		assert.NoError(t, err, "failed to clean test data dirs")
+	}()
+
+	tx := createCreateAliasV1(t)
+	balanceDiffs := []balanceDiff{
+		{senderAddr, "", tx.Fee, 0},
+		{minerAddr, "", 0, tx.Fee},
+	}
+	setBalances(t, to, balanceDiffs)
+	blocks := []block{{timestamp0, blockID0}}
+	validateTx(t, to.tv, tx, blocks, true)
+	err := to.tv.performTransactions()
+	assert.NoError(t, err, "performTransactions() failed")
+
+	// Alias is resolved while it is not flushed yet.
+	addr, err := to.aliases.newestAddrByAlias(tx.Alias.Alias)
+	assert.NoError(t, err, "newestAddrByAlias() failed")
+	assert.Equal(t, senderAddr, addr.String())
+	// Same alias can not be taken twice.
+	block, parent := blankBlocks(t, timestamp0, crypto.Signature{})
+	err = to.tv.validateTransaction(block, parent, tx, true)
+	assert.Error(t, err, "validateTransaction() did not fail with duplicate alias")
+
+	flushBalances(t, to.balances)
+	err = to.aliases.flush()
+	assert.NoError(t, err, "aliases.flush() failed")
+	flushAssets(t, to.assets)
+	checkBalances(t, to.balances, balanceDiffs)
+	addr, err = to.aliases.addrByAlias(tx.Alias.Alias)
+	assert.NoError(t, err, "addrByAlias() failed")
+	assert.Equal(t, senderAddr, addr.String())
+	aliases, err := to.aliases.aliasesByAddr(*addr)
+	assert.NoError(t, err, "aliasesByAddr() failed")
+	assert.Equal(t, []string{tx.Alias.Alias}, aliases)
+}
+
+// failingKeyVal fails every check of existence of keys.
+type failingKeyVal struct {
+	keyvalue.IterableKeyVal
+}
+
+func (f *failingKeyVal) Has(key []byte) (bool, error) {
+	return false, errors.New("storage failure")
+}
+
+func TestCreateAliasStorageFailure(t *testing.T) {
+	to, path := createTestObjects(t)
+
+	defer func() {
+		err := to.assets.db.Close()
+		assert.NoError(t, err, "db.Close() failed")
+		err = util.CleanTemporaryDirs(path)
+		assert.NoError(t, err, "failed to clean test data dirs")
+	}()
+
+	aliases, err := newAliases(&failingKeyVal{to.assets.db}, to.assets.dbBatch, &mock{}, &mockBlockInfo{})
+	assert.NoError(t, err, "newAliases() failed")
+	addr, err := proto.NewAddressFromString(senderAddr)
+	assert.NoError(t, err, "NewAddressFromString() failed")
+	err = aliases.createAlias("alias", &aliasRecord{address: addr})
+	assert.EqualError(t, err, "failed to check alias alias: storage failure")
+	_, err = aliases.addrByAlias("alias")
+	assert.Error(t, err)
+	assert.NotEqual(t, errAliasNotFound, err)
+
+	// Unknown alias is reported as not found.
+	_, err = to.aliases.addrByAlias("unknown")
+	assert.Equal(t, errAliasNotFound, err)
+	_, err = to.aliases.newestAddrByAlias("unknown")
+	assert.Equal(t, errAliasNotFound, err)
+}
@@ -1,18 +1,144 @@
 package wallet
 
 import (
+	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
+	"encoding/binary"
 	"fmt"
-	"golang.org/x/crypto/argon2"
 	"io"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/argon2"
+)
+
+// ErrInvalidPassword is returned when the wallet can't be decrypted with the given password.
+var ErrInvalidPassword = errors.New("invalid password")
+
+// ContainerVersion is the version of the encrypted container written by Seal.
+const ContainerVersion = 1
+
+const (
+	saltSize   = 16
+	nonceSize  = 12
+	keySize    = 32
+	headerSize = 4 + 1 + 4 + 4 + 1 + saltSize + nonceSize
 )
 
+// containerMagic starts every sealed wallet. Wallets of the legacy format start with the random IV instead.
+var containerMagic = []byte("WAVW")
+
+// KDFParams are the parameters of Argon2id key derivation stored in the header of the container.
+type KDFParams struct {
+	Time    uint32 // Number of passes
+	Memory  uint32 // Memory in KiB
+	Threads uint8  // Number of threads, at most 255
+}
+
+// DefaultKDFParams are the parameters of Argon2id used by Encode.
+var DefaultKDFParams = KDFParams{Time: 4, Memory: 64 * 1024, Threads: 4}
+
+// Upper bounds of key derivation parameters. The header is authenticated only after the key is derived, so the
+// parameters of the file are limited to keep a crafted wallet from exhausting the memory and the CPU.
+const (
+	maxKDFTime   = 16
+	maxKDFMemory = 1024 * 1024 // 1 GiB
+)
+
+func (p KDFParams) validate() error {
+	if p.Time == 0 || p.Memory == 0 || p.Threads == 0 {
+		return errors.Errorf("invalid key derivation parameters: time %d, memory %d, threads %d", p.Time, p.Memory, p.Threads)
+	}
+	if p.Time > maxKDFTime || p.Memory > maxKDFMemory {
+		return errors.Errorf("key derivation parameters exceed limits: time %d (max %d), memory %d (max %d)", p.Time, maxKDFTime, p.Memory, maxKDFMemory)
+	}
+	return nil
+}
+
+func (p KDFParams) key(password, salt []byte) []byte {
+	return argon2.IDKey(password, salt, p.Time, p.Memory, p.Threads, keySize)
+}
+
+// Seal encrypts the plaintext with AES-GCM using the key derived from the password with the random salt.
+// The header of the container, which holds the version, the key derivation parameters, the salt and the nonce,
+// is authenticated along with the ciphertext.
+func Seal(password, plaintext []byte, params KDFParams) ([]byte, error) {
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+	header := make([]byte, headerSize)
+	copy(header, containerMagic)
+	header[4] = ContainerVersion
+	binary.BigEndian.PutUint32(header[5:], params.Time)
+	binary.BigEndian.PutUint32(header[9:], params.Memory)
+	header[13] = params.Threads
+	salt := header[14 : 14+saltSize]
+	nonce := header[14+saltSize:]
+	if _, err := io.ReadFull(rand.Reader, header[14:]); err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(params.key(password, salt))
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(header, nonce, plaintext, header), nil
+}
+
+// Open decrypts the container created by Seal. Data that is not a container is decrypted as the legacy wallet,
+// in this case the returned flag is set.
+func Open(password, data []byte) ([]byte, bool, error) {
+	if !IsSealed(data) {
+		plaintext, err := NewCrypt(password).Decrypt(data)
+		return plaintext, true, err
+	}
+	if len(data) < headerSize {
+		return nil, false, errors.Errorf("invalid wallet size %d", len(data))
+	}
+	header := data[:headerSize]
+	if v := header[4]; v != ContainerVersion {
+		return nil, false, errors.Errorf("unsupported wallet container version %d", v)
+	}
+	params := KDFParams{
+		Time:    binary.BigEndian.Uint32(header[5:]),
+		Memory:  binary.BigEndian.Uint32(header[9:]),
+		Threads: header[13],
+	}
+	if err := params.validate(); err != nil {
+		return nil, false, err
+	}
+	salt := header[14 : 14+saltSize]
+	nonce := header[14+saltSize:]
+	aead, err := newAEAD(params.key(password, salt))
+	if err != nil {
+		return nil, false, err
+	}
+	plaintext, err := aead.Open(nil, nonce, data[headerSize:], header)
+	if err != nil {
+		return nil, false, ErrInvalidPassword
+	}
+	return plaintext, false, nil
+}
+
+// IsSealed checks that the data is the container created by Seal rather than the legacy wallet.
+func IsSealed(data []byte) bool {
+	return bytes.HasPrefix(data, containerMagic)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
 type crypt struct {
 	key []byte
 }
 
+// NewCrypt creates the cipher of the legacy wallet format: unauthenticated AES-CFB with the key derived from
+// the password with the constant salt. It is kept to read old wallets, new wallets are encrypted with Seal.
 func NewCrypt(key []byte) *crypt {
 	salt := []byte("E84265D411C08F99E092AE237F4EC250B2F20B2EAB7CFB2FCB0857880983DF44")
 	pass := argon2.IDKey(key, salt, 4, 64*1024, 4, 32)
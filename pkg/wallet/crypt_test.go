@@ -1,6 +1,8 @@
 package wallet
 
 import (
+	"encoding/binary"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"testing"
@@ -19,3 +21,63 @@ func TestCryptKey(t *testing.T) {
 
 	assert.Equal(t, word, string(word2))
 }
+
+func TestSealOpen(t *testing.T) {
+	params := KDFParams{Time: 1, Memory: 1024, Threads: 1}
+	password := []byte("password")
+	data := []byte("bla bla bla")
+
+	sealed, err := Seal(password, data, params)
+	require.NoError(t, err)
+	assert.True(t, IsSealed(sealed))
+	assert.Equal(t, headerSize+len(data)+16, len(sealed))
+
+	plaintext, legacy, err := Open(password, sealed)
+	require.NoError(t, err)
+	assert.False(t, legacy)
+	assert.Equal(t, data, plaintext)
+
+	// Salt and nonce are random
+	sealed2, err := Seal(password, data, params)
+	require.NoError(t, err)
+	assert.NotEqual(t, sealed[14:headerSize], sealed2[14:headerSize])
+
+	_, _, err = Open([]byte("wrong"), sealed)
+	assert.Equal(t, ErrInvalidPassword, err)
+
+	// Both the header and the ciphertext are authenticated
+	for _, i := range []int{headerSize - 1, headerSize, len(sealed) - 1} {
+		tampered := append([]byte(nil), sealed...)
+		tampered[i] ^= 1
+		_, _, err = Open(password, tampered)
+		assert.Equal(t, ErrInvalidPassword, err, i)
+	}
+	tampered := append([]byte(nil), sealed...)
+	tampered[4] = 2
+	_, _, err = Open(password, tampered)
+	assert.EqualError(t, err, "unsupported wallet container version 2")
+	tampered[4], tampered[13] = ContainerVersion, 0
+	_, _, err = Open(password, tampered)
+	assert.EqualError(t, err, "invalid key derivation parameters: time 1, memory 1024, threads 0")
+	// Oversized parameters are rejected before the key derivation
+	binary.BigEndian.PutUint32(tampered[9:], maxKDFMemory+1)
+	tampered[13] = 1
+	_, _, err = Open(password, tampered)
+	assert.EqualError(t, err, "key derivation parameters exceed limits: time 1 (max 16), memory 1048577 (max 1048576)")
+	binary.BigEndian.PutUint32(tampered[5:], 0xffffffff)
+	binary.BigEndian.PutUint32(tampered[9:], 1024)
+	_, _, err = Open(password, tampered)
+	assert.EqualError(t, err, "key derivation parameters exceed limits: time 4294967295 (max 16), memory 1024 (max 1048576)")
+	_, err = Seal(password, data, KDFParams{Time: 17, Memory: 1024, Threads: 1})
+	assert.Error(t, err)
+	_, _, err = Open(password, sealed[:headerSize-1])
+	assert.EqualError(t, err, "invalid wallet size 41")
+
+	old, err := NewCrypt(password).Encrypt(data)
+	require.NoError(t, err)
+	assert.False(t, IsSealed(old))
+	plaintext, legacy, err = Open(password, old)
+	require.NoError(t, err)
+	assert.True(t, legacy)
+	assert.Equal(t, data, plaintext)
+}
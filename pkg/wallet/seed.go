@@ -0,0 +1,62 @@
+package wallet
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SeedPhraseWords is the number of words of the seed phrase generated by Waves clients.
+const SeedPhraseWords = 15
+
+var dictionaryIndex map[string]int
+
+func init() {
+	dictionaryIndex = make(map[string]int, len(dictionary))
+	for i, w := range dictionary {
+		dictionaryIndex[w] = i
+	}
+}
+
+// GenerateSeedPhrase generates the new seed phrase the same way as Waves clients do: each word is selected from
+// the dictionary by the random 32-bit number. The phrase holds 165 bits of entropy.
+func GenerateSeedPhrase() (string, error) {
+	random := make([]byte, 4*SeedPhraseWords)
+	if _, err := rand.Read(random); err != nil {
+		return "", errors.Wrap(err, "failed to generate seed phrase")
+	}
+	words := make([]string, SeedPhraseWords)
+	for i := range words {
+		words[i] = dictionary[binary.BigEndian.Uint32(random[4*i:])%uint32(len(dictionary))]
+	}
+	return strings.Join(words, " "), nil
+}
+
+// ValidateSeedPhrase checks that the phrase consists of SeedPhraseWords words of the dictionary separated by
+// single spaces. Waves clients use the phrase as is, so the phrase is not normalized.
+func ValidateSeedPhrase(phrase string) error {
+	if n := len(strings.Fields(phrase)); n != SeedPhraseWords {
+		return errors.Errorf("seed phrase should consist of %d words, found %d", SeedPhraseWords, n)
+	}
+	words := strings.Split(phrase, " ")
+	if len(words) != SeedPhraseWords {
+		return errors.New("words of seed phrase should be separated by single spaces")
+	}
+	for i, w := range words {
+		if _, ok := dictionaryIndex[w]; !ok {
+			return errors.Errorf("unknown word '%s' at position %d of seed phrase", w, i+1)
+		}
+	}
+	return nil
+}
+
+// NewWalletFromSeedPhrase imports the valid seed phrase. Accounts of the wallet have the same keys and addresses as
+// the accounts created from the phrase by the Scala node or Waves Keeper.
+func NewWalletFromSeedPhrase(phrase string) (*Wallet, error) {
+	if err := ValidateSeedPhrase(phrase); err != nil {
+		return nil, err
+	}
+	return NewWalletFromSeed([]byte(phrase))
+}
@@ -0,0 +1,66 @@
+package wallet
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+func TestGenerateSeedPhrase(t *testing.T) {
+	p1, err := GenerateSeedPhrase()
+	require.NoError(t, err)
+	assert.Len(t, strings.Split(p1, " "), SeedPhraseWords)
+	assert.NoError(t, ValidateSeedPhrase(p1))
+
+	p2, err := GenerateSeedPhrase()
+	require.NoError(t, err)
+	assert.NotEqual(t, p1, p2)
+}
+
+func TestValidateSeedPhrase(t *testing.T) {
+	for _, tc := range []struct {
+		phrase string
+		err    string
+	}{
+		{"exile region inmate brass mobile hour best spy gospel gown grace actor armed gift radar", ""},
+		{"exile region inmate brass mobile hour best spy gospel gown grace actor armed gift", "seed phrase should consist of 15 words, found 14"},
+		{"", "seed phrase should consist of 15 words, found 0"},
+		{"exile region inmate brass mobile hour best spy gospel gown grace actor armed gift  radar", "words of seed phrase should be separated by single spaces"},
+		{" exile region inmate brass mobile hour best spy gospel gown grace actor armed gift radar", "words of seed phrase should be separated by single spaces"},
+		{"exile region inmate brass mobile hour best spy gospel gown grace actor armed gift Radar", "unknown word 'Radar' at position 15 of seed phrase"},
+		{"exile regoin inmate brass mobile hour best spy gospel gown grace actor armed gift radar", "unknown word 'regoin' at position 2 of seed phrase"},
+	} {
+		err := ValidateSeedPhrase(tc.phrase)
+		if tc.err == "" {
+			assert.NoError(t, err, tc.phrase)
+		} else {
+			assert.EqualError(t, err, tc.err, tc.phrase)
+		}
+	}
+}
+
+func TestNewWalletFromSeedPhrase(t *testing.T) {
+	phrase := "exile region inmate brass mobile hour best spy gospel gown grace actor armed gift radar"
+	w, err := NewWalletFromSeedPhrase(phrase)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(phrase), w.Seed())
+
+	// Seed of the account created by the Scala node is the secure hash of 4 bytes of the nonce followed by the phrase
+	d, err := crypto.SecureHash(append([]byte{0, 0, 0, 0}, phrase...))
+	require.NoError(t, err)
+	_, pub := crypto.GenerateKeyPair(d.Bytes())
+	_, public, err := w.GenPair()
+	require.NoError(t, err)
+	assert.Equal(t, pub, public)
+
+	addr, err := w.Address(w.Accounts()[0], proto.TestNetScheme)
+	require.NoError(t, err)
+	assert.Equal(t, "3MqBoAUmn1XKCebApkszLSUqFpGf5yQZqeL", addr.String())
+
+	_, err = NewWalletFromSeedPhrase("exile region")
+	assert.Error(t, err)
+}
@@ -6,43 +6,92 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
 )
 
+// CurrentVersion is the version of WalletFormat written by Encode.
+const CurrentVersion = 1
+
+// DefaultLabel is the label of the first account of the new wallet and of the account migrated from version 0.
+const DefaultLabel = "default"
+
+// WalletFormat is the content of the wallet file.
+// Version 0 holds the seed and the single Index of the account, version 1 holds the seed and many accounts.
 type WalletFormat struct {
-	Version int32  `json:"version"`
-	Seed    []byte `json:"seed"`
-	Index   uint32 `json:"index"`
+	Version  int32     `json:"version"`
+	Seed     []byte    `json:"seed"`
+	Index    uint32    `json:"index,omitempty"`
+	Accounts []Account `json:"accounts,omitempty"`
+}
+
+// Account is the key pair derived from the seed of the wallet by its index.
+type Account struct {
+	Index uint32 `json:"index"`
+	Label string `json:"label"`
 }
 
 type Wallet struct {
 	format WalletFormat
 }
 
+// NewWalletFromSeed creates the wallet with the single account of index 0.
 func NewWalletFromSeed(seed []byte) (*Wallet, error) {
 	s := make([]byte, len(seed))
 	copy(s, seed)
 	return &Wallet{
 		format: WalletFormat{
-			Version: 0,
-			Seed:    s,
-			Index:   0,
+			Version:  CurrentVersion,
+			Seed:     s,
+			Accounts: []Account{{Index: 0, Label: DefaultLabel}},
 		},
 	}, nil
 }
 
+// migrate upgrades the format to the current version.
+func migrate(format *WalletFormat) error {
+	switch format.Version {
+	case 0:
+		format.Accounts = []Account{{Index: format.Index, Label: DefaultLabel}}
+		format.Index = 0
+		format.Version = 1
+		return nil
+	case CurrentVersion:
+		return nil
+	default:
+		return errors.Errorf("unsupported wallet version %d", format.Version)
+	}
+}
+
+// Encode encrypts the wallet with the password using DefaultKDFParams.
 func (a *Wallet) Encode(password []byte) ([]byte, error) {
-	crypt := NewCrypt(password)
+	return a.EncodeWithParams(password, DefaultKDFParams)
+}
+
+// EncodeWithParams encrypts the wallet with the password using the given parameters of key derivation.
+func (a *Wallet) EncodeWithParams(password []byte, params KDFParams) ([]byte, error) {
 	walletData, err := json.Marshal(a.format)
 	if err != nil {
 		return nil, err
 	}
 
-	return crypt.Encrypt(walletData)
+	return Seal(password, walletData, params)
 }
 
+// GenPair derives the key pair of the default account of the wallet. It fails if the default account was removed or
+// renamed, so the key of other account is never used instead silently.
 func (a *Wallet) GenPair() (crypto.SecretKey, crypto.PublicKey, error) {
+	acc, err := a.Account(DefaultLabel)
+	if err != nil {
+		return crypto.SecretKey{}, crypto.PublicKey{}, errors.Wrap(err, "select the account explicitly")
+	}
+	return a.KeyPair(acc)
+}
+
+// KeyPair derives the key pair of the account the same way as the Scala node does: the seed of the account is the
+// secure hash of the index followed by the seed of the wallet.
+func (a *Wallet) KeyPair(account Account) (crypto.SecretKey, crypto.PublicKey, error) {
 	prefix := make([]byte, 4)
-	binary.BigEndian.PutUint32(prefix, a.format.Index)
+	binary.BigEndian.PutUint32(prefix, account.Index)
 
 	s := append(prefix, a.format.Seed...)
 
@@ -56,9 +105,104 @@ func (a *Wallet) GenPair() (crypto.SecretKey, crypto.PublicKey, error) {
 	return priv, pub, nil
 }
 
+// Address returns the address of the account on the network with the given scheme.
+func (a *Wallet) Address(account Account, scheme byte) (proto.Address, error) {
+	_, pub, err := a.KeyPair(account)
+	if err != nil {
+		return proto.Address{}, err
+	}
+	return proto.NewAddressFromPublicKey(scheme, pub)
+}
+
+// Accounts returns accounts of the wallet in the order of addition.
+func (a *Wallet) Accounts() []Account {
+	out := make([]Account, len(a.format.Accounts))
+	copy(out, a.format.Accounts)
+	return out
+}
+
+// Account returns the account with the label.
+func (a *Wallet) Account(label string) (Account, error) {
+	i, err := a.find(label)
+	if err != nil {
+		return Account{}, err
+	}
+	return a.format.Accounts[i], nil
+}
+
+func (a *Wallet) find(label string) (int, error) {
+	for i, acc := range a.format.Accounts {
+		if acc.Label == label {
+			return i, nil
+		}
+	}
+	return 0, errors.Errorf("account '%s' not found", label)
+}
+
+func (a *Wallet) checkLabel(label string) error {
+	if label == "" {
+		return errors.New("empty account label")
+	}
+	if _, err := a.find(label); err == nil {
+		return errors.Errorf("account '%s' already exists", label)
+	}
+	return nil
+}
+
+// AddAccount derives the new account with the index next to the greatest index of the accounts of the wallet.
+func (a *Wallet) AddAccount(label string) (Account, error) {
+	var index uint32
+	for i, acc := range a.format.Accounts {
+		if i == 0 || acc.Index >= index {
+			index = acc.Index + 1
+		}
+	}
+	return a.AddAccountWithIndex(label, index)
+}
+
+// AddAccountWithIndex derives the new account with the given index, it restores the removed account.
+func (a *Wallet) AddAccountWithIndex(label string, index uint32) (Account, error) {
+	if err := a.checkLabel(label); err != nil {
+		return Account{}, err
+	}
+	for _, acc := range a.format.Accounts {
+		if acc.Index == index {
+			return Account{}, errors.Errorf("account with index %d already exists as '%s'", index, acc.Label)
+		}
+	}
+	acc := Account{Index: index, Label: label}
+	a.format.Accounts = append(a.format.Accounts, acc)
+	return acc, nil
+}
+
+// RemoveAccount removes the account from the wallet. The key pair of the account could be derived again by
+// AddAccountWithIndex with the same index.
+func (a *Wallet) RemoveAccount(label string) error {
+	i, err := a.find(label)
+	if err != nil {
+		return err
+	}
+	a.format.Accounts = append(a.format.Accounts[:i], a.format.Accounts[i+1:]...)
+	return nil
+}
+
+// RenameAccount changes the label of the account.
+func (a *Wallet) RenameAccount(label, newLabel string) error {
+	i, err := a.find(label)
+	if err != nil {
+		return err
+	}
+	if err := a.checkLabel(newLabel); err != nil {
+		return err
+	}
+	a.format.Accounts[i].Label = newLabel
+	return nil
+}
+
+// Decode decrypts the wallet and migrates it to the current version of the format.
+// Wallets encrypted with the legacy cipher are read as well, Encode writes them in the new container.
 func Decode(walletData []byte, password []byte) (*Wallet, error) {
-	crypt := NewCrypt(password)
-	bts, err := crypt.Decrypt(walletData)
+	bts, _, err := Open(password, walletData)
 	if err != nil {
 		return nil, err
 	}
@@ -66,7 +210,11 @@ func Decode(walletData []byte, password []byte) (*Wallet, error) {
 	format := WalletFormat{}
 	err = json.Unmarshal(bts, &format)
 	if err != nil {
-		return nil, errors.New("invalid password")
+		// The legacy cipher is not authenticated, so the wrong password results in garbage
+		return nil, ErrInvalidPassword
+	}
+	if err := migrate(&format); err != nil {
+		return nil, err
 	}
 	return &Wallet{
 		format: format,
@@ -1,6 +1,7 @@
 package wallet
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -22,7 +23,7 @@ func TestWallet_EncodeDecode(t *testing.T) {
 	assert.Equal(t, w.Seed(), w2.Seed())
 
 	_, err = Decode(bts, []byte("unknown password"))
-	require.Error(t, err)
+	assert.Equal(t, ErrInvalidPassword, err)
 
 	_, public, err := w.GenPair()
 	require.NoError(t, err)
@@ -32,3 +33,117 @@ func TestWallet_EncodeDecode(t *testing.T) {
 
 	assert.Equal(t, "3MqBoAUmn1XKCebApkszLSUqFpGf5yQZqeL", addr.String())
 }
+
+func TestWallet_MigrateVersion0(t *testing.T) {
+	password := []byte("123456")
+	seed := []byte("exile region inmate brass mobile hour best spy gospel gown grace actor armed gift radar")
+	data, err := json.Marshal(map[string]interface{}{"version": 0, "seed": seed, "index": 0})
+	require.NoError(t, err)
+	bts, err := NewCrypt(password).Encrypt(data)
+	require.NoError(t, err)
+
+	w, err := Decode(bts, password)
+	require.NoError(t, err)
+	assert.Equal(t, seed, w.Seed())
+	assert.Equal(t, []Account{{Index: 0, Label: DefaultLabel}}, w.Accounts())
+	addr, err := w.Address(w.Accounts()[0], proto.TestNetScheme)
+	require.NoError(t, err)
+	assert.Equal(t, "3MqBoAUmn1XKCebApkszLSUqFpGf5yQZqeL", addr.String())
+
+	data, err = json.Marshal(map[string]interface{}{"version": 2, "seed": seed})
+	require.NoError(t, err)
+	bts, err = NewCrypt(password).Encrypt(data)
+	require.NoError(t, err)
+	_, err = Decode(bts, password)
+	assert.EqualError(t, err, "unsupported wallet version 2")
+}
+
+func TestWallet_Accounts(t *testing.T) {
+	password := []byte("123456")
+	w, err := NewWalletFromSeed([]byte("exile region inmate brass mobile hour best spy gospel gown grace actor armed gift radar"))
+	require.NoError(t, err)
+
+	savings, err := w.AddAccount("savings")
+	require.NoError(t, err)
+	assert.Equal(t, Account{Index: 1, Label: "savings"}, savings)
+	_, err = w.AddAccount("savings")
+	assert.EqualError(t, err, "account 'savings' already exists")
+	_, err = w.AddAccount("")
+	assert.Error(t, err)
+	trading, err := w.AddAccount("trading")
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, trading.Index)
+
+	require.NoError(t, w.RenameAccount("savings", "deposit"))
+	assert.EqualError(t, w.RenameAccount("deposit", "trading"), "account 'trading' already exists")
+	assert.EqualError(t, w.RenameAccount("savings", "other"), "account 'savings' not found")
+	require.NoError(t, w.RemoveAccount(DefaultLabel))
+	assert.EqualError(t, w.RemoveAccount(DefaultLabel), "account 'default' not found")
+
+	bts, err := w.Encode(password)
+	require.NoError(t, err)
+	w2, err := Decode(bts, password)
+	require.NoError(t, err)
+	assert.Equal(t, []Account{{Index: 1, Label: "deposit"}, {Index: 2, Label: "trading"}}, w2.Accounts())
+
+	// Next account doesn't reuse the index of the removed one
+	acc, err := w2.AddAccount("next")
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, acc.Index)
+
+	// Key pair of the removed default account is lost for GenPair until the account is restored by its index
+	_, _, err = w2.GenPair()
+	assert.EqualError(t, err, "select the account explicitly: account 'default' not found")
+	_, err = w2.AddAccountWithIndex("other", 2)
+	assert.EqualError(t, err, "account with index 2 already exists as 'trading'")
+	restored, err := w2.AddAccountWithIndex(DefaultLabel, 0)
+	require.NoError(t, err)
+	sk0, pk0, err := w.KeyPair(Account{Index: 0})
+	require.NoError(t, err)
+	sk2, pk2, err := w2.GenPair()
+	require.NoError(t, err)
+	assert.Equal(t, sk0, sk2)
+	assert.Equal(t, pk0, pk2)
+	assert.Equal(t, Account{Index: 0, Label: DefaultLabel}, restored)
+
+	deposit, err := w2.Account("deposit")
+	require.NoError(t, err)
+	_, pk1, err := w2.KeyPair(deposit)
+	require.NoError(t, err)
+	assert.NotEqual(t, pk0, pk1)
+
+	mainnet, err := w2.Address(deposit, proto.MainNetScheme)
+	require.NoError(t, err)
+	testnet, err := w2.Address(deposit, proto.TestNetScheme)
+	require.NoError(t, err)
+	assert.Equal(t, byte(proto.MainNetScheme), mainnet[1])
+	assert.Equal(t, byte(proto.TestNetScheme), testnet[1])
+	assert.Equal(t, mainnet[2:22], testnet[2:22])
+}
+
+func TestWallet_DecodeLegacy(t *testing.T) {
+	password := []byte("123456")
+	seed := []byte("exile region inmate brass mobile hour best spy gospel gown grace actor armed gift radar")
+	w, err := NewWalletFromSeed(seed)
+	require.NoError(t, err)
+	data, err := json.Marshal(w.format)
+	require.NoError(t, err)
+	old, err := NewCrypt(password).Encrypt(data)
+	require.NoError(t, err)
+
+	_, err = Decode(append([]byte(nil), old...), []byte("unknown password"))
+	assert.Equal(t, ErrInvalidPassword, err)
+
+	w2, err := Decode(old, password)
+	require.NoError(t, err)
+	assert.Equal(t, seed, w2.Seed())
+
+	bts, err := w2.EncodeWithParams([]byte("new password"), KDFParams{Time: 1, Memory: 1024, Threads: 1})
+	require.NoError(t, err)
+	assert.True(t, IsSealed(bts))
+	w3, err := Decode(bts, []byte("new password"))
+	require.NoError(t, err)
+	assert.Equal(t, w2.Accounts(), w3.Accounts())
+	_, err = Decode(bts, password)
+	assert.Equal(t, ErrInvalidPassword, err)
+}